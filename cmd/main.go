@@ -6,27 +6,64 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/enrico5b1b4/tbwrap"
+	"github.com/husol/telegram-reminder-bot/pkg/allowlist"
 	"github.com/husol/telegram-reminder-bot/pkg/bot"
+	"github.com/husol/telegram-reminder-bot/pkg/command"
+	"github.com/husol/telegram-reminder-bot/pkg/date"
 	"github.com/husol/telegram-reminder-bot/pkg/db"
+	tb "gopkg.in/tucnak/telebot.v2"
 )
 
+const pollerTimeout = 15 * time.Second
+
 // nolint:funlen
 func main() {
 	dbFile := MustGetEnv("TELEGRAM_REMINDER_DB_FILE")
 	telegramBotToken := MustGetEnv("TELEGRAM_REMINDER_BOT_TOKEN")
-	allowedChats := parseAllowedChats(MustGetEnv("TELEGRAM_ALLOWED_CHATS"))
+	ownerID := parseOwnerID(os.Getenv("TELEGRAM_REMINDER_OWNER_ID"))
+	// when the bot has an owner chats can ask them to use it with /remindregister and the chats listed here
+	// are approved when it starts, without one only the chats listed here can use it
+	var allowedChats []int
+	if ownerID == 0 {
+		allowedChats = parseAllowedChats(MustGetEnv("TELEGRAM_ALLOWED_CHATS"))
+	} else {
+		allowedChats = parseAllowedChats(os.Getenv("TELEGRAM_ALLOWED_CHATS"))
+	}
+	minCronInterval := parseMinCronInterval(os.Getenv("TELEGRAM_REMINDER_MIN_CRON_INTERVAL"))
 
-	database, err := db.SetupDB(dbFile, allowedChats)
+	setUpChats := allowedChats
+	if ownerID != 0 {
+		setUpChats = append(setUpChats, ownerID)
+	}
+	database, err := db.SetupDB(dbFile, setUpChats)
 	if err != nil {
 		log.Fatal(err)
 	}
 	defer database.Close()
 
+	allowList := allowlist.NewService(allowlist.NewStore(database), ownerID, date.RealTimeNow)
+	err = allowList.Load(allowedChats)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// the telebot bot is created here rather than by tbwrap as it is also needed to download files, edit and forward
+	// messages and look up the admins of groups
+	teleBot, err := tb.NewBot(tb.Settings{
+		Token:  telegramBotToken,
+		Poller: allowlist.NewPoller(pollerTimeout, allowList),
+	})
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
 	botConfig := tbwrap.Config{
-		Token:        telegramBotToken,
-		AllowedChats: allowedChats,
+		Token: telegramBotToken,
+		TBot:  teleBot,
 	}
 	telegramBot, err := tbwrap.NewBot(botConfig)
 	if err != nil {
@@ -34,11 +71,15 @@ func main() {
 		return
 	}
 
-	appBot := bot.New(allowedChats, database, telegramBot)
+	appBot := bot.New(allowList, database, telegramBot, teleBot, teleBot, teleBot, teleBot, minCronInterval)
 	appBot.Start()
 }
 
 func parseAllowedChats(list string) []int {
+	if strings.TrimSpace(list) == "" {
+		return nil
+	}
+
 	sepList := strings.Split(list, ",")
 	intList := make([]int, len(sepList))
 	var err error
@@ -53,6 +94,36 @@ func parseAllowedChats(list string) []int {
 	return intList
 }
 
+// parseOwnerID parses the ID of the user who approves the chats which ask to use the bot.
+// The bot has no owner when it is not set
+func parseOwnerID(value string) int {
+	if strings.TrimSpace(value) == "" {
+		return 0
+	}
+
+	ownerID, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	return ownerID
+}
+
+// parseMinCronInterval parses how often reminders set with a cron spec can be sent at most e.g. "15m".
+// The default is used when it is not set
+func parseMinCronInterval(value string) time.Duration {
+	if value == "" {
+		return command.DefaultMinCronInterval
+	}
+
+	minInterval, err := time.ParseDuration(value)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	return minInterval
+}
+
 func MustGetEnv(name string) string {
 	value := os.Getenv(name)
 	if value == "" {
@@ -6,17 +6,24 @@ import (
 	"log"
 	"os"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/enrico5b1b4/tbwrap"
+	"github.com/husol/telegram-reminder-bot/pkg/allowlist"
 	"github.com/husol/telegram-reminder-bot/pkg/bot"
+	"github.com/husol/telegram-reminder-bot/pkg/command"
+	"github.com/husol/telegram-reminder-bot/pkg/date"
 	"github.com/husol/telegram-reminder-bot/pkg/db"
 	"github.com/husol/telegram-reminder-bot/pkg/telegram/fakes"
 	"github.com/stretchr/testify/require"
 	bolt "go.etcd.io/bbolt"
 )
 
-const chatID = 123456
+const (
+	chatID  = 123456
+	ownerID = 654321
+)
 
 func TestE2E(t *testing.T) {
 	checkSkip(t)
@@ -109,6 +116,95 @@ func TestE2E(t *testing.T) {
 	require.Contains(t, telebot.OutboundSendMessages[16], `MSG8_`)
 	require.Contains(t, telebot.OutboundSendMessages[16], `MSG9_`)
 	require.Contains(t, telebot.OutboundSendMessages[16], `MSG10_`)
+
+	// Pause and resume a reminder
+	reminderID, err = getReminderIDForMessageFromRemindList(telebot.OutboundSendMessages[16], "MSG6_")
+	require.NoError(t, err)
+
+	telebot.SimulateIncomingMessageToChat(chatID, fmt.Sprintf("/remindpause %s", reminderID))
+	require.Contains(t, telebot.OutboundSendMessages[17], fmt.Sprintf("Reminder %s has been paused", reminderID))
+
+	telebot.SimulateIncomingMessageToChat(chatID, "/remindlist")
+	require.Contains(t, telebot.OutboundSendMessages[18], `*Inactive*`)
+	require.Contains(t, telebot.OutboundSendMessages[18], `MSG6_`)
+
+	telebot.SimulateIncomingMessageToChat(chatID, fmt.Sprintf("/remindresume %s", reminderID))
+	require.Contains(t, telebot.OutboundSendMessages[19], fmt.Sprintf("Reminder %s has been resumed", reminderID))
+
+	// Edit a reminder
+	telebot.SimulateIncomingMessageToChat(chatID, fmt.Sprintf("/remindedit %s every wednesday at 9:00 MSG6_EDITED", reminderID))
+	require.Contains(t, telebot.OutboundSendMessages[20], `Reminder "MSG6_EDITED" has been updated`)
+
+	telebot.SimulateIncomingMessageToChat(chatID, "/remindlist")
+	editedReminderID, err := getReminderIDForMessageFromRemindList(telebot.OutboundSendMessages[21], "MSG6_EDITED")
+	require.NoError(t, err)
+	require.Equal(t, reminderID, editedReminderID)
+
+	// Send a reminder again until it is marked as done
+	telebot.SimulateIncomingMessageToChat(chatID, fmt.Sprintf("/remindnag %s every 10 minutes up to 3 times", reminderID))
+	require.Contains(t, telebot.OutboundSendMessages[22], fmt.Sprintf("Reminder %s will be sent again every 10 minutes", reminderID))
+
+	telebot.SimulateIncomingMessageToChat(chatID, fmt.Sprintf("/remindnag %s off", reminderID))
+	require.Contains(t, telebot.OutboundSendMessages[23], fmt.Sprintf("Reminder %s will no longer be sent again", reminderID))
+
+	// End a recurring reminder after a number of times
+	telebot.SimulateIncomingMessageToChat(chatID, "/remind me every day at 8pm for 3 times MSG11_")
+	require.Contains(t, telebot.OutboundSendMessages[24], `Reminder "MSG11_" has been added`)
+
+	telebot.SimulateIncomingMessageToChat(chatID, "/remindlist")
+	endingReminderID, err := getReminderIDForMessageFromRemindList(telebot.OutboundSendMessages[25], "MSG11_")
+	require.NoError(t, err)
+
+	telebot.SimulateIncomingMessageToChat(chatID, fmt.Sprintf("/reminddetail %s", endingReminderID))
+	require.Contains(t, telebot.OutboundSendMessages[26], "*Remaining*: 3 times")
+
+	// Follow the holidays of the chat
+	telebot.SimulateIncomingMessageToChat(chatID, "/setholidays vn")
+	require.Contains(t, telebot.OutboundSendMessages[27], "Holidays have been set to Vietnam")
+
+	telebot.SimulateIncomingMessageToChat(chatID, "/remind me every business day at 9:00 MSG12_")
+	require.Contains(t, telebot.OutboundSendMessages[28], `Reminder "MSG12_" has been added`)
+
+	telebot.SimulateIncomingMessageToChat(chatID, "/remindlist")
+	businessDayReminderID, err := getReminderIDForMessageFromRemindList(telebot.OutboundSendMessages[29], "MSG12_")
+	require.NoError(t, err)
+
+	telebot.SimulateIncomingMessageToChat(chatID, fmt.Sprintf("/reminddetail %s", businessDayReminderID))
+	require.Contains(t, telebot.OutboundSendMessages[30], "*Holidays*: Skip holidays")
+
+	// Skip the next times a recurring reminder is due
+	telebot.SimulateIncomingMessageToChat(chatID, fmt.Sprintf("/remindskip %s 2", endingReminderID))
+	require.Contains(t, telebot.OutboundSendMessages[31], fmt.Sprintf("Reminder %s will not be sent on", endingReminderID))
+
+	telebot.SimulateIncomingMessageToChat(chatID, fmt.Sprintf("/reminddetail %s", endingReminderID))
+	require.Equal(t, 2, strings.Count(telebot.OutboundSendMessages[32], "*Will skip*:"))
+
+	// Set a reminder with a cron spec
+	telebot.SimulateIncomingMessageToChat(chatID, `/remind cron "*/15 9-17 * * 1-5" MSG13_`)
+	require.Contains(t, telebot.OutboundSendMessages[33], `Reminder "MSG13_" has been added`)
+	require.Contains(t, telebot.OutboundSendMessages[33], "Next runs:")
+
+	telebot.SimulateIncomingMessageToChat(chatID, `/remind cron "* * * * *" MSG14_`)
+	require.Contains(t, telebot.OutboundSendMessages[34], "reminders can be sent at most every 5m")
+
+	// Set a reminder on a date with a year
+	telebot.SimulateIncomingMessageToChat(chatID, "/remind me on 2099-03-14 at 10:00 MSG15_")
+	require.Contains(t, telebot.OutboundSendMessages[35], "Sat, 14 Mar 2099 10:00")
+
+	telebot.SimulateIncomingMessageToChat(chatID, "/setdateorder mdy")
+	telebot.SimulateIncomingMessageToChat(chatID, "/remind me on 03/14/2099 at 10:00 MSG16_")
+	require.Contains(t, telebot.OutboundSendMessages[37], "Sat, 14 Mar 2099 10:00")
+
+	// List the reminders with a tag
+	telebot.SimulateIncomingMessageToChat(chatID, "/remind me every day at 9:00 MSG17_ #ops")
+	require.Contains(t, telebot.OutboundSendMessages[38], `Reminder "MSG17_ #ops" has been added`)
+
+	telebot.SimulateIncomingMessageToChat(chatID, "/remindlist #ops")
+	require.Contains(t, telebot.OutboundSendMessages[39], `MSG17_`)
+	require.NotContains(t, telebot.OutboundSendMessages[39], `MSG16_`)
+
+	telebot.SimulateIncomingMessageToChat(chatID, "/remindlist paused")
+	require.Contains(t, telebot.OutboundSendMessages[40], "No reminders match paused.")
 }
 
 func setup(dbFile string, allowedChats []int) (*fakes.TeleBot, *bolt.DB, error) {
@@ -127,7 +223,13 @@ func setup(dbFile string, allowedChats []int) (*fakes.TeleBot, *bolt.DB, error)
 		return nil, nil, err
 	}
 
-	appBot := bot.New(allowedChats, database, telegramBot)
+	allowList := allowlist.NewService(allowlist.NewStore(database), ownerID, date.RealTimeNow)
+	err = allowList.Load(allowedChats)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	appBot := bot.New(allowList, database, telegramBot, fakes.NewFileGetter(), fakes.NewMessageEditor(), fakes.NewMessageForwarder(), fakes.NewChatMemberGetter(), command.DefaultMinCronInterval)
 	appBot.Start()
 
 	return teleBot, database, nil
@@ -4,6 +4,7 @@ go 1.16
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/enrico5b1b4/capture v0.0.3
 	github.com/enrico5b1b4/tbwrap v0.0.9
 	github.com/golang/mock v1.4.4
 	github.com/kr/text v0.2.0 // indirect
@@ -0,0 +1,22 @@
+package allowlist
+
+import "time"
+
+// Status is how far the request of a chat to use the bot has got
+type Status string
+
+const (
+	Pending  Status = "pending"
+	Approved Status = "approved"
+	Denied   Status = "denied"
+)
+
+// Chat is a chat which asked to use the bot with /remindregister, or was allowed to when the bot started
+type Chat struct {
+	ChatID      int        `json:"chat_id"`
+	Title       string     `json:"title"` // the title of a group or the name of a user
+	RequestedBy string     `json:"requested_by"`
+	Status      Status     `json:"status"`
+	RequestedAt time.Time  `json:"requested_at"`
+	DecidedAt   *time.Time `json:"decided_at"`
+}
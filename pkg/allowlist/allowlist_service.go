@@ -0,0 +1,141 @@
+package allowlist
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	ErrAlreadyApproved  = errors.New("chat already approved")
+	ErrAlreadyRequested = errors.New("chat already requested")
+)
+
+// Service keeps which chats can use the bot. The approved chats are also held in memory
+// as every update the bot receives is checked against them
+type Service struct {
+	store    Storer
+	ownerID  int
+	timeNow  func() time.Time
+	mu       sync.RWMutex
+	approved map[int]bool
+}
+
+func NewService(store Storer, ownerID int, timeNow func() time.Time) *Service {
+	return &Service{
+		store:    store,
+		ownerID:  ownerID,
+		timeNow:  timeNow,
+		approved: map[int]bool{},
+	}
+}
+
+// Load approves the chats the bot was started with and the private chat of its owner if it has one,
+// then reads the chats which were approved before
+func (s *Service) Load(chats []int) error {
+	if s.ownerID != 0 {
+		chats = append([]int{s.ownerID}, chats...)
+	}
+
+	for _, chatID := range chats {
+		chat, err := s.store.GetChat(chatID)
+		if err != nil && err != ErrNotFound {
+			return err
+		}
+		if chat != nil && chat.Status == Approved {
+			continue
+		}
+
+		timeNow := s.timeNow().In(time.UTC)
+		err = s.store.UpsertChat(&Chat{ChatID: chatID, Status: Approved, RequestedAt: timeNow, DecidedAt: &timeNow})
+		if err != nil {
+			return err
+		}
+	}
+
+	stored, err := s.store.GetChats()
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range stored {
+		s.approved[stored[i].ChatID] = stored[i].Status == Approved
+	}
+
+	return nil
+}
+
+// OwnerID is the user who approves the chats which ask to use the bot, 0 when the bot has no owner
+func (s *Service) OwnerID() int {
+	return s.ownerID
+}
+
+func (s *Service) IsAllowed(chatID int) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.approved[chatID]
+}
+
+// ApprovedChats returns the IDs of the chats which can use the bot in ascending order
+func (s *Service) ApprovedChats() []int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var chats []int
+	for chatID, approved := range s.approved {
+		if approved {
+			chats = append(chats, chatID)
+		}
+	}
+	sort.Ints(chats)
+
+	return chats
+}
+
+// Request records that a chat asked to use the bot, which waits for the owner to approve or deny it.
+// Chats which were denied can ask again
+func (s *Service) Request(chat Chat) (*Chat, error) {
+	existing, err := s.store.GetChat(chat.ChatID)
+	if err != nil && err != ErrNotFound {
+		return nil, err
+	}
+	if existing != nil && existing.Status == Approved {
+		return nil, ErrAlreadyApproved
+	}
+	if existing != nil && existing.Status == Pending {
+		return nil, ErrAlreadyRequested
+	}
+
+	chat.Status = Pending
+	chat.RequestedAt = s.timeNow().In(time.UTC)
+	chat.DecidedAt = nil
+
+	return &chat, s.store.UpsertChat(&chat)
+}
+
+// Decide approves or denies a chat which asked to use the bot.
+// A chat which was approved can also be denied later on, which stops it using the bot
+func (s *Service) Decide(chatID int, status Status) (*Chat, error) {
+	chat, err := s.store.GetChat(chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	timeNow := s.timeNow().In(time.UTC)
+	chat.Status = status
+	chat.DecidedAt = &timeNow
+	err = s.store.UpsertChat(chat)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.approved[chatID] = status == Approved
+
+	return chat, nil
+}
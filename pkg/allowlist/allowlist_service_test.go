@@ -0,0 +1,187 @@
+package allowlist_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/husol/telegram-reminder-bot/pkg/allowlist"
+	"github.com/husol/telegram-reminder-bot/pkg/allowlist/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+const (
+	ownerID = 7
+	chatID  = -1001
+)
+
+func timeNow() time.Time {
+	return time.Date(2020, time.April, 1, 9, 0, 0, 0, time.UTC)
+}
+
+func TestService_Load(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	store := mocks.NewMockStorer(mockCtrl)
+	store.EXPECT().GetChat(ownerID).Return(nil, allowlist.ErrNotFound)
+	store.EXPECT().UpsertChat(gomock.Any()).DoAndReturn(func(chat *allowlist.Chat) error {
+		assert.Equal(t, ownerID, chat.ChatID)
+		assert.Equal(t, allowlist.Approved, chat.Status)
+		return nil
+	})
+	store.EXPECT().GetChat(1).Return(&allowlist.Chat{ChatID: 1, Status: allowlist.Approved}, nil)
+	store.EXPECT().GetChats().Return([]allowlist.Chat{
+		{ChatID: ownerID, Status: allowlist.Approved},
+		{ChatID: 1, Status: allowlist.Approved},
+		{ChatID: chatID, Status: allowlist.Approved},
+		{ChatID: -1002, Status: allowlist.Pending},
+		{ChatID: -1003, Status: allowlist.Denied},
+	}, nil)
+
+	service := allowlist.NewService(store, ownerID, timeNow)
+	require.NoError(t, service.Load([]int{1}))
+	assert.Equal(t, []int{chatID, 1, ownerID}, service.ApprovedChats())
+	assert.True(t, service.IsAllowed(chatID))
+	assert.False(t, service.IsAllowed(-1002))
+	assert.False(t, service.IsAllowed(-1003))
+}
+
+func TestService_Load_WithoutOwner(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	store := mocks.NewMockStorer(mockCtrl)
+	store.EXPECT().GetChat(chatID).Return(&allowlist.Chat{ChatID: chatID, Status: allowlist.Approved}, nil)
+	store.EXPECT().GetChats().Return([]allowlist.Chat{{ChatID: chatID, Status: allowlist.Approved}}, nil)
+
+	service := allowlist.NewService(store, 0, timeNow)
+	require.NoError(t, service.Load([]int{chatID}))
+	assert.Equal(t, []int{chatID}, service.ApprovedChats())
+	assert.False(t, service.IsAllowed(0))
+}
+
+func TestService_Request(t *testing.T) {
+	testCases := map[string]struct {
+		existing    *allowlist.Chat
+		expectedErr error
+	}{
+		"a new chat":                   {},
+		"a chat which was denied":      {existing: &allowlist.Chat{ChatID: chatID, Status: allowlist.Denied}},
+		"a chat which can use the bot": {existing: &allowlist.Chat{ChatID: chatID, Status: allowlist.Approved}, expectedErr: allowlist.ErrAlreadyApproved},
+		"a chat which already asked":   {existing: &allowlist.Chat{ChatID: chatID, Status: allowlist.Pending}, expectedErr: allowlist.ErrAlreadyRequested},
+	}
+
+	for name := range testCases {
+		t.Run(name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			testCase := testCases[name]
+			store := mocks.NewMockStorer(mockCtrl)
+			if testCase.existing != nil {
+				store.EXPECT().GetChat(chatID).Return(testCase.existing, nil)
+			} else {
+				store.EXPECT().GetChat(chatID).Return(nil, allowlist.ErrNotFound)
+			}
+			expectedChat := &allowlist.Chat{ChatID: chatID, Title: "Ops", Status: allowlist.Pending, RequestedAt: timeNow()}
+			if testCase.expectedErr == nil {
+				store.EXPECT().UpsertChat(expectedChat).Return(nil)
+			}
+
+			service := allowlist.NewService(store, ownerID, timeNow)
+			chat, err := service.Request(allowlist.Chat{ChatID: chatID, Title: "Ops"})
+			if testCase.expectedErr != nil {
+				assert.Equal(t, testCase.expectedErr, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, expectedChat, chat)
+			assert.False(t, service.IsAllowed(chatID))
+		})
+	}
+}
+
+func TestService_Decide(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	store := mocks.NewMockStorer(mockCtrl)
+	store.EXPECT().GetChat(chatID).Return(&allowlist.Chat{ChatID: chatID, Status: allowlist.Pending}, nil).Times(2)
+	store.EXPECT().UpsertChat(gomock.Any()).Return(nil).Times(2)
+	service := allowlist.NewService(store, ownerID, timeNow)
+
+	chat, err := service.Decide(chatID, allowlist.Approved)
+	require.NoError(t, err)
+	assert.Equal(t, allowlist.Approved, chat.Status)
+	assert.True(t, service.IsAllowed(chatID))
+
+	_, err = service.Decide(chatID, allowlist.Denied)
+	require.NoError(t, err)
+	assert.False(t, service.IsAllowed(chatID))
+}
+
+func TestNewPoller(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	store := mocks.NewMockStorer(mockCtrl)
+	store.EXPECT().GetChat(chatID).Return(&allowlist.Chat{ChatID: chatID, Status: allowlist.Pending}, nil)
+	store.EXPECT().UpsertChat(gomock.Any()).Return(nil)
+	service := allowlist.NewService(store, ownerID, timeNow)
+	_, err := service.Decide(chatID, allowlist.Approved)
+	require.NoError(t, err)
+	poller := allowlist.NewPoller(time.Second, service)
+	message := func(chatID int64, text string) *tb.Message {
+		return &tb.Message{Chat: &tb.Chat{ID: chatID}, Text: text}
+	}
+	private := func(userID int64) *tb.Message {
+		return &tb.Message{Chat: &tb.Chat{ID: userID, Type: tb.ChatPrivate}}
+	}
+
+	testCases := map[string]struct {
+		update  *tb.Update
+		allowed bool
+	}{
+		"a message from an approved chat": {update: &tb.Update{Message: message(chatID, "/remindlist")}, allowed: true},
+		"a message from another chat":     {update: &tb.Update{Message: message(-1002, "/remindlist")}},
+		"the command to ask to use the bot": {
+			update:  &tb.Update{Message: message(-1002, "/remindregister@reminder_bot")},
+			allowed: true,
+		},
+		"a button pressed in an approved chat": {
+			update:  &tb.Update{Callback: &tb.Callback{Message: message(chatID, "")}},
+			allowed: true,
+		},
+		"a button pressed in another chat": {update: &tb.Update{Callback: &tb.Callback{Message: message(-1002, "")}}},
+		"a button of a reminder of an approved chat pressed in the private chat it was sent to": {
+			update:  &tb.Update{Callback: &tb.Callback{Message: private(42), Data: "\fDoneBtn|3:-1001"}},
+			allowed: true,
+		},
+		"a checklist of an approved chat ticked in the private chat it was sent to": {
+			update:  &tb.Update{Callback: &tb.Callback{Message: private(42), Data: "\fChecklistItemBtn|3:12:0:-1001"}},
+			allowed: true,
+		},
+		"a button of a reminder of another chat pressed in a private chat": {
+			update: &tb.Update{Callback: &tb.Callback{Message: private(42), Data: "\fDoneBtn|3:-1002"}},
+		},
+		"a button without a chat pressed in a private chat": {
+			update: &tb.Update{Callback: &tb.Callback{Message: private(42), Data: "\fDoneBtn|3"}},
+		},
+		"a button of a reminder of an approved chat pressed in another group": {
+			update: &tb.Update{Callback: &tb.Callback{Message: message(-1002, ""), Data: "\fDoneBtn|3:-1001"}},
+		},
+	}
+
+	for name := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, testCases[name].allowed, poller.Filter(testCases[name].update))
+		})
+	}
+}
+
+func TestNewPoller_WithoutOwner(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	service := allowlist.NewService(mocks.NewMockStorer(mockCtrl), 0, timeNow)
+	poller := allowlist.NewPoller(time.Second, service)
+
+	assert.False(t, poller.Filter(&tb.Update{Message: &tb.Message{Chat: &tb.Chat{ID: -1002}, Text: "/remindregister"}}))
+}
@@ -0,0 +1,91 @@
+package allowlist
+
+//go:generate mockgen -source=$GOFILE -destination=mocks/${GOFILE} -package=mocks
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var AllowedChatsBucket = []byte("allowedchats")
+
+var ErrNotFound = errors.New("chat not found")
+
+type Storer interface {
+	GetChat(chatID int) (*Chat, error)
+	GetChats() ([]Chat, error)
+	UpsertChat(*Chat) error
+}
+
+type Store struct {
+	db *bolt.DB
+}
+
+func NewStore(db *bolt.DB) *Store {
+	return &Store{db: db}
+}
+
+func (s *Store) UpsertChat(chat *Chat) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(AllowedChatsBucket)
+
+		buf, err := json.Marshal(chat)
+		if err != nil {
+			return err
+		}
+
+		return bucket.Put(itob(chat.ChatID), buf)
+	})
+}
+
+func (s *Store) GetChat(chatID int) (*Chat, error) {
+	var chat Chat
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(AllowedChatsBucket)
+		v := bucket.Get(itob(chatID))
+		if v == nil {
+			return ErrNotFound
+		}
+
+		return json.Unmarshal(v, &chat)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &chat, nil
+}
+
+func (s *Store) GetChats() ([]Chat, error) {
+	chats := []Chat{}
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(AllowedChatsBucket)
+
+		return bucket.ForEach(func(k, v []byte) error {
+			var chat Chat
+
+			err := json.Unmarshal(v, &chat)
+			if err != nil {
+				return err
+			}
+
+			chats = append(chats, chat)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return chats, nil
+}
+
+// itob converts int to []byte
+func itob(v int) []byte {
+	return []byte(strconv.FormatInt(int64(v), 10))
+}
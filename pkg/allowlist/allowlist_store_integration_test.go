@@ -0,0 +1,59 @@
+package allowlist_test
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/husol/telegram-reminder-bot/pkg/allowlist"
+	"github.com/husol/telegram-reminder-bot/pkg/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllowListStore(t *testing.T) {
+	checkSkip(t)
+
+	chatID := generateRandomInt()
+	database, err := db.SetupDB(testDBFile(), nil)
+	require.NoError(t, err)
+	defer database.Close()
+	store := allowlist.NewStore(database)
+
+	_, err = store.GetChat(chatID)
+	assert.Equal(t, allowlist.ErrNotFound, err)
+
+	requestedAt := time.Date(2020, time.April, 1, 9, 0, 0, 0, time.UTC)
+	chat := &allowlist.Chat{ChatID: chatID, Title: "Ops", RequestedBy: "Alice", Status: allowlist.Pending, RequestedAt: requestedAt}
+	require.NoError(t, store.UpsertChat(chat))
+
+	foundChat, err := store.GetChat(chatID)
+	require.NoError(t, err)
+	assert.Equal(t, chat, foundChat)
+
+	chats, err := store.GetChats()
+	require.NoError(t, err)
+	assert.Contains(t, chats, *chat)
+}
+
+func checkSkip(t *testing.T) {
+	testDBFile := os.Getenv("TEST_DB_FILE")
+	if testDBFile == "" {
+		t.Skip()
+	}
+}
+
+func testDBFile() string {
+	return fmt.Sprintf("../%s", os.Getenv("TEST_DB_FILE"))
+}
+
+func generateRandomInt() int {
+	nBig, err := rand.Int(rand.Reader, big.NewInt(10000))
+	if err != nil {
+		panic(err)
+	}
+	return int(nBig.Int64())
+}
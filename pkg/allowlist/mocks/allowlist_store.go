@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: allowlist_store.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	allowlist "github.com/husol/telegram-reminder-bot/pkg/allowlist"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockStorer is a mock of Storer interface
+type MockStorer struct {
+	ctrl     *gomock.Controller
+	recorder *MockStorerMockRecorder
+}
+
+// MockStorerMockRecorder is the mock recorder for MockStorer
+type MockStorerMockRecorder struct {
+	mock *MockStorer
+}
+
+// NewMockStorer creates a new mock instance
+func NewMockStorer(ctrl *gomock.Controller) *MockStorer {
+	mock := &MockStorer{ctrl: ctrl}
+	mock.recorder = &MockStorerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockStorer) EXPECT() *MockStorerMockRecorder {
+	return m.recorder
+}
+
+// GetChat mocks base method
+func (m *MockStorer) GetChat(chatID int) (*allowlist.Chat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChat", chatID)
+	ret0, _ := ret[0].(*allowlist.Chat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChat indicates an expected call of GetChat
+func (mr *MockStorerMockRecorder) GetChat(chatID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChat", reflect.TypeOf((*MockStorer)(nil).GetChat), chatID)
+}
+
+// GetChats mocks base method
+func (m *MockStorer) GetChats() ([]allowlist.Chat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChats")
+	ret0, _ := ret[0].([]allowlist.Chat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChats indicates an expected call of GetChats
+func (mr *MockStorerMockRecorder) GetChats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChats", reflect.TypeOf((*MockStorer)(nil).GetChats))
+}
+
+// UpsertChat mocks base method
+func (m *MockStorer) UpsertChat(arg0 *allowlist.Chat) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertChat", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertChat indicates an expected call of UpsertChat
+func (mr *MockStorerMockRecorder) UpsertChat(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertChat", reflect.TypeOf((*MockStorer)(nil).UpsertChat), arg0)
+}
@@ -0,0 +1,64 @@
+package allowlist
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+// RegisterCommand is the command chats which can't use the bot yet send to ask the owner to approve them
+const RegisterCommand = "/remindregister"
+
+// NewPoller returns a poller which only lets through the updates of the chats which can use the bot,
+// apart from the command to ask to use it when the bot has an owner to approve them.
+// Buttons pressed in a private chat are let through when the chat they are for can use the bot
+func NewPoller(pollTimeout time.Duration, service *Service) *tb.MiddlewarePoller {
+	poller := &tb.LongPoller{Timeout: pollTimeout}
+
+	return tb.NewMiddlewarePoller(poller, func(upd *tb.Update) bool {
+		if upd.Message != nil {
+			if service.IsAllowed(int(upd.Message.Chat.ID)) {
+				return true
+			}
+
+			return service.OwnerID() != 0 && strings.HasPrefix(upd.Message.Text, RegisterCommand)
+		}
+
+		if upd.Callback != nil && upd.Callback.Message != nil {
+			if service.IsAllowed(int(upd.Callback.Message.Chat.ID)) {
+				return true
+			}
+
+			if upd.Callback.Message.Chat.Type != tb.ChatPrivate {
+				return false
+			}
+
+			chatID, ok := callbackChatID(upd.Callback.Data)
+
+			return ok && service.IsAllowed(chatID)
+		}
+
+		return false
+	})
+}
+
+// callbackChatID returns the chat a button pressed in another chat is for.
+// Such buttons carry the chat as the last field of their data
+// e.g. "\fDoneBtn|3:-1001234" for a reminder sent by private message
+func callbackChatID(data string) (int, bool) {
+	i := strings.Index(data, "|")
+	if i == -1 {
+		return 0, false
+	}
+
+	fields := strings.Split(data[i+1:], ":")
+	if len(fields) < 2 {
+		return 0, false
+	}
+
+	chatID, err := strconv.Atoi(fields[len(fields)-1])
+
+	return chatID, err == nil
+}
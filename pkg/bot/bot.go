@@ -2,14 +2,21 @@ package bot
 
 import (
 	"log"
+	"time"
 
+	"github.com/husol/telegram-reminder-bot/pkg/allowlist"
 	"github.com/husol/telegram-reminder-bot/pkg/chatpreference"
 	"github.com/husol/telegram-reminder-bot/pkg/command"
 	"github.com/husol/telegram-reminder-bot/pkg/cron"
 	"github.com/husol/telegram-reminder-bot/pkg/date"
+	"github.com/husol/telegram-reminder-bot/pkg/db"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
+	"github.com/husol/telegram-reminder-bot/pkg/permission"
 	"github.com/husol/telegram-reminder-bot/pkg/reminder"
 	"github.com/husol/telegram-reminder-bot/pkg/telegram"
+	"github.com/husol/telegram-reminder-bot/pkg/userpreference"
 	"go.etcd.io/bbolt"
+	tb "gopkg.in/tucnak/telebot.v2"
 )
 
 type Bot struct {
@@ -19,27 +26,42 @@ type Bot struct {
 
 // nolint:funlen,lll
 func New(
-	allowedChats []int,
+	allowList *allowlist.Service,
 	database *bbolt.DB,
 	telegramBot telegram.TBWrapBot,
+	fileGetter telegram.FileGetter,
+	messageEditor telegram.MessageEditor,
+	messageForwarder telegram.MessageForwarder,
+	chatMembers telegram.ChatMemberGetter,
+	minCronInterval time.Duration,
 ) *Bot {
 	cronScheduler := cron.NewScheduler()
 	reminderStore := reminder.NewStore(database)
+	occurrenceStore := reminder.NewOccurrenceStore(database)
 	chatPreferenceStore := chatpreference.NewStore(database)
 	chatPreferenceService := chatpreference.NewService(chatPreferenceStore)
-	remindCronFuncService := reminder.NewCronFuncService(telegramBot, cronScheduler, reminderStore, chatPreferenceStore)
+	userPreferenceStore := userpreference.NewStore(database)
+	remindCronFuncService := reminder.NewCronFuncService(telegramBot, messageForwarder, cronScheduler, reminderStore, occurrenceStore, chatPreferenceStore)
 	remindListService := command.NewRemindListService(reminderStore, cronScheduler, chatPreferenceStore)
 	remindDeleteService := command.NewRemindeDeleteService(reminderStore, cronScheduler)
 	reminderScheduler := reminder.NewScheduler(telegramBot, remindCronFuncService, reminderStore, cronScheduler, chatPreferenceStore)
 	remindDateService := reminder.NewService(reminderScheduler, reminderStore, chatPreferenceStore, date.RealTimeNow)
-	remindDetailService := command.NewRemindDetailService(reminderStore, cronScheduler, chatPreferenceStore)
-	reminderLoader := reminder.NewLoaderService(telegramBot, cronScheduler, reminderStore, chatPreferenceStore, remindCronFuncService)
-	setTimeZoneService := command.NewSetTimezoneService(chatPreferenceStore, reminderLoader)
-	remindDetailButtons := command.NewRemindDetailButtons()
-	remindListButtons := command.NewRemindListButtons()
-	reminderCompleteButtons := reminder.NewButtons()
+	remindDetailService := command.NewRemindDetailService(reminderStore, occurrenceStore, cronScheduler, chatPreferenceStore)
+	reminderLoader := reminder.NewLoaderService(telegramBot, cronScheduler, reminderStore, chatPreferenceStore, remindCronFuncService, date.RealTimeNow).
+		ForAllowedChats(allowList.IsAllowed)
+	setTimeZoneService := command.NewSetTimezoneService(chatPreferenceStore, userPreferenceStore, reminderLoader)
+	setHolidaysService := command.NewSetHolidaysService(chatPreferenceStore, reminderLoader)
+	permissionService := permission.NewService(reminderStore, chatPreferenceStore, chatMembers)
+	remindRegisterService := command.NewRemindRegisterService(allowList, chatPreferenceService, reminderLoader, func(chatID int) error {
+		return db.SetUpChat(database, chatID)
+	})
+	// buttons are handled by their unique name, which is the same in every language
+	remindDetailButtons := command.NewRemindDetailButtons(i18n.English)
+	remindListButtons := command.NewRemindListButtons(i18n.English)
+	reminderCompleteButtons := reminder.NewButtons(i18n.English)
+	remindRegisterButtons := command.NewRemindRegisterButtons(i18n.English)
 
-	chatPreferenceService.CreateDefaultChatPreferences(allowedChats)
+	chatPreferenceService.CreateDefaultChatPreferences(allowList.ApprovedChats())
 
 	// check if DB exists and load schedules
 	remindersLoaded, err := reminderLoader.LoadSchedulesFromDB()
@@ -48,58 +70,68 @@ func New(
 	}
 	log.Printf("loaded %d reminders", remindersLoaded)
 
+	// the errors the handlers reply with are read from the catalog in the language of the chat
+	telegramBot = newTranslatingBot(telegramBot, chatPreferenceService)
 	telegramBot.Handle(command.HandlePatternRemindList,
-		command.HandleRemindList(remindListService, remindListButtons))
+		command.HandleRemindList(remindListService, chatPreferenceService, command.NewRemindListButtons))
 	telegramBot.Handle(command.HandlePatternHelp,
-		command.HandleRemindHelp())
+		command.HandleRemindHelp(chatPreferenceService))
 	telegramBot.HandleMultiRegExp(command.HandlePatternRemindDetail,
-		command.HandleRemindDetail(remindDetailService, command.NewRemindDetailButtons()))
+		command.HandleRemindDetail(remindDetailService, chatPreferenceService, command.NewRemindDetailButtons))
 	telegramBot.HandleMultiRegExp(command.HandlePatternRemindDelete,
-		command.HandleRemindDelete(remindDeleteService),
+		command.HandleRemindDelete(remindDeleteService, permissionService, chatPreferenceService),
 	)
-
-	telegramBot.HandleRegExp(
-		command.HandlePatternRemindDayMonth,
-		command.HandleRemindDayMonth(remindDateService),
+	telegramBot.HandleMultiRegExp(command.HandlePatternRemindEditMessage,
+		command.HandleRemindEditMessage(remindDateService, permissionService, chatPreferenceService),
 	)
-	telegramBot.HandleRegExp(
-		command.HandlePatternRemindDayOfWeek,
-		command.HandleRemindDayOfWeek(remindDateService),
+	telegramBot.HandleMultiRegExp(command.HandlePatternRemindEdit,
+		command.HandleRemindEdit(remindDateService, userPreferenceStore, permissionService, chatPreferenceService, minCronInterval),
 	)
-	telegramBot.HandleRegExp(
-		command.HandlePatternRemindEveryDayNumber,
-		command.HandleRemindEveryDayNumber(remindDateService),
+	telegramBot.HandleMultiRegExp(command.HandlePatternRemindPause,
+		command.HandleRemindPause(remindDateService, permissionService, chatPreferenceService),
 	)
-	telegramBot.HandleRegExp(
-		command.HandlePatternRemindEveryDayNumberMonth,
-		command.HandleRemindEveryDayNumberMonth(remindDateService),
+	telegramBot.HandleMultiRegExp(command.HandlePatternRemindResume,
+		command.HandleRemindResume(remindDateService, permissionService, chatPreferenceService),
 	)
-	telegramBot.HandleRegExp(
-		command.HandlePatternRemindIn,
-		command.HandleRemindIn(remindDateService),
+	telegramBot.HandleMultiRegExp(command.HandlePatternRemindSkip,
+		command.HandleRemindSkip(remindDateService, permissionService, chatPreferenceService),
 	)
-	telegramBot.HandleRegExp(
-		command.HandlePatternRemindEvery,
-		command.HandleRemindEvery(remindDateService),
+	telegramBot.HandleMultiRegExp(command.HandlePatternRemindRosterSkip,
+		command.HandleRemindRosterSkip(remindDateService, permissionService, chatPreferenceService),
 	)
-	telegramBot.HandleRegExp(
-		command.HandlePatternRemindWhen,
-		command.HandleRemindWhen(remindDateService),
+	telegramBot.HandleMultiRegExp(command.HandlePatternRemindRosterSwap,
+		command.HandleRemindRosterSwap(remindDateService, permissionService, chatPreferenceService),
 	)
-	telegramBot.HandleRegExp(
-		command.HandlePatternRemindEveryDayOfWeek,
-		command.HandleRemindEveryDayOfWeek(remindDateService),
+	telegramBot.HandleRegExp(command.HandlePatternRemindNagOff,
+		command.HandleRemindNagOff(remindDateService, permissionService, chatPreferenceService),
 	)
-	telegramBot.HandleRegExp(
-		command.HandlePatternRemindEveryDay,
-		command.HandleRemindEveryDay(remindDateService),
+	telegramBot.HandleRegExp(command.HandlePatternRemindNag,
+		command.HandleRemindNag(remindDateService, permissionService, chatPreferenceService),
 	)
-	telegramBot.HandleRegExp(
-		command.HandlePatternRemindAt,
-		command.HandleRemindAt(remindDateService),
+
+	telegramBot.HandleMultiRegExp(
+		command.HandlePatternRemind,
+		command.HandleRemind(remindDateService, userPreferenceStore, chatPreferenceService, minCronInterval),
 	)
 	telegramBot.Handle(command.HandlePatternGetTimezone, command.HandleGetTimezone(chatPreferenceStore))
-	telegramBot.HandleRegExp(command.HandlePatternSetTimezone, command.HandleSetTimezone(setTimeZoneService))
+	telegramBot.HandleRegExp(command.HandlePatternSetUserTimezone, command.HandleSetUserTimezone(setTimeZoneService, chatPreferenceService))
+	telegramBot.HandleRegExp(command.HandlePatternSetTimezone, command.HandleSetTimezone(setTimeZoneService, permissionService, chatPreferenceService))
+	telegramBot.HandleRegExp(command.HandlePatternSetLateReminders, command.HandleSetLateReminders(chatPreferenceStore, permissionService))
+	telegramBot.HandleRegExp(command.HandlePatternSetDateOrder, command.HandleSetDateOrder(chatPreferenceStore, permissionService))
+	telegramBot.HandleRegExp(command.HandlePatternSetLanguage, command.HandleSetLanguage(chatPreferenceStore, permissionService))
+	telegramBot.HandleRegExp(command.HandlePatternSetPermissions, command.HandleSetPermissions(chatPreferenceStore, permissionService))
+	telegramBot.HandleRegExp(command.HandlePatternSetHolidays, command.HandleSetHolidays(setHolidaysService, permissionService, chatPreferenceService))
+	telegramBot.Handle(command.HandlePatternRemindRegister,
+		command.HandleRemindRegister(remindRegisterService, telegramBot, chatPreferenceService))
+	handleRemindWithAttachment := command.HandleRemindWithAttachment(
+		remindDateService, userPreferenceStore, chatPreferenceService, minCronInterval,
+	)
+	telegramBot.Handle(tb.OnDocument, command.HandleCaptions(
+		command.HandleSetHolidaysFromFile(setHolidaysService, permissionService, chatPreferenceService, fileGetter),
+		handleRemindWithAttachment,
+	))
+	telegramBot.Handle(tb.OnPhoto, handleRemindWithAttachment)
+	telegramBot.Handle(tb.OnVoice, handleRemindWithAttachment)
 
 	// buttons
 	telegramBot.HandleButton(
@@ -108,7 +140,19 @@ func New(
 	)
 	telegramBot.HandleButton(
 		remindDetailButtons[command.ReminderDetailDeleteBtn],
-		command.HandleReminderDetailDeleteBtn(remindDetailService),
+		command.HandleReminderDetailDeleteBtn(remindDetailService, permissionService, chatPreferenceService),
+	)
+	telegramBot.HandleButton(
+		remindDetailButtons[command.ReminderDetailEditBtn],
+		command.HandleReminderDetailEditBtn(remindDetailService, permissionService, chatPreferenceService),
+	)
+	telegramBot.HandleButton(
+		remindDetailButtons[command.ReminderDetailPauseBtn],
+		command.HandleReminderDetailPauseBtn(remindDateService, permissionService, chatPreferenceService),
+	)
+	telegramBot.HandleButton(
+		remindDetailButtons[command.ReminderDetailResumeBtn],
+		command.HandleReminderDetailResumeBtn(remindDateService, permissionService, chatPreferenceService),
 	)
 	telegramBot.HandleButton(
 		remindDetailButtons[command.ReminderDetailShowReminderCommandBtn],
@@ -116,71 +160,116 @@ func New(
 	)
 	telegramBot.HandleButton(
 		remindListButtons[command.ReminderListRemoveCompletedRemindersBtn],
-		command.HandleReminderListRemoveCompletedRemindersBtn(remindListService),
+		command.HandleReminderListRemoveCompletedRemindersBtn(remindListService, permissionService, chatPreferenceService),
 	)
 	telegramBot.HandleButton(
 		remindListButtons[command.ReminderListCloseCommandBtn],
 		command.HandleRemindListCloseBtn(),
 	)
+	handleReminderListPageBtn := command.HandleReminderListPageBtn(
+		remindListService, chatPreferenceService, command.NewRemindListButtons, messageEditor,
+	)
+	telegramBot.HandleButton(remindListButtons[command.ReminderListPreviousPageBtn], handleReminderListPageBtn)
+	telegramBot.HandleButton(remindListButtons[command.ReminderListNextPageBtn], handleReminderListPageBtn)
+	telegramBot.HandleButton(
+		remindListButtons[command.ReminderListDetailBtn],
+		command.HandleReminderListDetailBtn(remindDetailService, chatPreferenceService, command.NewRemindDetailButtons),
+	)
+	telegramBot.HandleButton(
+		remindListButtons[command.ReminderListPauseBtn],
+		command.HandleReminderListPauseBtn(
+			remindDateService, remindListService, permissionService, chatPreferenceService, command.NewRemindListButtons, messageEditor,
+		),
+	)
+	telegramBot.HandleButton(
+		remindListButtons[command.ReminderListResumeBtn],
+		command.HandleReminderListResumeBtn(
+			remindDateService, remindListService, permissionService, chatPreferenceService, command.NewRemindListButtons, messageEditor,
+		),
+	)
+	telegramBot.HandleButton(
+		remindListButtons[command.ReminderListDeleteBtn],
+		command.HandleReminderListDeleteBtn(
+			remindDeleteService, remindListService, permissionService, chatPreferenceService, command.NewRemindListButtons, messageEditor,
+		),
+	)
 	telegramBot.HandleButton(
 		reminderCompleteButtons[reminder.Snooze10MinuteBtn],
-		reminder.HandleReminderSnoozeAmountDateTimeBtn(remindDateService, reminderStore, reminder.AmountDateTime{Minutes: 10}),
+		reminder.HandleReminderSnoozeAmountDateTimeBtn(
+			remindDateService, remindCronFuncService, reminderStore, permissionService, reminder.AmountDateTime{Minutes: 10},
+		),
 	)
 	telegramBot.HandleButton(
 		reminderCompleteButtons[reminder.Snooze20MinuteBtn],
-		reminder.HandleReminderSnoozeAmountDateTimeBtn(remindDateService, reminderStore, reminder.AmountDateTime{Minutes: 20}),
+		reminder.HandleReminderSnoozeAmountDateTimeBtn(
+			remindDateService, remindCronFuncService, reminderStore, permissionService, reminder.AmountDateTime{Minutes: 20},
+		),
 	)
 	telegramBot.HandleButton(
 		reminderCompleteButtons[reminder.Snooze30MinuteBtn],
-		reminder.HandleReminderSnoozeAmountDateTimeBtn(remindDateService, reminderStore, reminder.AmountDateTime{Minutes: 30}),
+		reminder.HandleReminderSnoozeAmountDateTimeBtn(
+			remindDateService, remindCronFuncService, reminderStore, permissionService, reminder.AmountDateTime{Minutes: 30},
+		),
 	)
 	telegramBot.HandleButton(
 		reminderCompleteButtons[reminder.Snooze1HourBtn],
-		reminder.HandleReminderSnoozeAmountDateTimeBtn(remindDateService, reminderStore, reminder.AmountDateTime{Minutes: 60}),
+		reminder.HandleReminderSnoozeAmountDateTimeBtn(
+			remindDateService, remindCronFuncService, reminderStore, permissionService, reminder.AmountDateTime{Minutes: 60},
+		),
 	)
 	telegramBot.HandleButton(
 		reminderCompleteButtons[reminder.SnoozeThisAfternoonBtn],
-		reminder.HandleReminderSnoozeWordDateTimeBtn(remindDateService, reminderStore, reminder.WordDateTime{
-			When:   reminder.Today,
-			Hour:   15,
-			Minute: 0,
-		}),
+		reminder.HandleReminderSnoozeWordDateTimeBtn(
+			remindDateService, remindCronFuncService, reminderStore, permissionService, reminder.WordDateTime{
+				When:   reminder.Today,
+				Hour:   15,
+				Minute: 0,
+			},
+		),
 	)
 	telegramBot.HandleButton(
 		reminderCompleteButtons[reminder.SnoozeThisEveningBtn],
-		reminder.HandleReminderSnoozeWordDateTimeBtn(remindDateService, reminderStore, reminder.WordDateTime{
-			When:   reminder.Today,
-			Hour:   20,
-			Minute: 0,
-		}),
+		reminder.HandleReminderSnoozeWordDateTimeBtn(
+			remindDateService, remindCronFuncService, reminderStore, permissionService, reminder.WordDateTime{
+				When:   reminder.Today,
+				Hour:   20,
+				Minute: 0,
+			},
+		),
 	)
 	telegramBot.HandleButton(
 		reminderCompleteButtons[reminder.SnoozeTomorrowMorningBtn],
-		reminder.HandleReminderSnoozeWordDateTimeBtn(remindDateService, reminderStore, reminder.WordDateTime{
-			When:   reminder.Tomorrow,
-			Hour:   9,
-			Minute: 0,
-		}),
+		reminder.HandleReminderSnoozeWordDateTimeBtn(
+			remindDateService, remindCronFuncService, reminderStore, permissionService, reminder.WordDateTime{
+				When:   reminder.Tomorrow,
+				Hour:   9,
+				Minute: 0,
+			},
+		),
 	)
 	telegramBot.HandleButton(
 		reminderCompleteButtons[reminder.SnoozeTomorrowAfternoonBtn],
-		reminder.HandleReminderSnoozeWordDateTimeBtn(remindDateService, reminderStore, reminder.WordDateTime{
-			When:   reminder.Tomorrow,
-			Hour:   15,
-			Minute: 0,
-		}),
+		reminder.HandleReminderSnoozeWordDateTimeBtn(
+			remindDateService, remindCronFuncService, reminderStore, permissionService, reminder.WordDateTime{
+				When:   reminder.Tomorrow,
+				Hour:   15,
+				Minute: 0,
+			},
+		),
 	)
 	telegramBot.HandleButton(
 		reminderCompleteButtons[reminder.SnoozeTomorrowEveningBtn],
-		reminder.HandleReminderSnoozeWordDateTimeBtn(remindDateService, reminderStore, reminder.WordDateTime{
-			When:   reminder.Tomorrow,
-			Hour:   20,
-			Minute: 0,
-		}),
+		reminder.HandleReminderSnoozeWordDateTimeBtn(
+			remindDateService, remindCronFuncService, reminderStore, permissionService, reminder.WordDateTime{
+				When:   reminder.Tomorrow,
+				Hour:   20,
+				Minute: 0,
+			},
+		),
 	)
 	telegramBot.HandleButton(
 		reminderCompleteButtons[reminder.SnoozeBtn],
-		reminder.HandleReminderSnoozeBtn(reminderStore),
+		reminder.HandleReminderSnoozeBtn(reminderStore, chatPreferenceService),
 	)
 	telegramBot.HandleButton(
 		reminderCompleteButtons[reminder.SnoozeCloseBtn],
@@ -188,7 +277,35 @@ func New(
 	)
 	telegramBot.HandleButton(
 		reminderCompleteButtons[reminder.CompleteBtn],
-		reminder.HandleReminderCompleteBtn(remindCronFuncService, reminderStore),
+		reminder.HandleReminderCompleteBtn(remindCronFuncService, reminderStore, permissionService),
+	)
+	telegramBot.HandleButton(
+		reminderCompleteButtons[reminder.DoneBtn],
+		reminder.HandleReminderDoneBtn(remindCronFuncService, reminderStore, permissionService),
+	)
+	telegramBot.HandleButton(
+		reminderCompleteButtons[reminder.SkipNextBtn],
+		reminder.HandleReminderSkipNextBtn(remindDateService, reminderStore, permissionService, chatPreferenceService),
+	)
+	telegramBot.HandleButton(
+		reminderCompleteButtons[reminder.OnItBtn],
+		reminder.HandleReminderAcknowledgeBtn(remindCronFuncService, reminderStore, occurrenceStore, messageEditor, reminder.AckOnIt),
+	)
+	telegramBot.HandleButton(
+		reminderCompleteButtons[reminder.AckDoneBtn],
+		reminder.HandleReminderAcknowledgeBtn(remindCronFuncService, reminderStore, occurrenceStore, messageEditor, reminder.AckDone),
+	)
+	telegramBot.HandleButton(
+		reminderCompleteButtons[reminder.ChecklistItemBtn],
+		reminder.HandleReminderChecklistItemBtn(remindCronFuncService, reminderStore, occurrenceStore, messageEditor),
+	)
+	telegramBot.HandleButton(
+		remindRegisterButtons[command.RemindRegisterApproveBtn],
+		command.HandleRemindRegisterDecisionBtn(remindRegisterService, telegramBot, messageEditor, chatPreferenceService, allowlist.Approved),
+	)
+	telegramBot.HandleButton(
+		remindRegisterButtons[command.RemindRegisterDenyBtn],
+		command.HandleRemindRegisterDecisionBtn(remindRegisterService, telegramBot, messageEditor, chatPreferenceService, allowlist.Denied),
 	)
 
 	return &Bot{
@@ -0,0 +1,46 @@
+package bot
+
+import (
+	"github.com/enrico5b1b4/tbwrap"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
+	"github.com/husol/telegram-reminder-bot/pkg/telegram"
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+// translatingBot replies with the errors of its handlers in the language of the chat,
+// as the services behind the handlers return errors read from the catalog without knowing the language
+type translatingBot struct {
+	telegram.TBWrapBot
+	languages i18n.Languages
+}
+
+func newTranslatingBot(bot telegram.TBWrapBot, languages i18n.Languages) *translatingBot {
+	return &translatingBot{TBWrapBot: bot, languages: languages}
+}
+
+func (b *translatingBot) Handle(path string, handler tbwrap.HandlerFunc) {
+	b.TBWrapBot.Handle(path, b.translate(handler))
+}
+
+func (b *translatingBot) HandleButton(path *tb.InlineButton, handler tbwrap.HandlerFunc) {
+	b.TBWrapBot.HandleButton(path, b.translate(handler))
+}
+
+func (b *translatingBot) HandleRegExp(path string, handler tbwrap.HandlerFunc) {
+	b.TBWrapBot.HandleRegExp(path, b.translate(handler))
+}
+
+func (b *translatingBot) HandleMultiRegExp(paths []string, handler tbwrap.HandlerFunc) {
+	b.TBWrapBot.HandleMultiRegExp(paths, b.translate(handler))
+}
+
+func (b *translatingBot) translate(handler tbwrap.HandlerFunc) tbwrap.HandlerFunc {
+	return func(c tbwrap.Context) error {
+		err := handler(c)
+		if err == nil {
+			return nil
+		}
+
+		return i18n.Translate(b.languages.ChatLanguage(int(c.ChatID())), err)
+	}
+}
@@ -1,6 +1,42 @@
 package chatpreference
 
+import (
+	"github.com/husol/telegram-reminder-bot/pkg/holiday"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
+)
+
 type ChatPreference struct {
-	ChatID   int    `json:"chat_id"`
-	TimeZone string `json:"time_zone"`
+	ChatID            int               `json:"chat_id"`
+	TimeZone          string            `json:"time_zone"`
+	SkipLateReminders bool              `json:"skip_late_reminders"`
+	Holidays          *holiday.Calendar `json:"holidays"`
+	DateOrder         DateOrder         `json:"date_order"`
+	Language          i18n.Language     `json:"language"` // empty until the chat sets one, which is read as English
+	Permissions       Permissions       `json:"permissions"`
+}
+
+// DateOrder is the order in which the day and month of dates written with numbers are read e.g. 14/03/2027
+type DateOrder int
+
+const (
+	DayMonth DateOrder = 0
+	MonthDay DateOrder = 1
+)
+
+func (o DateOrder) String() string {
+	return [...]string{"day/month/year", "month/day/year"}[o]
+}
+
+// Permissions is who in a group can edit, pause, skip, delete and finish reminders.
+// Admins of the group always can, and anyone can in a private chat. The settings of the chat are left to its admins
+type Permissions int
+
+const (
+	Everyone    Permissions = 0
+	CreatorOnly Permissions = 1 // the member who set a reminder
+	AdminsOnly  Permissions = 2
+)
+
+func (p Permissions) String() string {
+	return [...]string{"everyone", "creator", "admins"}[p]
 }
@@ -1,5 +1,7 @@
 package chatpreference
 
+import "github.com/husol/telegram-reminder-bot/pkg/i18n"
+
 const defaultTimeZone = "Asia/Ho_Chi_Minh"
 
 type Service struct {
@@ -18,3 +20,13 @@ func (s *Service) CreateDefaultChatPreferences(chats []int) {
 		}
 	}
 }
+
+// ChatLanguage returns the language the chat reads the bot in, English when it has not set one
+func (s *Service) ChatLanguage(chatID int) i18n.Language {
+	cp, err := s.store.GetChatPreference(chatID)
+	if err != nil || cp.Language == "" {
+		return i18n.English
+	}
+
+	return cp.Language
+}
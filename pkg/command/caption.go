@@ -0,0 +1,19 @@
+package command
+
+import (
+	"github.com/enrico5b1b4/tbwrap"
+)
+
+// HandleCaptions handles a file sent to the chat with each of the commands which can be written as its caption,
+// as a bot only has a single handler for each kind of file. Each handler leaves alone captions which are not its command
+func HandleCaptions(handlers ...func(c tbwrap.Context) error) func(c tbwrap.Context) error {
+	return func(c tbwrap.Context) error {
+		for _, handler := range handlers {
+			if err := handler(c); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
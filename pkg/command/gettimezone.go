@@ -1,10 +1,9 @@
 package command
 
 import (
-	"fmt"
-
 	"github.com/enrico5b1b4/tbwrap"
 	"github.com/husol/telegram-reminder-bot/pkg/chatpreference"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
 )
 
 // nolint:lll
@@ -17,7 +16,7 @@ func HandleGetTimezone(store chatpreference.Storer) func(c tbwrap.Context) error
 			return err
 		}
 
-		_, err = c.Send(fmt.Sprintf("Your timezone is: %s", cp.TimeZone))
+		_, err = c.Send(i18n.T(cp.Language, i18n.TimezoneIs, cp.TimeZone))
 
 		return err
 	}
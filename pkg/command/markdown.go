@@ -0,0 +1,11 @@
+package command
+
+import "strings"
+
+// markdownEscaper escapes the characters which start an entity in messages sent as Markdown
+var markdownEscaper = strings.NewReplacer("_", `\_`, "*", `\*`, "`", "\\`", "[", `\[`)
+
+// escapeMarkdown makes text which may contain Markdown characters, such as a cron spec, show as it was written
+func escapeMarkdown(text string) string {
+	return markdownEscaper.Replace(text)
+}
@@ -3,12 +3,29 @@ package command
 import (
 	"fmt"
 
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
 	"github.com/husol/telegram-reminder-bot/pkg/reminder"
 )
 
-func ReminderAddedSuccessMessage(message string, nextSchedule reminder.NextScheduleChatTime) string {
-	return fmt.Sprintf("Reminder \"%s\" has been added for %s",
-		message,
-		nextSchedule.Time.In(nextSchedule.Location).Format("Mon, 02 Jan 2006 15:04 MST"),
-	)
+func ReminderAddedSuccessMessage(lang i18n.Language, message string, nextSchedule reminder.NextScheduleChatTime) string {
+	return i18n.T(lang, i18n.ReminderAdded, message, formatNextSchedule(lang, nextSchedule))
+}
+
+func ReminderResumedSuccessMessage(lang i18n.Language, reminderID int, nextSchedule reminder.NextScheduleChatTime) string {
+	return i18n.T(lang, i18n.ReminderResumed, reminderID, formatNextSchedule(lang, nextSchedule))
+}
+
+func ReminderEditedSuccessMessage(lang i18n.Language, message string, nextSchedule reminder.NextScheduleChatTime) string {
+	return i18n.T(lang, i18n.ReminderUpdated, message, formatNextSchedule(lang, nextSchedule))
+}
+
+// formatNextSchedule formats the next time a reminder is sent in the timezone of the chat,
+// followed by the time in the timezone it was set in when that is a different one
+func formatNextSchedule(lang i18n.Language, nextSchedule reminder.NextScheduleChatTime) string {
+	chatTime := i18n.FormatTime(lang, i18n.DateTimeZone, nextSchedule.Time.In(nextSchedule.Location))
+	if nextSchedule.ReminderLocation == nil {
+		return chatTime
+	}
+
+	return fmt.Sprintf("%s (%s)", chatTime, i18n.FormatTime(lang, i18n.DateTimeZone, nextSchedule.Time.In(nextSchedule.ReminderLocation)))
 }
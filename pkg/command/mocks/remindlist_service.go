@@ -35,18 +35,18 @@ func (m *MockRemindListServicer) EXPECT() *MockRemindListServicerMockRecorder {
 }
 
 // GetRemindersByChatID mocks base method
-func (m *MockRemindListServicer) GetRemindersByChatID(chatID int) ([]command.ByJobStatusList, error) {
+func (m *MockRemindListServicer) GetRemindersByChatID(chatID int, filter command.RemindListFilter) ([]command.ByJobStatusList, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRemindersByChatID", chatID)
+	ret := m.ctrl.Call(m, "GetRemindersByChatID", chatID, filter)
 	ret0, _ := ret[0].([]command.ByJobStatusList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetRemindersByChatID indicates an expected call of GetRemindersByChatID
-func (mr *MockRemindListServicerMockRecorder) GetRemindersByChatID(chatID interface{}) *gomock.Call {
+func (mr *MockRemindListServicerMockRecorder) GetRemindersByChatID(chatID, filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRemindersByChatID", reflect.TypeOf((*MockRemindListServicer)(nil).GetRemindersByChatID), chatID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRemindersByChatID", reflect.TypeOf((*MockRemindListServicer)(nil).GetRemindersByChatID), chatID, filter)
 }
 
 // RemoveCompletedReminders mocks base method
@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: remindregister_service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	allowlist "github.com/husol/telegram-reminder-bot/pkg/allowlist"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRemindRegisterServicer is a mock of RemindRegisterServicer interface
+type MockRemindRegisterServicer struct {
+	ctrl     *gomock.Controller
+	recorder *MockRemindRegisterServicerMockRecorder
+}
+
+// MockRemindRegisterServicerMockRecorder is the mock recorder for MockRemindRegisterServicer
+type MockRemindRegisterServicerMockRecorder struct {
+	mock *MockRemindRegisterServicer
+}
+
+// NewMockRemindRegisterServicer creates a new mock instance
+func NewMockRemindRegisterServicer(ctrl *gomock.Controller) *MockRemindRegisterServicer {
+	mock := &MockRemindRegisterServicer{ctrl: ctrl}
+	mock.recorder = &MockRemindRegisterServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockRemindRegisterServicer) EXPECT() *MockRemindRegisterServicerMockRecorder {
+	return m.recorder
+}
+
+// OwnerID mocks base method
+func (m *MockRemindRegisterServicer) OwnerID() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OwnerID")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// OwnerID indicates an expected call of OwnerID
+func (mr *MockRemindRegisterServicerMockRecorder) OwnerID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OwnerID", reflect.TypeOf((*MockRemindRegisterServicer)(nil).OwnerID))
+}
+
+// Request mocks base method
+func (m *MockRemindRegisterServicer) Request(chat allowlist.Chat) (*allowlist.Chat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Request", chat)
+	ret0, _ := ret[0].(*allowlist.Chat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Request indicates an expected call of Request
+func (mr *MockRemindRegisterServicerMockRecorder) Request(chat interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Request", reflect.TypeOf((*MockRemindRegisterServicer)(nil).Request), chat)
+}
+
+// Approve mocks base method
+func (m *MockRemindRegisterServicer) Approve(chatID int) (*allowlist.Chat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Approve", chatID)
+	ret0, _ := ret[0].(*allowlist.Chat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Approve indicates an expected call of Approve
+func (mr *MockRemindRegisterServicerMockRecorder) Approve(chatID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Approve", reflect.TypeOf((*MockRemindRegisterServicer)(nil).Approve), chatID)
+}
+
+// Deny mocks base method
+func (m *MockRemindRegisterServicer) Deny(chatID int) (*allowlist.Chat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Deny", chatID)
+	ret0, _ := ret[0].(*allowlist.Chat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Deny indicates an expected call of Deny
+func (mr *MockRemindRegisterServicerMockRecorder) Deny(chatID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Deny", reflect.TypeOf((*MockRemindRegisterServicer)(nil).Deny), chatID)
+}
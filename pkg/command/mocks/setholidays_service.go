@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: setholidays_service.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	holiday "github.com/husol/telegram-reminder-bot/pkg/holiday"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSetHolidaysServicer is a mock of SetHolidaysServicer interface
+type MockSetHolidaysServicer struct {
+	ctrl     *gomock.Controller
+	recorder *MockSetHolidaysServicerMockRecorder
+}
+
+// MockSetHolidaysServicerMockRecorder is the mock recorder for MockSetHolidaysServicer
+type MockSetHolidaysServicerMockRecorder struct {
+	mock *MockSetHolidaysServicer
+}
+
+// NewMockSetHolidaysServicer creates a new mock instance
+func NewMockSetHolidaysServicer(ctrl *gomock.Controller) *MockSetHolidaysServicer {
+	mock := &MockSetHolidaysServicer{ctrl: ctrl}
+	mock.recorder = &MockSetHolidaysServicerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockSetHolidaysServicer) EXPECT() *MockSetHolidaysServicerMockRecorder {
+	return m.recorder
+}
+
+// SetHolidays mocks base method
+func (m *MockSetHolidaysServicer) SetHolidays(chatID int, calendar *holiday.Calendar) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHolidays", chatID, calendar)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHolidays indicates an expected call of SetHolidays
+func (mr *MockSetHolidaysServicerMockRecorder) SetHolidays(chatID, calendar interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHolidays", reflect.TypeOf((*MockSetHolidaysServicer)(nil).SetHolidays), chatID, calendar)
+}
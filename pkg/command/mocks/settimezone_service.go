@@ -46,3 +46,17 @@ func (mr *MockSetTimezoneServicerMockRecorder) SetTimeZone(chatID, timezone inte
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTimeZone", reflect.TypeOf((*MockSetTimezoneServicer)(nil).SetTimeZone), chatID, timezone)
 }
+
+// SetUserTimeZone mocks base method
+func (m *MockSetTimezoneServicer) SetUserTimeZone(userID int, timezone string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserTimeZone", userID, timezone)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUserTimeZone indicates an expected call of SetUserTimeZone
+func (mr *MockSetTimezoneServicerMockRecorder) SetUserTimeZone(userID, timezone interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserTimeZone", reflect.TypeOf((*MockSetTimezoneServicer)(nil).SetUserTimeZone), userID, timezone)
+}
@@ -0,0 +1,52 @@
+package command
+
+import (
+	"errors"
+
+	"github.com/enrico5b1b4/tbwrap"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
+	"gopkg.in/tucnak/telebot.v2"
+)
+
+// senderID returns the user who sent the command or pressed the button,
+// 0 when the command was sent on behalf of the chat
+func senderID(c tbwrap.Context) int {
+	if c.Callback() != nil {
+		if c.Callback().Sender == nil {
+			return 0
+		}
+		return c.Callback().Sender.ID
+	}
+
+	if c.Message() == nil || c.Message().Sender == nil {
+		return 0
+	}
+
+	return c.Message().Sender.ID
+}
+
+// notAllowedError is replied to a command sent by a member who is not allowed to send it under the permissions of the chat
+func notAllowedError(lang i18n.Language) error {
+	return errors.New(i18n.T(lang, i18n.NotAllowed))
+}
+
+// adminsOnlyError is replied to a command which changes the settings of a chat sent by a member who is not one of its admins
+func adminsOnlyError(lang i18n.Language) error {
+	return errors.New(i18n.T(lang, i18n.AdminsOnly))
+}
+
+// respondNotAllowed answers a button pressed by a member who is not allowed to press it with an alert instead of acting on it
+func respondNotAllowed(c tbwrap.Context, lang i18n.Language) error {
+	return c.Respond(c.Callback(), &telebot.CallbackResponse{
+		Text:      i18n.T(lang, i18n.NotAllowed),
+		ShowAlert: true,
+	})
+}
+
+// respondAdminsOnly answers a button which changes the settings of a chat pressed by a member who is not one of its admins
+func respondAdminsOnly(c tbwrap.Context, lang i18n.Language) error {
+	return c.Respond(c.Callback(), &telebot.CallbackResponse{
+		Text:      i18n.T(lang, i18n.AdminsOnly),
+		ShowAlert: true,
+	})
+}
@@ -0,0 +1,17 @@
+package command_test
+
+import (
+	"github.com/golang/mock/gomock"
+	permissionMocks "github.com/husol/telegram-reminder-bot/pkg/permission/mocks"
+)
+
+// allowingChecker lets every member manage reminders and settings
+func allowingChecker(mockCtrl *gomock.Controller) *permissionMocks.MockChecker {
+	checker := permissionMocks.NewMockChecker(mockCtrl)
+	checker.EXPECT().CanManageReminder(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil).AnyTimes()
+	checker.EXPECT().CanManageChat(gomock.Any(), gomock.Any()).Return(true, nil).AnyTimes()
+	checker.EXPECT().CanSetTimeZone(gomock.Any(), gomock.Any()).Return(true, nil).AnyTimes()
+	checker.EXPECT().IsAdmin(gomock.Any(), gomock.Any()).Return(true, nil).AnyTimes()
+
+	return checker
+}
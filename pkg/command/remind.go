@@ -0,0 +1,385 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf16"
+
+	"github.com/enrico5b1b4/tbwrap"
+	"github.com/husol/telegram-reminder-bot/pkg/date"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
+	"github.com/husol/telegram-reminder-bot/pkg/parser"
+	"github.com/husol/telegram-reminder-bot/pkg/reminder"
+	"github.com/husol/telegram-reminder-bot/pkg/userpreference"
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+// DefaultMinCronInterval is how often a reminder set with a cron spec can be sent at most when it is not configured
+const DefaultMinCronInterval = 5 * time.Minute
+
+// maxLengthRepliedMessage is how much of the text of a message a reminder set by replying to it takes as its message
+const maxLengthRepliedMessage = 50
+
+// cronPreviewRuns is how many of the upcoming runs of a cron spec are shown when a reminder is set with it
+const cronPreviewRuns = 5
+
+// HandlePatternRemind matches every "/remind <who> <when> <what>" command, which is read by the parser,
+// and its Vietnamese form "/nhắc <who> <when> <what>"
+var HandlePatternRemind = []string{
+	`/remind (?P<expression>.*)`,
+	`/nhac (?P<expression>.*)`,
+	`/nhắc (?P<expression>.*)`,
+}
+
+func HandleRemind(
+	service reminder.ServiceReminder,
+	userPreferenceStore userpreference.Storer,
+	languages i18n.Languages,
+	minCronInterval time.Duration,
+) func(c tbwrap.Context) error {
+	remind := remindFrom(service, userPreferenceStore, languages, minCronInterval)
+
+	return func(c tbwrap.Context) error {
+		return remind(c, c.Text(), c.Message().Entities, nil)
+	}
+}
+
+// HandleRemindWithAttachment sets a reminder written as the caption of a photo, document or voice message,
+// which it is sent with. Captions which are not a "/remind" command are left alone
+func HandleRemindWithAttachment(
+	service reminder.ServiceReminder,
+	userPreferenceStore userpreference.Storer,
+	languages i18n.Languages,
+	minCronInterval time.Duration,
+) func(c tbwrap.Context) error {
+	remind := remindFrom(service, userPreferenceStore, languages, minCronInterval)
+
+	return func(c tbwrap.Context) error {
+		caption := strings.TrimSpace(c.Message().Caption)
+		if !isRemindCommand(caption) {
+			return nil
+		}
+
+		return remind(c, caption, c.Message().CaptionEntities, reminder.NewAttachment(c.Message()))
+	}
+}
+
+// remindFrom returns a function which sets the reminder written in text, sent with an attachment if it is not nil.
+// A reminder replying to a location is sent with it as locations can't have a caption
+func remindFrom(
+	service reminder.ServiceReminder,
+	userPreferenceStore userpreference.Storer,
+	languages i18n.Languages,
+	minCronInterval time.Duration,
+) func(c tbwrap.Context, text string, entities []tb.MessageEntity, attachment *reminder.Attachment) error {
+	return func(c tbwrap.Context, text string, entities []tb.MessageEntity, attachment *reminder.Attachment) error {
+		lang := languages.ChatLanguage(int(c.ChatID()))
+		remind, err := parser.Parse(text, lang, mentions(text, entities)...)
+		replyTo := c.Message().ReplyTo
+		if attachment == nil && replyTo != nil && replyTo.Location != nil {
+			attachment = reminder.NewAttachment(replyTo)
+			replyTo = nil
+		}
+		if errors.Is(err, parser.ErrMissingWhat) {
+			switch {
+			case replyTo != nil:
+				// the message which is replied to is what to remind about
+				remind.What = repliedMessage(lang, replyTo)
+				err = nil
+			case attachment != nil:
+				remind.What = attachmentName(lang, attachment)
+				err = nil
+			}
+		}
+		if err != nil {
+			return translateParseError(lang, err)
+		}
+
+		chatService, err := inSenderTimeZone(service, userPreferenceStore, senderID(c))
+		if err != nil {
+			return err
+		}
+		if sender := c.Message().Sender; sender != nil {
+			chatService = chatService.ForCreator(sender.ID)
+		}
+		if recipient := recipientOf(c.Message(), remind); recipient != nil {
+			chatService = chatService.ForRecipient(recipient)
+		}
+		if roster := rosterOf(c.Message(), remind); roster != nil {
+			chatService = chatService.ForRoster(roster)
+		}
+		if replyTo != nil {
+			chatService = chatService.ReplyingTo(replyTo.ID)
+		}
+		if attachment != nil {
+			chatService = chatService.WithAttachment(attachment)
+		}
+
+		nextSchedule, preview, err := addRemind(chatService, lang, int(c.ChatID()), text, remind, minCronInterval)
+		if err != nil {
+			return err
+		}
+
+		_, err = c.Send(ReminderAddedSuccessMessage(lang, remind.What, nextSchedule) + preview)
+
+		return err
+	}
+}
+
+// isRemindCommand reports whether text is a "/remind" command
+func isRemindCommand(text string) bool {
+	for _, pattern := range HandlePatternRemind {
+		if matched, _ := regexp.MatchString("^"+pattern, text); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// repliedMessage is the message of a reminder set by replying to a message without saying what to remind about,
+// which is the start of the text or caption of the message replied to
+func repliedMessage(lang i18n.Language, m *tb.Message) string {
+	text := m.Text
+	if text == "" {
+		text = m.Caption
+	}
+	text = strings.TrimSpace(strings.SplitN(text, "\n", 2)[0])
+	if text == "" {
+		return i18n.T(lang, i18n.RepliedMessage)
+	}
+
+	if runes := []rune(text); len(runes) > maxLengthRepliedMessage {
+		return string(runes[:maxLengthRepliedMessage]) + "…"
+	}
+
+	return text
+}
+
+// inSenderTimeZone reads the times written by the sender of a command in their own timezone when they have set one,
+// otherwise they are read in the timezone of the chat
+func inSenderTimeZone(
+	service reminder.ServiceReminder, userPreferenceStore userpreference.Storer, userID int,
+) (reminder.ServiceReminder, error) {
+	if userID == 0 {
+		return service, nil
+	}
+
+	userPreference, err := userPreferenceStore.GetUserPreference(userID)
+	if err == userpreference.ErrNotFound {
+		return service, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if userPreference.TimeZone == "" {
+		return service, nil
+	}
+
+	return service.InTimeZone(userPreference.TimeZone), nil
+}
+
+// recipientOf returns the user of a group the reminder is for, or nil when it is for the whole chat.
+// "me" is who sent the command
+func recipientOf(m *tb.Message, remind *parser.Remind) *reminder.Recipient {
+	if m.Private() {
+		return nil
+	}
+
+	var recipient reminder.Recipient
+	switch remind.Who {
+	case parser.Me:
+		if m.Sender == nil {
+			return nil
+		}
+		recipient = reminder.Recipient{
+			UserID:   m.Sender.ID,
+			Username: m.Sender.Username,
+			Name:     strings.TrimSpace(m.Sender.FirstName + " " + m.Sender.LastName),
+		}
+	case parser.User:
+		recipient = remind.Recipient
+		// users who name themselves can be mentioned by their ID
+		if m.Sender != nil && recipient.Username != "" && strings.EqualFold(recipient.Username, m.Sender.Username) {
+			recipient.UserID = m.Sender.ID
+		}
+	default:
+		return nil
+	}
+
+	recipient.Private = remind.Private
+
+	return &recipient
+}
+
+// rosterOf returns the users of a group who take turns to be reminded, or nil when the reminder has no roster.
+// Rosters are only kept in groups as there is no one else to take turns with in a private chat
+func rosterOf(m *tb.Message, remind *parser.Remind) *reminder.Roster {
+	if m.Private() || remind.Who != parser.Roster {
+		return nil
+	}
+
+	members := make([]reminder.Recipient, len(remind.Roster))
+	for i, member := range remind.Roster {
+		if m.Sender != nil && member.Username != "" && strings.EqualFold(member.Username, m.Sender.Username) {
+			member.UserID = m.Sender.ID
+		}
+		member.Private = remind.Private
+		members[i] = member
+	}
+
+	return &reminder.Roster{Members: members}
+}
+
+// mentions returns the users mentioned by name in the text of a command, whose offsets Telegram counts in UTF-16 code units
+func mentions(text string, entities []tb.MessageEntity) []parser.Mention {
+	var mentions []parser.Mention
+	for _, entity := range entities {
+		if entity.Type != tb.EntityTMention || entity.User == nil {
+			continue
+		}
+
+		mentions = append(mentions, parser.Mention{
+			Start:  utf16Offset(text, entity.Offset),
+			End:    utf16Offset(text, entity.Offset+entity.Length),
+			UserID: entity.User.ID,
+		})
+	}
+
+	return mentions
+}
+
+// utf16Offset returns the byte offset in text of an offset counted in UTF-16 code units
+func utf16Offset(text string, offset int) int {
+	units := 0
+	for i, r := range text {
+		if units >= offset {
+			return i
+		}
+		units += len(utf16.Encode([]rune{r}))
+	}
+
+	return len(text)
+}
+
+// addRemind adds a reminder for when the parsed command says.
+// It also returns the upcoming runs of a reminder set with a cron spec, or the lunar date of the next run
+// of a reminder on lunar dates
+func addRemind(
+	service reminder.ServiceReminder, lang i18n.Language, chatID int, command string, remind *parser.Remind, minCronInterval time.Duration,
+) (reminder.NextScheduleChatTime, string, error) {
+	var nextSchedule reminder.NextScheduleChatTime
+	var err error
+
+	switch when := remind.When.(type) {
+	case parser.OnDate:
+		nextSchedule, err = service.AddReminderOnDateTime(chatID, command, when.DateTime, remind.What)
+	case parser.OnDay:
+		nextSchedule, err = service.AddReminderOnWordDateTime(chatID, command, when.WordDateTime, remind.What)
+	case parser.In:
+		nextSchedule, err = service.AddReminderIn(chatID, command, when.AmountDateTime, remind.What)
+	case parser.Every:
+		nextSchedule, err = service.AddReminderEvery(chatID, command, when.AmountDateTime, remind.What)
+	case parser.EveryWeeks:
+		nextSchedule, err = service.AddReminderEveryWeeks(chatID, command, when.WeeklyDateTime, remind.What)
+	case parser.Repeat:
+		nextSchedule, err = service.AddRepeatableReminderOnDateTime(chatID, command, &when.RepeatableDateTime, remind.What)
+	case parser.Lunar:
+		nextSchedule, err = service.AddReminderOnLunarDate(chatID, command, when.LunarDateTime, remind.What)
+
+		return nextSchedule, lunarDateMessage(lang, nextSchedule), err
+	case parser.Cron:
+		cronSpec := reminder.CronSpec{Spec: when.Spec, MinInterval: minCronInterval}
+		preview, previewErr := service.PreviewCronSpec(chatID, cronSpec, cronPreviewRuns)
+		if previewErr != nil {
+			return nextSchedule, "", previewErr
+		}
+
+		nextSchedule, err = service.AddReminderOnCronSpec(chatID, command, cronSpec, remind.What)
+
+		return nextSchedule, cronPreviewMessage(lang, preview), err
+	default:
+		err = errors.New(i18n.T(lang, i18n.NotUnderstood, command))
+	}
+
+	return nextSchedule, "", err
+}
+
+// editRemind replaces a reminder with one for when the parsed command says.
+// It also returns the upcoming runs of a reminder set with a cron spec, or the lunar date of the next run
+// of a reminder on lunar dates
+func editRemind(
+	service reminder.ServiceReminder, lang i18n.Language, chatID, reminderID int, command string, remind *parser.Remind, minCronInterval time.Duration,
+) (reminder.NextScheduleChatTime, string, error) {
+	var nextSchedule reminder.NextScheduleChatTime
+	var err error
+
+	switch when := remind.When.(type) {
+	case parser.OnDate:
+		nextSchedule, err = service.EditReminderOnDateTime(chatID, reminderID, command, when.DateTime, remind.What)
+	case parser.OnDay:
+		nextSchedule, err = service.EditReminderOnWordDateTime(chatID, reminderID, command, when.WordDateTime, remind.What)
+	case parser.In:
+		nextSchedule, err = service.EditReminderIn(chatID, reminderID, command, when.AmountDateTime, remind.What)
+	case parser.Every:
+		nextSchedule, err = service.EditReminderEvery(chatID, reminderID, command, when.AmountDateTime, remind.What)
+	case parser.EveryWeeks:
+		nextSchedule, err = service.EditReminderEveryWeeks(chatID, reminderID, command, when.WeeklyDateTime, remind.What)
+	case parser.Repeat:
+		nextSchedule, err = service.EditRepeatableReminderOnDateTime(chatID, reminderID, command, &when.RepeatableDateTime, remind.What)
+	case parser.Lunar:
+		nextSchedule, err = service.EditReminderOnLunarDate(chatID, reminderID, command, when.LunarDateTime, remind.What)
+
+		return nextSchedule, lunarDateMessage(lang, nextSchedule), err
+	case parser.Cron:
+		cronSpec := reminder.CronSpec{Spec: when.Spec, MinInterval: minCronInterval}
+		preview, previewErr := service.PreviewCronSpec(chatID, cronSpec, cronPreviewRuns)
+		if previewErr != nil {
+			return nextSchedule, "", previewErr
+		}
+
+		nextSchedule, err = service.EditReminderOnCronSpec(chatID, reminderID, command, cronSpec, remind.What)
+
+		return nextSchedule, cronPreviewMessage(lang, preview), err
+	default:
+		err = errors.New(i18n.T(lang, i18n.NotUnderstood, command))
+	}
+
+	return nextSchedule, "", err
+}
+
+// cronPreviewMessage lists the upcoming runs of a reminder set with a cron spec
+func cronPreviewMessage(lang i18n.Language, runs []time.Time) string {
+	var sb strings.Builder
+	sb.WriteString("\n" + i18n.T(lang, i18n.NextRuns))
+	for i := range runs {
+		sb.WriteString(fmt.Sprintf("\n%s", i18n.FormatTime(lang, i18n.DateTimeZone, runs[i])))
+	}
+
+	return sb.String()
+}
+
+// lunarDateMessage is the lunar date of the next run of a reminder on lunar dates
+func lunarDateMessage(lang i18n.Language, nextSchedule reminder.NextScheduleChatTime) string {
+	if nextSchedule.Location == nil {
+		return ""
+	}
+
+	return fmt.Sprintf(" (%s)", i18n.FormatLunarDate(lang, date.ToLunar(nextSchedule.Time.In(nextSchedule.Location))))
+}
+
+// translateParseError returns the errors of the parser in the language of the chat
+func translateParseError(lang i18n.Language, err error) error {
+	var notUnderstood *parser.NotUnderstoodError
+	switch {
+	case errors.As(err, &notUnderstood):
+		return errors.New(i18n.T(lang, i18n.NotUnderstood, notUnderstood.Words))
+	case errors.Is(err, parser.ErrMissingWhat):
+		return errors.New(i18n.T(lang, i18n.MissingWhat))
+	}
+
+	return err
+}
@@ -0,0 +1,555 @@
+package command_test
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/enrico5b1b4/tbwrap"
+	"github.com/golang/mock/gomock"
+	"github.com/husol/telegram-reminder-bot/pkg/command"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
+	"github.com/husol/telegram-reminder-bot/pkg/reminder"
+	"github.com/husol/telegram-reminder-bot/pkg/reminder/mocks"
+	fakeBot "github.com/husol/telegram-reminder-bot/pkg/telegram/fakes"
+	"github.com/husol/telegram-reminder-bot/pkg/userpreference"
+	userPreferenceMocks "github.com/husol/telegram-reminder-bot/pkg/userpreference/mocks"
+	"github.com/stretchr/testify/require"
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+// nolint:funlen
+func TestHandleRemind(t *testing.T) {
+	handlerPattern, err := regexp.Compile(command.HandlePatternRemind[0])
+	require.NoError(t, err)
+	chat := &tb.Chat{ID: int64(1)}
+	nextSchedule := reminder.NextScheduleChatTime{Time: time.Now(), Location: time.UTC}
+
+	testCases := map[string]struct {
+		text   string
+		expect func(text string, m *mocks.MockServicerMockRecorder)
+	}{
+		"on a date": {
+			text: "/remind me on the 4th of March at 10:30 update weekly report",
+			expect: func(text string, m *mocks.MockServicerMockRecorder) {
+				m.AddReminderOnDateTime(
+					1, text, reminder.DateTime{DayOfMonth: 4, Month: 3, Hour: 10, Minute: 30}, "update weekly report").
+					Return(nextSchedule, nil)
+			},
+		},
+		"on a day of the week": {
+			text: "/remind me next Friday afternoon update weekly report",
+			expect: func(text string, m *mocks.MockServicerMockRecorder) {
+				m.AddReminderOnDateTime(
+					1, text, reminder.DateTime{DayOfWeek: "5", Hour: 15}, "update weekly report").
+					Return(nextSchedule, nil)
+			},
+		},
+		"on a word": {
+			text: "/remind me tomorrow at noon update weekly report",
+			expect: func(text string, m *mocks.MockServicerMockRecorder) {
+				m.AddReminderOnWordDateTime(
+					1, text, reminder.WordDateTime{When: reminder.Tomorrow, Hour: 12}, "update weekly report").
+					Return(nextSchedule, nil)
+			},
+		},
+		"in an amount of time": {
+			text: "/remind me in 2 weeks at 9am update weekly report",
+			expect: func(text string, m *mocks.MockServicerMockRecorder) {
+				m.AddReminderIn(
+					1, text, reminder.AmountDateTime{Weeks: 2, At: &reminder.TimeOfDay{Hour: 9}}, "update weekly report").
+					Return(nextSchedule, nil)
+			},
+		},
+		"every amount of time": {
+			text: "/remind me every 2 hours update weekly report",
+			expect: func(text string, m *mocks.MockServicerMockRecorder) {
+				m.AddReminderEvery(1, text, reminder.AmountDateTime{Hours: 2}, "update weekly report").
+					Return(nextSchedule, nil)
+			},
+		},
+		"every few weeks": {
+			text: "/remind me every 2 weeks on monday update weekly report",
+			expect: func(text string, m *mocks.MockServicerMockRecorder) {
+				m.AddReminderEveryWeeks(
+					1, text, reminder.WeeklyDateTime{Weeks: 2, DayOfWeek: 1, Hour: 9}, "update weekly report").
+					Return(nextSchedule, nil)
+			},
+		},
+		"repeatable": {
+			text: "/remind me every tuesday at 8:23 update weekly report",
+			expect: func(text string, m *mocks.MockServicerMockRecorder) {
+				m.AddRepeatableReminderOnDateTime(
+					1,
+					text,
+					&reminder.RepeatableDateTime{DayOfWeek: "2", Month: "*", Hour: "8", Minute: "23"},
+					"update weekly report").
+					Return(nextSchedule, nil)
+			},
+		},
+	}
+
+	for name := range testCases {
+		t.Run(name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			bot := fakeBot.NewTBWrapBot()
+			c := tbwrap.NewContext(bot, &tb.Message{Text: testCases[name].text, Chat: chat}, nil, handlerPattern)
+			mockReminderService := mocks.NewMockServicer(mockCtrl)
+			testCases[name].expect(testCases[name].text, mockReminderService.EXPECT())
+
+			err := command.HandleRemind(mockReminderService, noUserPreference(mockCtrl), i18n.English, command.DefaultMinCronInterval)(c)
+			require.NoError(t, err)
+			require.Len(t, bot.OutboundSendMessages, 1)
+			require.Contains(t, bot.OutboundSendMessages[0], `Reminder "update weekly report" has been added`)
+		})
+	}
+
+	t.Run("failure with unknown expression", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		text := "/remind me sometime soon update weekly report"
+		c := tbwrap.NewContext(bot, &tb.Message{Text: text, Chat: chat}, nil, handlerPattern)
+		mockReminderService := mocks.NewMockServicer(mockCtrl)
+
+		err := command.HandleRemind(mockReminderService, noUserPreference(mockCtrl), i18n.English, command.DefaultMinCronInterval)(c)
+		require.EqualError(t, err, "could not understand 'sometime'")
+		require.Len(t, bot.OutboundSendMessages, 0)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		text := "/remind me in 2 minutes update weekly report"
+		c := tbwrap.NewContext(bot, &tb.Message{Text: text, Chat: chat}, nil, handlerPattern)
+		mockReminderService := mocks.NewMockServicer(mockCtrl)
+		mockReminderService.
+			EXPECT().
+			AddReminderIn(1, text, reminder.AmountDateTime{Minutes: 2}, "update weekly report").
+			Return(reminder.NextScheduleChatTime{}, errors.New("error"))
+
+		err := command.HandleRemind(mockReminderService, noUserPreference(mockCtrl), i18n.English, command.DefaultMinCronInterval)(c)
+		require.Error(t, err)
+		require.Len(t, bot.OutboundSendMessages, 0)
+	})
+}
+
+func TestHandleRemind_Recipient(t *testing.T) {
+	handlerPattern, err := regexp.Compile(command.HandlePatternRemind[0])
+	require.NoError(t, err)
+	group := &tb.Chat{ID: int64(1), Type: tb.ChatGroup}
+	sender := &tb.User{ID: 7, Username: "bob_smith", FirstName: "Bob", LastName: "Smith"}
+	nextSchedule := reminder.NextScheduleChatTime{Time: time.Now(), Location: time.UTC}
+	tomorrow := reminder.WordDateTime{When: reminder.Tomorrow, Hour: 9}
+
+	testCases := map[string]struct {
+		message   *tb.Message
+		recipient *reminder.Recipient
+	}{
+		"me in a group": {
+			message:   &tb.Message{Text: "/remind me tomorrow update weekly report", Chat: group, Sender: sender},
+			recipient: &reminder.Recipient{UserID: 7, Username: "bob_smith", Name: "Bob Smith"},
+		},
+		"a user privately": {
+			message:   &tb.Message{Text: "/remind @alice_b2 privately tomorrow update weekly report", Chat: group, Sender: sender},
+			recipient: &reminder.Recipient{Username: "alice_b2", Private: true},
+		},
+		"the sender by their username": {
+			message:   &tb.Message{Text: "/remind @Bob_Smith tomorrow update weekly report", Chat: group, Sender: sender},
+			recipient: &reminder.Recipient{UserID: 7, Username: "Bob_Smith"},
+		},
+		"a user mentioned by name": {
+			message: &tb.Message{
+				Text:   "/remind 🙂 Ngọc tomorrow update weekly report",
+				Chat:   group,
+				Sender: sender,
+				Entities: []tb.MessageEntity{
+					{Type: tb.EntityTMention, Offset: 8, Length: 7, User: &tb.User{ID: 42, FirstName: "Ngọc"}},
+				},
+			},
+			recipient: &reminder.Recipient{UserID: 42, Name: "🙂 Ngọc"},
+		},
+	}
+
+	for name := range testCases {
+		t.Run(name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			bot := fakeBot.NewTBWrapBot()
+			text := testCases[name].message.Text
+			c := tbwrap.NewContext(bot, testCases[name].message, nil, handlerPattern)
+			mockReminderService := mocks.NewMockServicer(mockCtrl)
+			mockReminderService.EXPECT().ForCreator(7).Return(mockReminderService)
+			mockReminderService.EXPECT().ForRecipient(testCases[name].recipient).Return(mockReminderService)
+			mockReminderService.EXPECT().AddReminderOnWordDateTime(1, text, tomorrow, "update weekly report").Return(nextSchedule, nil)
+
+			err := command.HandleRemind(mockReminderService, noUserPreference(mockCtrl), i18n.English, command.DefaultMinCronInterval)(c)
+			require.NoError(t, err)
+			require.Len(t, bot.OutboundSendMessages, 1)
+		})
+	}
+
+	t.Run("here is the whole group", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		text := "/remind here tomorrow update weekly report"
+		c := tbwrap.NewContext(bot, &tb.Message{Text: text, Chat: group, Sender: sender}, nil, handlerPattern)
+		mockReminderService := mocks.NewMockServicer(mockCtrl)
+		mockReminderService.EXPECT().ForCreator(7).Return(mockReminderService)
+		mockReminderService.EXPECT().AddReminderOnWordDateTime(1, text, tomorrow, "update weekly report").Return(nextSchedule, nil)
+
+		err := command.HandleRemind(mockReminderService, noUserPreference(mockCtrl), i18n.English, command.DefaultMinCronInterval)(c)
+		require.NoError(t, err)
+	})
+
+	t.Run("a roster of users who take turns", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		text := "/remind roster @alice_b2 @Bob_Smith tomorrow update weekly report"
+		c := tbwrap.NewContext(bot, &tb.Message{Text: text, Chat: group, Sender: sender}, nil, handlerPattern)
+		mockReminderService := mocks.NewMockServicer(mockCtrl)
+		mockReminderService.EXPECT().ForCreator(7).Return(mockReminderService)
+		mockReminderService.EXPECT().ForRoster(&reminder.Roster{Members: []reminder.Recipient{
+			{Username: "alice_b2"},
+			{UserID: 7, Username: "Bob_Smith"},
+		}}).Return(mockReminderService)
+		mockReminderService.EXPECT().AddReminderOnWordDateTime(1, text, tomorrow, "update weekly report").Return(nextSchedule, nil)
+
+		err := command.HandleRemind(mockReminderService, noUserPreference(mockCtrl), i18n.English, command.DefaultMinCronInterval)(c)
+		require.NoError(t, err)
+	})
+
+	t.Run("me in a private chat is the chat", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		text := "/remind me tomorrow update weekly report"
+		chat := &tb.Chat{ID: int64(1), Type: tb.ChatPrivate}
+		c := tbwrap.NewContext(bot, &tb.Message{Text: text, Chat: chat, Sender: sender}, nil, handlerPattern)
+		mockReminderService := mocks.NewMockServicer(mockCtrl)
+		mockReminderService.EXPECT().ForCreator(7).Return(mockReminderService)
+		mockReminderService.EXPECT().AddReminderOnWordDateTime(1, text, tomorrow, "update weekly report").Return(nextSchedule, nil)
+
+		err := command.HandleRemind(mockReminderService, noUserPreference(mockCtrl), i18n.English, command.DefaultMinCronInterval)(c)
+		require.NoError(t, err)
+	})
+}
+
+func TestHandleRemind_Vietnamese(t *testing.T) {
+	chat := &tb.Chat{ID: int64(1)}
+	nextSchedule := reminder.NextScheduleChatTime{
+		Time:     time.Date(2020, time.April, 2, 8, 0, 0, 0, time.UTC),
+		Location: time.UTC,
+	}
+
+	t.Run("nhac command in a chat which reads the bot in english", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		handlerPattern, err := regexp.Compile(command.HandlePatternRemind[1])
+		require.NoError(t, err)
+		bot := fakeBot.NewTBWrapBot()
+		text := "/nhac toi luc 8:00 ngay mai Họp nhóm"
+		c := tbwrap.NewContext(bot, &tb.Message{Text: text, Chat: chat}, nil, handlerPattern)
+		mockReminderService := mocks.NewMockServicer(mockCtrl)
+		mockReminderService.
+			EXPECT().
+			AddReminderOnWordDateTime(1, text, reminder.WordDateTime{When: reminder.Tomorrow, Hour: 8}, "Họp nhóm").
+			Return(nextSchedule, nil)
+
+		err = command.HandleRemind(mockReminderService, noUserPreference(mockCtrl), i18n.English, command.DefaultMinCronInterval)(c)
+		require.NoError(t, err)
+		require.Equal(t, []string{`Reminder "Họp nhóm" has been added for Thu, 02 Apr 2020 08:00 UTC`}, bot.OutboundSendMessages)
+	})
+
+	t.Run("remind command in a chat which reads the bot in vietnamese", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		handlerPattern, err := regexp.Compile(command.HandlePatternRemind[0])
+		require.NoError(t, err)
+		bot := fakeBot.NewTBWrapBot()
+		text := "/remind me ngày mai lúc 8 giờ Họp nhóm"
+		c := tbwrap.NewContext(bot, &tb.Message{Text: text, Chat: chat}, nil, handlerPattern)
+		mockReminderService := mocks.NewMockServicer(mockCtrl)
+		mockReminderService.
+			EXPECT().
+			AddReminderOnWordDateTime(1, text, reminder.WordDateTime{When: reminder.Tomorrow, Hour: 8}, "Họp nhóm").
+			Return(nextSchedule, nil)
+
+		err = command.HandleRemind(mockReminderService, noUserPreference(mockCtrl), i18n.Vietnamese, command.DefaultMinCronInterval)(c)
+		require.NoError(t, err)
+		require.Equal(t, []string{`Đã đặt nhắc nhở "Họp nhóm" vào Thứ Năm, 02/04/2020 08:00 UTC`}, bot.OutboundSendMessages)
+	})
+
+	t.Run("failure with unknown expression", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		handlerPattern, err := regexp.Compile(command.HandlePatternRemind[1])
+		require.NoError(t, err)
+		bot := fakeBot.NewTBWrapBot()
+		c := tbwrap.NewContext(bot, &tb.Message{Text: "/nhac toi lúc nào đó Họp nhóm", Chat: chat}, nil, handlerPattern)
+		mockReminderService := mocks.NewMockServicer(mockCtrl)
+
+		err = command.HandleRemind(mockReminderService, noUserPreference(mockCtrl), i18n.Vietnamese, command.DefaultMinCronInterval)(c)
+		require.EqualError(t, err, "không hiểu 'lúc nào'")
+		require.Len(t, bot.OutboundSendMessages, 0)
+	})
+}
+
+func TestHandleRemind_Cron(t *testing.T) {
+	handlerPattern, err := regexp.Compile(command.HandlePatternRemind[0])
+	require.NoError(t, err)
+	chat := &tb.Chat{ID: int64(1)}
+	preview := []time.Time{
+		time.Date(2020, time.April, 1, 14, 0, 0, 0, time.UTC),
+		time.Date(2020, time.April, 1, 14, 15, 0, 0, time.UTC),
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		text := `/remind cron "*/15 9-17 * * 1-5" Check the build queue`
+		bot := fakeBot.NewTBWrapBot()
+		c := tbwrap.NewContext(bot, &tb.Message{Text: text, Chat: chat}, nil, handlerPattern)
+		cronSpec := reminder.CronSpec{Spec: "*/15 9-17 * * 1-5", MinInterval: command.DefaultMinCronInterval}
+		mockReminderService := mocks.NewMockServicer(mockCtrl)
+		mockReminderService.
+			EXPECT().
+			PreviewCronSpec(1, cronSpec, 5).
+			Return(preview, nil)
+		mockReminderService.
+			EXPECT().
+			AddReminderOnCronSpec(1, text, cronSpec, "Check the build queue").
+			Return(reminder.NextScheduleChatTime{Time: preview[0], Location: time.UTC}, nil)
+
+		err := command.HandleRemind(mockReminderService, noUserPreference(mockCtrl), i18n.English, command.DefaultMinCronInterval)(c)
+		require.NoError(t, err)
+		require.Len(t, bot.OutboundSendMessages, 1)
+		require.Contains(t, bot.OutboundSendMessages[0], "Wed, 01 Apr 2020 14:15 UTC")
+	})
+
+	t.Run("does not add a reminder with an invalid spec", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		c := tbwrap.NewContext(bot, &tb.Message{Text: `/remind cron "* * * * *" Check the build queue`, Chat: chat}, nil, handlerPattern)
+		mockReminderService := mocks.NewMockServicer(mockCtrl)
+		mockReminderService.
+			EXPECT().
+			PreviewCronSpec(1, reminder.CronSpec{Spec: "* * * * *", MinInterval: command.DefaultMinCronInterval}, 5).
+			Return(nil, errors.New("error"))
+
+		err := command.HandleRemind(mockReminderService, noUserPreference(mockCtrl), i18n.English, command.DefaultMinCronInterval)(c)
+		require.Error(t, err)
+		require.Len(t, bot.OutboundSendMessages, 0)
+	})
+}
+
+func TestHandleRemind_Lunar(t *testing.T) {
+	handlerPattern, err := regexp.Compile(command.HandlePatternRemind[0])
+	require.NoError(t, err)
+	chat := &tb.Chat{ID: int64(1)}
+	loc, err := time.LoadLocation("Asia/Ho_Chi_Minh")
+	require.NoError(t, err)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	text := "/remind me every lunar 15th of the month Offer incense"
+	bot := fakeBot.NewTBWrapBot()
+	c := tbwrap.NewContext(bot, &tb.Message{Text: text, Chat: chat}, nil, handlerPattern)
+	mockReminderService := mocks.NewMockServicer(mockCtrl)
+	mockReminderService.
+		EXPECT().
+		AddReminderOnLunarDate(1, text, reminder.LunarDateTime{DayOfMonth: 15, Hour: 9}, "Offer incense").
+		Return(reminder.NextScheduleChatTime{Time: time.Date(2020, time.April, 7, 2, 0, 0, 0, time.UTC), Location: loc}, nil)
+
+	err = command.HandleRemind(mockReminderService, noUserPreference(mockCtrl), i18n.English, command.DefaultMinCronInterval)(c)
+	require.NoError(t, err)
+	require.Equal(t, []string{`Reminder "Offer incense" has been added for Tue, 07 Apr 2020 09:00 +07 (15/3/2020 lunar)`}, bot.OutboundSendMessages)
+}
+
+func TestHandleRemind_UserTimeZone(t *testing.T) {
+	handlerPattern, err := regexp.Compile(command.HandlePatternRemind[0])
+	require.NoError(t, err)
+	chat := &tb.Chat{ID: int64(-1), Type: tb.ChatGroup}
+	sender := &tb.User{ID: 7, Username: "bob_smith"}
+	hoChiMinh, err := time.LoadLocation("Asia/Ho_Chi_Minh")
+	require.NoError(t, err)
+	tomorrow := reminder.WordDateTime{When: reminder.Tomorrow, Hour: 9}
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	text := "/remind here tomorrow at 9:00 update weekly report"
+	bot := fakeBot.NewTBWrapBot()
+	c := tbwrap.NewContext(bot, &tb.Message{Text: text, Chat: chat, Sender: sender}, nil, handlerPattern)
+	mockUserPreferenceStore := userPreferenceMocks.NewMockStorer(mockCtrl)
+	mockUserPreferenceStore.
+		EXPECT().
+		GetUserPreference(7).
+		Return(&userpreference.UserPreference{UserID: 7, TimeZone: "Asia/Ho_Chi_Minh"}, nil)
+	mockReminderService := mocks.NewMockServicer(mockCtrl)
+	mockReminderService.EXPECT().InTimeZone("Asia/Ho_Chi_Minh").Return(mockReminderService)
+	mockReminderService.EXPECT().ForCreator(7).Return(mockReminderService)
+	mockReminderService.
+		EXPECT().
+		AddReminderOnWordDateTime(-1, text, tomorrow, "update weekly report").
+		Return(reminder.NextScheduleChatTime{
+			Time:             time.Date(2020, time.April, 7, 2, 0, 0, 0, time.UTC),
+			Location:         time.UTC,
+			ReminderLocation: hoChiMinh,
+		}, nil)
+
+	err = command.HandleRemind(mockReminderService, mockUserPreferenceStore, i18n.English, command.DefaultMinCronInterval)(c)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		`Reminder "update weekly report" has been added for Tue, 07 Apr 2020 02:00 UTC (Tue, 07 Apr 2020 09:00 +07)`,
+	}, bot.OutboundSendMessages)
+}
+
+func TestHandleRemind_ReplyTo(t *testing.T) {
+	handlerPattern, err := regexp.Compile(command.HandlePatternRemind[0])
+	require.NoError(t, err)
+	chat := &tb.Chat{ID: int64(1), Type: tb.ChatPrivate}
+	tomorrow := reminder.WordDateTime{When: reminder.Tomorrow, Hour: 9}
+	nextSchedule := reminder.NextScheduleChatTime{Time: time.Now(), Location: time.UTC}
+
+	testCases := map[string]struct {
+		text     string
+		replyTo  *tb.Message
+		expected string
+	}{
+		"a link": {
+			text:     "/remind me tomorrow at 9",
+			replyTo:  &tb.Message{ID: 55, Text: "https://example.com/report\nhave a look"},
+			expected: "https://example.com/report",
+		},
+		"a photo with a caption": {
+			text:     "/remind me tomorrow at 9",
+			replyTo:  &tb.Message{ID: 55, Caption: "Receipt for the team lunch", Photo: &tb.Photo{}},
+			expected: "Receipt for the team lunch",
+		},
+		"a document": {
+			text:     "/remind me tomorrow at 9",
+			replyTo:  &tb.Message{ID: 55, Document: &tb.Document{}},
+			expected: "this message",
+		},
+		"with what to remind about": {
+			text:     "/remind me tomorrow at 9 update weekly report",
+			replyTo:  &tb.Message{ID: 55, Text: "the report is late"},
+			expected: "update weekly report",
+		},
+	}
+
+	for name := range testCases {
+		t.Run(name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			testCase := testCases[name]
+			bot := fakeBot.NewTBWrapBot()
+			message := &tb.Message{Text: testCase.text, Chat: chat, ReplyTo: testCase.replyTo}
+			c := tbwrap.NewContext(bot, message, nil, handlerPattern)
+			mockReminderService := mocks.NewMockServicer(mockCtrl)
+			mockReminderService.EXPECT().ReplyingTo(55).Return(mockReminderService)
+			mockReminderService.EXPECT().AddReminderOnWordDateTime(1, testCase.text, tomorrow, testCase.expected).Return(nextSchedule, nil)
+
+			err := command.HandleRemind(mockReminderService, noUserPreference(mockCtrl), i18n.English, command.DefaultMinCronInterval)(c)
+			require.NoError(t, err)
+			require.Len(t, bot.OutboundSendMessages, 1)
+		})
+	}
+
+	t.Run("without what to remind about nor a reply", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		c := tbwrap.NewContext(bot, &tb.Message{Text: "/remind me tomorrow at 9", Chat: chat}, nil, handlerPattern)
+		mockReminderService := mocks.NewMockServicer(mockCtrl)
+
+		err := command.HandleRemind(mockReminderService, noUserPreference(mockCtrl), i18n.English, command.DefaultMinCronInterval)(c)
+		require.EqualError(t, err, "error: the reminder message is missing")
+	})
+}
+
+// noUserPreference is a store where no member has set their own timezone
+func noUserPreference(mockCtrl *gomock.Controller) *userPreferenceMocks.MockStorer {
+	store := userPreferenceMocks.NewMockStorer(mockCtrl)
+	store.EXPECT().GetUserPreference(gomock.Any()).Return(nil, userpreference.ErrNotFound).AnyTimes()
+
+	return store
+}
+
+// nolint:funlen
+func TestHandleRemindWithAttachment(t *testing.T) {
+	chat := &tb.Chat{ID: int64(1), Type: tb.ChatPrivate}
+	tomorrow := reminder.WordDateTime{When: reminder.Tomorrow, Hour: 9}
+	nextSchedule := reminder.NextScheduleChatTime{Time: time.Now(), Location: time.UTC}
+	photo := &reminder.Attachment{Type: reminder.AttachmentPhoto, FileID: "photo-1"}
+
+	t.Run("a photo with a reminder as its caption", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		caption := "/remind me tomorrow at 9 pay the invoice"
+		message := &tb.Message{Caption: caption, Photo: &tb.Photo{File: tb.File{FileID: "photo-1"}}, Chat: chat}
+		c := tbwrap.NewContext(bot, message, nil, nil)
+		mockReminderService := mocks.NewMockServicer(mockCtrl)
+		mockReminderService.EXPECT().WithAttachment(photo).Return(mockReminderService)
+		mockReminderService.EXPECT().AddReminderOnWordDateTime(1, caption, tomorrow, "pay the invoice").Return(nextSchedule, nil)
+
+		err := command.HandleRemindWithAttachment(mockReminderService, noUserPreference(mockCtrl), i18n.English, command.DefaultMinCronInterval)(c)
+		require.NoError(t, err)
+		require.Len(t, bot.OutboundSendMessages, 1)
+	})
+
+	t.Run("a photo without what to remind about", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		caption := "/remind me tomorrow at 9"
+		message := &tb.Message{Caption: caption, Photo: &tb.Photo{File: tb.File{FileID: "photo-1"}}, Chat: chat}
+		c := tbwrap.NewContext(bot, message, nil, nil)
+		mockReminderService := mocks.NewMockServicer(mockCtrl)
+		mockReminderService.EXPECT().WithAttachment(photo).Return(mockReminderService)
+		mockReminderService.EXPECT().AddReminderOnWordDateTime(1, caption, tomorrow, "photo").Return(nextSchedule, nil)
+
+		err := command.HandleRemindWithAttachment(mockReminderService, noUserPreference(mockCtrl), i18n.English, command.DefaultMinCronInterval)(c)
+		require.NoError(t, err)
+		require.Len(t, bot.OutboundSendMessages, 1)
+	})
+
+	t.Run("a caption which is not a reminder", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		message := &tb.Message{Caption: "holiday photos", Photo: &tb.Photo{}, Chat: chat}
+		c := tbwrap.NewContext(bot, message, nil, nil)
+		mockReminderService := mocks.NewMockServicer(mockCtrl)
+
+		err := command.HandleRemindWithAttachment(mockReminderService, noUserPreference(mockCtrl), i18n.English, command.DefaultMinCronInterval)(c)
+		require.NoError(t, err)
+		require.Empty(t, bot.OutboundSendMessages)
+	})
+
+	t.Run("replying to a location", func(t *testing.T) {
+		handlerPattern, err := regexp.Compile(command.HandlePatternRemind[0])
+		require.NoError(t, err)
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		text := "/remind me tomorrow at 9 meet the team"
+		replyTo := &tb.Message{ID: 55, Location: &tb.Location{Lat: 10.7769, Lng: 106.7009}}
+		c := tbwrap.NewContext(bot, &tb.Message{Text: text, Chat: chat, ReplyTo: replyTo}, nil, handlerPattern)
+		mockReminderService := mocks.NewMockServicer(mockCtrl)
+		location := &reminder.Attachment{Type: reminder.AttachmentLocation, Latitude: 10.7769, Longitude: 106.7009}
+		mockReminderService.EXPECT().WithAttachment(location).Return(mockReminderService)
+		mockReminderService.EXPECT().AddReminderOnWordDateTime(1, text, tomorrow, "meet the team").Return(nextSchedule, nil)
+
+		err = command.HandleRemind(mockReminderService, noUserPreference(mockCtrl), i18n.English, command.DefaultMinCronInterval)(c)
+		require.NoError(t, err)
+		require.Len(t, bot.OutboundSendMessages, 1)
+	})
+}
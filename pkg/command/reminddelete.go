@@ -1,9 +1,9 @@
 package command
 
 import (
-	"fmt"
-
 	"github.com/enrico5b1b4/tbwrap"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
+	"github.com/husol/telegram-reminder-bot/pkg/permission"
 )
 
 type MessageRemindDelete struct {
@@ -15,19 +15,30 @@ var HandlePatternRemindDelete = []string{
 	`/reminddelete_(?P<reminderID>\d{1,5})`,
 }
 
-func HandleRemindDelete(reminderDeleteService RemindDeleteServicer) func(c tbwrap.Context) error {
+func HandleRemindDelete(
+	reminderDeleteService RemindDeleteServicer, checker permission.Checker, languages i18n.Languages,
+) func(c tbwrap.Context) error {
 	return func(c tbwrap.Context) error {
 		message := new(MessageRemindDelete)
 		if err := c.Bind(message); err != nil {
 			return err
 		}
 
-		err := reminderDeleteService.DeleteReminder(int(c.ChatID()), message.ReminderID)
+		lang := languages.ChatLanguage(int(c.ChatID()))
+		allowed, err := checker.CanManageReminder(int(c.ChatID()), senderID(c), message.ReminderID)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return notAllowedError(lang)
+		}
+
+		err = reminderDeleteService.DeleteReminder(int(c.ChatID()), message.ReminderID)
 		if err != nil {
 			return err
 		}
 
-		_, err = c.Send(fmt.Sprintf("Reminder %d has been deleted", message.ReminderID))
+		_, err = c.Send(i18n.T(lang, i18n.ReminderDeleted, message.ReminderID))
 
 		return err
 	}
@@ -3,9 +3,8 @@ package command
 //go:generate mockgen -source=$GOFILE -destination=mocks/${GOFILE} -package=mocks
 
 import (
-	"errors"
-
 	"github.com/husol/telegram-reminder-bot/pkg/cron"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
 	"github.com/husol/telegram-reminder-bot/pkg/reminder"
 )
 
@@ -32,10 +31,13 @@ func (s *RemindDeleteService) DeleteReminder(chatID, id int) error {
 	}
 
 	if chatID != r.ChatID {
-		return errors.New("unauthorised to delete reminder")
+		return i18n.Errorf(i18n.ErrNotInChat, id)
 	}
 
 	s.scheduler.Remove(r.CronID)
+	if r.Nag != nil {
+		s.scheduler.Remove(r.Nag.CronID)
+	}
 
 	return s.reminderStore.DeleteReminder(r.ChatID, id)
 }
@@ -8,7 +8,9 @@ import (
 	"github.com/enrico5b1b4/tbwrap"
 	"github.com/golang/mock/gomock"
 	"github.com/husol/telegram-reminder-bot/pkg/command"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
 	"github.com/husol/telegram-reminder-bot/pkg/command/mocks"
+	permissionMocks "github.com/husol/telegram-reminder-bot/pkg/permission/mocks"
 	fakeBot "github.com/husol/telegram-reminder-bot/pkg/telegram/fakes"
 	"github.com/stretchr/testify/require"
 	tb "gopkg.in/tucnak/telebot.v2"
@@ -31,7 +33,7 @@ func TestHandleRemindDelete(t *testing.T) {
 			DeleteReminder(1, 1).
 			Return(nil)
 
-		err := command.HandleRemindDelete(mockReminderService)(c)
+		err := command.HandleRemindDelete(mockReminderService, allowingChecker(mockCtrl), i18n.English)(c)
 		require.NoError(t, err)
 		require.Len(t, bot.OutboundSendMessages, 1)
 	})
@@ -47,8 +49,26 @@ func TestHandleRemindDelete(t *testing.T) {
 			DeleteReminder(1, 1).
 			Return(errors.New("error"))
 
-		err := command.HandleRemindDelete(mockReminderService)(c)
+		err := command.HandleRemindDelete(mockReminderService, allowingChecker(mockCtrl), i18n.English)(c)
 		require.Error(t, err)
 		require.Len(t, bot.OutboundSendMessages, 0)
 	})
+
+	t.Run("failure when sender is not allowed", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		group := &tb.Chat{ID: int64(-1)}
+		c := tbwrap.NewContext(bot, &tb.Message{Text: text, Chat: group, Sender: &tb.User{ID: 7}}, nil, handlerPattern)
+		mockReminderService := mocks.NewMockRemindDeleteServicer(mockCtrl)
+		mockChecker := permissionMocks.NewMockChecker(mockCtrl)
+		mockChecker.
+			EXPECT().
+			CanManageReminder(-1, 7, 1).
+			Return(false, nil)
+
+		err := command.HandleRemindDelete(mockReminderService, mockChecker, i18n.English)(c)
+		require.EqualError(t, err, "error: you are not allowed to do that in this chat, see /setpermissions")
+		require.Len(t, bot.OutboundSendMessages, 0)
+	})
 }
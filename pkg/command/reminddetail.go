@@ -2,11 +2,16 @@ package command
 
 import (
 	"bytes"
+	"fmt"
 	"html/template"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/enrico5b1b4/tbwrap"
+	"github.com/husol/telegram-reminder-bot/pkg/cron"
+	"github.com/husol/telegram-reminder-bot/pkg/date"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
 	"github.com/husol/telegram-reminder-bot/pkg/reminder"
 	"gopkg.in/tucnak/telebot.v2"
 )
@@ -18,6 +23,13 @@ type MessageReminderDetail struct {
 type ReminderDetail struct {
 	reminder.Reminder
 	NextSchedule *time.Time
+	// LunarSchedule and NextLunarDate are set for reminders on lunar dates
+	LunarSchedule *reminder.LunarRecurrence
+	NextLunarDate *date.LunarDate
+	// Occurrences are the latest times the reminder was sent to a group, with who acknowledged each of them
+	Occurrences []reminder.Occurrence
+	// RosterTurns are who the next runs of a reminder whose members take turns are for
+	RosterTurns []reminder.RosterTurn
 }
 
 var HandlePatternRemindDetail = []string{
@@ -27,7 +39,13 @@ var HandlePatternRemindDetail = []string{
 	`/r_(?P<reminderID>\d{1,5})`,
 }
 
-func HandleRemindDetail(reminderDetailService RemindDetailServicer, buttons map[string]*telebot.InlineButton) func(c tbwrap.Context) error {
+// HandleRemindDetail replies with the details of a reminder and buttons to act on it
+// labelled in the language of the chat
+func HandleRemindDetail(
+	reminderDetailService RemindDetailServicer,
+	languages i18n.Languages,
+	buttons func(lang i18n.Language) map[string]*telebot.InlineButton,
+) func(c tbwrap.Context) error {
 	return func(c tbwrap.Context) error {
 		message := new(MessageReminderDetail)
 		if err := c.Bind(message); err != nil {
@@ -39,40 +57,155 @@ func HandleRemindDetail(reminderDetailService RemindDetailServicer, buttons map[
 			return err
 		}
 
-		var remindDetailInlineKeys [][]telebot.InlineButton
-		if len(buttons) > 0 {
-			closeCommandBtn := *buttons[ReminderDetailCloseCommandBtn]
-			closeCommandBtn.Data = strconv.Itoa(c.Message().ID)
-			remindDetailInlineKeys = append(remindDetailInlineKeys, []telebot.InlineButton{closeCommandBtn})
+		lang := languages.ChatLanguage(int(c.ChatID()))
+		reply, remindDetailInlineKeys, err := remindDetailMessage(lang, reminderDetail, buttons, c.Message().ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = c.Send(reply, &telebot.ReplyMarkup{
+			InlineKeyboard: remindDetailInlineKeys,
+		})
 
-			reminderDetailShowReminderCommandBtn := *buttons[ReminderDetailShowReminderCommandBtn]
-			reminderDetailShowReminderCommandBtn.Data = strconv.Itoa(message.ReminderID)
-			remindDetailInlineKeys = append(remindDetailInlineKeys, []telebot.InlineButton{reminderDetailShowReminderCommandBtn})
+		return err
+	}
+}
 
-			reminderDetailDeleteBtn := *buttons[ReminderDetailDeleteBtn]
-			reminderDetailDeleteBtn.Data = strconv.Itoa(message.ReminderID)
-			remindDetailInlineKeys = append(remindDetailInlineKeys, []telebot.InlineButton{reminderDetailDeleteBtn})
+// remindDetailMessage is the details of a reminder and the buttons to act on it.
+// Closing the details deletes the command they were asked for with too, unless commandMessageID is 0
+// as they were asked for with a button
+func remindDetailMessage(
+	lang i18n.Language,
+	reminderDetail *ReminderDetail,
+	buttons func(lang i18n.Language) map[string]*telebot.InlineButton,
+	commandMessageID int,
+) (string, [][]telebot.InlineButton, error) {
+	reminderID := reminderDetail.ID
+	var remindDetailInlineKeys [][]telebot.InlineButton
+	if buttons != nil {
+		buttons := buttons(lang)
+		closeCommandBtn := *buttons[ReminderDetailCloseCommandBtn]
+		if commandMessageID != 0 {
+			closeCommandBtn.Data = strconv.Itoa(commandMessageID)
 		}
+		remindDetailInlineKeys = append(remindDetailInlineKeys, []telebot.InlineButton{closeCommandBtn})
+
+		reminderDetailShowReminderCommandBtn := *buttons[ReminderDetailShowReminderCommandBtn]
+		reminderDetailShowReminderCommandBtn.Data = strconv.Itoa(reminderID)
+		remindDetailInlineKeys = append(remindDetailInlineKeys, []telebot.InlineButton{reminderDetailShowReminderCommandBtn})
 
-		t := template.Must(template.New("text").Parse(remindDetailText))
-		var buf bytes.Buffer
-		if execErr := t.Execute(&buf, reminderDetail); execErr != nil {
-			return execErr
+		switch reminderDetail.Status {
+		case cron.Active:
+			reminderDetailPauseBtn := *buttons[ReminderDetailPauseBtn]
+			reminderDetailPauseBtn.Data = strconv.Itoa(reminderID)
+			remindDetailInlineKeys = append(remindDetailInlineKeys, []telebot.InlineButton{reminderDetailPauseBtn})
+		case cron.Inactive:
+			reminderDetailResumeBtn := *buttons[ReminderDetailResumeBtn]
+			reminderDetailResumeBtn.Data = strconv.Itoa(reminderID)
+			remindDetailInlineKeys = append(remindDetailInlineKeys, []telebot.InlineButton{reminderDetailResumeBtn})
 		}
 
-		_, err = c.Send(buf.String(), &telebot.ReplyMarkup{
-			InlineKeyboard: remindDetailInlineKeys,
-		})
+		reminderDetailEditBtn := *buttons[ReminderDetailEditBtn]
+		reminderDetailEditBtn.Data = strconv.Itoa(reminderID)
+		remindDetailInlineKeys = append(remindDetailInlineKeys, []telebot.InlineButton{reminderDetailEditBtn})
 
-		return err
+		reminderDetailDeleteBtn := *buttons[ReminderDetailDeleteBtn]
+		reminderDetailDeleteBtn.Data = strconv.Itoa(reminderID)
+		remindDetailInlineKeys = append(remindDetailInlineKeys, []telebot.InlineButton{reminderDetailDeleteBtn})
+	}
+
+	t := template.Must(template.New("text").Funcs(templateFuncs(lang)).Parse(remindDetailText))
+	var buf bytes.Buffer
+	if execErr := t.Execute(&buf, reminderDetail); execErr != nil {
+		return "", nil, execErr
 	}
+
+	return buf.String(), remindDetailInlineKeys, nil
 }
 
 // nolint:lll
 const remindDetailText = `
-*Id*: {{.ID}}
-*Status*: {{.Status}}
-*Message*: {{.Data.Message}}
-*Command*: {{.Data.Command}}
-{{if .NextSchedule}}*Next Schedule*: {{.NextSchedule.Format "Mon, 02 Jan 2006 15:04 MST"}}{{end}}{{if .CompletedAt}}*Completed At*: {{.CompletedAt.Format "Mon, 02 Jan 2006 15:04 MST"}}{{end}}
+*{{t "detail.id"}}*: {{.ID}}
+*{{t "detail.status"}}*: {{status .Status}}
+*{{t "detail.message"}}*: {{.Data.Message}}
+*{{t "detail.command"}}*: {{escapeMarkdown .Data.Command}}
+{{with .Data.Recipient}}{{if not $.Data.Roster}}*{{t "detail.for"}}*: {{if .Private}}{{t "detail.for_privately" (escapeMarkdown .String)}}{{else}}{{escapeMarkdown .String}}{{end}}
+{{end}}{{end}}{{with .Data.Attachment}}*{{t "detail.attachment"}}*: {{attachment .}}
+{{end}}{{range .RosterTurns}}*{{t "detail.roster"}}*: {{format .At "datetime"}}, {{escapeMarkdown .Member.String}}
+{{end}}{{if .Nag}}*{{t "detail.until_done"}}*: {{t "detail.until_done_value" .Nag.Minutes .Nag.MaxResends}}
+{{end}}{{if .LunarSchedule}}*{{t "detail.lunar"}}*: {{lunarSchedule .LunarSchedule}}
+{{end}}{{if .RemainingRuns}}*{{t "detail.remaining"}}*: {{t "detail.remaining_value" .RemainingRuns}}
+{{end}}{{if .EndsAt}}*{{t "detail.ends"}}*: {{format .EndsAt "date"}}
+{{end}}{{if .Holidays}}*{{t "detail.holidays"}}*: {{holidays .Holidays}}
+{{end}}{{range .HolidayShifts}}{{if .MovedTo}}*{{t "detail.moved"}}*: {{t "detail.moved_value" (format .At "datetime") (format .MovedTo "daymonth") (or .Holiday (t "detail.weekend"))}}{{else}}*{{t "detail.skipped"}}*: {{t "detail.skipped_value" (format .At "datetime") .Holiday}}{{end}}
+{{end}}{{range .SkippedRuns}}*{{t "detail.will_skip"}}*: {{format . "datetime"}}
+{{end}}{{range .Occurrences}}*{{t "detail.sent"}}*: {{format .At "datetime"}}, {{if .Checklist}}{{t "detail.ticked" .Ticked (len .Checklist)}}{{else}}{{acks .Acks}}{{end}}
+{{end}}{{if .NextSchedule}}*{{t "detail.next_schedule"}}*: {{format .NextSchedule "datetimezone"}}{{if .NextLunarDate}} ({{lunar .NextLunarDate}}){{end}}{{end}}{{if .CompletedAt}}*{{t "detail.completed_at"}}*: {{format .CompletedAt "datetimezone"}}{{end}}
 `
+
+// templateFuncs are the functions the templates of the command replies use in the language of the chat
+func templateFuncs(lang i18n.Language) map[string]interface{} {
+	funcs := i18n.Funcs(lang)
+	funcs["escapeMarkdown"] = escapeMarkdown
+	funcs["status"] = func(status cron.JobStatus) string {
+		return i18n.T(lang, statusKeys[status])
+	}
+	funcs["holidays"] = func(policy cron.HolidayPolicy) string {
+		return i18n.T(lang, holidayPolicyKeys[policy])
+	}
+	funcs["acks"] = func(acks []reminder.Acknowledgement) string {
+		if len(acks) == 0 {
+			return i18n.T(lang, i18n.DetailNoAcks)
+		}
+
+		names := make([]string, len(acks))
+		for i := range acks {
+			names[i] = escapeMarkdown(acks[i].String())
+		}
+
+		return strings.Join(names, ", ")
+	}
+	funcs["attachment"] = func(a *reminder.Attachment) string {
+		return escapeMarkdown(attachmentName(lang, a))
+	}
+	funcs["lunarSchedule"] = func(r *reminder.LunarRecurrence) string {
+		if r.Month == 0 {
+			return i18n.T(lang, i18n.DetailLunarMonthly, r.Day)
+		}
+
+		return i18n.T(lang, i18n.DetailLunarYearly, r.Day, r.Month)
+	}
+
+	return funcs
+}
+
+// nolint:gochecknoglobals
+var statusKeys = map[cron.JobStatus]i18n.Key{
+	cron.Active:    i18n.StatusActive,
+	cron.Inactive:  i18n.StatusInactive,
+	cron.Completed: i18n.StatusCompleted,
+}
+
+// attachmentName is the kind of media a reminder is sent with, along with the name of a document
+func attachmentName(lang i18n.Language, a *reminder.Attachment) string {
+	name := i18n.T(lang, attachmentKeys[a.Type])
+	if a.FileName != "" {
+		return fmt.Sprintf("%s (%s)", name, a.FileName)
+	}
+
+	return name
+}
+
+// nolint:gochecknoglobals
+var attachmentKeys = map[reminder.AttachmentType]i18n.Key{
+	reminder.AttachmentPhoto:    i18n.AttachmentPhoto,
+	reminder.AttachmentDocument: i18n.AttachmentDocument,
+	reminder.AttachmentVoice:    i18n.AttachmentVoice,
+	reminder.AttachmentLocation: i18n.AttachmentLocation,
+}
+
+// nolint:gochecknoglobals
+var holidayPolicyKeys = map[cron.HolidayPolicy]i18n.Key{
+	cron.SkipHolidays:          i18n.SkipHolidays,
+	cron.MoveToNextBusinessDay: i18n.MoveToNextBusinessDay,
+}
@@ -3,8 +3,12 @@ package command
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/enrico5b1b4/tbwrap"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
+	"github.com/husol/telegram-reminder-bot/pkg/permission"
+	"github.com/husol/telegram-reminder-bot/pkg/reminder"
 	"gopkg.in/tucnak/telebot.v2"
 )
 
@@ -12,37 +16,67 @@ const (
 	ReminderDetailDeleteBtn              = "ReminderDetailDeleteBtn"
 	ReminderDetailShowReminderCommandBtn = "ReminderDetailShowReminderCommandBtn"
 	ReminderDetailCloseCommandBtn        = "ReminderDetailCloseCommandBtn"
+	ReminderDetailPauseBtn               = "ReminderDetailPauseBtn"
+	ReminderDetailResumeBtn              = "ReminderDetailResumeBtn"
+	ReminderDetailEditBtn                = "ReminderDetailEditBtn"
 )
 
-func NewRemindDetailButtons() map[string]*telebot.InlineButton {
+// NewRemindDetailButtons returns the buttons of the details of a reminder labelled in the language
+func NewRemindDetailButtons(lang i18n.Language) map[string]*telebot.InlineButton {
 	reminderDetailDeleteBtn := telebot.InlineButton{
 		Unique: ReminderDetailDeleteBtn,
-		Text:   "🗑 Delete Reminder",
+		Text:   i18n.T(lang, i18n.ButtonDeleteReminder),
 	}
 	reminderDetailShowReminderCommandBtn := telebot.InlineButton{
 		Unique: ReminderDetailShowReminderCommandBtn,
-		Text:   "📄 Show Reminder Command",
+		Text:   i18n.T(lang, i18n.ButtonShowReminderCommand),
 	}
 	closeCommandBtn := telebot.InlineButton{
 		Unique: ReminderDetailCloseCommandBtn,
-		Text:   "❌ Close Details",
+		Text:   i18n.T(lang, i18n.ButtonCloseDetails),
+	}
+	reminderDetailPauseBtn := telebot.InlineButton{
+		Unique: ReminderDetailPauseBtn,
+		Text:   i18n.T(lang, i18n.ButtonPauseReminder),
+	}
+	reminderDetailResumeBtn := telebot.InlineButton{
+		Unique: ReminderDetailResumeBtn,
+		Text:   i18n.T(lang, i18n.ButtonResumeReminder),
+	}
+	reminderDetailEditBtn := telebot.InlineButton{
+		Unique: ReminderDetailEditBtn,
+		Text:   i18n.T(lang, i18n.ButtonEditReminder),
 	}
 
 	return map[string]*telebot.InlineButton{
 		ReminderDetailDeleteBtn:              &reminderDetailDeleteBtn,
 		ReminderDetailShowReminderCommandBtn: &reminderDetailShowReminderCommandBtn,
 		ReminderDetailCloseCommandBtn:        &closeCommandBtn,
+		ReminderDetailPauseBtn:               &reminderDetailPauseBtn,
+		ReminderDetailResumeBtn:              &reminderDetailResumeBtn,
+		ReminderDetailEditBtn:                &reminderDetailEditBtn,
 	}
 }
 
 // nolint:interfacer
-func HandleReminderDetailDeleteBtn(service RemindDetailServicer) func(c tbwrap.Context) error {
+func HandleReminderDetailDeleteBtn(
+	service RemindDetailServicer, checker permission.Checker, languages i18n.Languages,
+) func(c tbwrap.Context) error {
 	return func(c tbwrap.Context) error {
 		reminderID, err := strconv.Atoi(c.Callback().Data)
 		if err != nil {
 			return err
 		}
 
+		lang := languages.ChatLanguage(int(c.ChatID()))
+		allowed, err := checker.CanManageReminder(int(c.ChatID()), senderID(c), reminderID)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return respondNotAllowed(c, lang)
+		}
+
 		err = c.Respond(c.Callback())
 		if err != nil {
 			return err
@@ -53,7 +87,75 @@ func HandleReminderDetailDeleteBtn(service RemindDetailServicer) func(c tbwrap.C
 			return err
 		}
 
-		_, err = c.Send(fmt.Sprintf("Reminder %s has been deleted", strconv.Itoa(reminderID)))
+		_, err = c.Send(i18n.T(lang, i18n.ReminderDeleted, reminderID))
+
+		return err
+	}
+}
+
+func HandleReminderDetailPauseBtn(
+	service reminder.ServiceReminder, checker permission.Checker, languages i18n.Languages,
+) func(c tbwrap.Context) error {
+	return func(c tbwrap.Context) error {
+		reminderID, err := strconv.Atoi(c.Callback().Data)
+		if err != nil {
+			return err
+		}
+
+		lang := languages.ChatLanguage(int(c.ChatID()))
+		allowed, err := checker.CanManageReminder(int(c.ChatID()), senderID(c), reminderID)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return respondNotAllowed(c, lang)
+		}
+
+		err = c.Respond(c.Callback())
+		if err != nil {
+			return err
+		}
+
+		err = service.PauseReminder(int(c.ChatID()), reminderID)
+		if err != nil {
+			return err
+		}
+
+		_, err = c.Send(i18n.T(lang, i18n.ReminderPaused, reminderID))
+
+		return err
+	}
+}
+
+func HandleReminderDetailResumeBtn(
+	service reminder.ServiceReminder, checker permission.Checker, languages i18n.Languages,
+) func(c tbwrap.Context) error {
+	return func(c tbwrap.Context) error {
+		reminderID, err := strconv.Atoi(c.Callback().Data)
+		if err != nil {
+			return err
+		}
+
+		lang := languages.ChatLanguage(int(c.ChatID()))
+		allowed, err := checker.CanManageReminder(int(c.ChatID()), senderID(c), reminderID)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return respondNotAllowed(c, lang)
+		}
+
+		err = c.Respond(c.Callback())
+		if err != nil {
+			return err
+		}
+
+		nextSchedule, err := service.ResumeReminder(int(c.ChatID()), reminderID)
+		if err != nil {
+			return err
+		}
+
+		_, err = c.Send(ReminderResumedSuccessMessage(lang, reminderID, nextSchedule))
 
 		return err
 	}
@@ -76,15 +178,32 @@ func HandleReminderShowReminderCommandBtn(reminderDetailService RemindDetailServ
 			return err
 		}
 
-		_, err = c.Send(reminder.Data.Command)
+		_, err = c.Send(escapeMarkdown(reminder.Data.Command))
 
 		return err
 	}
 }
 
-func HandleReminderDetailCloseBtn() func(c tbwrap.Context) error {
+// HandleReminderDetailEditBtn replies with the edit command for the reminder
+// so that it can be copied, changed and sent back
+func HandleReminderDetailEditBtn(
+	reminderDetailService RemindDetailServicer, checker permission.Checker, languages i18n.Languages,
+) func(c tbwrap.Context) error {
 	return func(c tbwrap.Context) error {
-		messageID, err := strconv.Atoi(c.Callback().Data)
+		reminderID, err := strconv.Atoi(c.Callback().Data)
+		if err != nil {
+			return err
+		}
+
+		allowed, err := checker.CanManageReminder(int(c.ChatID()), senderID(c), reminderID)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return respondNotAllowed(c, languages.ChatLanguage(int(c.ChatID())))
+		}
+
+		reminderDetail, err := reminderDetailService.GetReminder(int(c.ChatID()), reminderID)
 		if err != nil {
 			return err
 		}
@@ -94,11 +213,37 @@ func HandleReminderDetailCloseBtn() func(c tbwrap.Context) error {
 			return err
 		}
 
-		err = c.Delete(c.ChatID(), messageID)
+		_, err = c.Send(fmt.Sprintf("/remindedit %d %s", reminderID, strings.TrimPrefix(reminderDetail.Data.Command, remindEditPrefix)))
+
+		return err
+	}
+}
+
+// HandleReminderDetailCloseBtn deletes the details of a reminder along with the command they were asked for with,
+// which the button carries unless they were asked for with a button of the list of reminders
+func HandleReminderDetailCloseBtn() func(c tbwrap.Context) error {
+	return func(c tbwrap.Context) error {
+		var messageID int
+		if c.Callback().Data != "" {
+			var err error
+			messageID, err = strconv.Atoi(c.Callback().Data)
+			if err != nil {
+				return err
+			}
+		}
+
+		err := c.Respond(c.Callback())
 		if err != nil {
 			return err
 		}
 
+		if messageID != 0 {
+			err = c.Delete(c.ChatID(), messageID)
+			if err != nil {
+				return err
+			}
+		}
+
 		return c.Delete(c.ChatID(), c.Message().ID)
 	}
 }
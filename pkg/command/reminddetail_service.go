@@ -3,14 +3,18 @@ package command
 //go:generate mockgen -source=$GOFILE -destination=mocks/${GOFILE} -package=mocks
 
 import (
-	"errors"
 	"time"
 
 	"github.com/husol/telegram-reminder-bot/pkg/chatpreference"
 	"github.com/husol/telegram-reminder-bot/pkg/cron"
+	"github.com/husol/telegram-reminder-bot/pkg/date"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
 	"github.com/husol/telegram-reminder-bot/pkg/reminder"
 )
 
+// rosterPreviewTurns is how many of the upcoming turns of a roster are shown in the details of a reminder
+const rosterPreviewTurns = 5
+
 type RemindDetailServicer interface {
 	GetReminder(chatID, reminderID int) (*ReminderDetail, error)
 	DeleteReminder(chatID, ID int) error
@@ -18,17 +22,20 @@ type RemindDetailServicer interface {
 
 type RemindDetailService struct {
 	reminderStore       reminder.Storer
+	occurrenceStore     reminder.OccurrenceStorer
 	scheduler           cron.Scheduler
 	chatPreferenceStore chatpreference.Storer
 }
 
 func NewRemindDetailService(
 	reminderStore reminder.Storer,
+	occurrenceStore reminder.OccurrenceStorer,
 	scheduler cron.Scheduler,
 	chatPreferenceStore chatpreference.Storer,
 ) *RemindDetailService {
 	return &RemindDetailService{
 		reminderStore:       reminderStore,
+		occurrenceStore:     occurrenceStore,
 		scheduler:           scheduler,
 		chatPreferenceStore: chatPreferenceStore,
 	}
@@ -51,10 +58,54 @@ func (s *RemindDetailService) GetReminder(chatID, reminderID int) (*ReminderDeta
 	}
 
 	reminderDetail := &ReminderDetail{Reminder: *rem}
+	if rem.Lunar {
+		reminderDetail.LunarSchedule, err = reminder.ParseLunarRecurrence(rem.Schedule, loc)
+		if err != nil {
+			return nil, err
+		}
+	}
 	if rem.Status == cron.Active {
 		cronEntry := s.scheduler.GetEntryByID(rem.CronID)
 		nextScheduleInChatTimezone := cronEntry.Next.In(loc)
 		reminderDetail.NextSchedule = &nextScheduleInChatTimezone
+		if rem.Lunar {
+			nextLunarDate := date.ToLunar(nextScheduleInChatTimezone)
+			reminderDetail.NextLunarDate = &nextLunarDate
+		}
+
+		holidayShifts, err := reminder.HolidayShifts(chatPreference, rem, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		reminderDetail.HolidayShifts = holidayShiftsInLocation(holidayShifts, loc)
+
+		skippedRuns := make([]time.Time, len(rem.SkippedRuns))
+		for i := range rem.SkippedRuns {
+			skippedRuns[i] = rem.SkippedRuns[i].In(loc)
+		}
+		reminderDetail.SkippedRuns = skippedRuns
+
+		rosterTurns, err := reminder.UpcomingRosterTurns(chatPreference, rem, time.Now(), rosterPreviewTurns)
+		if err != nil {
+			return nil, err
+		}
+		for i := range rosterTurns {
+			rosterTurns[i].At = rosterTurns[i].At.In(loc)
+		}
+		reminderDetail.RosterTurns = rosterTurns
+	}
+	occurrences, err := s.occurrenceStore.GetOccurrencesByReminder(chatID, reminderID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range occurrences {
+		occurrences[i].At = occurrences[i].At.In(loc)
+	}
+	reminderDetail.Occurrences = occurrences
+
+	if rem.EndsAt != nil {
+		endsAtChatTimezone := rem.EndsAt.In(loc)
+		reminderDetail.EndsAt = &endsAtChatTimezone
 	}
 	if rem.Status == cron.Completed && rem.CompletedAt != nil {
 		completedAtChatTimezone := rem.CompletedAt.In(loc)
@@ -64,6 +115,20 @@ func (s *RemindDetailService) GetReminder(chatID, reminderID int) (*ReminderDeta
 	return reminderDetail, nil
 }
 
+// holidayShiftsInLocation returns copies of the holiday shifts of a reminder with their times in loc
+func holidayShiftsInLocation(shifts []cron.JobHolidayShift, loc *time.Location) []cron.JobHolidayShift {
+	inLocation := make([]cron.JobHolidayShift, len(shifts))
+	for i := range shifts {
+		inLocation[i] = cron.JobHolidayShift{At: shifts[i].At.In(loc), Holiday: shifts[i].Holiday}
+		if shifts[i].MovedTo != nil {
+			movedTo := shifts[i].MovedTo.In(loc)
+			inLocation[i].MovedTo = &movedTo
+		}
+	}
+
+	return inLocation
+}
+
 func (s *RemindDetailService) DeleteReminder(chatID, id int) error {
 	rem, err := s.reminderStore.GetReminder(chatID, id)
 	if err != nil {
@@ -71,10 +136,13 @@ func (s *RemindDetailService) DeleteReminder(chatID, id int) error {
 	}
 
 	if chatID != rem.ChatID {
-		return errors.New("unauthorised to delete reminder")
+		return i18n.Errorf(i18n.ErrNotInChat, id)
 	}
 
 	s.scheduler.Remove(rem.CronID)
+	if rem.Nag != nil {
+		s.scheduler.Remove(rem.Nag.CronID)
+	}
 
 	return s.reminderStore.DeleteReminder(rem.ChatID, id)
 }
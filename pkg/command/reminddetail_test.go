@@ -4,12 +4,19 @@ import (
 	"errors"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/enrico5b1b4/tbwrap"
+	"github.com/golang/mock/gomock"
 	"github.com/husol/telegram-reminder-bot/pkg/command"
 	"github.com/husol/telegram-reminder-bot/pkg/command/mocks"
+	"github.com/husol/telegram-reminder-bot/pkg/cron"
+	"github.com/husol/telegram-reminder-bot/pkg/date"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
+	permissionMocks "github.com/husol/telegram-reminder-bot/pkg/permission/mocks"
+	"github.com/husol/telegram-reminder-bot/pkg/reminder"
+	reminderMocks "github.com/husol/telegram-reminder-bot/pkg/reminder/mocks"
 	fakeBot "github.com/husol/telegram-reminder-bot/pkg/telegram/fakes"
-	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 	tb "gopkg.in/tucnak/telebot.v2"
 )
@@ -31,9 +38,206 @@ func TestHandleRemindDetail(t *testing.T) {
 			GetReminder(1, 2).
 			Return(&command.ReminderDetail{}, nil)
 
-		err := command.HandleRemindDetail(mockReminderService, nil)(c)
+		err := command.HandleRemindDetail(mockReminderService, i18n.English, nil)(c)
+		require.NoError(t, err)
+		require.Len(t, bot.OutboundSendMessages, 1)
+	})
+
+	t.Run("explains occurrences moved or skipped for holidays", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		c := tbwrap.NewContext(bot, &tb.Message{Text: text, Chat: chat}, nil, handlerPattern)
+		movedTo := time.Date(2020, time.May, 4, 9, 0, 0, 0, time.UTC)
+		reminderDetail := &command.ReminderDetail{NextSchedule: &movedTo}
+		reminderDetail.Holidays = cron.MoveToNextBusinessDay
+		reminderDetail.HolidayShifts = []cron.JobHolidayShift{
+			{At: time.Date(2020, time.April, 30, 9, 0, 0, 0, time.UTC), Holiday: "Reunification Day", MovedTo: &movedTo},
+			{At: time.Date(2020, time.May, 2, 9, 0, 0, 0, time.UTC), MovedTo: &movedTo},
+		}
+		mockReminderService := mocks.NewMockRemindDetailServicer(mockCtrl)
+		mockReminderService.
+			EXPECT().
+			GetReminder(1, 2).
+			Return(reminderDetail, nil)
+
+		err := command.HandleRemindDetail(mockReminderService, i18n.English, nil)(c)
+		require.NoError(t, err)
+		require.Len(t, bot.OutboundSendMessages, 1)
+		require.Contains(t, bot.OutboundSendMessages[0], "*Holidays*: Move to next business day")
+		require.Contains(t, bot.OutboundSendMessages[0], "*Moved*: Thu, 30 Apr 2020 09:00 to Mon, 04 May as it is Reunification Day")
+		require.Contains(t, bot.OutboundSendMessages[0], "*Moved*: Sat, 02 May 2020 09:00 to Mon, 04 May as it is a weekend")
+	})
+
+	t.Run("in the language of the chat", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		c := tbwrap.NewContext(bot, &tb.Message{Text: text, Chat: chat}, nil, handlerPattern)
+		movedTo := time.Date(2020, time.May, 4, 9, 0, 0, 0, time.UTC)
+		remainingRuns := 3
+		reminderDetail := &command.ReminderDetail{NextSchedule: &movedTo}
+		reminderDetail.Status = cron.Active
+		reminderDetail.RemainingRuns = &remainingRuns
+		reminderDetail.Holidays = cron.MoveToNextBusinessDay
+		reminderDetail.HolidayShifts = []cron.JobHolidayShift{
+			{At: time.Date(2020, time.May, 2, 9, 0, 0, 0, time.UTC), MovedTo: &movedTo},
+		}
+		mockReminderService := mocks.NewMockRemindDetailServicer(mockCtrl)
+		mockReminderService.
+			EXPECT().
+			GetReminder(1, 2).
+			Return(reminderDetail, nil)
+
+		err := command.HandleRemindDetail(mockReminderService, i18n.Vietnamese, nil)(c)
 		require.NoError(t, err)
 		require.Len(t, bot.OutboundSendMessages, 1)
+		require.Contains(t, bot.OutboundSendMessages[0], "*Trạng thái*: Đang hoạt động")
+		require.Contains(t, bot.OutboundSendMessages[0], "*Còn lại*: 3 lần")
+		require.Contains(t, bot.OutboundSendMessages[0], "*Ngày lễ*: Dời sang ngày làm việc tiếp theo")
+		require.Contains(t, bot.OutboundSendMessages[0], "*Đã dời*: Thứ Bảy, 02/05/2020 09:00 sang Thứ Hai, 04/05 vì là cuối tuần")
+		require.Contains(t, bot.OutboundSendMessages[0], "*Lần tiếp theo*: Thứ Hai, 04/05/2020 09:00 UTC")
+	})
+
+	t.Run("shows the lunar and solar dates of a reminder on lunar dates", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		c := tbwrap.NewContext(bot, &tb.Message{Text: text, Chat: chat}, nil, handlerPattern)
+		nextSchedule := time.Date(2020, time.April, 2, 9, 0, 0, 0, time.UTC)
+		reminderDetail := &command.ReminderDetail{
+			NextSchedule:  &nextSchedule,
+			LunarSchedule: &reminder.LunarRecurrence{Hour: 9, Day: 10, Month: 3},
+			NextLunarDate: &date.LunarDate{Year: 2020, Month: 3, Day: 10},
+		}
+		mockReminderService := mocks.NewMockRemindDetailServicer(mockCtrl)
+		mockReminderService.
+			EXPECT().
+			GetReminder(1, 2).
+			Return(reminderDetail, nil)
+
+		err := command.HandleRemindDetail(mockReminderService, i18n.English, nil)(c)
+		require.NoError(t, err)
+		require.Len(t, bot.OutboundSendMessages, 1)
+		require.Contains(t, bot.OutboundSendMessages[0], "*Lunar Date*: day 10 of lunar month 3 every year")
+		require.Contains(t, bot.OutboundSendMessages[0], "*Next Schedule*: Thu, 02 Apr 2020 09:00 UTC (10/3/2020 lunar)")
+	})
+
+	t.Run("shows the user a reminder is for", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		c := tbwrap.NewContext(bot, &tb.Message{Text: text, Chat: chat}, nil, handlerPattern)
+		reminderDetail := &command.ReminderDetail{Reminder: reminder.Reminder{
+			Data: reminder.Data{Recipient: &reminder.Recipient{Username: "alice_b", Private: true}},
+		}}
+		mockReminderService := mocks.NewMockRemindDetailServicer(mockCtrl)
+		mockReminderService.
+			EXPECT().
+			GetReminder(1, 2).
+			Return(reminderDetail, nil)
+
+		err := command.HandleRemindDetail(mockReminderService, i18n.English, nil)(c)
+		require.NoError(t, err)
+		require.Len(t, bot.OutboundSendMessages, 1)
+		require.Contains(t, bot.OutboundSendMessages[0], `*For*: @alice\_b, by private message`)
+	})
+
+	t.Run("shows what a reminder is sent with", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		c := tbwrap.NewContext(bot, &tb.Message{Text: text, Chat: chat}, nil, handlerPattern)
+		reminderDetail := &command.ReminderDetail{Reminder: reminder.Reminder{
+			Data: reminder.Data{Attachment: &reminder.Attachment{Type: reminder.AttachmentDocument, FileName: "report.pdf"}},
+		}}
+		mockReminderService := mocks.NewMockRemindDetailServicer(mockCtrl)
+		mockReminderService.
+			EXPECT().
+			GetReminder(1, 2).
+			Return(reminderDetail, nil)
+
+		err := command.HandleRemindDetail(mockReminderService, i18n.English, nil)(c)
+		require.NoError(t, err)
+		require.Len(t, bot.OutboundSendMessages, 1)
+		require.Contains(t, bot.OutboundSendMessages[0], "*Attachment*: document (report.pdf)")
+	})
+
+	t.Run("shows the upcoming turns of a roster", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		c := tbwrap.NewContext(bot, &tb.Message{Text: text, Chat: chat}, nil, handlerPattern)
+		reminderDetail := &command.ReminderDetail{
+			Reminder: reminder.Reminder{Data: reminder.Data{
+				Recipient: &reminder.Recipient{Username: "carol"},
+				Roster:    &reminder.Roster{Members: []reminder.Recipient{{Username: "alice_b"}, {UserID: 7, Name: "Bob"}}},
+			}},
+			RosterTurns: []reminder.RosterTurn{
+				{At: time.Date(2020, time.April, 6, 9, 0, 0, 0, time.UTC), Member: reminder.Recipient{Username: "alice_b"}},
+				{At: time.Date(2020, time.April, 13, 9, 0, 0, 0, time.UTC), Member: reminder.Recipient{UserID: 7, Name: "Bob"}},
+			},
+		}
+		mockReminderService := mocks.NewMockRemindDetailServicer(mockCtrl)
+		mockReminderService.
+			EXPECT().
+			GetReminder(1, 2).
+			Return(reminderDetail, nil)
+
+		err := command.HandleRemindDetail(mockReminderService, i18n.English, nil)(c)
+		require.NoError(t, err)
+		require.Len(t, bot.OutboundSendMessages, 1)
+		require.Contains(t, bot.OutboundSendMessages[0], "*Roster*: Mon, 06 Apr 2020 09:00, @alice\\_b\n*Roster*: Mon, 13 Apr 2020 09:00, Bob\n")
+		require.NotContains(t, bot.OutboundSendMessages[0], "*For*")
+	})
+
+	t.Run("shows who acknowledged each occurrence", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		c := tbwrap.NewContext(bot, &tb.Message{Text: text, Chat: chat}, nil, handlerPattern)
+		reminderDetail := &command.ReminderDetail{Occurrences: []reminder.Occurrence{
+			{At: time.Date(2020, time.April, 1, 9, 0, 0, 0, time.UTC)},
+			{
+				At: time.Date(2020, time.April, 2, 9, 0, 0, 0, time.UTC),
+				Acks: []reminder.Acknowledgement{
+					{UserID: 7, Name: "Bob_S", Status: reminder.AckDone},
+					{UserID: 8, Name: "Alice", Status: reminder.AckOnIt},
+				},
+			},
+		}}
+		mockReminderService := mocks.NewMockRemindDetailServicer(mockCtrl)
+		mockReminderService.
+			EXPECT().
+			GetReminder(1, 2).
+			Return(reminderDetail, nil)
+
+		err := command.HandleRemindDetail(mockReminderService, i18n.English, nil)(c)
+		require.NoError(t, err)
+		require.Len(t, bot.OutboundSendMessages, 1)
+		require.Contains(t, bot.OutboundSendMessages[0], "*Sent*: Wed, 01 Apr 2020 09:00, no one acknowledged it\n")
+		require.Contains(t, bot.OutboundSendMessages[0], `*Sent*: Thu, 02 Apr 2020 09:00, ✅ Bob\_S, 👋 Alice`)
+	})
+
+	t.Run("shows how many items of a checklist were ticked", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		c := tbwrap.NewContext(bot, &tb.Message{Text: text, Chat: chat}, nil, handlerPattern)
+		reminderDetail := &command.ReminderDetail{Occurrences: []reminder.Occurrence{{
+			At:        time.Date(2020, time.April, 3, 16, 0, 0, 0, time.UTC),
+			Checklist: []reminder.ChecklistItem{{Text: "tag the build", Ticked: true}, {Text: "deploy"}},
+		}}}
+		mockReminderService := mocks.NewMockRemindDetailServicer(mockCtrl)
+		mockReminderService.
+			EXPECT().
+			GetReminder(1, 2).
+			Return(reminderDetail, nil)
+
+		err := command.HandleRemindDetail(mockReminderService, i18n.English, nil)(c)
+		require.NoError(t, err)
+		require.Len(t, bot.OutboundSendMessages, 1)
+		require.Contains(t, bot.OutboundSendMessages[0], "*Sent*: Fri, 03 Apr 2020 16:00, 1 of 2 items ticked\n")
 	})
 
 	t.Run("failure", func(t *testing.T) {
@@ -47,8 +251,91 @@ func TestHandleRemindDetail(t *testing.T) {
 			GetReminder(1, 2).
 			Return(nil, errors.New("error"))
 
-		err := command.HandleRemindDetail(mockReminderService, nil)(c)
+		err := command.HandleRemindDetail(mockReminderService, i18n.English, nil)(c)
 		require.Error(t, err)
 		require.Len(t, bot.OutboundSendMessages, 0)
 	})
 }
+
+func TestHandleReminderDetailDeleteBtn(t *testing.T) {
+	group := &tb.Chat{ID: int64(-1)}
+	newContext := func(bot *fakeBot.TBWrapBot) tbwrap.Context {
+		msg := &tb.Message{ID: 10, Chat: group}
+		return tbwrap.NewContext(bot, msg, &tb.Callback{Data: "2", Sender: &tb.User{ID: 7}, Message: msg}, nil)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		mockReminderService := mocks.NewMockRemindDetailServicer(mockCtrl)
+		mockReminderService.
+			EXPECT().
+			DeleteReminder(-1, 2).
+			Return(nil)
+
+		err := command.HandleReminderDetailDeleteBtn(mockReminderService, allowingChecker(mockCtrl), i18n.English)(newContext(bot))
+		require.NoError(t, err)
+		require.Equal(t, []string{"Reminder 2 has been deleted"}, bot.OutboundSendMessages)
+	})
+
+	t.Run("alerts a member who is not allowed", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		mockReminderService := mocks.NewMockRemindDetailServicer(mockCtrl)
+		mockChecker := permissionMocks.NewMockChecker(mockCtrl)
+		mockChecker.
+			EXPECT().
+			CanManageReminder(-1, 7, 2).
+			Return(false, nil)
+
+		err := command.HandleReminderDetailDeleteBtn(mockReminderService, mockChecker, i18n.English)(newContext(bot))
+		require.NoError(t, err)
+		require.Empty(t, bot.OutboundSendMessages)
+		require.Len(t, bot.CallbackResponses, 1)
+		require.True(t, bot.CallbackResponses[0].ShowAlert)
+		require.Equal(t, "error: you are not allowed to do that in this chat, see /setpermissions", bot.CallbackResponses[0].Text)
+	})
+}
+
+func TestHandleReminderDetailPauseBtn(t *testing.T) {
+	group := &tb.Chat{ID: int64(-1)}
+	newContext := func(bot *fakeBot.TBWrapBot) tbwrap.Context {
+		msg := &tb.Message{ID: 10, Chat: group}
+		return tbwrap.NewContext(bot, msg, &tb.Callback{Data: "2", Sender: &tb.User{ID: 7}, Message: msg}, nil)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		mockReminderService := reminderMocks.NewMockServicer(mockCtrl)
+		mockReminderService.
+			EXPECT().
+			PauseReminder(-1, 2).
+			Return(nil)
+
+		err := command.HandleReminderDetailPauseBtn(mockReminderService, allowingChecker(mockCtrl), i18n.English)(newContext(bot))
+		require.NoError(t, err)
+		require.Len(t, bot.OutboundSendMessages, 1)
+	})
+
+	t.Run("alerts a member who is not allowed", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		mockReminderService := reminderMocks.NewMockServicer(mockCtrl)
+		mockChecker := permissionMocks.NewMockChecker(mockCtrl)
+		mockChecker.
+			EXPECT().
+			CanManageReminder(-1, 7, 2).
+			Return(false, nil)
+
+		err := command.HandleReminderDetailPauseBtn(mockReminderService, mockChecker, i18n.English)(newContext(bot))
+		require.NoError(t, err)
+		require.Empty(t, bot.OutboundSendMessages)
+		require.Len(t, bot.CallbackResponses, 1)
+		require.True(t, bot.CallbackResponses[0].ShowAlert)
+	})
+}
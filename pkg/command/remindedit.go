@@ -0,0 +1,114 @@
+package command
+
+import (
+	"time"
+
+	"github.com/enrico5b1b4/tbwrap"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
+	"github.com/husol/telegram-reminder-bot/pkg/parser"
+	"github.com/husol/telegram-reminder-bot/pkg/permission"
+	"github.com/husol/telegram-reminder-bot/pkg/reminder"
+	"github.com/husol/telegram-reminder-bot/pkg/userpreference"
+)
+
+type MessageRemindEdit struct {
+	ReminderID int    `regexpGroup:"reminderID"`
+	Expression string `regexpGroup:"expression"`
+}
+
+type MessageRemindEditMessage struct {
+	ReminderID int    `regexpGroup:"reminderID"`
+	Message    string `regexpGroup:"message"`
+}
+
+var HandlePatternRemindEditMessage = []string{
+	`/remindedit (?P<reminderID>\d{1,5}) message (?P<message>.*)`,
+	`/remindedit_(?P<reminderID>\d{1,5}) message (?P<message>.*)`,
+}
+
+var HandlePatternRemindEdit = []string{
+	`/remindedit (?P<reminderID>\d{1,5}) (?P<expression>.*)`,
+	`/remindedit_(?P<reminderID>\d{1,5}) (?P<expression>.*)`,
+}
+
+// remindEditPrefix is prepended to the expression of an edit
+// so that it can be parsed as a command which sets a reminder
+const remindEditPrefix = "/remind me "
+
+func HandleRemindEdit(
+	service reminder.ServiceReminder,
+	userPreferenceStore userpreference.Storer,
+	checker permission.Checker,
+	languages i18n.Languages,
+	minCronInterval time.Duration,
+) func(c tbwrap.Context) error {
+	return func(c tbwrap.Context) error {
+		message := new(MessageRemindEdit)
+		if err := c.Bind(message); err != nil {
+			return err
+		}
+
+		lang := languages.ChatLanguage(int(c.ChatID()))
+		allowed, err := checker.CanManageReminder(int(c.ChatID()), senderID(c), message.ReminderID)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return notAllowedError(lang)
+		}
+
+		text := remindEditPrefix + message.Expression
+		remind, err := parser.Parse(text, lang)
+		if err != nil {
+			return translateParseError(lang, err)
+		}
+
+		userService, err := inSenderTimeZone(service, userPreferenceStore, senderID(c))
+		if err != nil {
+			return err
+		}
+
+		nextSchedule, preview, err := editRemind(userService, lang, int(c.ChatID()), message.ReminderID, text, remind, minCronInterval)
+		if err != nil {
+			return err
+		}
+
+		reply := ReminderEditedSuccessMessage(lang, remind.What, nextSchedule) + preview
+		if nextSchedule.Paused {
+			reply += "\n" + i18n.T(lang, i18n.ReminderStaysPaused, message.ReminderID)
+		}
+
+		_, err = c.Send(reply)
+
+		return err
+	}
+}
+
+func HandleRemindEditMessage(
+	service reminder.ServiceReminder, checker permission.Checker, languages i18n.Languages,
+) func(c tbwrap.Context) error {
+	return func(c tbwrap.Context) error {
+		message := new(MessageRemindEditMessage)
+		if err := c.Bind(message); err != nil {
+			return err
+		}
+
+		lang := languages.ChatLanguage(int(c.ChatID()))
+		allowed, err := checker.CanManageReminder(int(c.ChatID()), senderID(c), message.ReminderID)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return notAllowedError(lang)
+		}
+
+		err = service.EditReminderMessage(int(c.ChatID()), message.ReminderID, message.Message)
+		if err != nil {
+			return err
+		}
+
+		_, err = c.Send(i18n.T(lang, i18n.ReminderMessageUpdated, message.ReminderID, message.Message))
+
+		return err
+	}
+}
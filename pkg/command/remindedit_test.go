@@ -7,132 +7,129 @@ import (
 	"time"
 
 	"github.com/enrico5b1b4/tbwrap"
+	"github.com/golang/mock/gomock"
 	"github.com/husol/telegram-reminder-bot/pkg/command"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
 	"github.com/husol/telegram-reminder-bot/pkg/reminder"
 	"github.com/husol/telegram-reminder-bot/pkg/reminder/mocks"
 	fakeBot "github.com/husol/telegram-reminder-bot/pkg/telegram/fakes"
-	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 	tb "gopkg.in/tucnak/telebot.v2"
 )
 
-// nolint:dupl
-func TestHandleRemindInPattern1(t *testing.T) {
-	handlerPattern, err := regexp.Compile(command.HandlePatternRemindIn)
+func TestHandleRemindEdit(t *testing.T) {
+	handlerPattern, err := regexp.Compile(command.HandlePatternRemindEdit[0])
 	require.NoError(t, err)
-	text := "/remind me in 2 minutes update weekly report"
 	chat := &tb.Chat{ID: int64(1)}
 
-	t.Run("success", func(t *testing.T) {
+	t.Run("success with recurring expression", func(t *testing.T) {
 		mockCtrl := gomock.NewController(t)
 		defer mockCtrl.Finish()
 		bot := fakeBot.NewTBWrapBot()
+		text := "/remindedit 2 every tuesday at 8:23 update weekly report"
 		c := tbwrap.NewContext(bot, &tb.Message{Text: text, Chat: chat}, nil, handlerPattern)
 		mockReminderService := mocks.NewMockServicer(mockCtrl)
 		mockReminderService.
 			EXPECT().
-			AddReminderIn(
+			EditRepeatableReminderOnDateTime(
 				1,
-				text,
-				reminder.AmountDateTime{
-					Days:    0,
-					Hours:   0,
-					Minutes: 2,
+				2,
+				"/remind me every tuesday at 8:23 update weekly report",
+				&reminder.RepeatableDateTime{
+					DayOfWeek: "2",
+					Month:     "*",
+					Hour:      "8",
+					Minute:    "23",
 				},
 				"update weekly report").
 			Return(reminder.NextScheduleChatTime{Time: time.Now(), Location: time.UTC}, nil)
 
-		err := command.HandleRemindIn(mockReminderService)(c)
+		err := command.HandleRemindEdit(mockReminderService, noUserPreference(mockCtrl), allowingChecker(mockCtrl), i18n.English, command.DefaultMinCronInterval)(c)
 		require.NoError(t, err)
 		require.Len(t, bot.OutboundSendMessages, 1)
+		require.Contains(t, bot.OutboundSendMessages[0], `Reminder "update weekly report" has been updated`)
 	})
 
-	t.Run("failure", func(t *testing.T) {
+	t.Run("success with amount expression", func(t *testing.T) {
 		mockCtrl := gomock.NewController(t)
 		defer mockCtrl.Finish()
 		bot := fakeBot.NewTBWrapBot()
+		text := "/remindedit 2 in 2 minutes update weekly report"
 		c := tbwrap.NewContext(bot, &tb.Message{Text: text, Chat: chat}, nil, handlerPattern)
 		mockReminderService := mocks.NewMockServicer(mockCtrl)
 		mockReminderService.
 			EXPECT().
-			AddReminderIn(
+			EditReminderIn(
 				1,
-				text,
-				reminder.AmountDateTime{
-					Days:    0,
-					Hours:   0,
-					Minutes: 2,
-				},
+				2,
+				"/remind me in 2 minutes update weekly report",
+				reminder.AmountDateTime{Minutes: 2},
 				"update weekly report").
-			Return(reminder.NextScheduleChatTime{}, errors.New("error"))
+			Return(reminder.NextScheduleChatTime{Time: time.Now(), Location: time.UTC}, nil)
 
-		err := command.HandleRemindIn(mockReminderService)(c)
-		require.Error(t, err)
-		require.Len(t, bot.OutboundSendMessages, 0)
+		err := command.HandleRemindEdit(mockReminderService, noUserPreference(mockCtrl), allowingChecker(mockCtrl), i18n.English, command.DefaultMinCronInterval)(c)
+		require.NoError(t, err)
+		require.Len(t, bot.OutboundSendMessages, 1)
 	})
-}
-
-// nolint:dupl
-func TestHandleRemindInPattern2(t *testing.T) {
-	handlerPattern, err := regexp.Compile(command.HandlePatternRemindIn)
-	require.NoError(t, err)
-	text := "/remind me in 2 minutes, 3 days update weekly report"
-	chat := &tb.Chat{ID: int64(1)}
 
-	t.Run("success", func(t *testing.T) {
+	t.Run("success with a paused reminder", func(t *testing.T) {
 		mockCtrl := gomock.NewController(t)
 		defer mockCtrl.Finish()
 		bot := fakeBot.NewTBWrapBot()
+		text := "/remindedit 2 in 2 minutes update weekly report"
 		c := tbwrap.NewContext(bot, &tb.Message{Text: text, Chat: chat}, nil, handlerPattern)
 		mockReminderService := mocks.NewMockServicer(mockCtrl)
 		mockReminderService.
 			EXPECT().
-			AddReminderIn(
-				1,
-				text,
-				reminder.AmountDateTime{
-					Days:    3,
-					Hours:   0,
-					Minutes: 2,
-				},
-				"update weekly report").
-			Return(reminder.NextScheduleChatTime{Time: time.Now(), Location: time.UTC}, nil)
+			EditReminderIn(1, 2, "/remind me in 2 minutes update weekly report", reminder.AmountDateTime{Minutes: 2}, "update weekly report").
+			Return(reminder.NextScheduleChatTime{Time: time.Now(), Location: time.UTC, Paused: true}, nil)
 
-		err := command.HandleRemindIn(mockReminderService)(c)
+		err := command.HandleRemindEdit(mockReminderService, noUserPreference(mockCtrl), allowingChecker(mockCtrl), i18n.English, command.DefaultMinCronInterval)(c)
 		require.NoError(t, err)
 		require.Len(t, bot.OutboundSendMessages, 1)
+		require.Contains(t, bot.OutboundSendMessages[0], "\nIt stays paused until it is resumed with /remindresume 2")
+	})
+
+	t.Run("failure with unknown expression", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		text := "/remindedit 2 sometime soon"
+		c := tbwrap.NewContext(bot, &tb.Message{Text: text, Chat: chat}, nil, handlerPattern)
+		mockReminderService := mocks.NewMockServicer(mockCtrl)
+
+		err := command.HandleRemindEdit(mockReminderService, noUserPreference(mockCtrl), allowingChecker(mockCtrl), i18n.English, command.DefaultMinCronInterval)(c)
+		require.EqualError(t, err, "could not understand 'sometime'")
+		require.Len(t, bot.OutboundSendMessages, 0)
 	})
 
 	t.Run("failure", func(t *testing.T) {
 		mockCtrl := gomock.NewController(t)
 		defer mockCtrl.Finish()
 		bot := fakeBot.NewTBWrapBot()
+		text := "/remindedit 2 in 2 minutes update weekly report"
 		c := tbwrap.NewContext(bot, &tb.Message{Text: text, Chat: chat}, nil, handlerPattern)
 		mockReminderService := mocks.NewMockServicer(mockCtrl)
 		mockReminderService.
 			EXPECT().
-			AddReminderIn(
+			EditReminderIn(
 				1,
-				text,
-				reminder.AmountDateTime{
-					Days:    3,
-					Hours:   0,
-					Minutes: 2,
-				},
+				2,
+				"/remind me in 2 minutes update weekly report",
+				reminder.AmountDateTime{Minutes: 2},
 				"update weekly report").
 			Return(reminder.NextScheduleChatTime{}, errors.New("error"))
 
-		err := command.HandleRemindIn(mockReminderService)(c)
+		err := command.HandleRemindEdit(mockReminderService, noUserPreference(mockCtrl), allowingChecker(mockCtrl), i18n.English, command.DefaultMinCronInterval)(c)
 		require.Error(t, err)
 		require.Len(t, bot.OutboundSendMessages, 0)
 	})
 }
 
-// nolint:dupl
-func TestHandleRemindInPattern3(t *testing.T) {
-	handlerPattern, err := regexp.Compile(command.HandlePatternRemindIn)
+func TestHandleRemindEditMessage(t *testing.T) {
+	handlerPattern, err := regexp.Compile(command.HandlePatternRemindEditMessage[0])
 	require.NoError(t, err)
-	text := "/remind me in 2 minutes, 1 hour, 3 days update weekly report"
+	text := "/remindedit 2 message update monthly report"
 	chat := &tb.Chat{ID: int64(1)}
 
 	t.Run("success", func(t *testing.T) {
@@ -143,18 +140,10 @@ func TestHandleRemindInPattern3(t *testing.T) {
 		mockReminderService := mocks.NewMockServicer(mockCtrl)
 		mockReminderService.
 			EXPECT().
-			AddReminderIn(
-				1,
-				text,
-				reminder.AmountDateTime{
-					Days:    3,
-					Hours:   1,
-					Minutes: 2,
-				},
-				"update weekly report").
-			Return(reminder.NextScheduleChatTime{Time: time.Now(), Location: time.UTC}, nil)
+			EditReminderMessage(1, 2, "update monthly report").
+			Return(nil)
 
-		err := command.HandleRemindIn(mockReminderService)(c)
+		err := command.HandleRemindEditMessage(mockReminderService, allowingChecker(mockCtrl), i18n.English)(c)
 		require.NoError(t, err)
 		require.Len(t, bot.OutboundSendMessages, 1)
 	})
@@ -167,18 +156,10 @@ func TestHandleRemindInPattern3(t *testing.T) {
 		mockReminderService := mocks.NewMockServicer(mockCtrl)
 		mockReminderService.
 			EXPECT().
-			AddReminderIn(
-				1,
-				text,
-				reminder.AmountDateTime{
-					Days:    3,
-					Hours:   1,
-					Minutes: 2,
-				},
-				"update weekly report").
-			Return(reminder.NextScheduleChatTime{}, errors.New("error"))
+			EditReminderMessage(1, 2, "update monthly report").
+			Return(errors.New("error"))
 
-		err := command.HandleRemindIn(mockReminderService)(c)
+		err := command.HandleRemindEditMessage(mockReminderService, allowingChecker(mockCtrl), i18n.English)(c)
 		require.Error(t, err)
 		require.Len(t, bot.OutboundSendMessages, 0)
 	})
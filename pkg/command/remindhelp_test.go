@@ -6,6 +6,7 @@ import (
 
 	"github.com/enrico5b1b4/tbwrap"
 	"github.com/husol/telegram-reminder-bot/pkg/command"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
 	fakeBot "github.com/husol/telegram-reminder-bot/pkg/telegram/fakes"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
@@ -24,7 +25,7 @@ func TestHandleRemindHelp(t *testing.T) {
 		bot := fakeBot.NewTBWrapBot()
 		c := tbwrap.NewContext(bot, &tb.Message{Text: text, Chat: chat}, nil, handlerPattern)
 
-		err := command.HandleRemindHelp()(c)
+		err := command.HandleRemindHelp(i18n.English)(c)
 		require.NoError(t, err)
 		require.Len(t, bot.OutboundSendMessages, 1)
 	})
@@ -2,55 +2,71 @@ package command
 
 import (
 	"bytes"
+	"errors"
 	"strconv"
+	"strings"
 	"text/template"
 
 	"github.com/enrico5b1b4/tbwrap"
+	"github.com/husol/telegram-reminder-bot/pkg/cron"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
 	"gopkg.in/tucnak/telebot.v2"
 )
 
 const HandlePatternRemindList = "/remindlist"
 
-func HandleRemindList(reminderListService RemindListServicer, buttons map[string]*telebot.InlineButton) func(c tbwrap.Context) error {
+// the words which filter the reminders listed by status or by when they are next due e.g. "/remindlist active"
+const (
+	RemindListActive    = "active"
+	RemindListPaused    = "paused"
+	RemindListCompleted = "completed"
+	RemindListToday     = "today"
+)
+
+// remindListPageSize is how many reminders are listed on each page of the list, which keeps the list
+// well within the length of a message and each of its reminders on a row of buttons
+const remindListPageSize = 10
+
+// remindListFilterPrefix starts the first line of a filtered list, which repeats the words it was filtered by
+const remindListFilterPrefix = "🔎 "
+
+// remindListMaxFilterData is how long the filter of a list can be in the data of its buttons,
+// which along with the rest of the data of the button with the longest name has to fit in the 64 bytes Telegram allows
+const remindListMaxFilterData = 20
+
+// HandleRemindList replies with the first page of the reminders of the chat grouped by status and day
+// and buttons labelled in the language of the chat.
+// The words after the command filter the reminders e.g. "/remindlist #ops today"
+func HandleRemindList(
+	reminderListService RemindListServicer,
+	languages i18n.Languages,
+	buttons func(lang i18n.Language) map[string]*telebot.InlineButton,
+) func(c tbwrap.Context) error {
 	return func(c tbwrap.Context) error {
-		remindersByStatus, err := reminderListService.GetRemindersByChatID(int(c.ChatID()))
+		lang := languages.ChatLanguage(int(c.ChatID()))
+		words := strings.Fields(c.Message().Text)[1:]
+		filter, err := parseRemindListFilter(lang, words)
 		if err != nil {
 			return err
 		}
 
-		// index 0 = cron.Active, index 1 = cron.Inactive, index 2 = cron.Completed
-		if len(remindersByStatus) == 0 ||
-			(len(remindersByStatus[0].Entries) == 0 &&
-				len(remindersByStatus[1].Entries) == 0 &&
-				len(remindersByStatus[2].Entries) == 0) {
-			_, err = c.Send("You have no reminders.")
-
+		remindersByStatus, err := reminderListService.GetRemindersByChatID(int(c.ChatID()), filter)
+		if err != nil {
 			return err
 		}
 
-		var remindListInlineKeys [][]telebot.InlineButton
-		if len(buttons) > 0 {
-			closeCommandBtn := *buttons[ReminderListCloseCommandBtn]
-			closeCommandBtn.Data = strconv.Itoa(c.Message().ID)
-			remindListInlineKeys = append(remindListInlineKeys, []telebot.InlineButton{closeCommandBtn})
-
-			// only show button if there are completed reminders
-			if len(remindersByStatus[2].Entries) > 0 {
-				reminderListRemoveCompletedRemindersBtn := *buttons[ReminderListRemoveCompletedRemindersBtn]
-				remindListInlineKeys = append(
-					remindListInlineKeys,
-					[]telebot.InlineButton{reminderListRemoveCompletedRemindersBtn},
-				)
-			}
+		if countListEntries(remindersByStatus) == 0 {
+			_, err = c.Send(noRemindersMessage(lang, words))
+
+			return err
 		}
 
-		t := template.Must(template.New("text").Parse(text))
-		var buf bytes.Buffer
-		if execErr := t.Execute(&buf, remindersByStatus); execErr != nil {
-			return execErr
+		reply, remindListInlineKeys, err := remindListPage(lang, remindersByStatus, filter, words, 0, c.Message().ID, buttons)
+		if err != nil {
+			return err
 		}
 
-		_, err = c.Send(buf.String(), &telebot.ReplyMarkup{
+		_, err = c.Send(reply, &telebot.ReplyMarkup{
 			InlineKeyboard: remindListInlineKeys,
 		})
 
@@ -58,10 +74,220 @@ func HandleRemindList(reminderListService RemindListServicer, buttons map[string
 	}
 }
 
+// noRemindersMessage is the reply to a list without reminders, which says what it was filtered by
+func noRemindersMessage(lang i18n.Language, words []string) string {
+	if len(words) > 0 {
+		return i18n.T(lang, i18n.NoRemindersMatching, escapeMarkdown(strings.Join(words, " ")))
+	}
+
+	return i18n.T(lang, i18n.NoReminders)
+}
+
+// remindListPage is a page of the list of reminders, starting from 0, along with its buttons: a row for each
+// of its reminders, a row to move to the pages next to it and the buttons of the whole list.
+// The buttons carry the filter of the list so that they can filter it again. A page past the last one is the last page
+func remindListPage(
+	lang i18n.Language,
+	remindersByStatus []ByJobStatusList,
+	filter RemindListFilter,
+	words []string,
+	page int,
+	commandMessageID int,
+	buttons func(lang i18n.Language) map[string]*telebot.InlineButton,
+) (string, [][]telebot.InlineButton, error) {
+	pages := (countListEntries(remindersByStatus) + remindListPageSize - 1) / remindListPageSize
+	if page >= pages {
+		page = pages - 1
+	}
+	if page < 0 {
+		page = 0
+	}
+	pageReminders := listEntriesBetween(remindersByStatus, page*remindListPageSize, (page+1)*remindListPageSize)
+
+	var remindListInlineKeys [][]telebot.InlineButton
+	if buttons != nil {
+		buttons := buttons(lang)
+		for _, byStatus := range pageReminders {
+			for _, group := range byStatus.Entries {
+				for i := range group.Entries {
+					remindListInlineKeys = append(
+						remindListInlineKeys,
+						remindListEntryButtons(lang, buttons, &group.Entries[i], page, commandMessageID, filter),
+					)
+				}
+			}
+		}
+
+		var pageButtons []telebot.InlineButton
+		if page > 0 {
+			previousPageBtn := *buttons[ReminderListPreviousPageBtn]
+			previousPageBtn.Data = remindListPageData(page-1, commandMessageID, filter)
+			pageButtons = append(pageButtons, previousPageBtn)
+		}
+		if page < pages-1 {
+			nextPageBtn := *buttons[ReminderListNextPageBtn]
+			nextPageBtn.Data = remindListPageData(page+1, commandMessageID, filter)
+			pageButtons = append(pageButtons, nextPageBtn)
+		}
+		if len(pageButtons) > 0 {
+			remindListInlineKeys = append(remindListInlineKeys, pageButtons)
+		}
+
+		closeCommandBtn := *buttons[ReminderListCloseCommandBtn]
+		closeCommandBtn.Data = strconv.Itoa(commandMessageID)
+		remindListInlineKeys = append(remindListInlineKeys, []telebot.InlineButton{closeCommandBtn})
+
+		// only show button if there are completed reminders
+		if len(remindersByStatus[2].Entries) > 0 {
+			reminderListRemoveCompletedRemindersBtn := *buttons[ReminderListRemoveCompletedRemindersBtn]
+			remindListInlineKeys = append(
+				remindListInlineKeys,
+				[]telebot.InlineButton{reminderListRemoveCompletedRemindersBtn},
+			)
+		}
+	}
+
+	t := template.Must(template.New("text").Funcs(templateFuncs(lang)).Parse(text))
+	var buf bytes.Buffer
+	if len(words) > 0 {
+		buf.WriteString(remindListFilterPrefix + escapeMarkdown(strings.Join(words, " ")) + "\n")
+	}
+	if execErr := t.Execute(&buf, pageReminders); execErr != nil {
+		return "", nil, execErr
+	}
+	if pages > 1 {
+		buf.WriteString(i18n.T(lang, i18n.ListPage, page+1, pages))
+	}
+
+	return buf.String(), remindListInlineKeys, nil
+}
+
+// remindListEntryButtons are the buttons to show the details of a reminder of the list, pause or resume it
+// and delete it
+func remindListEntryButtons(
+	lang i18n.Language,
+	buttons map[string]*telebot.InlineButton,
+	entry *ListEntry,
+	page int,
+	commandMessageID int,
+	filter RemindListFilter,
+) []telebot.InlineButton {
+	data := remindListEntryData(entry.ID, page, commandMessageID, filter)
+
+	detailBtn := *buttons[ReminderListDetailBtn]
+	detailBtn.Text = i18n.T(lang, i18n.ButtonListDetail, entry.ID)
+	detailBtn.Data = data
+	entryButtons := []telebot.InlineButton{detailBtn}
+
+	switch entry.Status {
+	case cron.Active:
+		pauseBtn := *buttons[ReminderListPauseBtn]
+		pauseBtn.Data = data
+		entryButtons = append(entryButtons, pauseBtn)
+	case cron.Inactive:
+		resumeBtn := *buttons[ReminderListResumeBtn]
+		resumeBtn.Data = data
+		entryButtons = append(entryButtons, resumeBtn)
+	}
+
+	deleteBtn := *buttons[ReminderListDeleteBtn]
+	deleteBtn.Data = data
+
+	return append(entryButtons, deleteBtn)
+}
+
+// countListEntries is the number of reminders of the list
+func countListEntries(remindersByStatus []ByJobStatusList) int {
+	count := 0
+	for _, byStatus := range remindersByStatus {
+		for _, group := range byStatus.Entries {
+			count += len(group.Entries)
+		}
+	}
+
+	return count
+}
+
+// listEntriesBetween keeps the reminders of the list from the one at index from up to the one before index to,
+// in the order they are listed, along with their status and day
+func listEntriesBetween(remindersByStatus []ByJobStatusList, from, to int) []ByJobStatusList {
+	index := 0
+	between := make([]ByJobStatusList, len(remindersByStatus))
+	for i, byStatus := range remindersByStatus {
+		between[i] = ByJobStatusList{Status: byStatus.Status, Entries: []ListEntryGroup{}}
+		for _, group := range byStatus.Entries {
+			var entries []ListEntry
+			for _, entry := range group.Entries {
+				if index >= from && index < to {
+					entries = append(entries, entry)
+				}
+				index++
+			}
+
+			if len(entries) > 0 {
+				between[i].Entries = append(between[i].Entries, ListEntryGroup{Time: group.Time, Entries: entries})
+			}
+		}
+	}
+
+	return between
+}
+
+// remindListStatusWords are the words which filter the list by status
+var remindListStatusWords = map[cron.JobStatus]string{
+	cron.Active:    RemindListActive,
+	cron.Inactive:  RemindListPaused,
+	cron.Completed: RemindListCompleted,
+}
+
+// remindListFilterWords are the words which filter the list as the filter does e.g. "#ops active today"
+func remindListFilterWords(filter RemindListFilter) []string {
+	var words []string
+	if filter.Tag != "" {
+		words = append(words, "#"+filter.Tag)
+	}
+	if filter.Status != 0 {
+		words = append(words, remindListStatusWords[filter.Status])
+	}
+	if filter.Today {
+		words = append(words, RemindListToday)
+	}
+
+	return words
+}
+
+// parseRemindListFilter reads the words written after /remindlist, each of which is a #tag,
+// a status or "today". The #tag has to be short enough for the buttons of the list to carry it
+func parseRemindListFilter(lang i18n.Language, words []string) (RemindListFilter, error) {
+	var filter RemindListFilter
+	for _, word := range words {
+		switch lower := strings.ToLower(word); {
+		case strings.HasPrefix(lower, "#") && len(lower) > 1:
+			filter.Tag = strings.TrimPrefix(lower, "#")
+		case lower == RemindListActive:
+			filter.Status = cron.Active
+		case lower == RemindListPaused:
+			filter.Status = cron.Inactive
+		case lower == RemindListCompleted:
+			filter.Status = cron.Completed
+		case lower == RemindListToday:
+			filter.Today = true
+		default:
+			return RemindListFilter{}, errors.New(i18n.T(lang, i18n.UnknownFilter, escapeMarkdown(word)))
+		}
+	}
+
+	if len(remindListFilterData(filter)) > remindListMaxFilterData {
+		return RemindListFilter{}, errors.New(i18n.T(lang, i18n.TagTooLong, escapeMarkdown(filter.Tag)))
+	}
+
+	return filter, nil
+}
+
 // nolint:lll
 const text = `
-{{ range . }}{{if .Entries}}*{{.Status}}*{{$previousTimeKey:=""}}
-{{ range .Entries }}{{ if .Time }}{{$currentTimeKey:=.Time.Format "2 Jan 2006"}}{{if ne $currentTimeKey $previousTimeKey}}*{{$currentTimeKey}}*{{printf "\n"}}{{$previousTimeKey = $currentTimeKey}}{{end}}{{ end }}{{ range .Entries }}{{$nextSchedule:=""}}{{if .NextSchedule}}{{$nextSchedule = .NextSchedule.Format "15:04"}}{{end}}{{if ( and (.RunOnlyOnce) (not .RepeatSchedule))}}{{printf "- _%s_ %s [[/r_%d]]" $nextSchedule .Data.Message .ID}}{{ else }}{{printf "- 🔁 _%s_ %s [[/r_%d]]" $nextSchedule .Data.Message .ID}}{{ end }}{{printf "\n"}}{{ end }}
+{{ range . }}{{if .Entries}}*{{status .Status}}*{{$previousTimeKey:=""}}
+{{ range .Entries }}{{ if .Time }}{{$currentTimeKey:=format .Time "day"}}{{if ne $currentTimeKey $previousTimeKey}}*{{$currentTimeKey}}*{{printf "\n"}}{{$previousTimeKey = $currentTimeKey}}{{end}}{{ end }}{{ range .Entries }}{{$nextSchedule:=""}}{{if .NextSchedule}}{{$nextSchedule = .NextSchedule.Format "15:04"}}{{end}}{{if .ReminderSchedule}}{{$nextSchedule = printf "%s (%s)" $nextSchedule (.ReminderSchedule.Format "15:04 MST")}}{{end}}{{if ( and (.RunOnlyOnce) (not .RepeatSchedule))}}{{printf "- _%s_ %s [[/r_%d]]" $nextSchedule .Data.Message .ID}}{{ else }}{{printf "- 🔁 _%s_ %s [[/r_%d]]" $nextSchedule .Data.Message .ID}}{{ end }}{{printf "\n"}}{{ end }}
 {{ end }}{{ end }}
 {{ end }}
 `
@@ -1,37 +1,101 @@
 package command
 
 import (
+	"fmt"
+	"log"
 	"strconv"
+	"strings"
 
 	"github.com/enrico5b1b4/tbwrap"
+	"github.com/husol/telegram-reminder-bot/pkg/cron"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
+	"github.com/husol/telegram-reminder-bot/pkg/permission"
+	"github.com/husol/telegram-reminder-bot/pkg/reminder"
+	"github.com/husol/telegram-reminder-bot/pkg/telegram"
 	"gopkg.in/tucnak/telebot.v2"
 )
 
 const (
 	ReminderListRemoveCompletedRemindersBtn = "ReminderListRemoveCompletedRemindersBtn"
 	ReminderListCloseCommandBtn             = "ReminderListCloseCommandBtn"
+	ReminderListPreviousPageBtn             = "ReminderListPreviousPageBtn"
+	ReminderListNextPageBtn                 = "ReminderListNextPageBtn"
+	ReminderListDetailBtn                   = "ReminderListDetailBtn"
+	ReminderListPauseBtn                    = "ReminderListPauseBtn"
+	ReminderListResumeBtn                   = "ReminderListResumeBtn"
+	ReminderListDeleteBtn                   = "ReminderListDeleteBtn"
 )
 
-func NewRemindListButtons() map[string]*telebot.InlineButton {
+// NewRemindListButtons returns the buttons of the list of reminders labelled in the language
+func NewRemindListButtons(lang i18n.Language) map[string]*telebot.InlineButton {
 	reminderListRemoveCompletedRemindersBtn := telebot.InlineButton{
 		Unique: ReminderListRemoveCompletedRemindersBtn,
-		Text:   "🗑 Remove completed reminders",
+		Text:   i18n.T(lang, i18n.ButtonRemoveCompletedReminders),
 	}
 
 	closeCommandBtn := telebot.InlineButton{
 		Unique: ReminderListCloseCommandBtn,
-		Text:   "❌ Close list",
+		Text:   i18n.T(lang, i18n.ButtonCloseList),
+	}
+
+	previousPageBtn := telebot.InlineButton{
+		Unique: ReminderListPreviousPageBtn,
+		Text:   i18n.T(lang, i18n.ButtonPreviousPage),
+	}
+
+	nextPageBtn := telebot.InlineButton{
+		Unique: ReminderListNextPageBtn,
+		Text:   i18n.T(lang, i18n.ButtonNextPage),
+	}
+
+	// the text of the button is set for each reminder as it carries its ID
+	detailBtn := telebot.InlineButton{
+		Unique: ReminderListDetailBtn,
+	}
+
+	pauseBtn := telebot.InlineButton{
+		Unique: ReminderListPauseBtn,
+		Text:   i18n.T(lang, i18n.ButtonListPause),
+	}
+
+	resumeBtn := telebot.InlineButton{
+		Unique: ReminderListResumeBtn,
+		Text:   i18n.T(lang, i18n.ButtonListResume),
+	}
+
+	deleteBtn := telebot.InlineButton{
+		Unique: ReminderListDeleteBtn,
+		Text:   i18n.T(lang, i18n.ButtonListDelete),
 	}
 
 	return map[string]*telebot.InlineButton{
 		ReminderListRemoveCompletedRemindersBtn: &reminderListRemoveCompletedRemindersBtn,
 		ReminderListCloseCommandBtn:             &closeCommandBtn,
+		ReminderListPreviousPageBtn:             &previousPageBtn,
+		ReminderListNextPageBtn:                 &nextPageBtn,
+		ReminderListDetailBtn:                   &detailBtn,
+		ReminderListPauseBtn:                    &pauseBtn,
+		ReminderListResumeBtn:                   &resumeBtn,
+		ReminderListDeleteBtn:                   &deleteBtn,
 	}
 }
 
-func HandleReminderListRemoveCompletedRemindersBtn(reminderListService RemindListServicer) func(c tbwrap.Context) error {
+// HandleReminderListRemoveCompletedRemindersBtn deletes the completed reminders of the chat,
+// which only its admins can do as the reminders may have been set by others
+func HandleReminderListRemoveCompletedRemindersBtn(
+	reminderListService RemindListServicer, checker permission.Checker, languages i18n.Languages,
+) func(c tbwrap.Context) error {
 	return func(c tbwrap.Context) error {
-		err := c.Respond(c.Callback())
+		lang := languages.ChatLanguage(int(c.ChatID()))
+		allowed, err := checker.CanManageChat(int(c.ChatID()), senderID(c))
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return respondAdminsOnly(c, lang)
+		}
+
+		err = c.Respond(c.Callback())
 		if err != nil {
 			return err
 		}
@@ -41,7 +105,7 @@ func HandleReminderListRemoveCompletedRemindersBtn(reminderListService RemindLis
 			return err
 		}
 
-		_, err = c.Send("Completed reminders have been removed")
+		_, err = c.Send(i18n.T(lang, i18n.CompletedRemindersRemoved))
 
 		return err
 	}
@@ -67,3 +131,325 @@ func HandleRemindListCloseBtn() func(c tbwrap.Context) error {
 		return c.Delete(c.ChatID(), c.Message().ID)
 	}
 }
+
+// HandleReminderListPageBtn shows the page of the list the button moves to in place of the page shown
+func HandleReminderListPageBtn(
+	reminderListService RemindListServicer,
+	languages i18n.Languages,
+	buttons func(lang i18n.Language) map[string]*telebot.InlineButton,
+	editor telegram.MessageEditor,
+) func(c tbwrap.Context) error {
+	return func(c tbwrap.Context) error {
+		page, commandMessageID, filter, err := callbackRemindListPage(c)
+		if err != nil {
+			return err
+		}
+
+		err = c.Respond(c.Callback())
+		if err != nil {
+			return err
+		}
+
+		lang := languages.ChatLanguage(int(c.ChatID()))
+
+		return editRemindListPage(c, reminderListService, lang, buttons, editor, page, commandMessageID, filter)
+	}
+}
+
+// HandleReminderListDetailBtn replies with the details of a reminder of the list
+func HandleReminderListDetailBtn(
+	reminderDetailService RemindDetailServicer,
+	languages i18n.Languages,
+	buttons func(lang i18n.Language) map[string]*telebot.InlineButton,
+) func(c tbwrap.Context) error {
+	return func(c tbwrap.Context) error {
+		reminderID, _, _, _, err := callbackRemindListEntry(c)
+		if err != nil {
+			return err
+		}
+
+		reminderDetail, err := reminderDetailService.GetReminder(int(c.ChatID()), reminderID)
+		if err != nil {
+			return err
+		}
+
+		err = c.Respond(c.Callback())
+		if err != nil {
+			return err
+		}
+
+		reply, remindDetailInlineKeys, err := remindDetailMessage(
+			languages.ChatLanguage(int(c.ChatID())), reminderDetail, buttons, 0,
+		)
+		if err != nil {
+			return err
+		}
+
+		_, err = c.Send(reply, &telebot.ReplyMarkup{
+			InlineKeyboard: remindDetailInlineKeys,
+		})
+
+		return err
+	}
+}
+
+// HandleReminderListPauseBtn pauses a reminder of the list and shows the page it is on again,
+// which only members who can manage the reminder can do
+// nolint:interfacer
+func HandleReminderListPauseBtn(
+	service reminder.ServiceReminder,
+	reminderListService RemindListServicer,
+	checker permission.Checker,
+	languages i18n.Languages,
+	buttons func(lang i18n.Language) map[string]*telebot.InlineButton,
+	editor telegram.MessageEditor,
+) func(c tbwrap.Context) error {
+	return func(c tbwrap.Context) error {
+		reminderID, page, commandMessageID, filter, err := callbackRemindListEntry(c)
+		if err != nil {
+			return err
+		}
+
+		lang := languages.ChatLanguage(int(c.ChatID()))
+		allowed, err := checker.CanManageReminder(int(c.ChatID()), senderID(c), reminderID)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return respondNotAllowed(c, lang)
+		}
+
+		err = service.PauseReminder(int(c.ChatID()), reminderID)
+		if err != nil {
+			return respondWithError(c, lang, err)
+		}
+
+		err = c.Respond(c.Callback(), &telebot.CallbackResponse{Text: i18n.T(lang, i18n.ReminderPaused, reminderID)})
+		if err != nil {
+			return err
+		}
+
+		return editRemindListPage(c, reminderListService, lang, buttons, editor, page, commandMessageID, filter)
+	}
+}
+
+// HandleReminderListResumeBtn resumes a paused reminder of the list and shows the page it is on again,
+// which only members who can manage the reminder can do
+// nolint:interfacer
+func HandleReminderListResumeBtn(
+	service reminder.ServiceReminder,
+	reminderListService RemindListServicer,
+	checker permission.Checker,
+	languages i18n.Languages,
+	buttons func(lang i18n.Language) map[string]*telebot.InlineButton,
+	editor telegram.MessageEditor,
+) func(c tbwrap.Context) error {
+	return func(c tbwrap.Context) error {
+		reminderID, page, commandMessageID, filter, err := callbackRemindListEntry(c)
+		if err != nil {
+			return err
+		}
+
+		lang := languages.ChatLanguage(int(c.ChatID()))
+		allowed, err := checker.CanManageReminder(int(c.ChatID()), senderID(c), reminderID)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return respondNotAllowed(c, lang)
+		}
+
+		nextSchedule, err := service.ResumeReminder(int(c.ChatID()), reminderID)
+		if err != nil {
+			return respondWithError(c, lang, err)
+		}
+
+		err = c.Respond(c.Callback(), &telebot.CallbackResponse{
+			Text: ReminderResumedSuccessMessage(lang, reminderID, nextSchedule),
+		})
+		if err != nil {
+			return err
+		}
+
+		return editRemindListPage(c, reminderListService, lang, buttons, editor, page, commandMessageID, filter)
+	}
+}
+
+// HandleReminderListDeleteBtn deletes a reminder of the list and shows the page it was on again,
+// which only members who can manage the reminder can do
+// nolint:interfacer
+func HandleReminderListDeleteBtn(
+	service RemindDeleteServicer,
+	reminderListService RemindListServicer,
+	checker permission.Checker,
+	languages i18n.Languages,
+	buttons func(lang i18n.Language) map[string]*telebot.InlineButton,
+	editor telegram.MessageEditor,
+) func(c tbwrap.Context) error {
+	return func(c tbwrap.Context) error {
+		reminderID, page, commandMessageID, filter, err := callbackRemindListEntry(c)
+		if err != nil {
+			return err
+		}
+
+		lang := languages.ChatLanguage(int(c.ChatID()))
+		allowed, err := checker.CanManageReminder(int(c.ChatID()), senderID(c), reminderID)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return respondNotAllowed(c, lang)
+		}
+
+		err = service.DeleteReminder(int(c.ChatID()), reminderID)
+		if err != nil {
+			return respondWithError(c, lang, err)
+		}
+
+		err = c.Respond(c.Callback(), &telebot.CallbackResponse{Text: i18n.T(lang, i18n.ReminderDeleted, reminderID)})
+		if err != nil {
+			return err
+		}
+
+		return editRemindListPage(c, reminderListService, lang, buttons, editor, page, commandMessageID, filter)
+	}
+}
+
+// respondWithError answers a button whose action failed with the error so that it stops loading,
+// the error is then sent to the chat as well
+func respondWithError(c tbwrap.Context, lang i18n.Language, err error) error {
+	respondErr := c.Respond(c.Callback(), &telebot.CallbackResponse{Text: i18n.Translate(lang, err).Error()})
+	if respondErr != nil {
+		log.Printf("respondWithError Respond err: %q", respondErr)
+	}
+
+	return err
+}
+
+// editRemindListPage replaces the list of reminders in the message of the button with a page of it,
+// filtered as the list shown
+func editRemindListPage(
+	c tbwrap.Context,
+	reminderListService RemindListServicer,
+	lang i18n.Language,
+	buttons func(lang i18n.Language) map[string]*telebot.InlineButton,
+	editor telegram.MessageEditor,
+	page int,
+	commandMessageID int,
+	filter RemindListFilter,
+) error {
+	words := remindListFilterWords(filter)
+	remindersByStatus, err := reminderListService.GetRemindersByChatID(int(c.ChatID()), filter)
+	if err != nil {
+		return err
+	}
+
+	if countListEntries(remindersByStatus) == 0 {
+		_, err = editor.Edit(c.Message(), noRemindersMessage(lang, words), &telebot.SendOptions{ParseMode: telebot.ModeMarkdown})
+
+		return err
+	}
+
+	reply, remindListInlineKeys, err := remindListPage(lang, remindersByStatus, filter, words, page, commandMessageID, buttons)
+	if err != nil {
+		return err
+	}
+
+	_, err = editor.Edit(c.Message(), reply, &telebot.SendOptions{
+		ParseMode:   telebot.ModeMarkdown,
+		ReplyMarkup: &telebot.ReplyMarkup{InlineKeyboard: remindListInlineKeys},
+	})
+
+	return err
+}
+
+// remindListPageData is the data of a button which shows a page of the list asked for with a command message
+// e.g. "1:42:0t#ops" for the second page of the list of the reminders tagged #ops due today asked for with message 42
+func remindListPageData(page, commandMessageID int, filter RemindListFilter) string {
+	return fmt.Sprintf("%d:%d:%s", page, commandMessageID, remindListFilterData(filter))
+}
+
+// remindListEntryData is the data of a button which acts on a reminder of a page of the list e.g. "3:1:42:0"
+func remindListEntryData(reminderID, page, commandMessageID int, filter RemindListFilter) string {
+	return fmt.Sprintf("%d:%s", reminderID, remindListPageData(page, commandMessageID, filter))
+}
+
+// remindListFilterData is the filter of the list in the data of its buttons: the status it lists or 0,
+// followed by "t" when it lists the reminders due today and by its #tag e.g. "1t#ops"
+func remindListFilterData(filter RemindListFilter) string {
+	data := strconv.Itoa(int(filter.Status))
+	if filter.Today {
+		data += "t"
+	}
+	if filter.Tag != "" {
+		data += "#" + filter.Tag
+	}
+
+	return data
+}
+
+// parseRemindListFilterData reads the filter of the list in the data of its buttons
+func parseRemindListFilterData(data string) (RemindListFilter, error) {
+	var filter RemindListFilter
+	if i := strings.Index(data, "#"); i >= 0 {
+		filter.Tag = data[i+1:]
+		data = data[:i]
+	}
+	if strings.HasSuffix(data, "t") {
+		filter.Today = true
+		data = strings.TrimSuffix(data, "t")
+	}
+
+	status, err := strconv.Atoi(data)
+	if err != nil || status < 0 || status > int(cron.Completed) {
+		return RemindListFilter{}, i18n.Errorf(i18n.ErrUnknownButton)
+	}
+	filter.Status = cron.JobStatus(status)
+
+	return filter, nil
+}
+
+func callbackRemindListPage(c tbwrap.Context) (page, commandMessageID int, filter RemindListFilter, err error) {
+	ids, filter, err := callbackRemindListData(c, 2)
+	if err != nil {
+		return 0, 0, RemindListFilter{}, err
+	}
+
+	return ids[0], ids[1], filter, nil
+}
+
+func callbackRemindListEntry(
+	c tbwrap.Context,
+) (reminderID, page, commandMessageID int, filter RemindListFilter, err error) {
+	ids, filter, err := callbackRemindListData(c, 3)
+	if err != nil {
+		return 0, 0, 0, RemindListFilter{}, err
+	}
+
+	return ids[0], ids[1], ids[2], filter, nil
+}
+
+// callbackRemindListData reads the numbers separated by ":" the data of a button of the list starts with,
+// followed by the filter of the list
+func callbackRemindListData(c tbwrap.Context, count int) ([]int, RemindListFilter, error) {
+	parts := strings.Split(c.Callback().Data, ":")
+	if len(parts) != count+1 {
+		return nil, RemindListFilter{}, i18n.Errorf(i18n.ErrUnknownButton)
+	}
+
+	ids := make([]int, count)
+	for i := range parts[:count] {
+		id, err := strconv.Atoi(parts[i])
+		if err != nil {
+			return nil, RemindListFilter{}, err
+		}
+		ids[i] = id
+	}
+
+	filter, err := parseRemindListFilterData(parts[count])
+	if err != nil {
+		return nil, RemindListFilter{}, err
+	}
+
+	return ids, filter, nil
+}
@@ -32,12 +32,22 @@ type ListEntryGroup struct {
 type ListEntry struct {
 	reminder.Reminder
 	NextSchedule *time.Time
+	// ReminderSchedule is NextSchedule in the timezone the reminder was set in, nil when it is the one of the chat
+	ReminderSchedule *time.Time
 }
 
 const maxLengthMessageEntry = 20
 
+// RemindListFilter selects the reminders which are listed e.g. "/remindlist #ops today".
+// Fields which are not set select every reminder
+type RemindListFilter struct {
+	Tag    string         // without "#" e.g. "ops"
+	Status cron.JobStatus // 0 for any status
+	Today  bool           // only the active reminders which are next due today in the timezone of the chat
+}
+
 type RemindListServicer interface {
-	GetRemindersByChatID(chatID int) ([]ByJobStatusList, error)
+	GetRemindersByChatID(chatID int, filter RemindListFilter) ([]ByJobStatusList, error)
 	RemoveCompletedReminders(chatID int) error
 }
 
@@ -45,6 +55,7 @@ type RemindListService struct {
 	reminderStore       reminder.Storer
 	scheduler           cron.Scheduler
 	chatPreferenceStore chatpreference.Storer
+	timeNow             func() time.Time
 }
 
 func NewRemindListService(
@@ -56,21 +67,33 @@ func NewRemindListService(
 		reminderStore:       reminderStore,
 		scheduler:           scheduler,
 		chatPreferenceStore: chatPreferenceStore,
+		timeNow:             time.Now,
 	}
 }
 
-func (s *RemindListService) GetRemindersByChatID(chatID int) ([]ByJobStatusList, error) {
-	reminders, err := s.reminderStore.GetAllRemindersByChatID(chatID)
+// GetRemindersByChatID returns the reminders of a chat the filter selects grouped by status and by the day
+// they are next due
+func (s *RemindListService) GetRemindersByChatID(chatID int, filter RemindListFilter) ([]ByJobStatusList, error) {
+	chatPreference, err := s.chatPreferenceStore.GetChatPreference(chatID)
 	if err != nil {
 		return nil, err
 	}
 
-	chatPreference, err := s.chatPreferenceStore.GetChatPreference(chatID)
+	chatLocalTimezone, err := time.LoadLocation(chatPreference.TimeZone)
 	if err != nil {
 		return nil, err
 	}
 
-	chatLocalTimezone, err := time.LoadLocation(chatPreference.TimeZone)
+	storeFilter := reminder.Filter{Tag: filter.Tag, Status: filter.Status}
+	if filter.Today {
+		now := s.timeNow().In(chatLocalTimezone)
+		startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, chatLocalTimezone)
+		endOfDay := startOfDay.AddDate(0, 0, 1)
+		storeFilter.DueFrom = &startOfDay
+		storeFilter.DueBefore = &endOfDay
+	}
+
+	reminders, err := s.reminderStore.GetRemindersByFilter(chatID, storeFilter)
 	if err != nil {
 		return nil, err
 	}
@@ -95,6 +118,14 @@ func (s *RemindListService) GetRemindersByChatID(chatID int) ([]ByJobStatusList,
 			nextSchedule := cronEntry.Next.In(chatLocalTimezone)
 
 			rLE.NextSchedule = &nextSchedule
+			if reminders[i].TimeZone != "" && reminders[i].TimeZone != chatPreference.TimeZone {
+				reminderTimezone, err := time.LoadLocation(reminders[i].TimeZone)
+				if err != nil {
+					return nil, err
+				}
+				reminderSchedule := nextSchedule.In(reminderTimezone)
+				rLE.ReminderSchedule = &reminderSchedule
+			}
 			var err error
 			timeKey, err = createTimeKey(nextSchedule)
 			if err != nil {
@@ -2,14 +2,23 @@ package command_test
 
 import (
 	"errors"
+	"fmt"
 	"regexp"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/enrico5b1b4/tbwrap"
 	"github.com/husol/telegram-reminder-bot/pkg/command"
+	"github.com/husol/telegram-reminder-bot/pkg/cron"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
 	"github.com/husol/telegram-reminder-bot/pkg/command/mocks"
+	"github.com/husol/telegram-reminder-bot/pkg/reminder"
+	reminderMocks "github.com/husol/telegram-reminder-bot/pkg/reminder/mocks"
+	permissionMocks "github.com/husol/telegram-reminder-bot/pkg/permission/mocks"
 	fakeBot "github.com/husol/telegram-reminder-bot/pkg/telegram/fakes"
 	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	tb "gopkg.in/tucnak/telebot.v2"
 )
@@ -28,14 +37,71 @@ func TestHandleRemindList(t *testing.T) {
 		mockReminderService := mocks.NewMockRemindListServicer(mockCtrl)
 		mockReminderService.
 			EXPECT().
-			GetRemindersByChatID(1).
+			GetRemindersByChatID(1, command.RemindListFilter{}).
 			Return([]command.ByJobStatusList{}, nil)
 
-		err := command.HandleRemindList(mockReminderService, nil)(c)
+		err := command.HandleRemindList(mockReminderService, i18n.English, nil)(c)
 		require.NoError(t, err)
 		require.Len(t, bot.OutboundSendMessages, 1)
 	})
 
+	t.Run("filtered by tag, status and day", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		c := tbwrap.NewContext(bot, &tb.Message{Text: "/remindlist #Ops active today", Chat: chat}, nil, handlerPattern)
+		mockReminderService := mocks.NewMockRemindListServicer(mockCtrl)
+		mockReminderService.
+			EXPECT().
+			GetRemindersByChatID(1, command.RemindListFilter{Tag: "ops", Status: cron.Active, Today: true}).
+			Return([]command.ByJobStatusList{}, nil)
+
+		err := command.HandleRemindList(mockReminderService, i18n.English, nil)(c)
+		require.NoError(t, err)
+		require.Equal(t, []string{"No reminders match #Ops active today."}, bot.OutboundSendMessages)
+	})
+
+	t.Run("lists the first page", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		c := tbwrap.NewContext(bot, &tb.Message{ID: 42, Text: text, Chat: chat}, nil, handlerPattern)
+		mockReminderService := mocks.NewMockRemindListServicer(mockCtrl)
+		mockReminderService.
+			EXPECT().
+			GetRemindersByChatID(1, command.RemindListFilter{}).
+			Return(activeReminders(12), nil)
+
+		err := command.HandleRemindList(mockReminderService, i18n.English, command.NewRemindListButtons)(c)
+		require.NoError(t, err)
+		require.Len(t, bot.OutboundSendMessages, 1)
+		assert.Contains(t, bot.OutboundSendMessages[0], "MSG10_ [[/r_10]]")
+		assert.NotContains(t, bot.OutboundSendMessages[0], "MSG11_")
+		assert.Contains(t, bot.OutboundSendMessages[0], "Page 1 of 2")
+	})
+
+	t.Run("failure with an unknown filter", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		c := tbwrap.NewContext(bot, &tb.Message{Text: "/remindlist tomorrow", Chat: chat}, nil, handlerPattern)
+		mockReminderService := mocks.NewMockRemindListServicer(mockCtrl)
+
+		err := command.HandleRemindList(mockReminderService, i18n.English, nil)(c)
+		require.EqualError(t, err, "error: tomorrow is not a filter of the list, use a #tag, active, paused, completed or today")
+	})
+
+	t.Run("failure with a tag too long for the buttons of the list", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		c := tbwrap.NewContext(bot, &tb.Message{Text: "/remindlist #quarterly_infrastructure", Chat: chat}, nil, handlerPattern)
+		mockReminderService := mocks.NewMockRemindListServicer(mockCtrl)
+
+		err := command.HandleRemindList(mockReminderService, i18n.English, nil)(c)
+		require.EqualError(t, err, "error: #quarterly\\_infrastructure is too long to filter the list by")
+	})
+
 	t.Run("failure", func(t *testing.T) {
 		mockCtrl := gomock.NewController(t)
 		defer mockCtrl.Finish()
@@ -44,11 +110,238 @@ func TestHandleRemindList(t *testing.T) {
 		mockReminderService := mocks.NewMockRemindListServicer(mockCtrl)
 		mockReminderService.
 			EXPECT().
-			GetRemindersByChatID(1).
+			GetRemindersByChatID(1, command.RemindListFilter{}).
 			Return([]command.ByJobStatusList{}, errors.New("error"))
 
-		err := command.HandleRemindList(mockReminderService, nil)(c)
+		err := command.HandleRemindList(mockReminderService, i18n.English, nil)(c)
 		require.Error(t, err)
 		require.Len(t, bot.OutboundSendMessages, 0)
 	})
 }
+
+// activeReminders is a list of active reminders due the same day with IDs from 1 to count
+func activeReminders(count int) []command.ByJobStatusList {
+	day := time.Date(2027, 3, 14, 0, 0, 0, 0, time.UTC)
+	entries := make([]command.ListEntry, count)
+	for i := range entries {
+		next := day.Add(time.Duration(i) * time.Minute)
+		entries[i] = command.ListEntry{
+			Reminder: reminder.Reminder{
+				Job:  cron.Job{ID: i + 1, Status: cron.Active, RunOnlyOnce: true},
+				Data: reminder.Data{Message: fmt.Sprintf("MSG%d_", i+1)},
+			},
+			NextSchedule: &next,
+		}
+	}
+
+	return []command.ByJobStatusList{
+		{Status: cron.Active, Entries: []command.ListEntryGroup{{Time: &day, Entries: entries}}},
+		{Status: cron.Inactive, Entries: []command.ListEntryGroup{}},
+		{Status: cron.Completed, Entries: []command.ListEntryGroup{}},
+	}
+}
+
+func newRemindListButtonContext(bot *fakeBot.TBWrapBot, messageText, data string) tbwrap.Context {
+	msg := &tb.Message{ID: 50, Text: messageText, Chat: &tb.Chat{ID: int64(1)}}
+	return tbwrap.NewContext(bot, msg, &tb.Callback{Data: data, Sender: &tb.User{ID: 7}, Message: msg}, nil)
+}
+
+func TestHandleReminderListPageBtn(t *testing.T) {
+	t.Run("shows the next page of a filtered list", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		editor := fakeBot.NewMessageEditor()
+		mockReminderService := mocks.NewMockRemindListServicer(mockCtrl)
+		mockReminderService.
+			EXPECT().
+			GetRemindersByChatID(1, command.RemindListFilter{Tag: "ops", Today: true}).
+			Return(activeReminders(12), nil)
+
+		err := command.HandleReminderListPageBtn(mockReminderService, i18n.English, command.NewRemindListButtons, editor)(
+			newRemindListButtonContext(bot, "🔎 #ops today\n\nActive", "1:42:0t#ops"),
+		)
+		require.NoError(t, err)
+		require.Len(t, editor.Edits, 1)
+		assert.True(t, strings.HasPrefix(editor.Edits[0], "🔎 #ops today\n"))
+		assert.Contains(t, editor.Edits[0], "MSG11_ [[/r_11]]")
+		assert.NotContains(t, editor.Edits[0], "MSG10_")
+		assert.Contains(t, editor.Edits[0], "Page 2 of 2")
+
+		keyboard := editor.Keyboards[0]
+		require.Len(t, keyboard, 4)
+		assert.Equal(t, []string{"ℹ️ 11", "⏸ Pause", "🗑 Delete"}, buttonTexts(keyboard[0]))
+		assert.Equal(t, "11:1:42:0t#ops", keyboard[0][0].Data)
+		assert.Equal(t, []string{"◀"}, buttonTexts(keyboard[2]))
+		assert.Equal(t, "0:42:0t#ops", keyboard[2][0].Data)
+		assert.Equal(t, command.ReminderListCloseCommandBtn, keyboard[3][0].Unique)
+		assert.Equal(t, "42", keyboard[3][0].Data)
+	})
+
+	t.Run("failure with a button which is not for a page", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		editor := fakeBot.NewMessageEditor()
+		mockReminderService := mocks.NewMockRemindListServicer(mockCtrl)
+
+		err := command.HandleReminderListPageBtn(mockReminderService, i18n.English, command.NewRemindListButtons, editor)(
+			newRemindListButtonContext(bot, "Active", "42"),
+		)
+		require.Error(t, err)
+		require.Empty(t, editor.Edits)
+	})
+}
+
+func TestHandleReminderListDetailBtn(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	bot := fakeBot.NewTBWrapBot()
+	mockReminderDetailService := mocks.NewMockRemindDetailServicer(mockCtrl)
+	mockReminderDetailService.
+		EXPECT().
+		GetReminder(1, 2).
+		Return(&command.ReminderDetail{Reminder: reminder.Reminder{
+			Job:  cron.Job{ID: 2, Status: cron.Inactive},
+			Data: reminder.Data{Message: "MSG2_"},
+		}}, nil)
+
+	err := command.HandleReminderListDetailBtn(mockReminderDetailService, i18n.English, command.NewRemindDetailButtons)(
+		newRemindListButtonContext(bot, "Active", "2:0:42:0"),
+	)
+	require.NoError(t, err)
+	require.Len(t, bot.OutboundSendMessages, 1)
+	assert.Contains(t, bot.OutboundSendMessages[0], "MSG2_")
+}
+
+func TestHandleReminderListPauseBtn(t *testing.T) {
+	t.Run("pauses a reminder of the list", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		editor := fakeBot.NewMessageEditor()
+		mockService := reminderMocks.NewMockServicer(mockCtrl)
+		mockService.EXPECT().PauseReminder(1, 2).Return(nil)
+		// reminder 2 is listed as paused after the active ones
+		reminders := activeReminders(3)
+		paused := reminders[0].Entries[0].Entries[1]
+		paused.Status = cron.Inactive
+		paused.NextSchedule = nil
+		reminders[0].Entries[0].Entries = append(reminders[0].Entries[0].Entries[:1], reminders[0].Entries[0].Entries[2])
+		reminders[1].Entries = []command.ListEntryGroup{{Entries: []command.ListEntry{paused}}}
+		mockReminderListService := mocks.NewMockRemindListServicer(mockCtrl)
+		mockReminderListService.
+			EXPECT().
+			GetRemindersByChatID(1, command.RemindListFilter{}).
+			Return(reminders, nil)
+
+		err := command.HandleReminderListPauseBtn(
+			mockService, mockReminderListService, allowingChecker(mockCtrl), i18n.English, command.NewRemindListButtons, editor,
+		)(newRemindListButtonContext(bot, "Active", "2:0:42:0"))
+		require.NoError(t, err)
+		require.Len(t, bot.CallbackResponses, 1)
+		assert.Equal(t, "Reminder 2 has been paused", bot.CallbackResponses[0].Text)
+		require.Len(t, editor.Edits, 1)
+		assert.NotContains(t, editor.Edits[0], "Page")
+		assert.Equal(t, []string{"ℹ️ 2", "▶️ Resume", "🗑 Delete"}, buttonTexts(editor.Keyboards[0][2]))
+	})
+
+	t.Run("answers the button when the reminder can't be paused", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		editor := fakeBot.NewMessageEditor()
+		mockService := reminderMocks.NewMockServicer(mockCtrl)
+		mockService.EXPECT().PauseReminder(1, 2).Return(errors.New("error"))
+
+		err := command.HandleReminderListPauseBtn(
+			mockService, mocks.NewMockRemindListServicer(mockCtrl), allowingChecker(mockCtrl), i18n.English, command.NewRemindListButtons, editor,
+		)(newRemindListButtonContext(bot, "Active", "2:0:42:0"))
+		require.EqualError(t, err, "error")
+		require.Len(t, bot.CallbackResponses, 1)
+		assert.Equal(t, "error", bot.CallbackResponses[0].Text)
+		assert.Empty(t, editor.Edits)
+	})
+
+	t.Run("alerts a member who is not allowed", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		editor := fakeBot.NewMessageEditor()
+		mockChecker := permissionMocks.NewMockChecker(mockCtrl)
+		mockChecker.
+			EXPECT().
+			CanManageReminder(1, 7, 2).
+			Return(false, nil)
+
+		err := command.HandleReminderListPauseBtn(
+			reminderMocks.NewMockServicer(mockCtrl),
+			mocks.NewMockRemindListServicer(mockCtrl),
+			mockChecker,
+			i18n.English,
+			command.NewRemindListButtons,
+			editor,
+		)(newRemindListButtonContext(bot, "Active", "2:0:42:0"))
+		require.NoError(t, err)
+		require.Len(t, bot.CallbackResponses, 1)
+		assert.True(t, bot.CallbackResponses[0].ShowAlert)
+		assert.Empty(t, editor.Edits)
+	})
+}
+
+func TestHandleReminderListDeleteBtn(t *testing.T) {
+	t.Run("deletes the last reminder of the list", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		editor := fakeBot.NewMessageEditor()
+		mockDeleteService := mocks.NewMockRemindDeleteServicer(mockCtrl)
+		mockDeleteService.EXPECT().DeleteReminder(1, 2).Return(nil)
+		mockReminderListService := mocks.NewMockRemindListServicer(mockCtrl)
+		mockReminderListService.
+			EXPECT().
+			GetRemindersByChatID(1, command.RemindListFilter{Status: cron.Active}).
+			Return([]command.ByJobStatusList{}, nil)
+
+		err := command.HandleReminderListDeleteBtn(
+			mockDeleteService, mockReminderListService, allowingChecker(mockCtrl), i18n.English, command.NewRemindListButtons, editor,
+		)(newRemindListButtonContext(bot, "🔎 active", "2:0:42:1"))
+		require.NoError(t, err)
+		assert.Equal(t, "Reminder 2 has been deleted", bot.CallbackResponses[0].Text)
+		assert.Equal(t, []string{"No reminders match active."}, editor.Edits)
+	})
+
+	t.Run("alerts a member who is not allowed", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		editor := fakeBot.NewMessageEditor()
+		mockChecker := permissionMocks.NewMockChecker(mockCtrl)
+		mockChecker.
+			EXPECT().
+			CanManageReminder(1, 7, 2).
+			Return(false, nil)
+
+		err := command.HandleReminderListDeleteBtn(
+			mocks.NewMockRemindDeleteServicer(mockCtrl),
+			mocks.NewMockRemindListServicer(mockCtrl),
+			mockChecker,
+			i18n.English,
+			command.NewRemindListButtons,
+			editor,
+		)(newRemindListButtonContext(bot, "Active", "2:0:42:0"))
+		require.NoError(t, err)
+		require.Len(t, bot.CallbackResponses, 1)
+		assert.True(t, bot.CallbackResponses[0].ShowAlert)
+		assert.Empty(t, editor.Edits)
+	})
+}
+
+func buttonTexts(row []tb.InlineButton) []string {
+	texts := make([]string, len(row))
+	for i := range row {
+		texts[i] = row[i].Text
+	}
+
+	return texts
+}
@@ -0,0 +1,101 @@
+package command
+
+import (
+	"errors"
+
+	"github.com/enrico5b1b4/tbwrap"
+	"github.com/husol/telegram-reminder-bot/pkg/cron"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
+	"github.com/husol/telegram-reminder-bot/pkg/permission"
+	"github.com/husol/telegram-reminder-bot/pkg/reminder"
+)
+
+// DefaultNagMaxResends is how many times a reminder is sent again when no limit is given
+const DefaultNagMaxResends = 10
+
+type MessageRemindNag struct {
+	ReminderID int `regexpGroup:"reminderID"`
+	Minutes    int `regexpGroup:"minutes"`
+	Times      int `regexpGroup:"times"`
+}
+
+type MessageRemindNagOff struct {
+	ReminderID int `regexpGroup:"reminderID"`
+}
+
+const HandlePatternRemindNag = `/remindnag (?P<reminderID>\d{1,5}) every (?P<minutes>\d{1,3}) minutes?(?: up to (?P<times>\d{1,3}) times)?`
+const HandlePatternRemindNagOff = `/remindnag (?P<reminderID>\d{1,5}) off`
+
+func HandleRemindNag(
+	service reminder.ServiceReminder, checker permission.Checker, languages i18n.Languages,
+) func(c tbwrap.Context) error {
+	return func(c tbwrap.Context) error {
+		message := new(MessageRemindNag)
+		if err := c.Bind(message); err != nil {
+			return err
+		}
+
+		lang := languages.ChatLanguage(int(c.ChatID()))
+		allowed, err := checker.CanManageReminder(int(c.ChatID()), senderID(c), message.ReminderID)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return notAllowedError(lang)
+		}
+
+		if message.Minutes < 1 {
+			return errors.New(i18n.T(lang, i18n.NagTooOften))
+		}
+
+		maxResends := message.Times
+		if maxResends == 0 {
+			maxResends = DefaultNagMaxResends
+		}
+
+		err = service.SetReminderNag(int(c.ChatID()), message.ReminderID, &cron.JobNag{
+			Minutes:    message.Minutes,
+			MaxResends: maxResends,
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = c.Send(i18n.T(lang, i18n.ReminderNagOn,
+			message.ReminderID,
+			message.Minutes,
+			maxResends,
+		))
+
+		return err
+	}
+}
+
+func HandleRemindNagOff(
+	service reminder.ServiceReminder, checker permission.Checker, languages i18n.Languages,
+) func(c tbwrap.Context) error {
+	return func(c tbwrap.Context) error {
+		message := new(MessageRemindNagOff)
+		if err := c.Bind(message); err != nil {
+			return err
+		}
+
+		lang := languages.ChatLanguage(int(c.ChatID()))
+		allowed, err := checker.CanManageReminder(int(c.ChatID()), senderID(c), message.ReminderID)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return notAllowedError(lang)
+		}
+
+		err = service.SetReminderNag(int(c.ChatID()), message.ReminderID, nil)
+		if err != nil {
+			return err
+		}
+
+		_, err = c.Send(i18n.T(lang, i18n.ReminderNagOff, message.ReminderID))
+
+		return err
+	}
+}
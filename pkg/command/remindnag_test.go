@@ -0,0 +1,122 @@
+package command_test
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/enrico5b1b4/tbwrap"
+	"github.com/golang/mock/gomock"
+	"github.com/husol/telegram-reminder-bot/pkg/command"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
+	"github.com/husol/telegram-reminder-bot/pkg/cron"
+	"github.com/husol/telegram-reminder-bot/pkg/reminder/mocks"
+	fakeBot "github.com/husol/telegram-reminder-bot/pkg/telegram/fakes"
+	"github.com/stretchr/testify/require"
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+func TestHandleRemindNag(t *testing.T) {
+	handlerPattern, err := regexp.Compile(command.HandlePatternRemindNag)
+	require.NoError(t, err)
+	chat := &tb.Chat{ID: int64(1)}
+
+	t.Run("success", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		c := tbwrap.NewContext(bot, &tb.Message{Text: "/remindnag 1 every 10 minutes up to 3 times", Chat: chat}, nil, handlerPattern)
+		mockReminderService := mocks.NewMockServicer(mockCtrl)
+		mockReminderService.
+			EXPECT().
+			SetReminderNag(1, 1, &cron.JobNag{Minutes: 10, MaxResends: 3}).
+			Return(nil)
+
+		err := command.HandleRemindNag(mockReminderService, allowingChecker(mockCtrl), i18n.English)(c)
+		require.NoError(t, err)
+		require.Len(t, bot.OutboundSendMessages, 1)
+	})
+
+	t.Run("success with default number of times", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		c := tbwrap.NewContext(bot, &tb.Message{Text: "/remindnag 1 every 1 minute", Chat: chat}, nil, handlerPattern)
+		mockReminderService := mocks.NewMockServicer(mockCtrl)
+		mockReminderService.
+			EXPECT().
+			SetReminderNag(1, 1, &cron.JobNag{Minutes: 1, MaxResends: command.DefaultNagMaxResends}).
+			Return(nil)
+
+		err := command.HandleRemindNag(mockReminderService, allowingChecker(mockCtrl), i18n.English)(c)
+		require.NoError(t, err)
+		require.Len(t, bot.OutboundSendMessages, 1)
+	})
+
+	t.Run("failure when interval is zero", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		c := tbwrap.NewContext(bot, &tb.Message{Text: "/remindnag 1 every 0 minutes", Chat: chat}, nil, handlerPattern)
+		mockReminderService := mocks.NewMockServicer(mockCtrl)
+
+		err := command.HandleRemindNag(mockReminderService, allowingChecker(mockCtrl), i18n.English)(c)
+		require.Error(t, err)
+		require.Len(t, bot.OutboundSendMessages, 0)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		c := tbwrap.NewContext(bot, &tb.Message{Text: "/remindnag 1 every 10 minutes", Chat: chat}, nil, handlerPattern)
+		mockReminderService := mocks.NewMockServicer(mockCtrl)
+		mockReminderService.
+			EXPECT().
+			SetReminderNag(1, 1, gomock.Any()).
+			Return(errors.New("error"))
+
+		err := command.HandleRemindNag(mockReminderService, allowingChecker(mockCtrl), i18n.English)(c)
+		require.Error(t, err)
+		require.Len(t, bot.OutboundSendMessages, 0)
+	})
+}
+
+func TestHandleRemindNagOff(t *testing.T) {
+	handlerPattern, err := regexp.Compile(command.HandlePatternRemindNagOff)
+	require.NoError(t, err)
+	text := "/remindnag 1 off"
+	chat := &tb.Chat{ID: int64(1)}
+
+	t.Run("success", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		c := tbwrap.NewContext(bot, &tb.Message{Text: text, Chat: chat}, nil, handlerPattern)
+		mockReminderService := mocks.NewMockServicer(mockCtrl)
+		mockReminderService.
+			EXPECT().
+			SetReminderNag(1, 1, nil).
+			Return(nil)
+
+		err := command.HandleRemindNagOff(mockReminderService, allowingChecker(mockCtrl), i18n.English)(c)
+		require.NoError(t, err)
+		require.Len(t, bot.OutboundSendMessages, 1)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		c := tbwrap.NewContext(bot, &tb.Message{Text: text, Chat: chat}, nil, handlerPattern)
+		mockReminderService := mocks.NewMockServicer(mockCtrl)
+		mockReminderService.
+			EXPECT().
+			SetReminderNag(1, 1, nil).
+			Return(errors.New("error"))
+
+		err := command.HandleRemindNagOff(mockReminderService, allowingChecker(mockCtrl), i18n.English)(c)
+		require.Error(t, err)
+		require.Len(t, bot.OutboundSendMessages, 0)
+	})
+}
@@ -0,0 +1,46 @@
+package command
+
+import (
+	"github.com/enrico5b1b4/tbwrap"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
+	"github.com/husol/telegram-reminder-bot/pkg/permission"
+	"github.com/husol/telegram-reminder-bot/pkg/reminder"
+)
+
+type MessageRemindPause struct {
+	ReminderID int `regexpGroup:"reminderID"`
+}
+
+var HandlePatternRemindPause = []string{
+	`/remindpause (?P<reminderID>\d{1,5})`,
+	`/remindpause_(?P<reminderID>\d{1,5})`,
+}
+
+func HandleRemindPause(
+	service reminder.ServiceReminder, checker permission.Checker, languages i18n.Languages,
+) func(c tbwrap.Context) error {
+	return func(c tbwrap.Context) error {
+		message := new(MessageRemindPause)
+		if err := c.Bind(message); err != nil {
+			return err
+		}
+
+		lang := languages.ChatLanguage(int(c.ChatID()))
+		allowed, err := checker.CanManageReminder(int(c.ChatID()), senderID(c), message.ReminderID)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return notAllowedError(lang)
+		}
+
+		err = service.PauseReminder(int(c.ChatID()), message.ReminderID)
+		if err != nil {
+			return err
+		}
+
+		_, err = c.Send(i18n.T(lang, i18n.ReminderPaused, message.ReminderID))
+
+		return err
+	}
+}
@@ -0,0 +1,74 @@
+package command_test
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/enrico5b1b4/tbwrap"
+	"github.com/golang/mock/gomock"
+	"github.com/husol/telegram-reminder-bot/pkg/command"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
+	permissionMocks "github.com/husol/telegram-reminder-bot/pkg/permission/mocks"
+	"github.com/husol/telegram-reminder-bot/pkg/reminder/mocks"
+	fakeBot "github.com/husol/telegram-reminder-bot/pkg/telegram/fakes"
+	"github.com/stretchr/testify/require"
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+func TestHandleRemindPause(t *testing.T) {
+	handlerPattern, err := regexp.Compile(command.HandlePatternRemindPause[0])
+	require.NoError(t, err)
+	text := "/remindpause 1"
+	chat := &tb.Chat{ID: int64(1)}
+
+	t.Run("success", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		c := tbwrap.NewContext(bot, &tb.Message{Text: text, Chat: chat}, nil, handlerPattern)
+		mockReminderService := mocks.NewMockServicer(mockCtrl)
+		mockReminderService.
+			EXPECT().
+			PauseReminder(1, 1).
+			Return(nil)
+
+		err := command.HandleRemindPause(mockReminderService, allowingChecker(mockCtrl), i18n.English)(c)
+		require.NoError(t, err)
+		require.Len(t, bot.OutboundSendMessages, 1)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		c := tbwrap.NewContext(bot, &tb.Message{Text: text, Chat: chat}, nil, handlerPattern)
+		mockReminderService := mocks.NewMockServicer(mockCtrl)
+		mockReminderService.
+			EXPECT().
+			PauseReminder(1, 1).
+			Return(errors.New("error"))
+
+		err := command.HandleRemindPause(mockReminderService, allowingChecker(mockCtrl), i18n.English)(c)
+		require.Error(t, err)
+		require.Len(t, bot.OutboundSendMessages, 0)
+	})
+
+	t.Run("failure when sender is not allowed", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		group := &tb.Chat{ID: int64(-1)}
+		c := tbwrap.NewContext(bot, &tb.Message{Text: text, Chat: group, Sender: &tb.User{ID: 7}}, nil, handlerPattern)
+		mockReminderService := mocks.NewMockServicer(mockCtrl)
+		mockChecker := permissionMocks.NewMockChecker(mockCtrl)
+		mockChecker.
+			EXPECT().
+			CanManageReminder(-1, 7, 1).
+			Return(false, nil)
+
+		err := command.HandleRemindPause(mockReminderService, mockChecker, i18n.English)(c)
+		require.EqualError(t, err, "error: you are not allowed to do that in this chat, see /setpermissions")
+		require.Len(t, bot.OutboundSendMessages, 0)
+	})
+}
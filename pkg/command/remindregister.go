@@ -0,0 +1,82 @@
+package command
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/enrico5b1b4/tbwrap"
+	"github.com/husol/telegram-reminder-bot/pkg/allowlist"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
+	"github.com/husol/telegram-reminder-bot/pkg/telegram"
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+const HandlePatternRemindRegister = allowlist.RegisterCommand
+
+// HandleRemindRegister asks the owner of the bot by private message to approve the chat the command is sent in
+func HandleRemindRegister(
+	service RemindRegisterServicer, b telegram.MessageSender, languages i18n.Languages,
+) func(c tbwrap.Context) error {
+	return func(c tbwrap.Context) error {
+		lang := languages.ChatLanguage(int(c.ChatID()))
+		m := c.Message()
+		chat, err := service.Request(allowlist.Chat{
+			ChatID:      int(c.ChatID()),
+			Title:       chatTitle(m.Chat),
+			RequestedBy: userName(m.Sender),
+		})
+		switch err {
+		case nil:
+		case allowlist.ErrAlreadyApproved:
+			_, err = c.Send(i18n.T(lang, i18n.RegisterAlreadyApproved))
+			return err
+		case allowlist.ErrAlreadyRequested:
+			_, err = c.Send(i18n.T(lang, i18n.RegisterAlreadyRequested))
+			return err
+		default:
+			return err
+		}
+
+		ownerLang := languages.ChatLanguage(service.OwnerID())
+		buttons := NewRemindRegisterButtons(ownerLang)
+		approveBtn := *buttons[RemindRegisterApproveBtn]
+		approveBtn.Data = strconv.Itoa(chat.ChatID)
+		denyBtn := *buttons[RemindRegisterDenyBtn]
+		denyBtn.Data = strconv.Itoa(chat.ChatID)
+		_, err = b.Send(
+			&tb.User{ID: service.OwnerID()},
+			i18n.T(ownerLang, i18n.RegisterRequest, escapeMarkdown(chat.Title), chat.ChatID, escapeMarkdown(chat.RequestedBy)),
+			&tb.ReplyMarkup{InlineKeyboard: [][]tb.InlineButton{{approveBtn, denyBtn}}},
+		)
+		if err != nil {
+			return err
+		}
+
+		_, err = c.Send(i18n.T(lang, i18n.RegisterRequested))
+
+		return err
+	}
+}
+
+// chatTitle is the title of a group or the name of the user of a private chat
+func chatTitle(chat *tb.Chat) string {
+	if chat.Title != "" {
+		return chat.Title
+	}
+
+	return strings.TrimSpace(chat.FirstName + " " + chat.LastName)
+}
+
+// userName is the name of a user followed by their username if they have one e.g. "Alice (@alice)"
+func userName(user *tb.User) string {
+	if user == nil {
+		return ""
+	}
+
+	name := strings.TrimSpace(user.FirstName + " " + user.LastName)
+	if user.Username == "" {
+		return name
+	}
+
+	return name + " (@" + user.Username + ")"
+}
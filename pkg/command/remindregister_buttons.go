@@ -0,0 +1,92 @@
+package command
+
+import (
+	"strconv"
+
+	"github.com/enrico5b1b4/tbwrap"
+	"github.com/husol/telegram-reminder-bot/pkg/allowlist"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
+	"github.com/husol/telegram-reminder-bot/pkg/telegram"
+	"gopkg.in/tucnak/telebot.v2"
+)
+
+const (
+	RemindRegisterApproveBtn = "RemindRegisterApproveBtn"
+	RemindRegisterDenyBtn    = "RemindRegisterDenyBtn"
+)
+
+// NewRemindRegisterButtons returns the buttons the owner approves or denies a chat with labelled in the language
+func NewRemindRegisterButtons(lang i18n.Language) map[string]*telebot.InlineButton {
+	approveBtn := telebot.InlineButton{
+		Unique: RemindRegisterApproveBtn,
+		Text:   i18n.T(lang, i18n.ButtonApproveChat),
+	}
+	denyBtn := telebot.InlineButton{
+		Unique: RemindRegisterDenyBtn,
+		Text:   i18n.T(lang, i18n.ButtonDenyChat),
+	}
+
+	return map[string]*telebot.InlineButton{
+		RemindRegisterApproveBtn: &approveBtn,
+		RemindRegisterDenyBtn:    &denyBtn,
+	}
+}
+
+// nolint:gochecknoglobals
+var registerDecisionKeys = map[allowlist.Status]struct{ owner, chat i18n.Key }{
+	allowlist.Approved: {owner: i18n.RegisterApprovedOwner, chat: i18n.RegisterApproved},
+	allowlist.Denied:   {owner: i18n.RegisterDeniedOwner, chat: i18n.RegisterDenied},
+}
+
+// HandleRemindRegisterDecisionBtn approves or denies the chat carried by the button, which only the owner can press.
+// The request sent to the owner is edited to say what they decided and the chat is told about it
+func HandleRemindRegisterDecisionBtn(
+	service RemindRegisterServicer,
+	b telegram.MessageSender,
+	editor telegram.MessageEditor,
+	languages i18n.Languages,
+	status allowlist.Status,
+) func(c tbwrap.Context) error {
+	return func(c tbwrap.Context) error {
+		ownerLang := languages.ChatLanguage(service.OwnerID())
+		if c.Callback().Sender == nil || c.Callback().Sender.ID != service.OwnerID() {
+			return c.Respond(c.Callback(), &telebot.CallbackResponse{
+				Text:      i18n.T(ownerLang, i18n.RegisterOwnerOnly),
+				ShowAlert: true,
+			})
+		}
+
+		err := c.Respond(c.Callback())
+		if err != nil {
+			return err
+		}
+
+		chatID, err := strconv.Atoi(c.Callback().Data)
+		if err != nil {
+			return err
+		}
+
+		decide := service.Deny
+		if status == allowlist.Approved {
+			decide = service.Approve
+		}
+		chat, err := decide(chatID)
+		if err != nil {
+			return err
+		}
+
+		keys := registerDecisionKeys[status]
+		_, err = editor.Edit(
+			c.Message(),
+			i18n.T(ownerLang, keys.owner, escapeMarkdown(chat.Title), chat.ChatID),
+			&telebot.SendOptions{ParseMode: telebot.ModeMarkdown},
+		)
+		if err != nil {
+			return err
+		}
+
+		_, err = b.Send(&telebot.Chat{ID: int64(chatID)}, i18n.T(languages.ChatLanguage(chatID), keys.chat))
+
+		return err
+	}
+}
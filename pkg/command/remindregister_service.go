@@ -0,0 +1,78 @@
+package command
+
+//go:generate mockgen -source=$GOFILE -destination=mocks/${GOFILE} -package=mocks
+
+import (
+	"github.com/husol/telegram-reminder-bot/pkg/allowlist"
+	"github.com/husol/telegram-reminder-bot/pkg/chatpreference"
+	"github.com/husol/telegram-reminder-bot/pkg/reminder"
+)
+
+type RemindRegisterServicer interface {
+	OwnerID() int
+	Request(chat allowlist.Chat) (*allowlist.Chat, error)
+	Approve(chatID int) (*allowlist.Chat, error)
+	Deny(chatID int) (*allowlist.Chat, error)
+}
+
+type RemindRegisterService struct {
+	allowList             *allowlist.Service
+	chatPreferenceService *chatpreference.Service
+	reminderLoader        reminder.LoaderServicer
+	setUpChat             func(chatID int) error
+}
+
+// NewRemindRegisterService creates the service which approves the chats which ask to use the bot.
+// setUpChat creates what a chat needs to store its reminders
+func NewRemindRegisterService(
+	allowList *allowlist.Service,
+	chatPreferenceService *chatpreference.Service,
+	reminderLoader reminder.LoaderServicer,
+	setUpChat func(chatID int) error,
+) *RemindRegisterService {
+	return &RemindRegisterService{
+		allowList:             allowList,
+		chatPreferenceService: chatPreferenceService,
+		reminderLoader:        reminderLoader,
+		setUpChat:             setUpChat,
+	}
+}
+
+func (s *RemindRegisterService) OwnerID() int {
+	return s.allowList.OwnerID()
+}
+
+func (s *RemindRegisterService) Request(chat allowlist.Chat) (*allowlist.Chat, error) {
+	return s.allowList.Request(chat)
+}
+
+// Approve lets a chat use the bot once it has somewhere to store its reminders and its default preferences.
+// The reminders of a chat which was denied before are scheduled again
+func (s *RemindRegisterService) Approve(chatID int) (*allowlist.Chat, error) {
+	err := s.setUpChat(chatID)
+	if err != nil {
+		return nil, err
+	}
+	s.chatPreferenceService.CreateDefaultChatPreferences([]int{chatID})
+
+	chat, err := s.allowList.Decide(chatID, allowlist.Approved)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.reminderLoader.ReloadSchedulesForChat(chatID)
+
+	return chat, err
+}
+
+// Deny stops a chat using the bot, which also stops its reminders
+func (s *RemindRegisterService) Deny(chatID int) (*allowlist.Chat, error) {
+	chat, err := s.allowList.Decide(chatID, allowlist.Denied)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.reminderLoader.UnloadSchedulesForChat(chatID)
+
+	return chat, err
+}
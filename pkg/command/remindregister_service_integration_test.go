@@ -0,0 +1,71 @@
+package command_test
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/husol/telegram-reminder-bot/pkg/allowlist"
+	"github.com/husol/telegram-reminder-bot/pkg/command"
+	cronMocks "github.com/husol/telegram-reminder-bot/pkg/cron/mocks"
+	"github.com/husol/telegram-reminder-bot/pkg/db"
+	"github.com/husol/telegram-reminder-bot/pkg/reminder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemindRegisterService_Deny(t *testing.T) {
+	checkSkip(t)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	database, err := db.SetupDB(testDBFile(), nil)
+	require.NoError(t, err)
+	defer database.Close()
+	allowList := allowlist.NewService(allowlist.NewStore(database), ownerID, time.Now)
+	loader := reminder.NewLoaderService(
+		nil,
+		cronMocks.NewMockScheduler(mockCtrl),
+		reminder.NewStore(database),
+		nil,
+		nil,
+		time.Now,
+	)
+	service := command.NewRemindRegisterService(allowList, nil, loader, func(chatID int) error {
+		return db.SetUpChat(database, chatID)
+	})
+
+	t.Run("denies a chat which only asked to use the bot", func(t *testing.T) {
+		chatID := -generateRandomInt() - 1
+		_, err := service.Request(allowlist.Chat{ChatID: chatID, Title: "Ops"})
+		require.NoError(t, err)
+
+		chat, err := service.Deny(chatID)
+		require.NoError(t, err)
+		assert.Equal(t, allowlist.Denied, chat.Status)
+		assert.False(t, allowList.IsAllowed(chatID))
+	})
+}
+
+func checkSkip(t *testing.T) {
+	testDBFile := os.Getenv("TEST_DB_FILE")
+	if testDBFile == "" {
+		t.Skip()
+	}
+}
+
+func testDBFile() string {
+	return fmt.Sprintf("../%s", os.Getenv("TEST_DB_FILE"))
+}
+
+func generateRandomInt() int {
+	nBig, err := rand.Int(rand.Reader, big.NewInt(10000))
+	if err != nil {
+		panic(err)
+	}
+	return int(nBig.Int64())
+}
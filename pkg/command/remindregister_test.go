@@ -0,0 +1,120 @@
+package command_test
+
+import (
+	"testing"
+
+	"github.com/enrico5b1b4/tbwrap"
+	"github.com/golang/mock/gomock"
+	"github.com/husol/telegram-reminder-bot/pkg/allowlist"
+	"github.com/husol/telegram-reminder-bot/pkg/command"
+	"github.com/husol/telegram-reminder-bot/pkg/command/mocks"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
+	fakeBot "github.com/husol/telegram-reminder-bot/pkg/telegram/fakes"
+	"github.com/stretchr/testify/require"
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+const ownerID = 7
+
+func TestHandleRemindRegister(t *testing.T) {
+	group := &tb.Chat{ID: int64(-1001), Type: tb.ChatGroup, Title: "Ops_team"}
+	sender := &tb.User{ID: 42, FirstName: "Alice", Username: "alice"}
+
+	t.Run("asks the owner to approve the chat", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		owner := fakeBot.NewTBWrapBot()
+		c := tbwrap.NewContext(bot, &tb.Message{Text: "/remindregister", Chat: group, Sender: sender}, nil, nil)
+		mockRegisterService := mocks.NewMockRemindRegisterServicer(mockCtrl)
+		mockRegisterService.EXPECT().OwnerID().Return(ownerID).AnyTimes()
+		mockRegisterService.
+			EXPECT().
+			Request(allowlist.Chat{ChatID: -1001, Title: "Ops_team", RequestedBy: "Alice (@alice)"}).
+			Return(&allowlist.Chat{ChatID: -1001, Title: "Ops_team", RequestedBy: "Alice (@alice)", Status: allowlist.Pending}, nil)
+
+		err := command.HandleRemindRegister(mockRegisterService, owner, i18n.English)(c)
+		require.NoError(t, err)
+		require.Equal(t, []string{`*Ops\_team* (-1001) asked to use the bot, requested by Alice (@alice)`}, owner.OutboundSendMessages)
+		require.Equal(t, []string{"The owner of the bot has been asked to let this chat use it"}, bot.OutboundSendMessages)
+	})
+
+	t.Run("does not ask again for a chat which is waiting", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		owner := fakeBot.NewTBWrapBot()
+		c := tbwrap.NewContext(bot, &tb.Message{Text: "/remindregister", Chat: group, Sender: sender}, nil, nil)
+		mockRegisterService := mocks.NewMockRemindRegisterServicer(mockCtrl)
+		mockRegisterService.EXPECT().Request(gomock.Any()).Return(nil, allowlist.ErrAlreadyRequested)
+
+		err := command.HandleRemindRegister(mockRegisterService, owner, i18n.English)(c)
+		require.NoError(t, err)
+		require.Empty(t, owner.OutboundSendMessages)
+		require.Equal(t, []string{"This chat is still waiting for the owner of the bot to approve it"}, bot.OutboundSendMessages)
+	})
+}
+
+func TestHandleRemindRegisterDecisionBtn(t *testing.T) {
+	ownerChat := &tb.Chat{ID: int64(ownerID), Type: tb.ChatPrivate}
+	newContext := func(bot *fakeBot.TBWrapBot, sender *tb.User) tbwrap.Context {
+		msg := &tb.Message{ID: 10, Chat: ownerChat}
+		return tbwrap.NewContext(bot, msg, &tb.Callback{Data: "-1001", Sender: sender, Message: msg}, nil)
+	}
+
+	t.Run("approves the chat", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		editor := fakeBot.NewMessageEditor()
+		mockRegisterService := mocks.NewMockRemindRegisterServicer(mockCtrl)
+		mockRegisterService.EXPECT().OwnerID().Return(ownerID).AnyTimes()
+		mockRegisterService.
+			EXPECT().
+			Approve(-1001).
+			Return(&allowlist.Chat{ChatID: -1001, Title: "Ops", Status: allowlist.Approved}, nil)
+
+		err := command.HandleRemindRegisterDecisionBtn(mockRegisterService, bot, editor, i18n.English, allowlist.Approved)(
+			newContext(bot, &tb.User{ID: ownerID}),
+		)
+		require.NoError(t, err)
+		require.Equal(t, []string{"✅ *Ops* (-1001) can now use the bot"}, editor.Edits)
+		require.Equal(t, []string{"This chat can now use the bot, send /remindhelp to see what it can do"}, bot.OutboundSendMessages)
+	})
+
+	t.Run("denies the chat", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		editor := fakeBot.NewMessageEditor()
+		mockRegisterService := mocks.NewMockRemindRegisterServicer(mockCtrl)
+		mockRegisterService.EXPECT().OwnerID().Return(ownerID).AnyTimes()
+		mockRegisterService.
+			EXPECT().
+			Deny(-1001).
+			Return(&allowlist.Chat{ChatID: -1001, Title: "Ops", Status: allowlist.Denied}, nil)
+
+		err := command.HandleRemindRegisterDecisionBtn(mockRegisterService, bot, editor, i18n.English, allowlist.Denied)(
+			newContext(bot, &tb.User{ID: ownerID}),
+		)
+		require.NoError(t, err)
+		require.Equal(t, []string{"❌ *Ops* (-1001) was denied"}, editor.Edits)
+		require.Equal(t, []string{"The owner of the bot did not approve this chat"}, bot.OutboundSendMessages)
+	})
+
+	t.Run("only the owner can decide", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		editor := fakeBot.NewMessageEditor()
+		mockRegisterService := mocks.NewMockRemindRegisterServicer(mockCtrl)
+		mockRegisterService.EXPECT().OwnerID().Return(ownerID).AnyTimes()
+
+		err := command.HandleRemindRegisterDecisionBtn(mockRegisterService, bot, editor, i18n.English, allowlist.Approved)(
+			newContext(bot, &tb.User{ID: 42}),
+		)
+		require.NoError(t, err)
+		require.Empty(t, editor.Edits)
+		require.Empty(t, bot.OutboundSendMessages)
+	})
+}
@@ -0,0 +1,46 @@
+package command
+
+import (
+	"github.com/enrico5b1b4/tbwrap"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
+	"github.com/husol/telegram-reminder-bot/pkg/permission"
+	"github.com/husol/telegram-reminder-bot/pkg/reminder"
+)
+
+type MessageRemindResume struct {
+	ReminderID int `regexpGroup:"reminderID"`
+}
+
+var HandlePatternRemindResume = []string{
+	`/remindresume (?P<reminderID>\d{1,5})`,
+	`/remindresume_(?P<reminderID>\d{1,5})`,
+}
+
+func HandleRemindResume(
+	service reminder.ServiceReminder, checker permission.Checker, languages i18n.Languages,
+) func(c tbwrap.Context) error {
+	return func(c tbwrap.Context) error {
+		message := new(MessageRemindResume)
+		if err := c.Bind(message); err != nil {
+			return err
+		}
+
+		lang := languages.ChatLanguage(int(c.ChatID()))
+		allowed, err := checker.CanManageReminder(int(c.ChatID()), senderID(c), message.ReminderID)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return notAllowedError(lang)
+		}
+
+		nextSchedule, err := service.ResumeReminder(int(c.ChatID()), message.ReminderID)
+		if err != nil {
+			return err
+		}
+
+		_, err = c.Send(ReminderResumedSuccessMessage(lang, message.ReminderID, nextSchedule))
+
+		return err
+	}
+}
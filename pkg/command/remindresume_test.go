@@ -0,0 +1,57 @@
+package command_test
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/enrico5b1b4/tbwrap"
+	"github.com/golang/mock/gomock"
+	"github.com/husol/telegram-reminder-bot/pkg/command"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
+	"github.com/husol/telegram-reminder-bot/pkg/reminder"
+	"github.com/husol/telegram-reminder-bot/pkg/reminder/mocks"
+	fakeBot "github.com/husol/telegram-reminder-bot/pkg/telegram/fakes"
+	"github.com/stretchr/testify/require"
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+func TestHandleRemindResume(t *testing.T) {
+	handlerPattern, err := regexp.Compile(command.HandlePatternRemindResume[0])
+	require.NoError(t, err)
+	text := "/remindresume 1"
+	chat := &tb.Chat{ID: int64(1)}
+
+	t.Run("success", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		c := tbwrap.NewContext(bot, &tb.Message{Text: text, Chat: chat}, nil, handlerPattern)
+		mockReminderService := mocks.NewMockServicer(mockCtrl)
+		mockReminderService.
+			EXPECT().
+			ResumeReminder(1, 1).
+			Return(reminder.NextScheduleChatTime{Time: time.Now(), Location: time.UTC}, nil)
+
+		err := command.HandleRemindResume(mockReminderService, allowingChecker(mockCtrl), i18n.English)(c)
+		require.NoError(t, err)
+		require.Len(t, bot.OutboundSendMessages, 1)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		c := tbwrap.NewContext(bot, &tb.Message{Text: text, Chat: chat}, nil, handlerPattern)
+		mockReminderService := mocks.NewMockServicer(mockCtrl)
+		mockReminderService.
+			EXPECT().
+			ResumeReminder(1, 1).
+			Return(reminder.NextScheduleChatTime{}, errors.New("error"))
+
+		err := command.HandleRemindResume(mockReminderService, allowingChecker(mockCtrl), i18n.English)(c)
+		require.Error(t, err)
+		require.Len(t, bot.OutboundSendMessages, 0)
+	})
+}
@@ -0,0 +1,91 @@
+package command
+
+import (
+	"github.com/enrico5b1b4/tbwrap"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
+	"github.com/husol/telegram-reminder-bot/pkg/permission"
+	"github.com/husol/telegram-reminder-bot/pkg/reminder"
+)
+
+type MessageRemindRoster struct {
+	ReminderID int `regexpGroup:"reminderID"`
+}
+
+type MessageRemindRosterSwap struct {
+	ReminderID int    `regexpGroup:"reminderID"`
+	Member     string `regexpGroup:"member"`
+}
+
+var HandlePatternRemindRosterSkip = []string{
+	`/remindroster (?P<reminderID>\d{1,5}) skip`,
+	`/remindroster_(?P<reminderID>\d{1,5}) skip`,
+}
+
+var HandlePatternRemindRosterSwap = []string{
+	`/remindroster (?P<reminderID>\d{1,5}) swap (?P<member>.+)`,
+	`/remindroster_(?P<reminderID>\d{1,5}) swap (?P<member>.+)`,
+}
+
+// HandleRemindRosterSkip skips the member of the roster of a reminder whose turn is next
+func HandleRemindRosterSkip(
+	service reminder.ServiceReminder, checker permission.Checker, languages i18n.Languages,
+) func(c tbwrap.Context) error {
+	return func(c tbwrap.Context) error {
+		message := new(MessageRemindRoster)
+		if err := c.Bind(message); err != nil {
+			return err
+		}
+
+		lang := languages.ChatLanguage(int(c.ChatID()))
+		allowed, err := checker.CanManageReminder(int(c.ChatID()), senderID(c), message.ReminderID)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return notAllowedError(lang)
+		}
+
+		skipped, next, err := service.SkipRosterTurn(int(c.ChatID()), message.ReminderID)
+		if err != nil {
+			return err
+		}
+
+		_, err = c.Send(i18n.T(
+			lang, i18n.RosterSkipped, message.ReminderID, escapeMarkdown(skipped.String()), escapeMarkdown(next.String()),
+		))
+
+		return err
+	}
+}
+
+// HandleRemindRosterSwap gives the next turn of the roster of a reminder to a member
+func HandleRemindRosterSwap(
+	service reminder.ServiceReminder, checker permission.Checker, languages i18n.Languages,
+) func(c tbwrap.Context) error {
+	return func(c tbwrap.Context) error {
+		message := new(MessageRemindRosterSwap)
+		if err := c.Bind(message); err != nil {
+			return err
+		}
+
+		lang := languages.ChatLanguage(int(c.ChatID()))
+		allowed, err := checker.CanManageReminder(int(c.ChatID()), senderID(c), message.ReminderID)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return notAllowedError(lang)
+		}
+
+		swapped, err := service.SwapRosterTurn(int(c.ChatID()), message.ReminderID, message.Member)
+		if err != nil {
+			return err
+		}
+
+		_, err = c.Send(i18n.T(
+			lang, i18n.RosterSwapped, escapeMarkdown(message.Member), message.ReminderID, escapeMarkdown(swapped.String()),
+		))
+
+		return err
+	}
+}
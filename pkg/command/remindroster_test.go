@@ -0,0 +1,112 @@
+package command_test
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/enrico5b1b4/tbwrap"
+	"github.com/golang/mock/gomock"
+	"github.com/husol/telegram-reminder-bot/pkg/command"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
+	permissionMocks "github.com/husol/telegram-reminder-bot/pkg/permission/mocks"
+	"github.com/husol/telegram-reminder-bot/pkg/reminder"
+	"github.com/husol/telegram-reminder-bot/pkg/reminder/mocks"
+	fakeBot "github.com/husol/telegram-reminder-bot/pkg/telegram/fakes"
+	"github.com/stretchr/testify/require"
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+func TestHandleRemindRosterSkip(t *testing.T) {
+	handlerPattern, err := regexp.Compile(command.HandlePatternRemindRosterSkip[0])
+	require.NoError(t, err)
+	chat := &tb.Chat{ID: int64(1)}
+
+	t.Run("success", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		c := tbwrap.NewContext(bot, &tb.Message{Text: "/remindroster 3 skip", Chat: chat}, nil, handlerPattern)
+		mockReminderService := mocks.NewMockServicer(mockCtrl)
+		mockReminderService.
+			EXPECT().
+			SkipRosterTurn(1, 3).
+			Return(reminder.Recipient{Username: "alice_b"}, reminder.Recipient{UserID: 7, Name: "Bob"}, nil)
+
+		err := command.HandleRemindRosterSkip(mockReminderService, allowingChecker(mockCtrl), i18n.English)(c)
+		require.NoError(t, err)
+		require.Equal(t, []string{`The turn of @alice\_b on reminder 3 has been skipped, Bob is next`}, bot.OutboundSendMessages)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		c := tbwrap.NewContext(bot, &tb.Message{Text: "/remindroster 3 skip", Chat: chat}, nil, handlerPattern)
+		mockReminderService := mocks.NewMockServicer(mockCtrl)
+		mockReminderService.
+			EXPECT().
+			SkipRosterTurn(1, 3).
+			Return(reminder.Recipient{}, reminder.Recipient{}, errors.New("error"))
+
+		err := command.HandleRemindRosterSkip(mockReminderService, allowingChecker(mockCtrl), i18n.English)(c)
+		require.Error(t, err)
+		require.Len(t, bot.OutboundSendMessages, 0)
+	})
+
+	t.Run("failure when sender is not allowed", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		group := &tb.Chat{ID: int64(-1)}
+		c := tbwrap.NewContext(bot, &tb.Message{Text: "/remindroster 3 skip", Chat: group, Sender: &tb.User{ID: 7}}, nil, handlerPattern)
+		mockReminderService := mocks.NewMockServicer(mockCtrl)
+		mockChecker := permissionMocks.NewMockChecker(mockCtrl)
+		mockChecker.
+			EXPECT().
+			CanManageReminder(-1, 7, 3).
+			Return(false, nil)
+
+		err := command.HandleRemindRosterSkip(mockReminderService, mockChecker, i18n.English)(c)
+		require.EqualError(t, err, "error: you are not allowed to do that in this chat, see /setpermissions")
+		require.Len(t, bot.OutboundSendMessages, 0)
+	})
+}
+
+func TestHandleRemindRosterSwap(t *testing.T) {
+	handlerPattern, err := regexp.Compile(command.HandlePatternRemindRosterSwap[0])
+	require.NoError(t, err)
+	chat := &tb.Chat{ID: int64(1)}
+
+	t.Run("success", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		c := tbwrap.NewContext(bot, &tb.Message{Text: "/remindroster 3 swap @carol", Chat: chat}, nil, handlerPattern)
+		mockReminderService := mocks.NewMockServicer(mockCtrl)
+		mockReminderService.
+			EXPECT().
+			SwapRosterTurn(1, 3, "@carol").
+			Return(reminder.Recipient{Username: "alice_b"}, nil)
+
+		err := command.HandleRemindRosterSwap(mockReminderService, allowingChecker(mockCtrl), i18n.English)(c)
+		require.NoError(t, err)
+		require.Equal(t, []string{`@carol takes the next turn on reminder 3 instead of @alice\_b`}, bot.OutboundSendMessages)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		c := tbwrap.NewContext(bot, &tb.Message{Text: "/remindroster 3 swap @carol", Chat: chat}, nil, handlerPattern)
+		mockReminderService := mocks.NewMockServicer(mockCtrl)
+		mockReminderService.
+			EXPECT().
+			SwapRosterTurn(1, 3, "@carol").
+			Return(reminder.Recipient{}, errors.New("error"))
+
+		err := command.HandleRemindRosterSwap(mockReminderService, allowingChecker(mockCtrl), i18n.English)(c)
+		require.Error(t, err)
+		require.Len(t, bot.OutboundSendMessages, 0)
+	})
+}
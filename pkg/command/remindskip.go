@@ -0,0 +1,59 @@
+package command
+
+import (
+	"strings"
+
+	"github.com/enrico5b1b4/tbwrap"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
+	"github.com/husol/telegram-reminder-bot/pkg/permission"
+	"github.com/husol/telegram-reminder-bot/pkg/reminder"
+)
+
+type MessageRemindSkip struct {
+	ReminderID int `regexpGroup:"reminderID"`
+	Times      int `regexpGroup:"times"`
+}
+
+var HandlePatternRemindSkip = []string{
+	`/remindskip (?P<reminderID>\d{1,5})(?: (?P<times>\d{1,3}))?`,
+	`/remindskip_(?P<reminderID>\d{1,5})`,
+}
+
+func HandleRemindSkip(
+	service reminder.ServiceReminder, checker permission.Checker, languages i18n.Languages,
+) func(c tbwrap.Context) error {
+	return func(c tbwrap.Context) error {
+		message := new(MessageRemindSkip)
+		if err := c.Bind(message); err != nil {
+			return err
+		}
+
+		lang := languages.ChatLanguage(int(c.ChatID()))
+		allowed, err := checker.CanManageReminder(int(c.ChatID()), senderID(c), message.ReminderID)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return notAllowedError(lang)
+		}
+
+		times := message.Times
+		if times == 0 {
+			times = 1
+		}
+
+		skipped, err := service.SkipReminder(int(c.ChatID()), message.ReminderID, times)
+		if err != nil {
+			return err
+		}
+
+		dates := make([]string, len(skipped))
+		for i := range skipped {
+			dates[i] = i18n.FormatTime(lang, i18n.DateTimeZone, skipped[i])
+		}
+
+		_, err = c.Send(i18n.T(lang, i18n.ReminderSkipped, message.ReminderID, strings.Join(dates, "\n")))
+
+		return err
+	}
+}
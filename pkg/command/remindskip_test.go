@@ -0,0 +1,71 @@
+package command_test
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/enrico5b1b4/tbwrap"
+	"github.com/golang/mock/gomock"
+	"github.com/husol/telegram-reminder-bot/pkg/command"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
+	"github.com/husol/telegram-reminder-bot/pkg/reminder/mocks"
+	fakeBot "github.com/husol/telegram-reminder-bot/pkg/telegram/fakes"
+	"github.com/stretchr/testify/require"
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+func TestHandleRemindSkip(t *testing.T) {
+	handlerPattern, err := regexp.Compile(command.HandlePatternRemindSkip[0])
+	require.NoError(t, err)
+	chat := &tb.Chat{ID: int64(1)}
+	skipped := []time.Time{
+		time.Date(2020, time.April, 2, 9, 0, 0, 0, time.UTC),
+		time.Date(2020, time.April, 3, 9, 0, 0, 0, time.UTC),
+	}
+
+	testCases := map[string]struct {
+		text          string
+		expectedTimes int
+	}{
+		"next run":       {text: "/remindskip 1", expectedTimes: 1},
+		"next few times": {text: "/remindskip 1 2", expectedTimes: 2},
+	}
+
+	for name := range testCases {
+		t.Run(name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			testCase := testCases[name]
+			bot := fakeBot.NewTBWrapBot()
+			c := tbwrap.NewContext(bot, &tb.Message{Text: testCase.text, Chat: chat}, nil, handlerPattern)
+			mockReminderService := mocks.NewMockServicer(mockCtrl)
+			mockReminderService.
+				EXPECT().
+				SkipReminder(1, 1, testCase.expectedTimes).
+				Return(skipped[:testCase.expectedTimes], nil)
+
+			err := command.HandleRemindSkip(mockReminderService, allowingChecker(mockCtrl), i18n.English)(c)
+			require.NoError(t, err)
+			require.Len(t, bot.OutboundSendMessages, 1)
+			require.Contains(t, bot.OutboundSendMessages[0], "Thu, 02 Apr 2020 09:00 UTC")
+		})
+	}
+
+	t.Run("failure", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		c := tbwrap.NewContext(bot, &tb.Message{Text: "/remindskip 1", Chat: chat}, nil, handlerPattern)
+		mockReminderService := mocks.NewMockServicer(mockCtrl)
+		mockReminderService.
+			EXPECT().
+			SkipReminder(1, 1, 1).
+			Return(nil, errors.New("error"))
+
+		err := command.HandleRemindSkip(mockReminderService, allowingChecker(mockCtrl), i18n.English)(c)
+		require.Error(t, err)
+		require.Len(t, bot.OutboundSendMessages, 0)
+	})
+}
@@ -0,0 +1,55 @@
+package command
+
+import (
+	"github.com/enrico5b1b4/tbwrap"
+	"github.com/husol/telegram-reminder-bot/pkg/chatpreference"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
+	"github.com/husol/telegram-reminder-bot/pkg/permission"
+)
+
+type MessageSetDateOrder struct {
+	Order string `regexpGroup:"order"`
+}
+
+const HandlePatternSetDateOrder = `/setdateorder (?P<order>dmy|mdy)`
+
+// HandleSetDateOrder sets whether dates written with numbers are read day or month first in the chat
+func HandleSetDateOrder(store chatpreference.Storer, checker permission.Checker) func(c tbwrap.Context) error {
+	return func(c tbwrap.Context) error {
+		message := new(MessageSetDateOrder)
+		if err := c.Bind(message); err != nil {
+			return err
+		}
+
+		cp, err := store.GetChatPreference(int(c.ChatID()))
+		if err != nil {
+			return err
+		}
+
+		allowed, err := checker.CanManageChat(int(c.ChatID()), senderID(c))
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return adminsOnlyError(cp.Language)
+		}
+
+		cp.DateOrder = chatpreference.DayMonth
+		if message.Order == "mdy" {
+			cp.DateOrder = chatpreference.MonthDay
+		}
+
+		err = store.UpsertChatPreference(cp)
+		if err != nil {
+			return err
+		}
+
+		order := i18n.T(cp.Language, i18n.DateOrderDayMonth)
+		if cp.DateOrder == chatpreference.MonthDay {
+			order = i18n.T(cp.Language, i18n.DateOrderMonthDay)
+		}
+		_, err = c.Send(i18n.T(cp.Language, i18n.DateOrderSet, order))
+
+		return err
+	}
+}
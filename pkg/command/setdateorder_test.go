@@ -0,0 +1,78 @@
+package command_test
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/enrico5b1b4/tbwrap"
+	"github.com/golang/mock/gomock"
+	"github.com/husol/telegram-reminder-bot/pkg/chatpreference"
+	"github.com/husol/telegram-reminder-bot/pkg/chatpreference/mocks"
+	"github.com/husol/telegram-reminder-bot/pkg/command"
+	fakeBot "github.com/husol/telegram-reminder-bot/pkg/telegram/fakes"
+	"github.com/stretchr/testify/require"
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+func TestHandleSetDateOrder(t *testing.T) {
+	handlerPattern, err := regexp.Compile(command.HandlePatternSetDateOrder)
+	require.NoError(t, err)
+	chat := &tb.Chat{ID: int64(1)}
+
+	testCases := map[string]struct {
+		text              string
+		expectedDateOrder chatpreference.DateOrder
+		expectedMessage   string
+	}{
+		"month first": {
+			text:              "/setdateorder mdy",
+			expectedDateOrder: chatpreference.MonthDay,
+			expectedMessage:   "Dates written with numbers will be read as month/day/year",
+		},
+		"day first": {
+			text:              "/setdateorder dmy",
+			expectedDateOrder: chatpreference.DayMonth,
+			expectedMessage:   "Dates written with numbers will be read as day/month/year",
+		},
+	}
+
+	for name := range testCases {
+		t.Run(name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			testCase := testCases[name]
+			bot := fakeBot.NewTBWrapBot()
+			c := tbwrap.NewContext(bot, &tb.Message{Text: testCase.text, Chat: chat}, nil, handlerPattern)
+			mockChatPreferenceStore := mocks.NewMockStorer(mockCtrl)
+			mockChatPreferenceStore.
+				EXPECT().
+				GetChatPreference(1).
+				Return(&chatpreference.ChatPreference{ChatID: 1, TimeZone: "Asia/Ho_Chi_Minh", DateOrder: chatpreference.MonthDay}, nil)
+			mockChatPreferenceStore.
+				EXPECT().
+				UpsertChatPreference(&chatpreference.ChatPreference{ChatID: 1, TimeZone: "Asia/Ho_Chi_Minh", DateOrder: testCase.expectedDateOrder}).
+				Return(nil)
+
+			err := command.HandleSetDateOrder(mockChatPreferenceStore, allowingChecker(mockCtrl))(c)
+			require.NoError(t, err)
+			require.Equal(t, []string{testCase.expectedMessage}, bot.OutboundSendMessages)
+		})
+	}
+
+	t.Run("failure", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		c := tbwrap.NewContext(bot, &tb.Message{Text: "/setdateorder mdy", Chat: chat}, nil, handlerPattern)
+		mockChatPreferenceStore := mocks.NewMockStorer(mockCtrl)
+		mockChatPreferenceStore.
+			EXPECT().
+			GetChatPreference(1).
+			Return(nil, errors.New("error"))
+
+		err := command.HandleSetDateOrder(mockChatPreferenceStore, allowingChecker(mockCtrl))(c)
+		require.Error(t, err)
+		require.Len(t, bot.OutboundSendMessages, 0)
+	})
+}
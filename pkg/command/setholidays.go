@@ -0,0 +1,120 @@
+package command
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+
+	"github.com/enrico5b1b4/tbwrap"
+	"github.com/husol/telegram-reminder-bot/pkg/holiday"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
+	"github.com/husol/telegram-reminder-bot/pkg/permission"
+	"github.com/husol/telegram-reminder-bot/pkg/telegram"
+)
+
+type MessageSetHolidays struct {
+	Calendar string `regexpGroup:"calendar"`
+}
+
+const HandlePatternSetHolidays = `/setholidays (?P<calendar>off|[a-zA-Z]{2})`
+
+// setHolidaysCaption is the caption of an .ics file sent to the chat to use it as holiday calendar
+const setHolidaysCaption = "/setholidays"
+
+// HandleSetHolidays sets the holiday calendar of the chat to one shipped with the bot, or turns it off
+func HandleSetHolidays(
+	service SetHolidaysServicer, checker permission.Checker, languages i18n.Languages,
+) func(c tbwrap.Context) error {
+	return func(c tbwrap.Context) error {
+		message := new(MessageSetHolidays)
+		if err := c.Bind(message); err != nil {
+			return err
+		}
+
+		lang := languages.ChatLanguage(int(c.ChatID()))
+		if err := canSetHolidays(c, checker, lang); err != nil {
+			return err
+		}
+
+		if message.Calendar == "off" {
+			if err := service.SetHolidays(int(c.ChatID()), nil); err != nil {
+				return err
+			}
+
+			_, err := c.Send(i18n.T(lang, i18n.HolidaysOff))
+			return err
+		}
+
+		calendar, err := holiday.Builtin(message.Calendar)
+		if err == holiday.ErrUnknownCalendar {
+			return errors.New(i18n.T(lang, i18n.NoCalendar,
+				message.Calendar,
+				strings.Join(holiday.BuiltinCodes(), ", "),
+				setHolidaysCaption,
+			))
+		}
+		if err != nil {
+			return err
+		}
+
+		return setHolidays(c, service, lang, calendar)
+	}
+}
+
+// HandleSetHolidaysFromFile sets the holiday calendar of the chat to an .ics file sent with the caption /setholidays.
+// Other files are ignored
+func HandleSetHolidaysFromFile(
+	service SetHolidaysServicer, checker permission.Checker, languages i18n.Languages, files telegram.FileGetter,
+) func(c tbwrap.Context) error {
+	return func(c tbwrap.Context) error {
+		document := c.Message().Document
+		if document == nil || !strings.HasPrefix(strings.TrimSpace(c.Message().Caption), setHolidaysCaption) {
+			return nil
+		}
+
+		lang := languages.ChatLanguage(int(c.ChatID()))
+		if err := canSetHolidays(c, checker, lang); err != nil {
+			return err
+		}
+
+		if !strings.EqualFold(filepath.Ext(document.FileName), ".ics") {
+			return errors.New(i18n.T(lang, i18n.NotICSFile, document.FileName))
+		}
+
+		file, err := files.GetFile(&document.File)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		calendar, err := holiday.ParseICS(file, strings.TrimSuffix(document.FileName, filepath.Ext(document.FileName)))
+		if err != nil {
+			return err
+		}
+
+		return setHolidays(c, service, lang, calendar)
+	}
+}
+
+// canSetHolidays returns an error when the sender is not allowed to set the holiday calendar of the chat
+func canSetHolidays(c tbwrap.Context, checker permission.Checker, lang i18n.Language) error {
+	allowed, err := checker.CanManageChat(int(c.ChatID()), senderID(c))
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return adminsOnlyError(lang)
+	}
+
+	return nil
+}
+
+func setHolidays(c tbwrap.Context, service SetHolidaysServicer, lang i18n.Language, calendar *holiday.Calendar) error {
+	if err := service.SetHolidays(int(c.ChatID()), calendar); err != nil {
+		return err
+	}
+
+	_, err := c.Send(i18n.T(lang, i18n.HolidaysSet, calendar.Name, calendar.Len()))
+
+	return err
+}
@@ -0,0 +1,43 @@
+package command
+
+import (
+	"github.com/husol/telegram-reminder-bot/pkg/chatpreference"
+	"github.com/husol/telegram-reminder-bot/pkg/holiday"
+	"github.com/husol/telegram-reminder-bot/pkg/reminder"
+)
+
+//go:generate mockgen -source=$GOFILE -destination=mocks/${GOFILE} -package=mocks
+
+type SetHolidaysServicer interface {
+	SetHolidays(chatID int, calendar *holiday.Calendar) error
+}
+
+type SetHolidaysService struct {
+	reminderLoader      reminder.LoaderServicer
+	chatPreferenceStore chatpreference.Storer
+}
+
+func NewSetHolidaysService(chatPreferenceStore chatpreference.Storer, reminderLoader reminder.LoaderServicer) *SetHolidaysService {
+	return &SetHolidaysService{
+		reminderLoader:      reminderLoader,
+		chatPreferenceStore: chatPreferenceStore,
+	}
+}
+
+// SetHolidays sets the holiday calendar followed by the reminders of a chat, or removes it when calendar is nil.
+// Reminders are reloaded as their schedules hold on to the calendar they were created with
+func (s *SetHolidaysService) SetHolidays(chatID int, calendar *holiday.Calendar) error {
+	chatPreference, err := s.chatPreferenceStore.GetChatPreference(chatID)
+	if err != nil {
+		return err
+	}
+
+	chatPreference.Holidays = calendar
+	if err = s.chatPreferenceStore.UpsertChatPreference(chatPreference); err != nil {
+		return err
+	}
+
+	_, err = s.reminderLoader.ReloadSchedulesForChat(chatID)
+
+	return err
+}
@@ -0,0 +1,152 @@
+package command_test
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/enrico5b1b4/tbwrap"
+	"github.com/golang/mock/gomock"
+	"github.com/husol/telegram-reminder-bot/pkg/command"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
+	"github.com/husol/telegram-reminder-bot/pkg/command/mocks"
+	"github.com/husol/telegram-reminder-bot/pkg/holiday"
+	permissionMocks "github.com/husol/telegram-reminder-bot/pkg/permission/mocks"
+	fakeBot "github.com/husol/telegram-reminder-bot/pkg/telegram/fakes"
+	"github.com/stretchr/testify/require"
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+func TestHandleSetHolidays(t *testing.T) {
+	handlerPattern, err := regexp.Compile(command.HandlePatternSetHolidays)
+	require.NoError(t, err)
+	chat := &tb.Chat{ID: int64(1)}
+
+	t.Run("builtin calendar", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		c := tbwrap.NewContext(bot, &tb.Message{Text: "/setholidays vn", Chat: chat}, nil, handlerPattern)
+		mockService := mocks.NewMockSetHolidaysServicer(mockCtrl)
+		mockService.
+			EXPECT().
+			SetHolidays(1, gomock.Any()).
+			DoAndReturn(func(chatID int, calendar *holiday.Calendar) error {
+				require.Equal(t, "Vietnam", calendar.Name)
+				return nil
+			})
+
+		err := command.HandleSetHolidays(mockService, allowingChecker(mockCtrl), i18n.English)(c)
+		require.NoError(t, err)
+		require.Len(t, bot.OutboundSendMessages, 1)
+		require.Contains(t, bot.OutboundSendMessages[0], "Holidays have been set to Vietnam")
+	})
+
+	t.Run("off", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		c := tbwrap.NewContext(bot, &tb.Message{Text: "/setholidays off", Chat: chat}, nil, handlerPattern)
+		mockService := mocks.NewMockSetHolidaysServicer(mockCtrl)
+		mockService.EXPECT().SetHolidays(1, nil).Return(nil)
+
+		err := command.HandleSetHolidays(mockService, allowingChecker(mockCtrl), i18n.English)(c)
+		require.NoError(t, err)
+		require.Equal(t, []string{"Holidays have been turned off"}, bot.OutboundSendMessages)
+	})
+
+	t.Run("unknown calendar", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		c := tbwrap.NewContext(bot, &tb.Message{Text: "/setholidays xx", Chat: chat}, nil, handlerPattern)
+		mockService := mocks.NewMockSetHolidaysServicer(mockCtrl)
+
+		err := command.HandleSetHolidays(mockService, allowingChecker(mockCtrl), i18n.English)(c)
+		require.Error(t, err)
+		require.Len(t, bot.OutboundSendMessages, 0)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		c := tbwrap.NewContext(bot, &tb.Message{Text: "/setholidays us", Chat: chat}, nil, handlerPattern)
+		mockService := mocks.NewMockSetHolidaysServicer(mockCtrl)
+		mockService.EXPECT().SetHolidays(1, gomock.Any()).Return(errors.New("error"))
+
+		err := command.HandleSetHolidays(mockService, allowingChecker(mockCtrl), i18n.English)(c)
+		require.Error(t, err)
+		require.Len(t, bot.OutboundSendMessages, 0)
+	})
+
+	t.Run("failure when sender is not an admin", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		group := &tb.Chat{ID: int64(-1)}
+		c := tbwrap.NewContext(bot, &tb.Message{Text: "/setholidays off", Chat: group, Sender: &tb.User{ID: 7}}, nil, handlerPattern)
+		mockService := mocks.NewMockSetHolidaysServicer(mockCtrl)
+		mockChecker := permissionMocks.NewMockChecker(mockCtrl)
+		mockChecker.
+			EXPECT().
+			CanManageChat(-1, 7).
+			Return(false, nil)
+
+		err := command.HandleSetHolidays(mockService, mockChecker, i18n.English)(c)
+		require.EqualError(t, err, "error: only the admins of this chat can do that")
+		require.Len(t, bot.OutboundSendMessages, 0)
+	})
+}
+
+func TestHandleSetHolidaysFromFile(t *testing.T) {
+	chat := &tb.Chat{ID: int64(1)}
+	files := fakeBot.NewFileGetter()
+	files.Files["calendar"] = "BEGIN:VEVENT\r\nDTSTART;VALUE=DATE:20200101\r\nSUMMARY:New Year's Day\r\nEND:VEVENT\r\n"
+
+	t.Run("success", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		document := &tb.Document{File: tb.File{FileID: "calendar"}, FileName: "office.ics"}
+		c := tbwrap.NewContext(bot, &tb.Message{Caption: "/setholidays", Document: document, Chat: chat}, nil, nil)
+		mockService := mocks.NewMockSetHolidaysServicer(mockCtrl)
+		mockService.
+			EXPECT().
+			SetHolidays(1, gomock.Any()).
+			DoAndReturn(func(chatID int, calendar *holiday.Calendar) error {
+				require.Equal(t, "office", calendar.Name)
+				require.Equal(t, 1, calendar.Len())
+				return nil
+			})
+
+		err := command.HandleSetHolidaysFromFile(mockService, allowingChecker(mockCtrl), i18n.English, files)(c)
+		require.NoError(t, err)
+		require.Len(t, bot.OutboundSendMessages, 1)
+	})
+
+	t.Run("file without caption is ignored", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		document := &tb.Document{File: tb.File{FileID: "calendar"}, FileName: "office.ics"}
+		c := tbwrap.NewContext(bot, &tb.Message{Document: document, Chat: chat}, nil, nil)
+		mockService := mocks.NewMockSetHolidaysServicer(mockCtrl)
+
+		err := command.HandleSetHolidaysFromFile(mockService, allowingChecker(mockCtrl), i18n.English, files)(c)
+		require.NoError(t, err)
+		require.Len(t, bot.OutboundSendMessages, 0)
+	})
+
+	t.Run("not an ics file", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		document := &tb.Document{File: tb.File{FileID: "calendar"}, FileName: "office.pdf"}
+		c := tbwrap.NewContext(bot, &tb.Message{Caption: "/setholidays", Document: document, Chat: chat}, nil, nil)
+		mockService := mocks.NewMockSetHolidaysServicer(mockCtrl)
+
+		err := command.HandleSetHolidaysFromFile(mockService, allowingChecker(mockCtrl), i18n.English, files)(c)
+		require.Error(t, err)
+	})
+}
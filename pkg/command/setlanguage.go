@@ -0,0 +1,47 @@
+package command
+
+import (
+	"github.com/enrico5b1b4/tbwrap"
+	"github.com/husol/telegram-reminder-bot/pkg/chatpreference"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
+	"github.com/husol/telegram-reminder-bot/pkg/permission"
+)
+
+type MessageSetLanguage struct {
+	Language string `regexpGroup:"language"`
+}
+
+const HandlePatternSetLanguage = `/setlanguage (?P<language>en|vi)`
+
+// HandleSetLanguage sets the language the chat reads the bot in
+func HandleSetLanguage(store chatpreference.Storer, checker permission.Checker) func(c tbwrap.Context) error {
+	return func(c tbwrap.Context) error {
+		message := new(MessageSetLanguage)
+		if err := c.Bind(message); err != nil {
+			return err
+		}
+
+		cp, err := store.GetChatPreference(int(c.ChatID()))
+		if err != nil {
+			return err
+		}
+
+		allowed, err := checker.CanManageChat(int(c.ChatID()), senderID(c))
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return adminsOnlyError(cp.Language)
+		}
+
+		cp.Language, _ = i18n.ParseLanguage(message.Language)
+		err = store.UpsertChatPreference(cp)
+		if err != nil {
+			return err
+		}
+
+		_, err = c.Send(i18n.T(cp.Language, i18n.LanguageSet, cp.Language.Name()))
+
+		return err
+	}
+}
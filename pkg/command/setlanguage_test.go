@@ -0,0 +1,102 @@
+package command_test
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/enrico5b1b4/tbwrap"
+	"github.com/golang/mock/gomock"
+	"github.com/husol/telegram-reminder-bot/pkg/chatpreference"
+	"github.com/husol/telegram-reminder-bot/pkg/chatpreference/mocks"
+	"github.com/husol/telegram-reminder-bot/pkg/command"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
+	permissionMocks "github.com/husol/telegram-reminder-bot/pkg/permission/mocks"
+	fakeBot "github.com/husol/telegram-reminder-bot/pkg/telegram/fakes"
+	"github.com/stretchr/testify/require"
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+func TestHandleSetLanguage(t *testing.T) {
+	handlerPattern, err := regexp.Compile(command.HandlePatternSetLanguage)
+	require.NoError(t, err)
+	chat := &tb.Chat{ID: int64(1)}
+
+	testCases := map[string]struct {
+		text             string
+		expectedLanguage i18n.Language
+		expectedMessage  string
+	}{
+		"vietnamese": {
+			text:             "/setlanguage vi",
+			expectedLanguage: i18n.Vietnamese,
+			expectedMessage:  "Bot sẽ trả lời bằng Tiếng Việt",
+		},
+		"english": {
+			text:             "/setlanguage en",
+			expectedLanguage: i18n.English,
+			expectedMessage:  "The bot will reply in English",
+		},
+	}
+
+	for name := range testCases {
+		t.Run(name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			testCase := testCases[name]
+			bot := fakeBot.NewTBWrapBot()
+			c := tbwrap.NewContext(bot, &tb.Message{Text: testCase.text, Chat: chat}, nil, handlerPattern)
+			mockChatPreferenceStore := mocks.NewMockStorer(mockCtrl)
+			mockChatPreferenceStore.
+				EXPECT().
+				GetChatPreference(1).
+				Return(&chatpreference.ChatPreference{ChatID: 1, TimeZone: "Asia/Ho_Chi_Minh"}, nil)
+			mockChatPreferenceStore.
+				EXPECT().
+				UpsertChatPreference(&chatpreference.ChatPreference{ChatID: 1, TimeZone: "Asia/Ho_Chi_Minh", Language: testCase.expectedLanguage}).
+				Return(nil)
+
+			err := command.HandleSetLanguage(mockChatPreferenceStore, allowingChecker(mockCtrl))(c)
+			require.NoError(t, err)
+			require.Equal(t, []string{testCase.expectedMessage}, bot.OutboundSendMessages)
+		})
+	}
+
+	t.Run("failure", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		c := tbwrap.NewContext(bot, &tb.Message{Text: "/setlanguage vi", Chat: chat}, nil, handlerPattern)
+		mockChatPreferenceStore := mocks.NewMockStorer(mockCtrl)
+		mockChatPreferenceStore.
+			EXPECT().
+			GetChatPreference(1).
+			Return(nil, errors.New("error"))
+
+		err := command.HandleSetLanguage(mockChatPreferenceStore, allowingChecker(mockCtrl))(c)
+		require.Error(t, err)
+		require.Len(t, bot.OutboundSendMessages, 0)
+	})
+
+	t.Run("failure when sender is not an admin", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		group := &tb.Chat{ID: int64(-1)}
+		c := tbwrap.NewContext(bot, &tb.Message{Text: "/setlanguage vi", Chat: group, Sender: &tb.User{ID: 7}}, nil, handlerPattern)
+		mockChatPreferenceStore := mocks.NewMockStorer(mockCtrl)
+		mockChatPreferenceStore.
+			EXPECT().
+			GetChatPreference(-1).
+			Return(&chatpreference.ChatPreference{ChatID: -1, TimeZone: "Asia/Ho_Chi_Minh"}, nil)
+		mockChecker := permissionMocks.NewMockChecker(mockCtrl)
+		mockChecker.
+			EXPECT().
+			CanManageChat(-1, 7).
+			Return(false, nil)
+
+		err := command.HandleSetLanguage(mockChatPreferenceStore, mockChecker)(c)
+		require.EqualError(t, err, "error: only the admins of this chat can do that")
+		require.Len(t, bot.OutboundSendMessages, 0)
+	})
+}
@@ -0,0 +1,53 @@
+package command
+
+import (
+	"github.com/enrico5b1b4/tbwrap"
+	"github.com/husol/telegram-reminder-bot/pkg/chatpreference"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
+	"github.com/husol/telegram-reminder-bot/pkg/permission"
+)
+
+type MessageSetLateReminders struct {
+	Value string `regexpGroup:"value"`
+}
+
+// nolint:lll
+const HandlePatternSetLateReminders = `/setlatereminders (?P<value>on|off)`
+
+// HandleSetLateReminders turns on or off the delivery of reminders
+// which were due while the bot was not running
+func HandleSetLateReminders(store chatpreference.Storer, checker permission.Checker) func(c tbwrap.Context) error {
+	return func(c tbwrap.Context) error {
+		message := new(MessageSetLateReminders)
+		if err := c.Bind(message); err != nil {
+			return err
+		}
+
+		cp, err := store.GetChatPreference(int(c.ChatID()))
+		if err != nil {
+			return err
+		}
+
+		allowed, err := checker.CanManageChat(int(c.ChatID()), senderID(c))
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return adminsOnlyError(cp.Language)
+		}
+
+		cp.SkipLateReminders = message.Value == "off"
+		err = store.UpsertChatPreference(cp)
+		if err != nil {
+			return err
+		}
+
+		key := i18n.LateRemindersOn
+		if cp.SkipLateReminders {
+			key = i18n.LateRemindersOff
+		}
+		_, err = c.Send(i18n.T(cp.Language, key))
+
+		return err
+	}
+}
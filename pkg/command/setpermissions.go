@@ -0,0 +1,61 @@
+package command
+
+import (
+	"errors"
+
+	"github.com/enrico5b1b4/tbwrap"
+	"github.com/husol/telegram-reminder-bot/pkg/chatpreference"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
+	"github.com/husol/telegram-reminder-bot/pkg/permission"
+)
+
+type MessageSetPermissions struct {
+	Value string `regexpGroup:"value"`
+}
+
+const HandlePatternSetPermissions = `/setpermissions (?P<value>everyone|creator|admins)`
+
+var permissionsNames = map[chatpreference.Permissions]i18n.Key{
+	chatpreference.Everyone:    i18n.PermissionsEveryone,
+	chatpreference.CreatorOnly: i18n.PermissionsCreator,
+	chatpreference.AdminsOnly:  i18n.PermissionsAdmins,
+}
+
+// HandleSetPermissions sets who in a group can edit, pause, skip, delete and finish reminders,
+// which only the admins of the group can do like changing the other settings of the chat
+func HandleSetPermissions(store chatpreference.Storer, checker permission.Checker) func(c tbwrap.Context) error {
+	return func(c tbwrap.Context) error {
+		message := new(MessageSetPermissions)
+		if err := c.Bind(message); err != nil {
+			return err
+		}
+
+		cp, err := store.GetChatPreference(int(c.ChatID()))
+		if err != nil {
+			return err
+		}
+
+		isAdmin, err := checker.IsAdmin(int(c.ChatID()), senderID(c))
+		if err != nil {
+			return err
+		}
+		if !isAdmin {
+			return errors.New(i18n.T(cp.Language, i18n.AdminsOnly))
+		}
+
+		for permissions := range permissionsNames {
+			if permissions.String() == message.Value {
+				cp.Permissions = permissions
+			}
+		}
+
+		err = store.UpsertChatPreference(cp)
+		if err != nil {
+			return err
+		}
+
+		_, err = c.Send(i18n.T(cp.Language, i18n.PermissionsSet, i18n.T(cp.Language, permissionsNames[cp.Permissions])))
+
+		return err
+	}
+}
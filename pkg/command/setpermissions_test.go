@@ -0,0 +1,71 @@
+package command_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/enrico5b1b4/tbwrap"
+	"github.com/golang/mock/gomock"
+	"github.com/husol/telegram-reminder-bot/pkg/chatpreference"
+	"github.com/husol/telegram-reminder-bot/pkg/chatpreference/mocks"
+	"github.com/husol/telegram-reminder-bot/pkg/command"
+	permissionMocks "github.com/husol/telegram-reminder-bot/pkg/permission/mocks"
+	fakeBot "github.com/husol/telegram-reminder-bot/pkg/telegram/fakes"
+	"github.com/stretchr/testify/require"
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+func TestHandleSetPermissions(t *testing.T) {
+	handlerPattern, err := regexp.Compile(command.HandlePatternSetPermissions)
+	require.NoError(t, err)
+	text := "/setpermissions creator"
+	group := &tb.Chat{ID: int64(-1)}
+	sender := &tb.User{ID: 7}
+
+	t.Run("success", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		c := tbwrap.NewContext(bot, &tb.Message{Text: text, Chat: group, Sender: sender}, nil, handlerPattern)
+		mockChatPreferenceStore := mocks.NewMockStorer(mockCtrl)
+		mockChatPreferenceStore.
+			EXPECT().
+			GetChatPreference(-1).
+			Return(&chatpreference.ChatPreference{ChatID: -1, TimeZone: "Asia/Ho_Chi_Minh"}, nil)
+		mockChatPreferenceStore.
+			EXPECT().
+			UpsertChatPreference(&chatpreference.ChatPreference{
+				ChatID:      -1,
+				TimeZone:    "Asia/Ho_Chi_Minh",
+				Permissions: chatpreference.CreatorOnly,
+			}).
+			Return(nil)
+		mockChecker := permissionMocks.NewMockChecker(mockCtrl)
+		mockChecker.EXPECT().IsAdmin(-1, 7).Return(true, nil)
+
+		err := command.HandleSetPermissions(mockChatPreferenceStore, mockChecker)(c)
+		require.NoError(t, err)
+		require.Equal(t,
+			[]string{"Reminders can now be edited, paused, skipped, deleted and finished by the member who set them and the admins"},
+			bot.OutboundSendMessages,
+		)
+	})
+
+	t.Run("failure when sender is not an admin", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		c := tbwrap.NewContext(bot, &tb.Message{Text: text, Chat: group, Sender: sender}, nil, handlerPattern)
+		mockChatPreferenceStore := mocks.NewMockStorer(mockCtrl)
+		mockChatPreferenceStore.
+			EXPECT().
+			GetChatPreference(-1).
+			Return(&chatpreference.ChatPreference{ChatID: -1, TimeZone: "Asia/Ho_Chi_Minh"}, nil)
+		mockChecker := permissionMocks.NewMockChecker(mockCtrl)
+		mockChecker.EXPECT().IsAdmin(-1, 7).Return(false, nil)
+
+		err := command.HandleSetPermissions(mockChatPreferenceStore, mockChecker)(c)
+		require.EqualError(t, err, "error: only the admins of this chat can do that")
+		require.Empty(t, bot.OutboundSendMessages)
+	})
+}
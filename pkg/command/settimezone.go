@@ -1,9 +1,9 @@
 package command
 
 import (
-	"fmt"
-
 	"github.com/enrico5b1b4/tbwrap"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
+	"github.com/husol/telegram-reminder-bot/pkg/permission"
 )
 
 type MessageSetTimezone struct {
@@ -13,19 +13,65 @@ type MessageSetTimezone struct {
 // nolint:lll
 const HandlePatternSetTimezone = `/settimezone (?P<timezone>.*)`
 
-func HandleSetTimezone(service SetTimezoneServicer) func(c tbwrap.Context) error {
+// HandlePatternSetUserTimezone also matches HandlePatternSetTimezone so it has to be handled first
+const HandlePatternSetUserTimezone = `/settimezone me (?P<timezone>.*)`
+
+// UserTimezoneOff goes back to reading the times a user writes in the timezone of the chat
+const UserTimezoneOff = "off"
+
+func HandleSetTimezone(
+	service SetTimezoneServicer, checker permission.Checker, languages i18n.Languages,
+) func(c tbwrap.Context) error {
 	return func(c tbwrap.Context) error {
 		message := new(MessageSetTimezone)
 		if err := c.Bind(message); err != nil {
 			return err
 		}
 
-		err := service.SetTimeZone(int(c.ChatID()), message.TimeZone)
+		lang := languages.ChatLanguage(int(c.ChatID()))
+		allowed, err := checker.CanSetTimeZone(int(c.ChatID()), senderID(c))
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return notAllowedError(lang)
+		}
+
+		err = service.SetTimeZone(int(c.ChatID()), message.TimeZone)
 		if err != nil {
 			return err
 		}
 
-		_, err = c.Send(fmt.Sprintf("Timezone has been updated to: %s", message.TimeZone))
+		_, err = c.Send(i18n.T(lang, i18n.TimezoneUpdated, message.TimeZone))
+		return err
+	}
+}
+
+// HandleSetUserTimezone sets the timezone the times written by the sender are read in, which is theirs to set
+func HandleSetUserTimezone(service SetTimezoneServicer, languages i18n.Languages) func(c tbwrap.Context) error {
+	return func(c tbwrap.Context) error {
+		message := new(MessageSetTimezone)
+		if err := c.Bind(message); err != nil {
+			return err
+		}
+
+		userID := senderID(c)
+		if userID == 0 {
+			return i18n.Errorf(i18n.ErrMemberTimezone)
+		}
+
+		err := service.SetUserTimeZone(userID, message.TimeZone)
+		if err != nil {
+			return err
+		}
+
+		lang := languages.ChatLanguage(int(c.ChatID()))
+		if message.TimeZone == UserTimezoneOff {
+			_, err = c.Send(i18n.T(lang, i18n.UserTimezoneOff))
+			return err
+		}
+
+		_, err = c.Send(i18n.T(lang, i18n.UserTimezoneUpdated, message.TimeZone))
 		return err
 	}
 }
@@ -5,23 +5,31 @@ import (
 
 	"github.com/husol/telegram-reminder-bot/pkg/chatpreference"
 	"github.com/husol/telegram-reminder-bot/pkg/reminder"
+	"github.com/husol/telegram-reminder-bot/pkg/userpreference"
 )
 
 //go:generate mockgen -source=$GOFILE -destination=mocks/${GOFILE} -package=mocks
 
 type SetTimezoneServicer interface {
 	SetTimeZone(chatID int, timezone string) error
+	SetUserTimeZone(userID int, timezone string) error
 }
 
 type SetTimezoneService struct {
 	reminderLoader      reminder.LoaderServicer
 	chatPreferenceStore chatpreference.Storer
+	userPreferenceStore userpreference.Storer
 }
 
-func NewSetTimezoneService(chatPreferenceStore chatpreference.Storer, reminderLoader reminder.LoaderServicer) *SetTimezoneService {
+func NewSetTimezoneService(
+	chatPreferenceStore chatpreference.Storer,
+	userPreferenceStore userpreference.Storer,
+	reminderLoader reminder.LoaderServicer,
+) *SetTimezoneService {
 	return &SetTimezoneService{
 		reminderLoader:      reminderLoader,
 		chatPreferenceStore: chatPreferenceStore,
+		userPreferenceStore: userPreferenceStore,
 	}
 }
 
@@ -30,14 +38,20 @@ func (s *SetTimezoneService) SetTimeZone(chatID int, timezone string) error {
 		return err
 	}
 
-	if err := s.chatPreferenceStore.UpsertChatPreference(&chatpreference.ChatPreference{
-		ChatID:   chatID,
-		TimeZone: timezone,
-	}); err != nil {
+	chatPreference, err := s.chatPreferenceStore.GetChatPreference(chatID)
+	if err != nil && err != chatpreference.ErrNotFound {
+		return err
+	}
+	if chatPreference == nil {
+		chatPreference = &chatpreference.ChatPreference{ChatID: chatID}
+	}
+
+	chatPreference.TimeZone = timezone
+	if err = s.chatPreferenceStore.UpsertChatPreference(chatPreference); err != nil {
 		return err
 	}
 
-	_, err := s.reminderLoader.ReloadSchedulesForChat(chatID)
+	_, err = s.reminderLoader.ReloadSchedulesForChat(chatID)
 	if err != nil {
 		return err
 	}
@@ -45,6 +59,29 @@ func (s *SetTimezoneService) SetTimeZone(chatID int, timezone string) error {
 	return nil
 }
 
+// SetUserTimeZone sets the timezone the times a user writes in reminders are read in, in every chat.
+// "off" reads them in the timezone of the chat again.
+// Reminders which were already set keep the timezone they were set in
+func (s *SetTimezoneService) SetUserTimeZone(userID int, timezone string) error {
+	if timezone == UserTimezoneOff {
+		timezone = ""
+	} else if err := validateTimeZone(timezone); err != nil {
+		return err
+	}
+
+	userPreference, err := s.userPreferenceStore.GetUserPreference(userID)
+	if err != nil && err != userpreference.ErrNotFound {
+		return err
+	}
+	if userPreference == nil {
+		userPreference = &userpreference.UserPreference{UserID: userID}
+	}
+
+	userPreference.TimeZone = timezone
+
+	return s.userPreferenceStore.UpsertUserPreference(userPreference)
+}
+
 // validateTimeZone validates input timezone
 func validateTimeZone(tz string) error {
 	_, err := time.LoadLocation(tz)
@@ -8,7 +8,9 @@ import (
 	"github.com/enrico5b1b4/tbwrap"
 	"github.com/golang/mock/gomock"
 	"github.com/husol/telegram-reminder-bot/pkg/command"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
 	"github.com/husol/telegram-reminder-bot/pkg/command/mocks"
+	permissionMocks "github.com/husol/telegram-reminder-bot/pkg/permission/mocks"
 	fakeBot "github.com/husol/telegram-reminder-bot/pkg/telegram/fakes"
 	"github.com/stretchr/testify/require"
 	tb "gopkg.in/tucnak/telebot.v2"
@@ -31,7 +33,7 @@ func TestHandleSetTimezone(t *testing.T) {
 			SetTimeZone(1, "Asia/Ho_Chi_Minh").
 			Return(nil)
 
-		err := command.HandleSetTimezone(mockService)(c)
+		err := command.HandleSetTimezone(mockService, allowingChecker(mockCtrl), i18n.English)(c)
 		require.NoError(t, err)
 		require.Len(t, bot.OutboundSendMessages, 1)
 	})
@@ -47,7 +49,82 @@ func TestHandleSetTimezone(t *testing.T) {
 			SetTimeZone(1, "Asia/Ho_Chi_Minh").
 			Return(errors.New("error"))
 
-		err := command.HandleSetTimezone(mockService)(c)
+		err := command.HandleSetTimezone(mockService, allowingChecker(mockCtrl), i18n.English)(c)
+		require.Error(t, err)
+		require.Len(t, bot.OutboundSendMessages, 0)
+	})
+
+	t.Run("failure when the member is not allowed under the permissions of the chat", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		group := &tb.Chat{ID: int64(-1), Type: tb.ChatGroup}
+		c := tbwrap.NewContext(bot, &tb.Message{Text: text, Chat: group, Sender: &tb.User{ID: 7}}, nil, handlerPattern)
+		mockService := mocks.NewMockSetTimezoneServicer(mockCtrl)
+		checker := permissionMocks.NewMockChecker(mockCtrl)
+		checker.EXPECT().CanSetTimeZone(-1, 7).Return(false, nil)
+
+		err := command.HandleSetTimezone(mockService, checker, i18n.English)(c)
+		require.EqualError(t, err, "error: you are not allowed to do that in this chat, see /setpermissions")
+		require.Len(t, bot.OutboundSendMessages, 0)
+	})
+}
+
+func TestHandleSetUserTimezone(t *testing.T) {
+	handlerPattern, err := regexp.Compile(command.HandlePatternSetUserTimezone)
+	require.NoError(t, err)
+	chat := &tb.Chat{ID: int64(-1), Type: tb.ChatGroup}
+	sender := &tb.User{ID: 7}
+
+	t.Run("success", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		text := "/settimezone me Europe/London"
+		c := tbwrap.NewContext(bot, &tb.Message{Text: text, Chat: chat, Sender: sender}, nil, handlerPattern)
+		mockService := mocks.NewMockSetTimezoneServicer(mockCtrl)
+		mockService.
+			EXPECT().
+			SetUserTimeZone(7, "Europe/London").
+			Return(nil)
+
+		err := command.HandleSetUserTimezone(mockService, i18n.English)(c)
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"Your timezone has been updated to: Europe/London. Times you write in reminders will be read in it",
+		}, bot.OutboundSendMessages)
+	})
+
+	t.Run("off", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		text := "/settimezone me off"
+		c := tbwrap.NewContext(bot, &tb.Message{Text: text, Chat: chat, Sender: sender}, nil, handlerPattern)
+		mockService := mocks.NewMockSetTimezoneServicer(mockCtrl)
+		mockService.
+			EXPECT().
+			SetUserTimeZone(7, command.UserTimezoneOff).
+			Return(nil)
+
+		err := command.HandleSetUserTimezone(mockService, i18n.English)(c)
+		require.NoError(t, err)
+		require.Equal(t, []string{"Times you write in reminders will be read in the timezone of the chat"}, bot.OutboundSendMessages)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakeBot.NewTBWrapBot()
+		text := "/settimezone me Nowhere/City"
+		c := tbwrap.NewContext(bot, &tb.Message{Text: text, Chat: chat, Sender: sender}, nil, handlerPattern)
+		mockService := mocks.NewMockSetTimezoneServicer(mockCtrl)
+		mockService.
+			EXPECT().
+			SetUserTimeZone(7, "Nowhere/City").
+			Return(errors.New("error"))
+
+		err := command.HandleSetUserTimezone(mockService, i18n.English)(c)
 		require.Error(t, err)
 		require.Len(t, bot.OutboundSendMessages, 0)
 	})
@@ -26,15 +26,37 @@ func (j JobStatus) String() string {
 	return [...]string{"", "Active", "Inactive", "Completed"}[j]
 }
 
+// HolidayPolicy is what a recurring job does when it is due on a holiday
+type HolidayPolicy int
+
+const (
+	IgnoreHolidays        HolidayPolicy = 0
+	SkipHolidays          HolidayPolicy = 1
+	MoveToNextBusinessDay HolidayPolicy = 2
+)
+
+func (h HolidayPolicy) String() string {
+	return [...]string{"", "Skip holidays", "Move to next business day"}[h]
+}
+
 type Job struct {
 	ID             int                `json:"id"`
 	CronID         int                `json:"cron_id"`
 	ChatID         int                `json:"owner_id"`
 	Schedule       string             `json:"schedule"`
+	TimeZone       string             `json:"time_zone"` // the timezone Schedule is read in, empty for the one of the chat
 	Type           JobType            `json:"type"`
 	Status         JobStatus          `json:"status"`
 	RunOnlyOnce    bool               `json:"run_only_once"`
+	RunAt          *time.Time         `json:"run_at"` // when a job set for a date with a year runs, as Schedule has no year
 	RepeatSchedule *JobRepeatSchedule `json:"repeat_schedule"`
+	Lunar          bool               `json:"lunar"` // the day of the month and the month of Schedule are lunar dates
+	Nag            *JobNag            `json:"nag"`
+	EndsAt         *time.Time         `json:"ends_at"`        // a recurring job is completed instead of running after EndsAt
+	RemainingRuns  *int               `json:"remaining_runs"` // a recurring job is completed once it has no runs remaining
+	Holidays       HolidayPolicy      `json:"holidays"`
+	HolidayShifts  []JobHolidayShift  `json:"holiday_shifts"` // kept for jobs with a RepeatSchedule as they are rescheduled after each run
+	SkippedRuns    []time.Time        `json:"skipped_runs"`   // upcoming runs of a recurring job which are passed over
 	CompletedAt    *time.Time         `json:"completed_at"`
 	NextRunAt      *time.Time         `json:"next_run_at"` // NextRunAt should match the schedule
 	LastRunAt      *time.Time         `json:"last_run_at"`
@@ -46,4 +68,25 @@ type JobRepeatSchedule struct {
 	Hours   int `json:"hours"`
 	Days    int `json:"days"`
 	Months  int `json:"months"`
+	// DayOfMonth is the day a job repeating every few months should run on.
+	// It is kept as months which are too short for it make the job run on their last day instead
+	DayOfMonth int `json:"day_of_month"`
+}
+
+// JobNag makes a job repeat every few minutes after it runs until it is acknowledged
+type JobNag struct {
+	Minutes    int `json:"minutes"`
+	MaxResends int `json:"max_resends"`
+	// Resends, CronID, NextRunAt and the occurrence being sent again describe the follow-up cycle in progress, if any
+	Resends      int        `json:"resends"`
+	CronID       int        `json:"cron_id"`
+	NextRunAt    *time.Time `json:"next_run_at"`
+	OccurrenceID int        `json:"occurrence_id"`
+}
+
+// JobHolidayShift is an occurrence of a job which was skipped, or moved to a business day, as it fell on a holiday
+type JobHolidayShift struct {
+	At      time.Time  `json:"at"`
+	Holiday string     `json:"holiday"` // empty for a weekend
+	MovedTo *time.Time `json:"moved_to"`
 }
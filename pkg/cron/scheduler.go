@@ -11,6 +11,7 @@ import (
 
 type Scheduler interface {
 	Add(spec string, cmd func()) (int, error)
+	AddSchedule(schedule Schedule, cmd func()) (int, error)
 	Remove(ID int)
 	GetEntryByID(ID int) Entry
 	Start()
@@ -21,6 +22,12 @@ type JobScheduler struct {
 	c *cron.Cron
 }
 
+// Schedule describes when a spec fires
+type Schedule interface {
+	// Next returns the next activation time, later than the given time
+	Next(time.Time) time.Time
+}
+
 type Entry struct {
 	ID   int
 	Next time.Time
@@ -33,6 +40,11 @@ func NewScheduler() *JobScheduler {
 	}
 }
 
+// ParseSchedule parses a standard 5 field spec, which may be prefixed with CRON_TZ
+func ParseSchedule(spec string) (Schedule, error) {
+	return cron.ParseStandard(spec)
+}
+
 func (s *JobScheduler) Start() {
 	s.c.Start()
 }
@@ -55,6 +67,13 @@ func (s *JobScheduler) Add(spec string, cmd func()) (int, error) {
 	return int(entryID), err
 }
 
+// AddSchedule adds a job with a schedule which can't be written as a spec
+func (s *JobScheduler) AddSchedule(schedule Schedule, cmd func()) (int, error) {
+	entryID := s.c.Schedule(schedule, cron.FuncJob(cmd))
+
+	return int(entryID), nil
+}
+
 func (s *JobScheduler) Remove(id int) {
 	s.c.Remove(cron.EntryID(id))
 }
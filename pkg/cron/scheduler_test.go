@@ -2,6 +2,7 @@ package cron_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/husol/telegram-reminder-bot/pkg/cron"
 	"github.com/stretchr/testify/require"
@@ -14,3 +15,20 @@ func TestStartAndStopScheduler(t *testing.T) {
 	err := scheduler.Stop()
 	require.NoError(t, err)
 }
+
+func TestParseSchedule(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		schedule, err := cron.ParseSchedule("CRON_TZ=Asia/Ho_Chi_Minh 52 13 * * 2")
+		require.NoError(t, err)
+
+		loc, err := time.LoadLocation("Asia/Ho_Chi_Minh")
+		require.NoError(t, err)
+		next := schedule.Next(time.Date(2020, time.April, 1, 13, 45, 0, 0, loc))
+		require.Equal(t, time.Date(2020, time.April, 7, 13, 52, 0, 0, loc), next.In(loc))
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		_, err := cron.ParseSchedule("61 13 * * 2")
+		require.Error(t, err)
+	})
+}
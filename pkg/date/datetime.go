@@ -41,6 +41,13 @@ func ToNumericMonth(month string) int {
 	}
 }
 
+// MaxDaysInMonth is the most days the month has in any year, which is 29 for February
+// nolint:gomnd
+func MaxDaysInMonth(month int) int {
+	// the day before the first of the next month in a leap year
+	return time.Date(2000, time.Month(month)+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
 // nolint:gomnd
 func ToNumericDayOfWeek(day string) int {
 	switch strings.ToLower(day) {
@@ -0,0 +1,256 @@
+package date
+
+import (
+	"math"
+	"time"
+)
+
+// The lunar calendar (âm lịch) is the Vietnamese lunisolar calendar. Its months start on the day of the new moon
+// and the 11th month is the one with the winter solstice. A year with 13 months has a leap month, which is the first
+// month without a principal term of the sun and takes the number of the month before it.
+// Dates are worked out offline with the astronomical algorithms of Hồ Ngọc Đức,
+// in the timezone of Vietnam which is also what the Chinese calendar uses but with UTC+8
+
+// lunarTimeZone is the offset from UTC in hours of the meridian the lunar calendar is worked out on
+const lunarTimeZone = 7.0
+
+// julianDayUnixEpoch is the Julian day number of 1 January 1970
+const julianDayUnixEpoch = 2440588
+
+// synodicMonth is the mean number of days from one new moon to the next
+const synodicMonth = 29.530588853
+
+// newMoon1900 is the Julian day of the new moon of 1 January 1900, which new moons are counted from
+const newMoon1900 = 2415021.076998695
+
+// LunarDate is a date of the lunar calendar
+type LunarDate struct {
+	Year  int
+	Month int
+	Day   int
+	Leap  bool // the leap month which follows the month with the same number
+}
+
+// LunarMonth is a month of the lunar calendar along with the solar dates it spans
+type LunarMonth struct {
+	Year  int
+	Month int
+	Leap  bool
+	Start time.Time // the first day of the month at midnight UTC
+	Days  int       // 29 or 30
+
+	k int // the number of new moons since newMoon1900
+}
+
+// ToLunar returns the lunar date of the day of t in its location
+func ToLunar(t time.Time) LunarDate {
+	jd := julianDay(t)
+	m := lunarMonthOf(jd)
+
+	return LunarDate{Year: m.Year, Month: m.Month, Day: jd - julianDay(m.Start) + 1, Leap: m.Leap}
+}
+
+// FromLunar returns the solar date of a lunar date at midnight in loc.
+// It returns false when the date does not exist e.g. a leap month in a year without it or the 30th of a short month
+// nolint:gomnd
+func FromLunar(d LunarDate, loc *time.Location) (time.Time, bool) {
+	if d.Month < 1 || d.Month > 12 || d.Day < 1 || d.Day > 30 {
+		return time.Time{}, false
+	}
+
+	var a11, b11 int
+	if d.Month < 11 {
+		a11 = lunarMonth11(d.Year - 1)
+		b11 = lunarMonth11(d.Year)
+	} else {
+		a11 = lunarMonth11(d.Year)
+		b11 = lunarMonth11(d.Year + 1)
+	}
+
+	k := int(math.Floor(0.5 + (float64(a11)-newMoon1900)/synodicMonth))
+	offset := d.Month - 11
+	if offset < 0 {
+		offset += 12
+	}
+
+	if b11-a11 > 365 {
+		leapOffset := leapMonthOffset(a11)
+		leapMonth := leapOffset - 2
+		if leapMonth <= 0 {
+			leapMonth += 12
+		}
+		if d.Leap && d.Month != leapMonth {
+			return time.Time{}, false
+		}
+		if d.Leap || offset >= leapOffset {
+			offset++
+		}
+	} else if d.Leap {
+		return time.Time{}, false
+	}
+
+	start := newMoonDay(k + offset)
+	if d.Day > newMoonDay(k+offset+1)-start {
+		return time.Time{}, false
+	}
+	solar := fromJulianDay(start + d.Day - 1)
+
+	return time.Date(solar.Year(), solar.Month(), solar.Day(), 0, 0, 0, 0, loc), true
+}
+
+// LunarMonthOf returns the lunar month the day of t in its location falls in
+func LunarMonthOf(t time.Time) LunarMonth {
+	return lunarMonthOf(julianDay(t))
+}
+
+// Next returns the lunar month which follows m
+func (m LunarMonth) Next() LunarMonth {
+	return lunarMonthAt(m.k + 1)
+}
+
+func lunarMonthOf(jd int) LunarMonth {
+	k := int(math.Floor((float64(jd) - newMoon1900) / synodicMonth))
+	if newMoonDay(k+1) <= jd {
+		k++
+	}
+
+	return lunarMonthAt(k)
+}
+
+// lunarMonthAt returns the lunar month starting on the kth new moon since newMoon1900
+// nolint:gomnd
+func lunarMonthAt(k int) LunarMonth {
+	start := newMoonDay(k)
+	m := LunarMonth{Start: fromJulianDay(start), Days: newMoonDay(k+1) - start, k: k}
+
+	year := m.Start.Year()
+	a11 := lunarMonth11(year)
+	b11 := a11
+	if a11 >= start {
+		m.Year = year
+		a11 = lunarMonth11(year - 1)
+	} else {
+		m.Year = year + 1
+		b11 = lunarMonth11(year + 1)
+	}
+
+	diff := int(math.Floor(float64(start-a11) / 29))
+	m.Month = diff + 11
+	if b11-a11 > 365 {
+		leapOffset := leapMonthOffset(a11)
+		if diff >= leapOffset {
+			m.Month = diff + 10
+			m.Leap = diff == leapOffset
+		}
+	}
+	if m.Month > 12 {
+		m.Month -= 12
+	}
+	if m.Month >= 11 && diff < 4 {
+		m.Year--
+	}
+
+	return m
+}
+
+// lunarMonth11 returns the Julian day the 11th lunar month starts on, which is before the end of the solar year
+// nolint:gomnd
+func lunarMonth11(year int) int {
+	off := julianDay(time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC)) - 2415021
+	k := int(math.Floor(float64(off) / synodicMonth))
+	nm := newMoonDay(k)
+	if sunLongitude(nm) >= 9 {
+		nm = newMoonDay(k - 1)
+	}
+
+	return nm
+}
+
+// leapMonthOffset returns how many months after the 11th month starting on a11 the leap month is
+// nolint:gomnd
+func leapMonthOffset(a11 int) int {
+	k := int(math.Floor((float64(a11)-newMoon1900)/synodicMonth + 0.5))
+	i := 1
+	arc := sunLongitude(newMoonDay(k + i))
+	for {
+		last := arc
+		i++
+		arc = sunLongitude(newMoonDay(k + i))
+		if arc == last || i >= 14 {
+			break
+		}
+	}
+
+	return i - 1
+}
+
+// newMoonDay returns the Julian day of the kth new moon since newMoon1900
+func newMoonDay(k int) int {
+	return int(math.Floor(newMoon(float64(k)) + 0.5 + lunarTimeZone/24))
+}
+
+// sunLongitude returns which of the 12 sectors of 30 degrees the sun is in at the start of the Julian day jd
+func sunLongitude(jd int) int {
+	return int(math.Floor(sunLongitudeRadians(float64(jd)-0.5-lunarTimeZone/24) / math.Pi * 6))
+}
+
+// newMoon returns the Julian day and time of the kth new moon since newMoon1900
+// nolint:gomnd
+func newMoon(k float64) float64 {
+	const dr = math.Pi / 180
+	t := k / 1236.85
+	t2 := t * t
+	t3 := t2 * t
+
+	jd := 2415020.75933 + 29.53058868*k + 0.0001178*t2 - 0.000000155*t3
+	jd += 0.00033 * math.Sin((166.56+132.87*t-0.009173*t2)*dr)
+	m := 359.2242 + 29.10535608*k - 0.0000333*t2 - 0.00000347*t3
+	mpr := 306.0253 + 385.81691806*k + 0.0107306*t2 + 0.00001236*t3
+	f := 21.2964 + 390.67050646*k - 0.0016528*t2 - 0.00000239*t3
+
+	c1 := (0.1734-0.000393*t)*math.Sin(m*dr) + 0.0021*math.Sin(2*dr*m)
+	c1 = c1 - 0.4068*math.Sin(mpr*dr) + 0.0161*math.Sin(dr*2*mpr)
+	c1 -= 0.0004 * math.Sin(dr*3*mpr)
+	c1 = c1 + 0.0104*math.Sin(dr*2*f) - 0.0051*math.Sin(dr*(m+mpr))
+	c1 = c1 - 0.0074*math.Sin(dr*(m-mpr)) + 0.0004*math.Sin(dr*(2*f+m))
+	c1 = c1 - 0.0004*math.Sin(dr*(2*f-m)) - 0.0006*math.Sin(dr*(2*f+mpr))
+	c1 = c1 + 0.0010*math.Sin(dr*(2*f-mpr)) + 0.0005*math.Sin(dr*(2*mpr+m))
+
+	var deltaT float64
+	if t < -11 {
+		deltaT = 0.001 + 0.000839*t + 0.0002261*t2 - 0.00000845*t3 - 0.000000081*t*t3
+	} else {
+		deltaT = -0.000278 + 0.000265*t + 0.000262*t2
+	}
+
+	return jd + c1 - deltaT
+}
+
+// sunLongitudeRadians returns the longitude of the sun, from 0 to 2π, at the Julian day and time jd
+// nolint:gomnd
+func sunLongitudeRadians(jd float64) float64 {
+	const dr = math.Pi / 180
+	t := (jd - 2451545.0) / 36525
+	t2 := t * t
+
+	m := 357.52910 + 35999.05030*t - 0.0001559*t2 - 0.00000048*t*t2
+	l0 := 280.46645 + 36000.76983*t + 0.0003032*t2
+	dl := (1.914600 - 0.004817*t - 0.000014*t2) * math.Sin(dr*m)
+	dl += (0.019993-0.000101*t)*math.Sin(dr*2*m) + 0.000290*math.Sin(dr*3*m)
+
+	l := (l0 + dl) * dr
+
+	return l - math.Pi*2*math.Floor(l/(math.Pi*2))
+}
+
+// julianDay returns the Julian day number of the day of t in its location
+func julianDay(t time.Time) int {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+
+	return int(day.Unix()/(24*60*60)) + julianDayUnixEpoch
+}
+
+// fromJulianDay returns the day with the Julian day number jd at midnight UTC
+func fromJulianDay(jd int) time.Time {
+	return time.Unix(int64(jd-julianDayUnixEpoch)*24*60*60, 0).UTC()
+}
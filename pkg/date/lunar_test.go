@@ -0,0 +1,95 @@
+package date_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/husol/telegram-reminder-bot/pkg/date"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLunar(t *testing.T) {
+	type test struct {
+		solar time.Time
+		lunar date.LunarDate
+	}
+
+	tests := map[string]test{
+		"tet 2024": {
+			solar: time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC),
+			lunar: date.LunarDate{Year: 2024, Month: 1, Day: 1},
+		},
+		"tet 2026": {
+			solar: time.Date(2026, 2, 17, 0, 0, 0, 0, time.UTC),
+			lunar: date.LunarDate{Year: 2026, Month: 1, Day: 1},
+		},
+		"mid-autumn 2024": {
+			solar: time.Date(2024, 9, 17, 0, 0, 0, 0, time.UTC),
+			lunar: date.LunarDate{Year: 2024, Month: 8, Day: 15},
+		},
+		"leap month": {
+			solar: time.Date(2025, 7, 25, 0, 0, 0, 0, time.UTC),
+			lunar: date.LunarDate{Year: 2025, Month: 6, Day: 1, Leap: true},
+		},
+		"month after the leap month": {
+			solar: time.Date(2023, 4, 20, 0, 0, 0, 0, time.UTC),
+			lunar: date.LunarDate{Year: 2023, Month: 3, Day: 1},
+		},
+		"12th month of the previous year": {
+			solar: time.Date(2025, 1, 28, 0, 0, 0, 0, time.UTC),
+			lunar: date.LunarDate{Year: 2024, Month: 12, Day: 29},
+		},
+		"vietnamese tet 1985 differs from the chinese new year": {
+			solar: time.Date(1985, 1, 21, 0, 0, 0, 0, time.UTC),
+			lunar: date.LunarDate{Year: 1985, Month: 1, Day: 1},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.lunar, date.ToLunar(tc.solar))
+
+			solar, ok := date.FromLunar(tc.lunar, time.UTC)
+			require.True(t, ok)
+			assert.Equal(t, tc.solar, solar)
+		})
+	}
+}
+
+func TestFromLunar_NotADate(t *testing.T) {
+	tests := map[string]date.LunarDate{
+		"no leap month that year":      {Year: 2024, Month: 6, Day: 1, Leap: true},
+		"not the leap month":           {Year: 2025, Month: 5, Day: 1, Leap: true},
+		"30th of a month with 29 days": {Year: 2024, Month: 12, Day: 30},
+		"month 13":                     {Year: 2024, Month: 13, Day: 1},
+	}
+
+	for name, d := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, ok := date.FromLunar(d, time.UTC)
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestLunarMonthOf(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Ho_Chi_Minh")
+	require.NoError(t, err)
+
+	m := date.LunarMonthOf(time.Date(2025, 7, 1, 20, 0, 0, 0, loc))
+	assert.Equal(t, 2025, m.Year)
+	assert.Equal(t, 6, m.Month)
+	assert.False(t, m.Leap)
+	assert.Equal(t, time.Date(2025, 6, 25, 0, 0, 0, 0, time.UTC), m.Start)
+	assert.Equal(t, 30, m.Days)
+
+	m = m.Next()
+	assert.Equal(t, 6, m.Month)
+	assert.True(t, m.Leap)
+	assert.Equal(t, time.Date(2025, 7, 25, 0, 0, 0, 0, time.UTC), m.Start)
+
+	m = m.Next()
+	assert.Equal(t, 7, m.Month)
+	assert.False(t, m.Leap)
+}
@@ -4,13 +4,15 @@ import (
 	"fmt"
 	"strconv"
 
+	"github.com/husol/telegram-reminder-bot/pkg/allowlist"
 	"github.com/husol/telegram-reminder-bot/pkg/chatpreference"
 	"github.com/husol/telegram-reminder-bot/pkg/reminder"
+	"github.com/husol/telegram-reminder-bot/pkg/userpreference"
 	"go.etcd.io/bbolt"
 )
 
-// SetupDB creates a root reminders bucket
-// Buckets are then created in the root bucket for each chat
+// SetupDB creates a root reminders bucket and a root occurrences bucket
+// Buckets are then created in the root buckets for each chat, and the reminders of the chats are indexed
 func SetupDB(filename string, chats []int) (*bbolt.DB, error) {
 	db, err := bbolt.Open(filename, 0600, nil)
 	if err != nil {
@@ -18,24 +20,45 @@ func SetupDB(filename string, chats []int) (*bbolt.DB, error) {
 	}
 
 	updateErr := db.Update(func(tx *bbolt.Tx) error {
-		rootReminderBucket, err := tx.CreateBucketIfNotExists(reminder.RemindersBucket)
+		_, err := tx.CreateBucketIfNotExists(reminder.RemindersBucket)
 		if err != nil {
 			return fmt.Errorf("could not create reminders bucket: %#v", err)
 		}
 
+		_, err = tx.CreateBucketIfNotExists(reminder.OccurrencesBucket)
+		if err != nil {
+			return fmt.Errorf("could not create occurrences bucket: %#v", err)
+		}
+
 		// create individual buckets for chats
 		for i := range chats {
-			_, err = rootReminderBucket.CreateBucketIfNotExists(itob(chats[i]))
+			err = createChatBuckets(tx, chats[i])
 			if err != nil {
-				return fmt.Errorf("could not create reminders bucket for chat: %d %#v", chats[i], err)
+				return err
 			}
 		}
 
+		// the index is built again so that it covers reminders stored before it was kept
+		err = reminder.IndexReminders(tx)
+		if err != nil {
+			return fmt.Errorf("could not index reminders: %#v", err)
+		}
+
 		_, err = tx.CreateBucketIfNotExists(chatpreference.ChatPreferencesBucket)
 		if err != nil {
 			return fmt.Errorf("could not create chat preferences bucket: %#v", err)
 		}
 
+		_, err = tx.CreateBucketIfNotExists(allowlist.AllowedChatsBucket)
+		if err != nil {
+			return fmt.Errorf("could not create allowed chats bucket: %#v", err)
+		}
+
+		_, err = tx.CreateBucketIfNotExists(userpreference.UserPreferencesBucket)
+		if err != nil {
+			return fmt.Errorf("could not create user preferences bucket: %#v", err)
+		}
+
 		return nil
 	})
 	if updateErr != nil {
@@ -45,6 +68,27 @@ func SetupDB(filename string, chats []int) (*bbolt.DB, error) {
 	return db, nil
 }
 
+// SetUpChat creates the buckets of a chat which was approved while the bot is running
+func SetUpChat(db *bbolt.DB, chatID int) error {
+	return db.Update(func(tx *bbolt.Tx) error {
+		return createChatBuckets(tx, chatID)
+	})
+}
+
+func createChatBuckets(tx *bbolt.Tx, chatID int) error {
+	_, err := tx.Bucket(reminder.RemindersBucket).CreateBucketIfNotExists(itob(chatID))
+	if err != nil {
+		return fmt.Errorf("could not create reminders bucket for chat: %d %#v", chatID, err)
+	}
+
+	_, err = tx.Bucket(reminder.OccurrencesBucket).CreateBucketIfNotExists(itob(chatID))
+	if err != nil {
+		return fmt.Errorf("could not create occurrences bucket for chat: %d %#v", chatID, err)
+	}
+
+	return nil
+}
+
 // itob converts int to []byte
 func itob(v int) []byte {
 	return []byte(strconv.FormatInt(int64(v), 10))
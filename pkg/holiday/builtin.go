@@ -0,0 +1,41 @@
+package holiday
+
+import (
+	"embed"
+	"errors"
+	"path"
+	"sort"
+	"strings"
+)
+
+//go:embed calendars/*.ics
+var builtinCalendars embed.FS
+
+var ErrUnknownCalendar = errors.New("error: unknown holiday calendar")
+
+// Builtin returns one of the calendars shipped with the bot by its country code e.g. "vn"
+func Builtin(code string) (*Calendar, error) {
+	file, err := builtinCalendars.Open(path.Join("calendars", strings.ToLower(code)+".ics"))
+	if err != nil {
+		return nil, ErrUnknownCalendar
+	}
+	defer file.Close()
+
+	return ParseICS(file, strings.ToUpper(code))
+}
+
+// BuiltinCodes returns the country codes of the calendars shipped with the bot
+func BuiltinCodes() []string {
+	entries, err := builtinCalendars.ReadDir("calendars")
+	if err != nil {
+		return nil
+	}
+
+	codes := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		codes = append(codes, strings.TrimSuffix(entry.Name(), ".ics"))
+	}
+	sort.Strings(codes)
+
+	return codes
+}
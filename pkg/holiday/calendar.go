@@ -0,0 +1,71 @@
+package holiday
+
+import (
+	"time"
+)
+
+const (
+	dateLayout   = "2006-01-02"
+	yearlyLayout = "01-02"
+)
+
+// Calendar is a set of public holidays which reminders can skip or move away from
+type Calendar struct {
+	Name   string            `json:"name"`
+	Dates  map[string]string `json:"dates"`  // names of holidays by date e.g. "2020-01-25"
+	Yearly map[string]string `json:"yearly"` // names of holidays falling on the same date every year e.g. "09-02"
+}
+
+func NewCalendar(name string) *Calendar {
+	return &Calendar{
+		Name:   name,
+		Dates:  map[string]string{},
+		Yearly: map[string]string{},
+	}
+}
+
+// Add adds a holiday on the date of t
+func (c *Calendar) Add(t time.Time, name string) {
+	c.Dates[t.Format(dateLayout)] = name
+}
+
+// AddYearly adds a holiday on the date of t every year
+func (c *Calendar) AddYearly(t time.Time, name string) {
+	c.Yearly[t.Format(yearlyLayout)] = name
+}
+
+// Len returns the number of holidays in the calendar, counting yearly ones once
+func (c *Calendar) Len() int {
+	if c == nil {
+		return 0
+	}
+
+	return len(c.Dates) + len(c.Yearly)
+}
+
+// HolidayOn returns the name of the holiday on the date of t, in the location of t.
+// A nil calendar has no holidays
+func (c *Calendar) HolidayOn(t time.Time) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+
+	if name, ok := c.Dates[t.Format(dateLayout)]; ok {
+		return name, true
+	}
+
+	name, ok := c.Yearly[t.Format(yearlyLayout)]
+
+	return name, ok
+}
+
+// IsBusinessDay reports whether the date of t is a weekday which is not a holiday
+func (c *Calendar) IsBusinessDay(t time.Time) bool {
+	if t.Weekday() == time.Saturday || t.Weekday() == time.Sunday {
+		return false
+	}
+
+	_, isHoliday := c.HolidayOn(t)
+
+	return !isHoliday
+}
@@ -0,0 +1,61 @@
+package holiday_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/husol/telegram-reminder-bot/pkg/holiday"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalendar_HolidayOn(t *testing.T) {
+	calendar := holiday.NewCalendar("Test")
+	calendar.Add(time.Date(2020, 1, 25, 0, 0, 0, 0, time.UTC), "Lunar New Year")
+	calendar.AddYearly(time.Date(2020, 9, 2, 0, 0, 0, 0, time.UTC), "National Day")
+
+	testCases := map[string]struct {
+		Time            time.Time
+		ExpectedHoliday string
+		ExpectedOK      bool
+	}{
+		"holiday on a date": {
+			Time:            time.Date(2020, 1, 25, 9, 0, 0, 0, time.UTC),
+			ExpectedHoliday: "Lunar New Year",
+			ExpectedOK:      true,
+		},
+		"holiday on a date in another year": {
+			Time:       time.Date(2021, 1, 25, 9, 0, 0, 0, time.UTC),
+			ExpectedOK: false,
+		},
+		"yearly holiday": {
+			Time:            time.Date(2025, 9, 2, 23, 59, 0, 0, time.UTC),
+			ExpectedHoliday: "National Day",
+			ExpectedOK:      true,
+		},
+		"not a holiday": {
+			Time:       time.Date(2020, 9, 3, 0, 0, 0, 0, time.UTC),
+			ExpectedOK: false,
+		},
+	}
+
+	for name := range testCases {
+		t.Run(name, func(t *testing.T) {
+			holidayName, ok := calendar.HolidayOn(testCases[name].Time)
+			assert.Equal(t, testCases[name].ExpectedHoliday, holidayName)
+			assert.Equal(t, testCases[name].ExpectedOK, ok)
+		})
+	}
+}
+
+func TestCalendar_IsBusinessDay(t *testing.T) {
+	calendar := holiday.NewCalendar("Test")
+	calendar.Add(time.Date(2020, 4, 30, 0, 0, 0, 0, time.UTC), "Reunification Day")
+
+	assert.True(t, calendar.IsBusinessDay(time.Date(2020, 4, 29, 9, 0, 0, 0, time.UTC)))
+	assert.False(t, calendar.IsBusinessDay(time.Date(2020, 4, 30, 9, 0, 0, 0, time.UTC)))
+	assert.False(t, calendar.IsBusinessDay(time.Date(2020, 5, 2, 9, 0, 0, 0, time.UTC)))
+
+	var noCalendar *holiday.Calendar
+	assert.True(t, noCalendar.IsBusinessDay(time.Date(2020, 4, 30, 9, 0, 0, 0, time.UTC)))
+	assert.False(t, noCalendar.IsBusinessDay(time.Date(2020, 5, 3, 9, 0, 0, 0, time.UTC)))
+}
@@ -0,0 +1,154 @@
+package holiday
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
+)
+
+// maxEventDays limits how many days a single event of an .ics file can cover
+const maxEventDays = 31
+
+var ErrNoHolidays = i18n.Errorf(i18n.ErrNoHolidays)
+
+type icsEvent struct {
+	start   time.Time
+	end     time.Time
+	summary string
+	yearly  bool
+}
+
+// ParseICS reads the all-day events of an iCalendar (.ics) file as holidays.
+// Events lasting several days make each of their days a holiday and
+// events repeating yearly are added as yearly holidays. Other repeat rules are not followed.
+// The calendar is named after its X-WR-CALNAME property, or defaultName if it has none
+func ParseICS(r io.Reader, defaultName string) (*Calendar, error) {
+	lines, err := unfoldLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	calendar := NewCalendar(defaultName)
+	var event *icsEvent
+
+	for _, line := range lines {
+		name, value := splitProperty(line)
+
+		switch {
+		case name == "X-WR-CALNAME" && value != "":
+			calendar.Name = unescapeText(value)
+
+		case name == "BEGIN" && value == "VEVENT":
+			event = &icsEvent{}
+
+		case name == "END" && value == "VEVENT":
+			if event != nil {
+				if err := addEvent(calendar, event); err != nil {
+					return nil, err
+				}
+			}
+			event = nil
+
+		case event == nil:
+			// other properties of the calendar are not needed
+
+		case name == "DTSTART":
+			event.start, err = parseDate(value)
+			if err != nil {
+				return nil, err
+			}
+
+		case name == "DTEND":
+			event.end, err = parseDate(value)
+			if err != nil {
+				return nil, err
+			}
+
+		case name == "SUMMARY":
+			event.summary = unescapeText(value)
+
+		case name == "RRULE":
+			event.yearly = strings.Contains(value, "FREQ=YEARLY")
+		}
+	}
+
+	if calendar.Len() == 0 {
+		return nil, ErrNoHolidays
+	}
+
+	return calendar, nil
+}
+
+func addEvent(calendar *Calendar, event *icsEvent) error {
+	if event.start.IsZero() {
+		return i18n.Errorf(i18n.ErrHolidayNoStart, event.summary)
+	}
+
+	// the end date of an all-day event is exclusive
+	end := event.end
+	if !end.After(event.start) {
+		end = event.start.AddDate(0, 0, 1)
+	}
+
+	for day, i := event.start, 0; day.Before(end) && i < maxEventDays; day, i = day.AddDate(0, 0, 1), i+1 {
+		if event.yearly {
+			calendar.AddYearly(day, event.summary)
+		} else {
+			calendar.Add(day, event.summary)
+		}
+	}
+
+	return nil
+}
+
+// unfoldLines joins the lines of an .ics file which were folded onto several by starting them with whitespace
+func unfoldLines(r io.Reader) ([]string, error) {
+	var lines []string
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+
+		lines = append(lines, line)
+	}
+
+	return lines, scanner.Err()
+}
+
+// splitProperty returns the name of a content line without its parameters, and its value
+// e.g. "DTSTART;VALUE=DATE:20200101" gives "DTSTART" and "20200101"
+func splitProperty(line string) (string, string) {
+	parts := strings.SplitN(line, ":", 2)
+	if len(parts) != 2 {
+		return "", ""
+	}
+
+	name := strings.SplitN(parts[0], ";", 2)[0]
+
+	return strings.ToUpper(strings.TrimSpace(name)), strings.TrimSpace(parts[1])
+}
+
+// parseDate parses the date of a DATE or DATE-TIME value e.g. "20200101" or "20200101T090000Z"
+func parseDate(value string) (time.Time, error) {
+	if len(value) < len("20060102") {
+		return time.Time{}, i18n.Errorf(i18n.ErrCalendarDate, value)
+	}
+
+	t, err := time.Parse("20060102", value[:len("20060102")])
+	if err != nil {
+		return time.Time{}, i18n.Errorf(i18n.ErrCalendarDate, value)
+	}
+
+	return t, nil
+}
+
+func unescapeText(value string) string {
+	return strings.NewReplacer(`\,`, ",", `\;`, ";", `\n`, " ", `\N`, " ", `\\`, `\`).Replace(value)
+}
@@ -0,0 +1,70 @@
+package holiday_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/husol/telegram-reminder-bot/pkg/holiday"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testICS = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"X-WR-CALNAME:Office\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART;VALUE=DATE:20200124\r\n" +
+	"DTEND;VALUE=DATE:20200127\r\n" +
+	"SUMMARY:Lunar New\r\n" +
+	"  Year\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART:20200501T000000Z\r\n" +
+	"RRULE:FREQ=YEARLY\r\n" +
+	"SUMMARY:Workers\\, Day\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestParseICS(t *testing.T) {
+	calendar, err := holiday.ParseICS(strings.NewReader(testICS), "holidays")
+	require.NoError(t, err)
+
+	assert.Equal(t, "Office", calendar.Name)
+	assert.Equal(t, map[string]string{
+		"2020-01-24": "Lunar New Year",
+		"2020-01-25": "Lunar New Year",
+		"2020-01-26": "Lunar New Year",
+	}, calendar.Dates)
+	assert.Equal(t, map[string]string{"05-01": "Workers, Day"}, calendar.Yearly)
+}
+
+func TestParseICS_Errors(t *testing.T) {
+	testCases := map[string]string{
+		"no holidays":  "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n",
+		"no start":     "BEGIN:VEVENT\r\nSUMMARY:Holiday\r\nEND:VEVENT\r\n",
+		"invalid date": "BEGIN:VEVENT\r\nDTSTART:2020-01-01\r\nEND:VEVENT\r\n",
+	}
+
+	for name := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := holiday.ParseICS(strings.NewReader(testCases[name]), "holidays")
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestBuiltin(t *testing.T) {
+	assert.Equal(t, []string{"us", "vn"}, holiday.BuiltinCodes())
+
+	calendar, err := holiday.Builtin("VN")
+	require.NoError(t, err)
+	assert.Equal(t, "Vietnam", calendar.Name)
+
+	name, ok := calendar.HolidayOn(time.Date(2021, 9, 2, 0, 0, 0, 0, time.UTC))
+	assert.True(t, ok)
+	assert.Equal(t, "National Day", name)
+
+	_, err = holiday.Builtin("xx")
+	require.Equal(t, holiday.ErrUnknownCalendar, err)
+}
@@ -0,0 +1,319 @@
+package i18n
+
+// nolint:gochecknoglobals,lll
+var english = map[Key]string{
+	LanguageName: "English",
+	LanguageSet:  "The bot will reply in %s",
+	Help: `
+*Available commands*
+
+_list reminders_
+/remindlist
+/remindlist #ops today
+/remindlist paused
+
+_get details of a reminder_
+[/r_ID]
+
+_delete a reminder_
+[/reminddelete_ID]
+
+_edit a reminder keeping its ID_
+/remindedit ID tomorrow at 9:00 Update your report
+/remindedit ID message Update your weekly report
+
+_pause and resume a reminder_
+[/remindpause_ID]
+[/remindresume_ID]
+
+_skip the next times a recurring reminder is due_
+[/remindskip_ID]
+/remindskip ID 3
+
+_send a reminder again until it is marked as done_
+/remindnag ID every 10 minutes
+/remindnag ID every 10 minutes up to 5 times
+/remindnag ID off
+
+_set a reminder_
+/remind me on the 1st of december Update your report
+/remind me on the 1st of december at 8:23 Update your report
+/remind me on March 14th 2027 at 10:00 Update your report
+/remind me on 2027-03-14 at 10:00 Update your report
+/remind me on 14/03/2027 at 10:00 Update your report
+/remind me tonight/this evening/tomorrow/tomorrow morning Update your report
+/remind me today/tomorrow at 21:00 Update your report
+/remind me on Tuesday at 22:00 Update your report
+/remind me next Friday afternoon Update your report
+/remind me on the 3rd at noon Update your report
+/remind me at 21:00 Update your report
+/remind me in 5 days, 3 hours, 4 minutes Update your report
+/remind me in 5 hours Update your report
+/remind me in 3 hours, 4 minutes Update your report
+/remind me in 4 minutes Update your report
+/remind me in 1 hour and 30 minutes Update your report
+/remind me in 2 weeks at 9am Update your report
+
+_set a recurring reminder_
+/remind me every 1st of december Update yearly report
+/remind me every 1st of december at 8:23 Update yearly report
+/remind me every 1st of the month Update monthly report
+/remind me every 1st of the month at 8:23 Update monthly report
+/remind me every Tuesday at 22:00 Update weekly report
+/remind me every first Monday of the month at 10:00 Sprint review
+/remind me every last Friday at 17:00 Team drinks
+/remind me every last day of the month Send invoices
+/remind me every day at 8pm Update daily report
+/remind me every business day at 9:00 Standup
+/remind me every 5 days, 3 hours, 4 minutes Update your report
+/remind me every 3 hours, 4 minutes Update your report
+/remind me every 2 minutes Update your report
+/remind me every 2 weeks on Monday at 9:00 Sprint planning
+/remind me every 3 months Test the smoke alarm
+
+_set a reminder on the lunar calendar_
+/remind me every lunar 15th of the month Offer incense
+/remind me every lunar 10th of month 3 Death anniversary
+
+_remind someone of a group, the whole group, or yourself by private message_
+/remind @alice tomorrow at 9:00 Update your report
+/remind here every Monday at 9:00 Team meeting
+/remind me privately tomorrow morning Update your report
+
+_reply to a message to be reminded about it_
+/remind me tomorrow at 9
+
+_send a photo, document or voice message with the reminder as its caption, or reply to a location_
+/remind me tomorrow at 9 Pay this invoice
+
+_tick the items of a checklist, one per line starting with -_
+/remind here every Friday at 16:00 Release checklist
+- tag the build
+- deploy
+
+_take turns to be reminded in a group_
+/remind roster @alice @brian @carol every Monday at 9:00 Take out the bins
+/remindroster ID skip
+/remindroster ID swap @brian
+
+_set a recurring reminder with a cron spec_
+/remind cron "\*/15 9-17 \* \* 1-5" Check the build queue
+
+_end a recurring reminder_
+/remind me every Tuesday at 9:00 until 31st of december Update weekly report
+/remind me every day at 8pm for 10 times Take your medicine
+
+_skip holidays or move to the next business day_
+/remind me every 1st of the month move to next business day Pay rent
+/remind me every Monday at 9:00 skip holidays Team meeting
+
+_set the holidays of the chat, or send an .ics file with the caption /setholidays_
+/setholidays vn
+/setholidays off
+
+_set timezone for chat reminders_
+/gettimezone
+/settimezone Asia/Ho_Chi_Minh
+
+_read the times you write in your own timezone, in every chat_
+/settimezone me Europe/London
+/settimezone me off
+
+_choose who in a group can edit, pause, skip, delete and finish reminders and set its timezone, admins only like the other settings_
+/setpermissions everyone
+/setpermissions creator
+/setpermissions admins
+
+_read dates written with numbers day or month first_
+/setdateorder dmy
+/setdateorder mdy
+
+_deliver reminders which were due while the bot was offline_
+/setlatereminders on
+/setlatereminders off
+
+_read the bot in English or Vietnamese_
+/setlanguage en
+/setlanguage vi
+
+_set a reminder in Vietnamese_
+/nhac toi ngay mai luc 8 gio Hop nhom
+`,
+
+	NotUnderstood: "could not understand '%s'",
+	MissingWhat:   "error: the reminder message is missing",
+	NagTooOften:   "error: reminders can be sent again at most every minute",
+	NoCalendar:    "error: there is no holiday calendar for '%s', try one of %s or send an .ics file with the caption %s",
+	NotICSFile:    "error: %s is not an .ics calendar file",
+	NotAllowed:    "error: you are not allowed to do that in this chat, see /setpermissions",
+	AdminsOnly:    "error: only the admins of this chat can do that",
+	UnknownFilter: "error: %s is not a filter of the list, use a #tag, active, paused, completed or today",
+	TagTooLong:    "error: #%s is too long to filter the list by",
+
+	ErrUnknownButton:   "error: this button can no longer be used",
+	ErrNotOnChecklist:  "error: the item is not on the checklist",
+	ErrCronFields:      "error: '%s' is not a cron spec with 5 fields: minute hour day-of-month month day-of-week",
+	ErrCronInvalid:     "error: '%s' is not a valid cron spec: %s",
+	ErrCronNeverRuns:   "error: '%s' never runs",
+	ErrCronTooOften:    "error: '%s' runs %s apart, reminders can be sent at most every %s",
+	ErrNotInRoster:     "error: %s is not in the roster of reminder %d",
+	ErrNoRoster:        "error: reminder %d has no roster",
+	ErrInvalidDate:     "error: day %d of month %d is not a valid date",
+	ErrDaysInMonth:     "error: month %d of %d does not have %d days",
+	ErrRepeatWeekly:    "error: reminder must repeat at least every week",
+	ErrLunarDay:        "error: %d is not a day of a lunar month",
+	ErrLunarMonth:      "error: %d is not a lunar month",
+	ErrEndsBeforeStart: "error: reminder would end before it is first sent",
+	ErrNotActive:       "error: reminder %d is not active",
+	ErrNotPaused:       "error: reminder %d is not paused",
+	ErrResumePassed:    "error: reminder %d can't be resumed as its time has passed",
+	ErrResumeEnded:     "error: reminder %d can't be resumed as it has ended",
+	ErrNotRecurring:    "error: reminder %d is not recurring",
+	ErrNoRunsToSkip:    "error: reminder %d has no more runs to skip",
+	ErrTooSoon:         "error: time must be at least 3 minutes in the future",
+	ErrNotInChat:       "error: reminder %d is not a reminder of this chat",
+	ErrMemberTimezone:  "error: the timezone of a member can only be set by the member",
+	ErrNoHolidays:      "error: no holidays found in the calendar",
+	ErrHolidayNoStart:  "error: holiday '%s' has no start date",
+	ErrCalendarDate:    "error: invalid date '%s' in calendar",
+
+	ReminderAdded:          "Reminder \"%s\" has been added for %s",
+	ReminderUpdated:        "Reminder \"%s\" has been updated for %s",
+	ReminderMessageUpdated: "Reminder %d message has been updated to \"%s\"",
+	ReminderResumed:        "Reminder %d has been resumed, next reminder on %s",
+	ReminderPaused:         "Reminder %d has been paused",
+	ReminderStaysPaused:    "It stays paused until it is resumed with /remindresume %d",
+	ReminderDeleted:        "Reminder %d has been deleted",
+	ReminderSkipped:        "Reminder %d will not be sent on\n%s",
+	ReminderSkippedNext:    "Reminder %d will not be sent on %s",
+	ReminderRescheduled:    "Reminder \"%s\" has been rescheduled for %s",
+	ReminderCompleted:      "Reminder \"%s\" has been completed",
+	ReminderDone:           "Reminder \"%s\" has been marked as done",
+	ReminderNagOn:          "Reminder %d will be sent again every %d minutes until it is marked as done, up to %d times",
+	ReminderNagOff:         "Reminder %d will no longer be sent again until it is marked as done",
+	ReminderNag:            "(reminder %d of %d, press ✅ Done to stop)",
+	ReminderLate:           "(late by %s)",
+	ReminderLateMissed:     "(missed %d times while offline, first one due %s ago)",
+	RepliedMessage:         "this message",
+	NextRuns:               "Next runs:",
+
+	RosterSkipped: "The turn of %[2]s on reminder %[1]d has been skipped, %[3]s is next",
+	RosterSwapped: "%s takes the next turn on reminder %d instead of %s",
+
+	RegisterRequest:          "*%s* (%d) asked to use the bot, requested by %s",
+	RegisterRequested:        "The owner of the bot has been asked to let this chat use it",
+	RegisterAlreadyApproved:  "This chat can already use the bot",
+	RegisterAlreadyRequested: "This chat is still waiting for the owner of the bot to approve it",
+	RegisterApproved:         "This chat can now use the bot, send /remindhelp to see what it can do",
+	RegisterDenied:           "The owner of the bot did not approve this chat",
+	RegisterApprovedOwner:    "✅ *%s* (%d) can now use the bot",
+	RegisterDeniedOwner:      "❌ *%s* (%d) was denied",
+	RegisterOwnerOnly:        "Only the owner of the bot can do this",
+
+	LunarDate:     "%d/%d/%d lunar",
+	LunarDateLeap: "%d/leap %d/%d lunar",
+
+	AckOnIt: "%s is on it",
+	AckDone: "%s is done",
+
+	ChecklistDone: "All done ✅",
+
+	NoReminders:               "You have no reminders.",
+	NoRemindersMatching:       "No reminders match %s.",
+	CompletedRemindersRemoved: "Completed reminders have been removed",
+	ListPage:                  "Page %d of %d",
+
+	StatusActive:    "Active",
+	StatusInactive:  "Inactive",
+	StatusCompleted: "Completed",
+
+	SkipHolidays:          "Skip holidays",
+	MoveToNextBusinessDay: "Move to next business day",
+
+	AttachmentPhoto:    "photo",
+	AttachmentDocument: "document",
+	AttachmentVoice:    "voice message",
+	AttachmentLocation: "location",
+
+	DetailID:             "Id",
+	DetailStatus:         "Status",
+	DetailMessage:        "Message",
+	DetailCommand:        "Command",
+	DetailFor:            "For",
+	DetailForPrivately:   "%s, by private message",
+	DetailUntilDone:      "Until Done",
+	DetailUntilDoneValue: "every %d minutes, up to %d times",
+	DetailRemaining:      "Remaining",
+	DetailRemainingValue: "%d times",
+	DetailEnds:           "Ends",
+	DetailHolidays:       "Holidays",
+	DetailMoved:          "Moved",
+	DetailMovedValue:     "%s to %s as it is %s",
+	DetailSkipped:        "Skipped",
+	DetailSkippedValue:   "%s as it is %s",
+	DetailWeekend:        "a weekend",
+	DetailWillSkip:       "Will skip",
+	DetailNextSchedule:   "Next Schedule",
+	DetailLunar:          "Lunar Date",
+	DetailLunarMonthly:   "day %d of every lunar month",
+	DetailLunarYearly:    "day %d of lunar month %d every year",
+	DetailCompletedAt:    "Completed At",
+	DetailSent:           "Sent",
+	DetailNoAcks:         "no one acknowledged it",
+	DetailTicked:         "%d of %d items ticked",
+	DetailRoster:         "Roster",
+	DetailAttachment:     "Attachment",
+
+	TimezoneIs:      "Your timezone is: %s",
+	TimezoneUpdated: "Timezone has been updated to: %s",
+
+	UserTimezoneUpdated: "Your timezone has been updated to: %s. Times you write in reminders will be read in it",
+	UserTimezoneOff:     "Times you write in reminders will be read in the timezone of the chat",
+
+	LateRemindersOn:  "Late reminders have been turned on",
+	LateRemindersOff: "Late reminders have been turned off",
+
+	DateOrderSet:      "Dates written with numbers will be read as %s",
+	DateOrderDayMonth: "day/month/year",
+	DateOrderMonthDay: "month/day/year",
+
+	HolidaysOff: "Holidays have been turned off",
+	HolidaysSet: "Holidays have been set to %s (%d holidays). Reminders set to skip holidays or move to the next business day will follow them",
+
+	PermissionsSet:      "Reminders can now be edited, paused, skipped, deleted and finished by %s",
+	PermissionsEveryone: "everyone",
+	PermissionsCreator:  "the member who set them and the admins",
+	PermissionsAdmins:   "the admins",
+
+	ButtonSnooze10Minutes:          "⏰ 10m",
+	ButtonSnooze20Minutes:          "⏰ 20m",
+	ButtonSnooze30Minutes:          "⏰ 30m",
+	ButtonSnooze1Hour:              "⏰ 1h",
+	ButtonSnoozeThisAfternoon:      "⏰ This Afternoon",
+	ButtonSnoozeThisEvening:        "⏰ This Evening",
+	ButtonSnoozeTomorrowMorning:    "⏰ Tomorrow Morning",
+	ButtonSnoozeTomorrowAfternoon:  "⏰ Tomorrow Afternoon",
+	ButtonSnoozeTomorrowEvening:    "⏰ Tomorrow Evening",
+	ButtonSnooze:                   "⏰ Snooze",
+	ButtonClose:                    "❌ Close",
+	ButtonFinishSchedule:           "✅ Finish Schedule",
+	ButtonDone:                     "✅ Done",
+	ButtonSkipNext:                 "⏭ Skip next",
+	ButtonOnIt:                     "👋 I'm on it",
+	ButtonDeleteReminder:           "🗑 Delete Reminder",
+	ButtonShowReminderCommand:      "📄 Show Reminder Command",
+	ButtonCloseDetails:             "❌ Close Details",
+	ButtonPauseReminder:            "⏸ Pause Reminder",
+	ButtonResumeReminder:           "▶️ Resume Reminder",
+	ButtonEditReminder:             "✏️ Edit Reminder",
+	ButtonRemoveCompletedReminders: "🗑 Remove completed reminders",
+	ButtonCloseList:                "❌ Close list",
+	ButtonPreviousPage:             "◀",
+	ButtonNextPage:                 "▶",
+	ButtonListDetail:               "ℹ️ %d",
+	ButtonListPause:                "⏸ Pause",
+	ButtonListResume:               "▶️ Resume",
+	ButtonListDelete:               "🗑 Delete",
+	ButtonApproveChat:              "✅ Approve",
+	ButtonDenyChat:                 "❌ Deny",
+}
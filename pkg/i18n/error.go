@@ -0,0 +1,37 @@
+package i18n
+
+import (
+	"errors"
+)
+
+// Error is an error the bot replies with, whose message is read from the catalog
+// in the language of the chat it is shown in
+type Error struct {
+	Key  Key
+	Args []interface{}
+}
+
+// Errorf returns an Error with the message of key formatted with args
+func Errorf(key Key, args ...interface{}) error {
+	return &Error{Key: key, Args: args}
+}
+
+// Error is the message of the error in English, which is what is logged
+func (e *Error) Error() string {
+	return e.In(English)
+}
+
+// In is the message of the error in the language
+func (e *Error) In(l Language) string {
+	return T(l, e.Key, e.Args...)
+}
+
+// Translate returns the message of the Error err is or wraps in the language, and err itself when it has none
+func Translate(l Language, err error) error {
+	var e *Error
+	if errors.As(err, &e) {
+		return errors.New(e.In(l))
+	}
+
+	return err
+}
@@ -0,0 +1,98 @@
+package i18n
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/husol/telegram-reminder-bot/pkg/date"
+)
+
+// Layout is how much of a time is shown e.g. DateTimeZone is the date, the time of the day and the timezone
+type Layout int
+
+const (
+	DateTimeZone Layout = iota
+	DateTime
+	Date
+	DayMonth
+	Day
+)
+
+// nolint:gochecknoglobals
+var layouts = map[Language][]string{
+	English: {
+		DateTimeZone: "Mon, 02 Jan 2006 15:04 MST",
+		DateTime:     "Mon, 02 Jan 2006 15:04",
+		Date:         "Mon, 02 Jan 2006",
+		DayMonth:     "Mon, 02 Jan",
+		Day:          "2 Jan 2006",
+	},
+	Vietnamese: {
+		DateTimeZone: "Mon, 02/01/2006 15:04 MST",
+		DateTime:     "Mon, 02/01/2006 15:04",
+		Date:         "Mon, 02/01/2006",
+		DayMonth:     "Mon, 02/01",
+		Day:          "02/01/2006",
+	},
+}
+
+// viWeekdays are the names of the days of the week in Vietnamese, which time.Format does not know
+// nolint:gochecknoglobals
+var viWeekdays = [...]string{"Chủ Nhật", "Thứ Hai", "Thứ Ba", "Thứ Tư", "Thứ Năm", "Thứ Sáu", "Thứ Bảy"}
+
+// FormatTime formats t the way it is written in the language
+func FormatTime(l Language, layout Layout, t time.Time) string {
+	languageLayouts, ok := layouts[l]
+	if !ok {
+		languageLayouts = layouts[English]
+	}
+
+	format := languageLayouts[layout]
+	if l == Vietnamese {
+		format = strings.Replace(format, "Mon", viWeekdays[t.Weekday()], 1)
+	}
+
+	return t.Format(format)
+}
+
+// FormatLunarDate formats a date of the lunar calendar e.g. "15/8/2025 lunar"
+func FormatLunarDate(l Language, d date.LunarDate) string {
+	if d.Leap {
+		return T(l, LunarDateLeap, d.Day, d.Month, d.Year)
+	}
+
+	return T(l, LunarDate, d.Day, d.Month, d.Year)
+}
+
+// Funcs are the functions templates use to translate texts and format times in the language
+// e.g. {{t "detail.status"}}, {{format .NextSchedule "datetimezone"}} and {{lunar .NextLunarDate}}.
+// Templates pass fields as they are, so args which are pointers are formatted as what they point to
+func Funcs(l Language) map[string]interface{} {
+	return map[string]interface{}{
+		"t": func(key string, args ...interface{}) string {
+			for i := range args {
+				if v := reflect.ValueOf(args[i]); v.Kind() == reflect.Ptr && !v.IsNil() {
+					args[i] = v.Elem().Interface()
+				}
+			}
+
+			return T(l, Key(key), args...)
+		},
+		"format": func(t time.Time, layout string) string {
+			return FormatTime(l, templateLayouts[layout], t)
+		},
+		"lunar": func(d date.LunarDate) string {
+			return FormatLunarDate(l, d)
+		},
+	}
+}
+
+// nolint:gochecknoglobals
+var templateLayouts = map[string]Layout{
+	"datetimezone": DateTimeZone,
+	"datetime":     DateTime,
+	"date":         Date,
+	"daymonth":     DayMonth,
+	"day":          Day,
+}
@@ -0,0 +1,78 @@
+// Package i18n holds the translations of everything the bot replies with.
+// Each chat reads the bot in the language stored in its chat preference, English when it has not chosen one
+package i18n
+
+import (
+	"fmt"
+)
+
+// Language is the code of a language the bot speaks e.g. "en"
+type Language string
+
+const (
+	English    Language = "en"
+	Vietnamese Language = "vi"
+)
+
+// Languages looks up the language a chat reads the bot in
+type Languages interface {
+	ChatLanguage(chatID int) Language
+}
+
+// ChatLanguage makes a single language usable for every chat
+func (l Language) ChatLanguage(int) Language {
+	return l
+}
+
+// Name is the name of the language written in the language itself e.g. "Tiếng Việt"
+func (l Language) Name() string {
+	return T(l, LanguageName)
+}
+
+// ParseLanguage returns the language with the given code
+func ParseLanguage(code string) (Language, bool) {
+	l := Language(code)
+	if _, ok := catalog[l]; !ok {
+		return "", false
+	}
+
+	return l, true
+}
+
+// SupportedLanguages returns the codes of the languages the bot speaks
+func SupportedLanguages() []Language {
+	return []Language{English, Vietnamese}
+}
+
+// Missing returns the keys which are translated in English but not in the language
+func Missing(l Language) []Key {
+	var missing []Key
+	for key := range english {
+		if _, ok := catalog[l][key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+
+	return missing
+}
+
+// nolint:gochecknoglobals
+var catalog = map[Language]map[Key]string{
+	English:    english,
+	Vietnamese: vietnamese,
+}
+
+// T returns the translation of key in the language, formatted with args.
+// Languages which are not known and keys which are not translated fall back to English
+func T(l Language, key Key, args ...interface{}) string {
+	text, ok := catalog[l][key]
+	if !ok {
+		text = english[key]
+	}
+
+	if len(args) == 0 {
+		return text
+	}
+
+	return fmt.Sprintf(text, args...)
+}
@@ -0,0 +1,124 @@
+package i18n_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/husol/telegram-reminder-bot/pkg/date"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestT(t *testing.T) {
+	type test struct {
+		language i18n.Language
+		key      i18n.Key
+		args     []interface{}
+		expected string
+	}
+
+	tests := map[string]test{
+		"english": {
+			language: i18n.English,
+			key:      i18n.ReminderPaused,
+			args:     []interface{}{1},
+			expected: "Reminder 1 has been paused",
+		},
+		"vietnamese": {
+			language: i18n.Vietnamese,
+			key:      i18n.ReminderPaused,
+			args:     []interface{}{1},
+			expected: "Đã tạm dừng nhắc nhở 1",
+		},
+		"without args": {
+			language: i18n.Vietnamese,
+			key:      i18n.NoReminders,
+			expected: "Bạn chưa có nhắc nhở nào.",
+		},
+		"unknown language falls back to english": {
+			language: i18n.Language("fr"),
+			key:      i18n.NoReminders,
+			expected: "You have no reminders.",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, i18n.T(tc.language, tc.key, tc.args...))
+		})
+	}
+}
+
+func TestParseLanguage(t *testing.T) {
+	l, ok := i18n.ParseLanguage("vi")
+	require.True(t, ok)
+	assert.Equal(t, i18n.Vietnamese, l)
+	assert.Equal(t, "Tiếng Việt", l.Name())
+
+	_, ok = i18n.ParseLanguage("fr")
+	assert.False(t, ok)
+}
+
+func TestTranslate(t *testing.T) {
+	err := fmt.Errorf("pausing: %w", i18n.Errorf(i18n.ErrNotActive, 3))
+	assert.EqualError(t, err, "pausing: error: reminder 3 is not active")
+	assert.EqualError(t, i18n.Translate(i18n.Vietnamese, err), "lỗi: nhắc nhở 3 không hoạt động")
+
+	other := errors.New("error")
+	assert.Equal(t, other, i18n.Translate(i18n.Vietnamese, other))
+}
+
+func TestFormatTime(t *testing.T) {
+	type test struct {
+		language i18n.Language
+		layout   i18n.Layout
+		expected string
+	}
+
+	loc, err := time.LoadLocation("Asia/Ho_Chi_Minh")
+	require.NoError(t, err)
+	sunday := time.Date(2027, 3, 14, 8, 5, 0, 0, loc)
+
+	tests := map[string]test{
+		"english date time zone": {
+			language: i18n.English,
+			layout:   i18n.DateTimeZone,
+			expected: "Sun, 14 Mar 2027 08:05 +07",
+		},
+		"english day": {
+			language: i18n.English,
+			layout:   i18n.Day,
+			expected: "14 Mar 2027",
+		},
+		"vietnamese date time zone": {
+			language: i18n.Vietnamese,
+			layout:   i18n.DateTimeZone,
+			expected: "Chủ Nhật, 14/03/2027 08:05 +07",
+		},
+		"vietnamese day and month": {
+			language: i18n.Vietnamese,
+			layout:   i18n.DayMonth,
+			expected: "Chủ Nhật, 14/03",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, i18n.FormatTime(tc.language, tc.layout, sunday))
+		})
+	}
+}
+
+func TestFormatLunarDate(t *testing.T) {
+	assert.Equal(t, "15/8/2025 lunar", i18n.FormatLunarDate(i18n.English, date.LunarDate{Year: 2025, Month: 8, Day: 15}))
+	assert.Equal(t, "1/6 nhuận/2025 âm lịch", i18n.FormatLunarDate(i18n.Vietnamese, date.LunarDate{Year: 2025, Month: 6, Day: 1, Leap: true}))
+}
+
+func TestMissing(t *testing.T) {
+	for _, l := range i18n.SupportedLanguages() {
+		assert.Empty(t, i18n.Missing(l), "%s is missing translations", l)
+	}
+}
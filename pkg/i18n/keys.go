@@ -0,0 +1,188 @@
+package i18n
+
+// Key identifies a text in the catalog. Templates look texts up by the value of the key e.g. {{t "detail.status"}}
+type Key string
+
+const (
+	LanguageName Key = "language.name"
+	LanguageSet  Key = "language.set"
+	Help         Key = "help"
+
+	NotUnderstood Key = "error.not_understood"
+	MissingWhat   Key = "error.missing_what"
+	NagTooOften   Key = "error.nag_too_often"
+	NoCalendar    Key = "error.no_calendar"
+	NotICSFile    Key = "error.not_ics_file"
+	NotAllowed    Key = "error.not_allowed"
+	AdminsOnly    Key = "error.admins_only"
+	UnknownFilter Key = "error.unknown_filter"
+	TagTooLong    Key = "error.tag_too_long"
+
+	// errors of the reminders and the services behind the commands
+	ErrUnknownButton   Key = "error.unknown_button"
+	ErrNotOnChecklist  Key = "error.not_on_checklist"
+	ErrCronFields      Key = "error.cron_fields"
+	ErrCronInvalid     Key = "error.cron_invalid"
+	ErrCronNeverRuns   Key = "error.cron_never_runs"
+	ErrCronTooOften    Key = "error.cron_too_often"
+	ErrNotInRoster     Key = "error.not_in_roster"
+	ErrNoRoster        Key = "error.no_roster"
+	ErrInvalidDate     Key = "error.invalid_date"
+	ErrDaysInMonth     Key = "error.days_in_month"
+	ErrRepeatWeekly    Key = "error.repeat_weekly"
+	ErrLunarDay        Key = "error.lunar_day"
+	ErrLunarMonth      Key = "error.lunar_month"
+	ErrEndsBeforeStart Key = "error.ends_before_start"
+	ErrNotActive       Key = "error.not_active"
+	ErrNotPaused       Key = "error.not_paused"
+	ErrResumePassed    Key = "error.resume_passed"
+	ErrResumeEnded     Key = "error.resume_ended"
+	ErrNotRecurring    Key = "error.not_recurring"
+	ErrNoRunsToSkip    Key = "error.no_runs_to_skip"
+	ErrTooSoon         Key = "error.too_soon"
+	ErrNotInChat       Key = "error.not_in_chat"
+	ErrMemberTimezone  Key = "error.member_timezone"
+	ErrNoHolidays      Key = "error.no_holidays"
+	ErrHolidayNoStart  Key = "error.holiday_no_start"
+	ErrCalendarDate    Key = "error.calendar_date"
+
+	ReminderAdded          Key = "reminder.added"
+	ReminderUpdated        Key = "reminder.updated"
+	ReminderMessageUpdated Key = "reminder.message_updated"
+	ReminderResumed        Key = "reminder.resumed"
+	ReminderPaused         Key = "reminder.paused"
+	ReminderStaysPaused    Key = "reminder.stays_paused"
+	ReminderDeleted        Key = "reminder.deleted"
+	ReminderSkipped        Key = "reminder.skipped"
+	ReminderSkippedNext    Key = "reminder.skipped_next"
+	ReminderRescheduled    Key = "reminder.rescheduled"
+	ReminderCompleted      Key = "reminder.completed"
+	ReminderDone           Key = "reminder.done"
+	ReminderNagOn          Key = "reminder.nag_on"
+	ReminderNagOff         Key = "reminder.nag_off"
+	ReminderNag            Key = "reminder.nag"
+	ReminderLate           Key = "reminder.late"
+	ReminderLateMissed     Key = "reminder.late_missed"
+	RepliedMessage         Key = "reminder.replied_message"
+	NextRuns               Key = "reminder.next_runs"
+
+	RosterSkipped Key = "roster.skipped"
+	RosterSwapped Key = "roster.swapped"
+
+	RegisterRequest          Key = "register.request"
+	RegisterRequested        Key = "register.requested"
+	RegisterAlreadyApproved  Key = "register.already_approved"
+	RegisterAlreadyRequested Key = "register.already_requested"
+	RegisterApproved         Key = "register.approved"
+	RegisterDenied           Key = "register.denied"
+	RegisterApprovedOwner    Key = "register.approved_owner"
+	RegisterDeniedOwner      Key = "register.denied_owner"
+	RegisterOwnerOnly        Key = "register.owner_only"
+
+	LunarDate     Key = "lunar.date"
+	LunarDateLeap Key = "lunar.date_leap"
+
+	AckOnIt Key = "ack.on_it"
+	AckDone Key = "ack.done"
+
+	ChecklistDone Key = "checklist.done"
+
+	NoReminders               Key = "list.no_reminders"
+	NoRemindersMatching       Key = "list.no_reminders_matching"
+	CompletedRemindersRemoved Key = "list.completed_removed"
+	ListPage                  Key = "list.page"
+
+	StatusActive    Key = "status.active"
+	StatusInactive  Key = "status.inactive"
+	StatusCompleted Key = "status.completed"
+
+	SkipHolidays          Key = "holidays.skip"
+	MoveToNextBusinessDay Key = "holidays.move"
+
+	AttachmentPhoto    Key = "attachment.photo"
+	AttachmentDocument Key = "attachment.document"
+	AttachmentVoice    Key = "attachment.voice"
+	AttachmentLocation Key = "attachment.location"
+
+	DetailID             Key = "detail.id"
+	DetailStatus         Key = "detail.status"
+	DetailMessage        Key = "detail.message"
+	DetailCommand        Key = "detail.command"
+	DetailFor            Key = "detail.for"
+	DetailForPrivately   Key = "detail.for_privately"
+	DetailUntilDone      Key = "detail.until_done"
+	DetailUntilDoneValue Key = "detail.until_done_value"
+	DetailRemaining      Key = "detail.remaining"
+	DetailRemainingValue Key = "detail.remaining_value"
+	DetailEnds           Key = "detail.ends"
+	DetailHolidays       Key = "detail.holidays"
+	DetailMoved          Key = "detail.moved"
+	DetailMovedValue     Key = "detail.moved_value"
+	DetailSkipped        Key = "detail.skipped"
+	DetailSkippedValue   Key = "detail.skipped_value"
+	DetailWeekend        Key = "detail.weekend"
+	DetailWillSkip       Key = "detail.will_skip"
+	DetailNextSchedule   Key = "detail.next_schedule"
+	DetailLunar          Key = "detail.lunar"
+	DetailLunarMonthly   Key = "detail.lunar_monthly"
+	DetailLunarYearly    Key = "detail.lunar_yearly"
+	DetailCompletedAt    Key = "detail.completed_at"
+	DetailSent           Key = "detail.sent"
+	DetailNoAcks         Key = "detail.no_acks"
+	DetailTicked         Key = "detail.ticked"
+	DetailRoster         Key = "detail.roster"
+	DetailAttachment     Key = "detail.attachment"
+
+	TimezoneIs      Key = "timezone.is"
+	TimezoneUpdated Key = "timezone.updated"
+
+	UserTimezoneUpdated Key = "user_timezone.updated"
+	UserTimezoneOff     Key = "user_timezone.off"
+
+	LateRemindersOn  Key = "late_reminders.on"
+	LateRemindersOff Key = "late_reminders.off"
+
+	DateOrderSet      Key = "date_order.set"
+	DateOrderDayMonth Key = "date_order.day_month"
+	DateOrderMonthDay Key = "date_order.month_day"
+
+	HolidaysOff Key = "holidays.off"
+	HolidaysSet Key = "holidays.set"
+
+	PermissionsSet      Key = "permissions.set"
+	PermissionsEveryone Key = "permissions.everyone"
+	PermissionsCreator  Key = "permissions.creator"
+	PermissionsAdmins   Key = "permissions.admins"
+
+	ButtonSnooze10Minutes          Key = "button.snooze_10_minutes"
+	ButtonSnooze20Minutes          Key = "button.snooze_20_minutes"
+	ButtonSnooze30Minutes          Key = "button.snooze_30_minutes"
+	ButtonSnooze1Hour              Key = "button.snooze_1_hour"
+	ButtonSnoozeThisAfternoon      Key = "button.snooze_this_afternoon"
+	ButtonSnoozeThisEvening        Key = "button.snooze_this_evening"
+	ButtonSnoozeTomorrowMorning    Key = "button.snooze_tomorrow_morning"
+	ButtonSnoozeTomorrowAfternoon  Key = "button.snooze_tomorrow_afternoon"
+	ButtonSnoozeTomorrowEvening    Key = "button.snooze_tomorrow_evening"
+	ButtonSnooze                   Key = "button.snooze"
+	ButtonClose                    Key = "button.close"
+	ButtonFinishSchedule           Key = "button.finish_schedule"
+	ButtonDone                     Key = "button.done"
+	ButtonSkipNext                 Key = "button.skip_next"
+	ButtonOnIt                     Key = "button.on_it"
+	ButtonDeleteReminder           Key = "button.delete_reminder"
+	ButtonShowReminderCommand      Key = "button.show_reminder_command"
+	ButtonCloseDetails             Key = "button.close_details"
+	ButtonPauseReminder            Key = "button.pause_reminder"
+	ButtonResumeReminder           Key = "button.resume_reminder"
+	ButtonEditReminder             Key = "button.edit_reminder"
+	ButtonRemoveCompletedReminders Key = "button.remove_completed_reminders"
+	ButtonCloseList                Key = "button.close_list"
+	ButtonPreviousPage             Key = "button.previous_page"
+	ButtonNextPage                 Key = "button.next_page"
+	ButtonListDetail               Key = "button.list_detail"
+	ButtonListPause                Key = "button.list_pause"
+	ButtonListResume               Key = "button.list_resume"
+	ButtonListDelete               Key = "button.list_delete"
+	ButtonApproveChat              Key = "button.approve_chat"
+	ButtonDenyChat                 Key = "button.deny_chat"
+)
@@ -0,0 +1,297 @@
+package i18n
+
+// nolint:gochecknoglobals,lll
+var vietnamese = map[Key]string{
+	LanguageName: "Tiếng Việt",
+	LanguageSet:  "Bot sẽ trả lời bằng %s",
+	Help: `
+*Các lệnh*
+
+_xem danh sách nhắc nhở_
+/remindlist
+/remindlist #ops today
+/remindlist paused
+
+_xem chi tiết một nhắc nhở_
+[/r_ID]
+
+_xoá một nhắc nhở_
+[/reminddelete_ID]
+
+_sửa một nhắc nhở, giữ nguyên ID_
+/remindedit ID ngày mai lúc 9:00 Cập nhật báo cáo
+/remindedit ID message Cập nhật báo cáo tuần
+
+_tạm dừng và tiếp tục một nhắc nhở_
+[/remindpause_ID]
+[/remindresume_ID]
+
+_bỏ qua những lần tới của một nhắc nhở định kỳ_
+[/remindskip_ID]
+/remindskip ID 3
+
+_nhắc lại cho đến khi được đánh dấu là xong_
+/remindnag ID every 10 minutes
+/remindnag ID every 10 minutes up to 5 times
+/remindnag ID off
+
+_đặt nhắc nhở_
+/nhac toi luc 8:00 ngay mai Họp nhóm
+/nhac toi ngày mai lúc 8 giờ Họp nhóm
+/nhac toi 8 giờ tối nay Gọi điện cho mẹ
+/nhac toi chiều mai Nộp báo cáo
+/nhac toi thứ sáu lúc 15h30 Họp giao ban
+/nhac toi ngày 14/3 lúc 10:00 Sinh nhật Lan
+/nhac toi ngày 14 tháng 3 năm 2027 Gia hạn hộ chiếu
+/nhac toi sau 2 tiếng nữa Uống thuốc
+/nhac toi trong 5 ngày, 3 giờ, 4 phút Cập nhật báo cáo
+
+_đặt nhắc nhở định kỳ_
+/nhac toi mỗi ngày lúc 8 giờ tối Cập nhật báo cáo ngày
+/nhac toi mỗi sáng Tập thể dục
+/nhac toi mỗi thứ hai lúc 9:00 Họp đầu tuần
+/nhac toi mỗi ngày làm việc lúc 9:00 Họp nhanh
+/nhac toi mỗi cuối tuần Dọn nhà
+/nhac toi ngày 1 hàng tháng Đóng tiền nhà
+/nhac toi mỗi ngày cuối tháng Gửi hoá đơn
+/nhac toi mỗi 2 tuần vào thứ hai lúc 9:00 Lập kế hoạch
+/nhac toi mỗi 3 tháng Kiểm tra báo cháy
+/nhac toi mỗi ngày lúc 20:00 10 lần Uống thuốc
+/nhac toi mỗi thứ hai lúc 9:00 đến ngày 31/12 Báo cáo tuần
+/nhac toi mỗi thứ hai lúc 9:00 bỏ qua ngày lễ Họp nhóm
+
+_đặt nhắc nhở theo âm lịch_
+/nhac toi ngày rằm hàng tháng âm lịch Thắp hương
+/nhac toi ngày 10 tháng 3 âm lịch Giỗ ông
+
+_nhắc một người trong nhóm, cả nhóm, hoặc nhắn riêng cho bạn_
+/nhac @alice ngày mai lúc 9:00 Nộp báo cáo
+/nhac cả nhóm mỗi thứ hai lúc 9:00 Họp nhóm
+/nhac toi nhắn riêng sáng mai Nộp báo cáo
+
+_trả lời một tin nhắn để được nhắc về tin nhắn đó_
+/nhac toi ngày mai lúc 9:00
+
+_gửi ảnh, tài liệu hoặc tin nhắn thoại với lời nhắc làm chú thích, hoặc trả lời một vị trí_
+/nhac toi ngày mai lúc 9:00 Thanh toán hoá đơn này
+
+_đánh dấu các mục của danh sách kiểm tra, mỗi dòng bắt đầu bằng -_
+/nhac cả nhóm mỗi thứ sáu lúc 16:00 Kiểm tra phát hành
+- gắn thẻ bản build
+- triển khai
+
+_luân phiên nhắc từng người trong nhóm_
+/nhac luân phiên @alice @brian @carol mỗi thứ hai lúc 9:00 Đổ rác
+/remindroster ID skip
+/remindroster ID swap @brian
+
+_đặt nhắc nhở định kỳ bằng cron_
+/remind cron "\*/15 9-17 \* \* 1-5" Kiểm tra hàng đợi build
+
+_đặt ngày lễ cho nhóm, hoặc gửi tệp .ics kèm chú thích /setholidays_
+/setholidays vn
+/setholidays off
+
+_đặt múi giờ cho nhóm_
+/gettimezone
+/settimezone Asia/Ho_Chi_Minh
+
+_đọc giờ bạn viết theo múi giờ của bạn trong mọi nhóm_
+/settimezone me Europe/London
+/settimezone me off
+
+_chọn ai trong nhóm được sửa, tạm dừng, bỏ qua, xoá và kết thúc nhắc nhở và đặt múi giờ của nhóm, chỉ quản trị viên như các cài đặt khác_
+/setpermissions everyone
+/setpermissions creator
+/setpermissions admins
+
+_đọc ngày viết bằng số theo thứ tự ngày hoặc tháng trước_
+/setdateorder dmy
+/setdateorder mdy
+
+_gửi những nhắc nhở bị lỡ khi bot không chạy_
+/setlatereminders on
+/setlatereminders off
+
+_dùng bot bằng tiếng Anh hoặc tiếng Việt_
+/setlanguage en
+/setlanguage vi
+`,
+
+	NotUnderstood: "không hiểu '%s'",
+	MissingWhat:   "lỗi: thiếu nội dung nhắc nhở",
+	NagTooOften:   "lỗi: chỉ có thể nhắc lại tối đa mỗi phút một lần",
+	NoCalendar:    "lỗi: không có lịch ngày lễ cho '%s', hãy thử %s hoặc gửi tệp .ics kèm chú thích %s",
+	NotICSFile:    "lỗi: %s không phải là tệp lịch .ics",
+	NotAllowed:    "lỗi: bạn không có quyền làm việc này trong nhóm này, xem /setpermissions",
+	AdminsOnly:    "lỗi: chỉ quản trị viên của nhóm này mới có thể làm việc này",
+	UnknownFilter: "lỗi: %s không phải là bộ lọc của danh sách, hãy dùng #thẻ, active, paused, completed hoặc today",
+	TagTooLong:    "lỗi: #%s quá dài để lọc danh sách",
+
+	ErrUnknownButton:   "lỗi: nút này không còn dùng được nữa",
+	ErrNotOnChecklist:  "lỗi: mục này không có trong danh sách",
+	ErrCronFields:      "lỗi: '%s' không phải là cron spec có 5 trường: phút giờ ngày-trong-tháng tháng ngày-trong-tuần",
+	ErrCronInvalid:     "lỗi: '%s' không phải là cron spec hợp lệ: %s",
+	ErrCronNeverRuns:   "lỗi: '%s' không bao giờ chạy",
+	ErrCronTooOften:    "lỗi: '%s' chạy cách nhau %s, chỉ có thể nhắc tối đa mỗi %s một lần",
+	ErrNotInRoster:     "lỗi: %s không có trong danh sách luân phiên của nhắc nhở %d",
+	ErrNoRoster:        "lỗi: nhắc nhở %d không có danh sách luân phiên",
+	ErrInvalidDate:     "lỗi: ngày %d tháng %d không phải là ngày hợp lệ",
+	ErrDaysInMonth:     "lỗi: tháng %d năm %d không có %d ngày",
+	ErrRepeatWeekly:    "lỗi: nhắc nhở phải lặp lại ít nhất mỗi tuần",
+	ErrLunarDay:        "lỗi: %d không phải là một ngày của tháng âm lịch",
+	ErrLunarMonth:      "lỗi: %d không phải là một tháng âm lịch",
+	ErrEndsBeforeStart: "lỗi: nhắc nhở sẽ kết thúc trước khi được gửi lần đầu",
+	ErrNotActive:       "lỗi: nhắc nhở %d không hoạt động",
+	ErrNotPaused:       "lỗi: nhắc nhở %d không bị tạm dừng",
+	ErrResumePassed:    "lỗi: không thể tiếp tục nhắc nhở %d vì đã qua thời gian của nó",
+	ErrResumeEnded:     "lỗi: không thể tiếp tục nhắc nhở %d vì nó đã kết thúc",
+	ErrNotRecurring:    "lỗi: nhắc nhở %d không lặp lại",
+	ErrNoRunsToSkip:    "lỗi: nhắc nhở %d không còn lần nào để bỏ qua",
+	ErrTooSoon:         "lỗi: thời gian phải cách hiện tại ít nhất 3 phút",
+	ErrNotInChat:       "lỗi: nhắc nhở %d không thuộc nhóm này",
+	ErrMemberTimezone:  "lỗi: chỉ thành viên đó mới có thể đặt múi giờ của mình",
+	ErrNoHolidays:      "lỗi: không tìm thấy ngày lễ nào trong lịch",
+	ErrHolidayNoStart:  "lỗi: ngày lễ '%s' không có ngày bắt đầu",
+	ErrCalendarDate:    "lỗi: ngày '%s' trong lịch không hợp lệ",
+
+	ReminderAdded:          "Đã đặt nhắc nhở \"%s\" vào %s",
+	ReminderUpdated:        "Đã cập nhật nhắc nhở \"%s\" vào %s",
+	ReminderMessageUpdated: "Đã đổi nội dung nhắc nhở %d thành \"%s\"",
+	ReminderResumed:        "Đã tiếp tục nhắc nhở %d, lần nhắc tiếp theo vào %s",
+	ReminderPaused:         "Đã tạm dừng nhắc nhở %d",
+	ReminderStaysPaused:    "Nhắc nhở vẫn tạm dừng cho đến khi được tiếp tục bằng /remindresume %d",
+	ReminderDeleted:        "Đã xoá nhắc nhở %d",
+	ReminderSkipped:        "Nhắc nhở %d sẽ không được gửi vào\n%s",
+	ReminderSkippedNext:    "Nhắc nhở %d sẽ không được gửi vào %s",
+	ReminderRescheduled:    "Đã dời nhắc nhở \"%s\" sang %s",
+	ReminderCompleted:      "Đã hoàn thành nhắc nhở \"%s\"",
+	ReminderDone:           "Đã đánh dấu nhắc nhở \"%s\" là xong",
+	ReminderNagOn:          "Nhắc nhở %d sẽ được gửi lại mỗi %d phút cho đến khi được đánh dấu là xong, tối đa %d lần",
+	ReminderNagOff:         "Nhắc nhở %d sẽ không còn được gửi lại cho đến khi được đánh dấu là xong",
+	ReminderNag:            "(lần nhắc %d trên %d, bấm ✅ Xong để dừng)",
+	ReminderLate:           "(trễ %s)",
+	ReminderLateMissed:     "(bị lỡ %d lần khi bot không chạy, lần đầu tiên cách đây %s)",
+	RepliedMessage:         "tin nhắn này",
+	NextRuns:               "Các lần tiếp theo:",
+
+	RosterSkipped: "Đã bỏ qua lượt của %[2]s trong nhắc nhở %[1]d, tiếp theo là %[3]s",
+	RosterSwapped: "%s nhận lượt tiếp theo của nhắc nhở %d thay cho %s",
+
+	RegisterRequest:          "*%s* (%d) xin dùng bot, người yêu cầu %s",
+	RegisterRequested:        "Đã gửi yêu cầu cho chủ bot để nhóm này được dùng bot",
+	RegisterAlreadyApproved:  "Nhóm này đã được dùng bot",
+	RegisterAlreadyRequested: "Nhóm này vẫn đang chờ chủ bot duyệt",
+	RegisterApproved:         "Nhóm này đã được dùng bot, gửi /remindhelp để xem các lệnh",
+	RegisterDenied:           "Chủ bot đã từ chối nhóm này",
+	RegisterApprovedOwner:    "✅ *%s* (%d) đã được dùng bot",
+	RegisterDeniedOwner:      "❌ *%s* (%d) đã bị từ chối",
+	RegisterOwnerOnly:        "Chỉ chủ bot mới làm được việc này",
+
+	LunarDate:     "%d/%d/%d âm lịch",
+	LunarDateLeap: "%d/%d nhuận/%d âm lịch",
+
+	AckOnIt: "%s đang làm",
+	AckDone: "%s đã xong",
+
+	ChecklistDone: "Đã xong tất cả ✅",
+
+	NoReminders:               "Bạn chưa có nhắc nhở nào.",
+	NoRemindersMatching:       "Không có nhắc nhở nào khớp với %s.",
+	CompletedRemindersRemoved: "Đã xoá các nhắc nhở đã hoàn thành",
+	ListPage:                  "Trang %d / %d",
+
+	StatusActive:    "Đang hoạt động",
+	StatusInactive:  "Tạm dừng",
+	StatusCompleted: "Đã hoàn thành",
+
+	SkipHolidays:          "Bỏ qua ngày lễ",
+	MoveToNextBusinessDay: "Dời sang ngày làm việc tiếp theo",
+
+	AttachmentPhoto:    "ảnh",
+	AttachmentDocument: "tài liệu",
+	AttachmentVoice:    "tin nhắn thoại",
+	AttachmentLocation: "vị trí",
+
+	DetailID:             "Id",
+	DetailStatus:         "Trạng thái",
+	DetailMessage:        "Nội dung",
+	DetailCommand:        "Lệnh",
+	DetailFor:            "Cho",
+	DetailForPrivately:   "%s, nhắn riêng",
+	DetailUntilDone:      "Nhắc đến khi xong",
+	DetailUntilDoneValue: "mỗi %d phút, tối đa %d lần",
+	DetailRemaining:      "Còn lại",
+	DetailRemainingValue: "%d lần",
+	DetailEnds:           "Kết thúc",
+	DetailHolidays:       "Ngày lễ",
+	DetailMoved:          "Đã dời",
+	DetailMovedValue:     "%s sang %s vì là %s",
+	DetailSkipped:        "Đã bỏ qua",
+	DetailSkippedValue:   "%s vì là %s",
+	DetailWeekend:        "cuối tuần",
+	DetailWillSkip:       "Sẽ bỏ qua",
+	DetailNextSchedule:   "Lần tiếp theo",
+	DetailLunar:          "Ngày âm lịch",
+	DetailLunarMonthly:   "ngày %d hàng tháng",
+	DetailLunarYearly:    "ngày %d tháng %d hàng năm",
+	DetailCompletedAt:    "Hoàn thành lúc",
+	DetailSent:           "Đã gửi",
+	DetailNoAcks:         "chưa ai xác nhận",
+	DetailTicked:         "đã đánh dấu %d trên %d mục",
+	DetailRoster:         "Luân phiên",
+	DetailAttachment:     "Tệp đính kèm",
+
+	TimezoneIs:      "Múi giờ của bạn là: %s",
+	TimezoneUpdated: "Đã đổi múi giờ thành: %s",
+
+	UserTimezoneUpdated: "Đã đổi múi giờ của bạn thành: %s. Giờ bạn viết trong lời nhắc sẽ được đọc theo múi giờ này",
+	UserTimezoneOff:     "Giờ bạn viết trong lời nhắc sẽ được đọc theo múi giờ của nhóm",
+
+	LateRemindersOn:  "Đã bật gửi nhắc nhở bị lỡ",
+	LateRemindersOff: "Đã tắt gửi nhắc nhở bị lỡ",
+
+	DateOrderSet:      "Ngày viết bằng số sẽ được đọc theo thứ tự %s",
+	DateOrderDayMonth: "ngày/tháng/năm",
+	DateOrderMonthDay: "tháng/ngày/năm",
+
+	HolidaysOff: "Đã tắt ngày lễ",
+	HolidaysSet: "Đã đặt ngày lễ theo %s (%d ngày lễ). Các nhắc nhở được đặt bỏ qua ngày lễ hoặc dời sang ngày làm việc tiếp theo sẽ theo lịch này",
+
+	PermissionsSet:      "Từ giờ nhắc nhở có thể được sửa, tạm dừng, bỏ qua, xoá và kết thúc bởi %s",
+	PermissionsEveryone: "mọi người",
+	PermissionsCreator:  "người đặt nhắc nhở và quản trị viên",
+	PermissionsAdmins:   "quản trị viên",
+
+	ButtonSnooze10Minutes:          "⏰ 10 phút",
+	ButtonSnooze20Minutes:          "⏰ 20 phút",
+	ButtonSnooze30Minutes:          "⏰ 30 phút",
+	ButtonSnooze1Hour:              "⏰ 1 giờ",
+	ButtonSnoozeThisAfternoon:      "⏰ Chiều nay",
+	ButtonSnoozeThisEvening:        "⏰ Tối nay",
+	ButtonSnoozeTomorrowMorning:    "⏰ Sáng mai",
+	ButtonSnoozeTomorrowAfternoon:  "⏰ Chiều mai",
+	ButtonSnoozeTomorrowEvening:    "⏰ Tối mai",
+	ButtonSnooze:                   "⏰ Nhắc lại sau",
+	ButtonClose:                    "❌ Đóng",
+	ButtonFinishSchedule:           "✅ Kết thúc lịch",
+	ButtonDone:                     "✅ Xong",
+	ButtonSkipNext:                 "⏭ Bỏ qua lần tới",
+	ButtonOnIt:                     "👋 Tôi đang làm",
+	ButtonDeleteReminder:           "🗑 Xoá nhắc nhở",
+	ButtonShowReminderCommand:      "📄 Xem lệnh nhắc nhở",
+	ButtonCloseDetails:             "❌ Đóng chi tiết",
+	ButtonPauseReminder:            "⏸ Tạm dừng nhắc nhở",
+	ButtonResumeReminder:           "▶️ Tiếp tục nhắc nhở",
+	ButtonEditReminder:             "✏️ Sửa nhắc nhở",
+	ButtonRemoveCompletedReminders: "🗑 Xoá các nhắc nhở đã hoàn thành",
+	ButtonCloseList:                "❌ Đóng danh sách",
+	ButtonPreviousPage:             "◀",
+	ButtonNextPage:                 "▶",
+	ButtonListDetail:               "ℹ️ %d",
+	ButtonListPause:                "⏸ Tạm dừng",
+	ButtonListResume:               "▶️ Tiếp tục",
+	ButtonListDelete:               "🗑 Xoá",
+	ButtonApproveChat:              "✅ Duyệt",
+	ButtonDenyChat:                 "❌ Từ chối",
+}
@@ -0,0 +1,415 @@
+package parser
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/husol/telegram-reminder-bot/pkg/cron"
+	"github.com/husol/telegram-reminder-bot/pkg/date"
+	"github.com/husol/telegram-reminder-bot/pkg/reminder"
+)
+
+// maxNth is the most times a day of the week comes round in a month
+const maxNth = 5
+
+type recurrenceKind int
+
+const (
+	recurAmount recurrenceKind = iota
+	recurWeeks
+	recurRepeat
+	recurLunar
+)
+
+// recurrence is when a recurring reminder is due
+type recurrence struct {
+	kind        recurrenceKind
+	amount      reminder.AmountDateTime
+	weeks       int
+	weekday     time.Weekday
+	repeat      reminder.RepeatableDateTime // only the day and the month are set
+	lunar       reminder.LunarDateTime      // only the day and the month are set
+	businessDay bool
+	timeOfDay
+	hasHolidays bool
+	holidays    cron.HolidayPolicy
+	ends        *reminder.EndCondition
+}
+
+// every parses a recurring time followed by a part of the day, a time, what happens on holidays
+// and when it ends, in any order e.g. "every first Monday of the month at 10:00 skip holidays for 6 times"
+// or in Vietnamese e.g. "mỗi thứ hai lúc 9 giờ"
+func (p *parser) every(when *Expression) bool {
+	r := &recurrence{}
+
+	switch {
+	case p.word("every"), p.vi && p.word("moi", "hang"):
+		if !p.recurrence(r) {
+			return false
+		}
+	// a day of every month can be written in Vietnamese without "mỗi" e.g. "ngày 5 hàng tháng",
+	// as can lunar dates which only ever recur e.g. "ngày 10 tháng 3 âm lịch"
+	case p.vi && p.try(func() bool { return p.viEveryLunarDay(r) }):
+	case p.vi && p.viEveryDayOfMonth(r) && r.repeat.Month == "*":
+	default:
+		return false
+	}
+
+	// amounts of time repeat from when the reminder is set, so they are not given a time
+	withTime := r.kind != recurAmount
+	for p.try(func() bool { return p.period(withTime, &r.timeOfDay) }) ||
+		p.try(func() bool { return withTime && p.clock(&r.timeOfDay) }) ||
+		p.try(func() bool { return p.holidays(r) }) ||
+		p.try(func() bool { return p.ends(r) }) {
+		// each option is set on the recurrence as it is parsed
+	}
+
+	*when = r.expression()
+
+	return true
+}
+
+// recurrence parses what comes after "every"
+func (p *parser) recurrence(r *recurrence) bool {
+	return (p.vi && p.try(func() bool { return p.viEveryLunarDay(r) })) ||
+		(p.vi && p.try(func() bool { return p.viEveryDayOfMonth(r) })) ||
+		(p.vi && p.try(func() bool { return p.viEveryLastDayOfMonth(r) })) ||
+		p.try(func() bool { return p.everyDayOfMonth(r) }) ||
+		p.try(func() bool { return p.everyLastDayOfMonth(r) }) ||
+		p.try(func() bool { return p.everyLunarDay(r) }) ||
+		p.try(func() bool { return p.everyNthWeekday(r) }) ||
+		p.try(func() bool { return p.everyWeeksOn(r) }) ||
+		p.try(func() bool { return p.everyAmount(r) }) ||
+		p.try(func() bool { return p.everyWeekday(r) }) ||
+		p.try(func() bool { return p.everyDay(r) })
+}
+
+func (r *recurrence) expression() Expression {
+	hour, minute := r.at(defaultHour)
+
+	switch r.kind {
+	case recurAmount:
+		amount := r.amount
+		amount.Ends = r.ends
+		amount.Holidays = r.holidays
+
+		return Every{AmountDateTime: amount}
+
+	case recurWeeks:
+		return EveryWeeks{WeeklyDateTime: reminder.WeeklyDateTime{
+			Weeks:     r.weeks,
+			DayOfWeek: int(r.weekday),
+			Hour:      hour,
+			Minute:    minute,
+			Ends:      r.ends,
+			Holidays:  r.holidays,
+		}}
+
+	case recurLunar:
+		lunar := r.lunar
+		lunar.Hour = hour
+		lunar.Minute = minute
+		lunar.Ends = r.ends
+		lunar.Holidays = r.holidays
+
+		return Lunar{LunarDateTime: lunar}
+
+	default:
+		repeat := r.repeat
+		repeat.Hour = strconv.Itoa(hour)
+		repeat.Minute = strconv.Itoa(minute)
+		repeat.Ends = r.ends
+		repeat.Holidays = r.holidays
+		if r.businessDay && repeat.Holidays == cron.IgnoreHolidays {
+			repeat.Holidays = cron.SkipHolidays
+		}
+
+		return Repeat{RepeatableDateTime: repeat}
+	}
+}
+
+// everyDayOfMonth parses a day of every month e.g. "4th of the month" or a day of every year e.g. "4th of January"
+// nolint:gomnd
+func (p *parser) everyDayOfMonth(r *recurrence) bool {
+	from := p.pos
+	day, ok := p.ordinal()
+	if !ok || !p.word("of") {
+		return false
+	}
+	if day < 1 || day > 31 {
+		return p.invalid(from)
+	}
+
+	month := "*"
+	if !p.phrase("the", "month") {
+		var m time.Month
+		if !p.month(&m) {
+			return false
+		}
+		if day > date.MaxDaysInMonth(int(m)) {
+			return p.invalid(from)
+		}
+		month = strconv.Itoa(int(m))
+	}
+
+	r.kind = recurRepeat
+	r.repeat = reminder.RepeatableDateTime{DayOfMonth: strconv.Itoa(day), Month: month}
+
+	return true
+}
+
+// everyLastDayOfMonth parses "last day of the month" which is set as the day of the month "L"
+func (p *parser) everyLastDayOfMonth(r *recurrence) bool {
+	if !p.phrase("last", "day", "of", "the", "month") {
+		return false
+	}
+
+	r.kind = recurRepeat
+	r.repeat = reminder.RepeatableDateTime{DayOfMonth: "L", Month: "*"}
+
+	return true
+}
+
+// everyLunarDay parses a day of every lunar month e.g. "lunar 15th of the month"
+// or a day of a lunar month every year e.g. "lunar 10th of month 3" or "lunar 10th of the 3rd month"
+// nolint:gomnd
+func (p *parser) everyLunarDay(r *recurrence) bool {
+	from := p.pos
+	if !p.word("lunar") {
+		return false
+	}
+
+	day, ok := p.ordinal()
+	if !ok || !p.word("of") {
+		return false
+	}
+	if day < 1 || day > 30 {
+		return p.invalid(from)
+	}
+
+	month := 0
+	switch {
+	case p.phrase("the", "month"):
+	case p.word("month"):
+		m, _, ok := p.number()
+		if !ok {
+			return false
+		}
+		month = m
+	case p.word("the"):
+		m, ok := p.ordinal()
+		if !ok || !p.word("month") {
+			return false
+		}
+		month = m
+	default:
+		return false
+	}
+	if month < 0 || month > 12 {
+		return p.invalid(from)
+	}
+
+	r.kind = recurLunar
+	r.lunar = reminder.LunarDateTime{DayOfMonth: day, Month: month}
+
+	return true
+}
+
+// everyNthWeekday parses a day of the week of every month e.g. "first Monday of the month" or "last Friday".
+// The day of the week is set as "D#N" for the Nth day D of the month or as "DL" for the last day D of the month
+func (p *parser) everyNthWeekday(r *recurrence) bool {
+	nth, ok := p.nth()
+	if !ok {
+		return false
+	}
+
+	var day time.Weekday
+	if !p.weekday(&day) {
+		return false
+	}
+	p.phrase("of", "the", "month")
+
+	dayOfWeek := strconv.Itoa(int(day))
+	if nth < 0 {
+		dayOfWeek += "L"
+	} else {
+		dayOfWeek = fmt.Sprintf("%s#%d", dayOfWeek, nth)
+	}
+
+	r.kind = recurRepeat
+	r.repeat = reminder.RepeatableDateTime{DayOfWeek: dayOfWeek, Month: "*"}
+
+	return true
+}
+
+// nth parses "first" to "fifth", "1st" to "5th" or "last" which is -1
+func (p *parser) nth() (int, bool) {
+	if n, ok := ordinals[p.peekWord()]; ok {
+		p.pos++
+		return n, true
+	}
+
+	n, _, ok := p.number()
+	if !ok || n < 1 || n > maxNth {
+		return 0, false
+	}
+	if _, ok := p.adjacentWord(ordinalSuffixes...); !ok {
+		return 0, false
+	}
+
+	return n, true
+}
+
+// everyWeeksOn parses a day of the week which comes round every few weeks e.g. "2 weeks on Monday"
+func (p *parser) everyWeeksOn(r *recurrence) bool {
+	n, _, ok := p.number()
+	if !ok {
+		return false
+	}
+	if u, ok := p.unitOf(p.peekWord()); !ok || u != weeks {
+		return false
+	}
+	p.pos++
+	if !p.word("on") && !(p.vi && p.word("vao")) {
+		return false
+	}
+
+	var day time.Weekday
+	if !p.weekday(&day) {
+		return false
+	}
+
+	r.kind = recurWeeks
+	r.weeks = n
+	r.weekday = day
+
+	return true
+}
+
+// everyAmount parses amounts of time e.g. "3 hours" or "1 month, 2 days", or a unit of time on its own e.g. "hour"
+func (p *parser) everyAmount(r *recurrence) bool {
+	var amount reminder.AmountDateTime
+	if !p.try(func() bool { return p.amount(&amount) }) && !p.singleUnit(&amount) {
+		return false
+	}
+
+	r.kind = recurAmount
+	r.amount = amount
+
+	return true
+}
+
+// singleUnit parses a unit of time on its own which is one of it e.g. "hour" or "tuần".
+// A day is left to be read as every day at a time of the day
+func (p *parser) singleUnit(amount *reminder.AmountDateTime) bool {
+	u, ok := p.unitOf(p.peekWord())
+	if !ok || u == days {
+		return false
+	}
+	p.pos++
+	addAmount(amount, u, 1)
+
+	return true
+}
+
+// everyWeekday parses a day of the week e.g. "Tuesday"
+func (p *parser) everyWeekday(r *recurrence) bool {
+	var day time.Weekday
+	if !p.weekday(&day) {
+		return false
+	}
+
+	r.kind = recurRepeat
+	r.repeat = reminder.RepeatableDateTime{DayOfWeek: strconv.Itoa(int(day)), Month: "*"}
+
+	return true
+}
+
+// everyDay parses "day", a part of the day e.g. "morning", "weekday", "weekend" or "business day",
+// or in Vietnamese "ngày", "ngày thường", "cuối tuần" or "ngày làm việc".
+// Business days skip holidays unless told otherwise
+func (p *parser) everyDay(r *recurrence) bool {
+	var repeat reminder.RepeatableDateTime
+
+	switch {
+	case p.word("day"):
+	case p.word("weekday"), p.vi && p.phrase("ngay", "thuong"):
+		repeat.DayOfWeek = "1-5"
+	case p.word("weekend"), p.vi && p.phrase("cuoi", "tuan"):
+		repeat.DayOfWeek = "6,0"
+	case p.phrase("business", "day"), p.vi && p.phrase("ngay", "lam", "viec"):
+		repeat.DayOfWeek = "1-5"
+		r.businessDay = true
+	case p.vi && p.word("ngay"):
+	default:
+		if !p.period(true, &r.timeOfDay) {
+			return false
+		}
+	}
+
+	r.kind = recurRepeat
+	r.repeat = repeat
+
+	return true
+}
+
+// holidays parses what a recurring reminder does when it is due on a holiday
+func (p *parser) holidays(r *recurrence) bool {
+	if r.hasHolidays {
+		return false
+	}
+
+	switch {
+	case p.phrase("skip", "holidays"):
+		r.holidays = cron.SkipHolidays
+	case p.phrase("move", "to", "the", "next", "business", "day"), p.phrase("move", "to", "next", "business", "day"):
+		r.holidays = cron.MoveToNextBusinessDay
+	case p.vi && p.viHolidays(r):
+	default:
+		return false
+	}
+	r.hasHolidays = true
+
+	return true
+}
+
+// ends parses how a recurring reminder ends e.g. "until 31st of December", "until December 31st, 2027",
+// "for 10 times" or "10 times". A date after "until" which can't be read fails the command
+// rather than being left as the message of a reminder which would never end
+func (p *parser) ends(r *recurrence) bool {
+	if r.ends != nil {
+		return false
+	}
+	if p.vi && p.try(func() bool { return p.viEnds(r) }) {
+		return true
+	}
+
+	from := p.pos
+	if p.word("until") {
+		until := &reminder.DateTime{}
+		p.word("the")
+		if !p.try(func() bool { return p.dayMonth(from, true, until) && until.Month > 0 }) &&
+			!p.try(func() bool { return p.monthDay(true, until) }) {
+			if p.pos < len(p.tokens) {
+				p.pos++
+			}
+			return p.invalid(from)
+		}
+		r.ends = &reminder.EndCondition{Until: until}
+
+		return true
+	}
+
+	p.word("for")
+	times, _, ok := p.number()
+	if !ok || !p.word("time", "times") {
+		return false
+	}
+	if times < 1 {
+		return p.invalid(from)
+	}
+	r.ends = &reminder.EndCondition{Times: times}
+
+	return true
+}
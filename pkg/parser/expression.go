@@ -0,0 +1,96 @@
+package parser
+
+import (
+	"github.com/husol/telegram-reminder-bot/pkg/reminder"
+)
+
+// Who is who a reminder is sent to
+type Who int
+
+const (
+	// Me is who sent the command, which is the whole chat in a private chat
+	Me Who = iota
+	// Here is the whole chat the reminder is set in
+	Here
+	// User is a user of the chat named by their username or mentioned by name e.g. "@alice"
+	User
+	// Roster is users of the chat who take turns to be reminded e.g. "roster @alice @bob"
+	Roster
+)
+
+// Remind is a parsed "/remind <who> <when> <what>" command
+type Remind struct {
+	Who Who
+	// Recipient is the user the reminder is for when Who is User. Users named by their username have no UserID
+	Recipient reminder.Recipient
+	// Roster is the users who take turns when Who is Roster, in the order they take them
+	Roster []reminder.Recipient
+	// Private is set when the reminder is sent to the user by private message rather than in the chat
+	// e.g. "/remind me privately tomorrow ..."
+	Private bool
+	When    Expression
+	What    string
+}
+
+// Mention is a user mentioned by name in the command. Telegram sends the user as an entity of the message
+// rather than in its text, for users without a username
+type Mention struct {
+	// Start and End are the byte offsets of the name in the command
+	Start  int
+	End    int
+	UserID int
+}
+
+// Expression is when a reminder is sent. Each expression holds what reminder.Service needs to schedule it
+type Expression interface {
+	expression()
+}
+
+// OnDate is a date or a day of the week e.g. "on the 3rd at noon" or "next Friday afternoon"
+type OnDate struct {
+	DateTime reminder.DateTime
+}
+
+// OnDay is today or tomorrow e.g. "tonight" or "tomorrow at 9am"
+type OnDay struct {
+	WordDateTime reminder.WordDateTime
+}
+
+// In is an amount of time from now e.g. "in 2 weeks at 9am"
+type In struct {
+	AmountDateTime reminder.AmountDateTime
+}
+
+// Every is an amount of time which repeats e.g. "every 3 hours"
+type Every struct {
+	AmountDateTime reminder.AmountDateTime
+}
+
+// EveryWeeks is a day of the week which comes round every few weeks e.g. "every 2 weeks on Monday"
+type EveryWeeks struct {
+	WeeklyDateTime reminder.WeeklyDateTime
+}
+
+// Repeat is a recurring date or day of the week e.g. "every first Monday of the month"
+type Repeat struct {
+	RepeatableDateTime reminder.RepeatableDateTime
+}
+
+// Lunar is a day of every lunar month or of a lunar month every year e.g. "every lunar 15th of the month"
+type Lunar struct {
+	LunarDateTime reminder.LunarDateTime
+}
+
+// Cron is a cron spec written by the user e.g. cron "*/15 9-17 * * 1-5"
+type Cron struct {
+	Spec string
+}
+
+func (OnDate) expression()     {}
+func (OnDay) expression()      {}
+func (In) expression()         {}
+func (Every) expression()      {}
+func (EveryWeeks) expression() {}
+func (Repeat) expression()     {}
+func (Lunar) expression()      {}
+func (Cron) expression()       {}
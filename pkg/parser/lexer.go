@@ -0,0 +1,154 @@
+package parser
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	wordToken   tokenKind = iota // letters, which may contain apostrophes e.g. today's
+	numberToken                  // digits
+	stringToken                  // text between double quotes, without the quotes
+	symbolToken                  // any other character e.g. : or ,
+)
+
+type token struct {
+	kind tokenKind
+	// text is lower cased for words, with the accents of Vietnamese letters removed e.g. "ngày mai" is "ngay mai"
+	text string
+	// start and end are the byte offsets of the token in the input
+	start int
+	end   int
+}
+
+// adjacent reports whether nothing separates the token from the next one e.g. 8 and pm in 8pm
+func (t token) adjacent(next token) bool {
+	return t.end == next.start
+}
+
+// lex splits the input into tokens. It never fails, text it does not recognise is returned as symbols
+func lex(input string) []token {
+	var tokens []token
+
+	for i := 0; i < len(input); {
+		r, size := utf8.DecodeRuneInString(input[i:])
+
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+
+		case isLetter(r):
+			end := scan(input, i, func(r rune) bool { return isLetter(r) || isApostrophe(r) })
+			tokens = append(tokens, token{kind: wordToken, text: fold(input[i:end]), start: i, end: end})
+			i = end
+
+		case unicode.IsDigit(r):
+			end := scan(input, i, unicode.IsDigit)
+			tokens = append(tokens, token{kind: numberToken, text: input[i:end], start: i, end: end})
+			i = end
+
+		case isOpeningQuote(r):
+			closing := strings.IndexFunc(input[i+size:], isClosingQuote)
+			if closing < 0 {
+				tokens = append(tokens, token{kind: symbolToken, text: string(r), start: i, end: i + size})
+				i += size
+
+				continue
+			}
+
+			_, closingSize := utf8.DecodeRuneInString(input[i+size+closing:])
+			end := i + size + closing + closingSize
+			tokens = append(tokens, token{kind: stringToken, text: input[i+size : i+size+closing], start: i, end: end})
+			i = end
+
+		default:
+			tokens = append(tokens, token{kind: symbolToken, text: string(r), start: i, end: i + size})
+			i += size
+		}
+	}
+
+	return tokens
+}
+
+// scan returns the offset of the first rune after start which does not satisfy f
+func scan(input string, start int, f func(r rune) bool) int {
+	end := start
+	for end < len(input) {
+		r, size := utf8.DecodeRuneInString(input[end:])
+		if !f(r) {
+			break
+		}
+		end += size
+	}
+
+	// a word does not end with an apostrophe e.g. the closing quote in 'tomorrow'
+	for end > start {
+		r, size := utf8.DecodeLastRuneInString(input[start:end])
+		if !isApostrophe(r) {
+			break
+		}
+		end -= size
+	}
+
+	return end
+}
+
+// fold lower cases a word and removes the accents of Vietnamese letters,
+// so that words can be typed with or without them e.g. "giờ" or "gio"
+func fold(word string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(word) {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		if base, ok := unaccented[r]; ok {
+			r = base
+		}
+		sb.WriteRune(r)
+	}
+
+	return sb.String()
+}
+
+// nolint:gochecknoglobals
+var unaccented = func() map[rune]rune {
+	letters := map[rune]string{
+		'a': "àáảãạăằắẳẵặâầấẩẫậ",
+		'd': "đ",
+		'e': "èéẻẽẹêềếểễệ",
+		'i': "ìíỉĩị",
+		'o': "òóỏõọôồốổỗộơờớởỡợ",
+		'u': "ùúủũụưừứửữự",
+		'y': "ỳýỷỹỵ",
+	}
+
+	m := make(map[rune]rune)
+	for base, accented := range letters {
+		for _, r := range accented {
+			m[r] = base
+		}
+	}
+
+	return m
+}()
+
+// isLetter includes the combining marks some keyboards type for accented letters
+func isLetter(r rune) bool {
+	return unicode.IsLetter(r) || unicode.Is(unicode.Mn, r)
+}
+
+func isApostrophe(r rune) bool {
+	return r == '\'' || r == '’'
+}
+
+// the curly quotes some keyboards type are accepted as well as straight ones
+func isOpeningQuote(r rune) bool {
+	return r == '"' || r == '“'
+}
+
+func isClosingQuote(r rune) bool {
+	return r == '"' || r == '”'
+}
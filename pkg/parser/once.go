@@ -0,0 +1,531 @@
+package parser
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/husol/telegram-reminder-bot/pkg/date"
+	"github.com/husol/telegram-reminder-bot/pkg/reminder"
+)
+
+type dayKind int
+
+const (
+	dayNone dayKind = iota
+	dayToday
+	dayTomorrow
+	dayWeekday
+	dayDate
+	dayIn
+)
+
+// moment is when a reminder which is sent once is due
+type moment struct {
+	day     dayKind
+	weekday time.Weekday
+	date    reminder.DateTime // only the date is set
+	amount  reminder.AmountDateTime
+	timeOfDay
+}
+
+// timeOfDay is the part of the day e.g. "afternoon" and the time e.g. "at 4pm" a reminder is sent at
+type timeOfDay struct {
+	hasPeriod   bool
+	period      int
+	hasClock    bool
+	hour        int
+	minute      int
+	hasMeridiem bool // the time says whether it is before or after noon e.g. "at 8pm"
+}
+
+func (t *timeOfDay) isSet() bool {
+	return t.hasPeriod || t.hasClock
+}
+
+// at returns the time which is given, else the hour of the part of the day, else the default hour.
+// A time in the afternoon or the evening is after noon e.g. "tonight at 8" is 20:00
+// nolint:gomnd
+func (t *timeOfDay) at(defaultHour int) (hour, minute int) {
+	switch {
+	case t.hasClock && !t.hasMeridiem && t.hasPeriod && t.period >= 12 && t.hour < 12:
+		return t.hour + 12, t.minute
+	case t.hasClock:
+		return t.hour, t.minute
+	case t.hasPeriod:
+		return t.period, 0
+	default:
+		return defaultHour, 0
+	}
+}
+
+// once parses a day, a part of the day and a time in any order e.g. "tomorrow afternoon at 4" or "at 4 tomorrow"
+func (p *parser) once(when *Expression) bool {
+	m := &moment{}
+	for p.try(func() bool { return p.day(m) }) ||
+		p.try(func() bool { return p.period(m.day != dayNone, &m.timeOfDay) }) ||
+		p.try(func() bool { return p.clock(&m.timeOfDay) }) {
+		// each part is set on the moment as it is parsed
+	}
+
+	if m.day == dayNone && !m.hasClock {
+		return false
+	}
+
+	hour, minute := m.at(defaultHour)
+	switch m.day {
+	case dayNone, dayToday:
+		*when = OnDay{WordDateTime: reminder.WordDateTime{When: reminder.Today, Hour: hour, Minute: minute}}
+	case dayTomorrow:
+		*when = OnDay{WordDateTime: reminder.WordDateTime{When: reminder.Tomorrow, Hour: hour, Minute: minute}}
+	case dayWeekday:
+		*when = OnDate{DateTime: reminder.DateTime{DayOfWeek: strconv.Itoa(int(m.weekday)), Hour: hour, Minute: minute}}
+	case dayDate:
+		dateTime := m.date
+		dateTime.Hour, dateTime.Minute = hour, minute
+		*when = OnDate{DateTime: dateTime}
+	case dayIn:
+		amount := m.amount
+		if m.isSet() {
+			amount.At = &reminder.TimeOfDay{Hour: hour, Minute: minute}
+		}
+		*when = In{AmountDateTime: amount}
+	}
+
+	return true
+}
+
+// day parses the day a reminder is sent on e.g. "tomorrow", "on Friday", "next Friday", "on the 3rd" or "in 2 weeks"
+func (p *parser) day(m *moment) bool {
+	if m.day != dayNone {
+		return false
+	}
+
+	// the moment is only changed when the whole day is parsed
+	next := *m
+	if !p.dayOf(&next) {
+		return false
+	}
+	*m = next
+
+	return true
+}
+
+func (p *parser) dayOf(m *moment) bool {
+	if p.vi && p.try(func() bool { return p.viDay(m) }) {
+		return true
+	}
+
+	switch {
+	case p.word("today"):
+		m.day = dayToday
+	case p.word("tomorrow"):
+		m.day = dayTomorrow
+	case p.word("tonight"):
+		m.day = dayToday
+		return p.setPeriod(&m.timeOfDay, periods["night"])
+	case p.word("this"):
+		m.day = dayToday
+		return p.period(true, &m.timeOfDay)
+	case p.word("next"):
+		m.day = dayWeekday
+		return p.weekday(&m.weekday)
+	case p.word("on"):
+		return p.on(m)
+	case p.word("in"):
+		m.day = dayIn
+		return p.amount(&m.amount)
+	default:
+		m.day = dayWeekday
+		return p.weekday(&m.weekday)
+	}
+
+	return true
+}
+
+// on parses what comes after "on" e.g. "Friday", "the 3rd of March", "March 3rd", "2027-03-14" or "14/03"
+func (p *parser) on(m *moment) bool {
+	from := p.pos - 1
+	if p.try(func() bool { return p.weekday(&m.weekday) }) {
+		m.day = dayWeekday
+		return true
+	}
+
+	m.day = dayDate
+	if p.try(func() bool { return p.isoDate(&m.date) }) || p.try(func() bool { return p.numericDate(&m.date) }) {
+		return true
+	}
+
+	if p.word("the") {
+		return p.dayMonth(from, true, &m.date)
+	}
+
+	return p.try(func() bool { return p.dayMonth(from, true, &m.date) }) ||
+		p.try(func() bool { return p.monthDay(true, &m.date) })
+}
+
+func (p *parser) weekday(day *time.Weekday) bool {
+	d, ok := weekdays[p.peekWord()]
+	if !ok {
+		return p.vi && p.viWeekday(day)
+	}
+	p.pos++
+	*day = d
+
+	return true
+}
+
+func (p *parser) month(month *time.Month) bool {
+	m, ok := months[p.peekWord()]
+	if !ok {
+		return false
+	}
+	p.pos++
+	*month = m
+
+	return true
+}
+
+// ordinal parses a number with or without a suffix e.g. "3rd" or "3", or an ordinal written as a word e.g. "third"
+func (p *parser) ordinal() (int, bool) {
+	if n, ok := ordinals[p.peekWord()]; ok && n > 0 {
+		p.pos++
+		return n, true
+	}
+
+	n, _, ok := p.number()
+	if !ok {
+		return 0, false
+	}
+	p.adjacentWord(ordinalSuffixes...)
+
+	return n, true
+}
+
+// nolint:gomnd
+func (p *parser) dayOfMonth() (int, bool) {
+	from := p.pos
+	day, ok := p.ordinal()
+	if !ok {
+		return 0, false
+	}
+	if day < 1 || day > 31 {
+		return 0, p.invalid(from)
+	}
+
+	return day, true
+}
+
+// dayMonth parses a day with an optional month and year e.g. "3rd", "3rd of March", "3 March" or "3rd of March, 2027".
+// from is where the phrase started for the error when the month is not valid
+func (p *parser) dayMonth(from int, withYear bool, dateTime *reminder.DateTime) bool {
+	day, ok := p.dayOfMonth()
+	if !ok {
+		return false
+	}
+	dateTime.DayOfMonth = day
+
+	of := p.word("of")
+	var month time.Month
+	if !p.month(&month) {
+		if !of {
+			return true
+		}
+		if p.pos < len(p.tokens) {
+			p.pos++
+		}
+		return p.invalid(from)
+	}
+	dateTime.Month = int(month)
+	if day > date.MaxDaysInMonth(dateTime.Month) {
+		return p.invalid(from)
+	}
+
+	if withYear {
+		p.try(func() bool { return p.year(dateTime) })
+	}
+
+	return true
+}
+
+// monthDay parses a month and a day with an optional year e.g. "March 3rd" or "March 3rd, 2027"
+func (p *parser) monthDay(withYear bool, dateTime *reminder.DateTime) bool {
+	from := p.pos
+	var month time.Month
+	if !p.month(&month) {
+		return false
+	}
+	dateTime.Month = int(month)
+
+	day, ok := p.dayOfMonth()
+	if !ok {
+		return false
+	}
+	if day > date.MaxDaysInMonth(dateTime.Month) {
+		return p.invalid(from)
+	}
+	dateTime.DayOfMonth = day
+
+	if withYear {
+		p.try(func() bool { return p.year(dateTime) })
+	}
+
+	return true
+}
+
+// nolint:gomnd
+func (p *parser) year(dateTime *reminder.DateTime) bool {
+	if t, ok := p.peek(); ok && t.kind == symbolToken && t.text == "," {
+		p.pos++
+	}
+
+	year, digits, ok := p.number()
+	if !ok || digits != 4 {
+		return false
+	}
+	dateTime.Year = year
+
+	return true
+}
+
+// isoDate parses a date written year first e.g. 2027-03-14
+// nolint:gomnd
+func (p *parser) isoDate(dateTime *reminder.DateTime) bool {
+	year, digits, ok := p.number()
+	if !ok || digits != 4 || !p.adjacentSymbol("-") {
+		return false
+	}
+
+	month, _, ok := p.adjacentNumber()
+	if !ok || !p.adjacentSymbol("-") {
+		return false
+	}
+
+	day, _, ok := p.adjacentNumber()
+	if !ok {
+		return false
+	}
+
+	dateTime.Year = year
+	dateTime.Month = month
+	dateTime.DayOfMonth = day
+
+	return true
+}
+
+// numericDate parses a date written with numbers e.g. 14/03/2027, 14.03.27 or 14/03.
+// Whether the day or the month comes first depends on the chat so they are set in the order they are written
+// nolint:gomnd
+func (p *parser) numericDate(dateTime *reminder.DateTime) bool {
+	first, digits, ok := p.number()
+	if !ok || digits > 2 || !p.adjacentSymbol("/", ".") {
+		return false
+	}
+
+	second, digits, ok := p.adjacentNumber()
+	if !ok || digits > 2 {
+		return false
+	}
+
+	dateTime.DayOfMonth = first
+	dateTime.Month = second
+	dateTime.NumericDate = true
+
+	p.try(func() bool {
+		if !p.adjacentSymbol("/", ".") {
+			return false
+		}
+
+		year, digits, ok := p.adjacentNumber()
+		if !ok || (digits != 2 && digits != 4) {
+			return false
+		}
+		if digits == 2 {
+			year += twoDigitYearBase
+		}
+		dateTime.Year = year
+
+		return true
+	})
+
+	return true
+}
+
+// period parses a part of the day e.g. "afternoon", "in the afternoon" or "buổi chiều".
+// It is only read after a day, so that "Friday afternoon" is a time but "afternoon" alone is part of the message
+func (p *parser) period(afterDay bool, t *timeOfDay) bool {
+	if !afterDay {
+		return false
+	}
+
+	p.phrase("in", "the")
+	if p.vi {
+		p.word("buoi")
+	}
+
+	hour, ok := p.periodOf(p.peekWord())
+	if !ok {
+		return false
+	}
+	p.pos++
+
+	return p.setPeriod(t, hour)
+}
+
+func (p *parser) periodOf(word string) (int, bool) {
+	if hour, ok := periods[word]; ok {
+		return hour, true
+	}
+	if !p.vi {
+		return 0, false
+	}
+	hour, ok := viPeriods[word]
+
+	return hour, ok
+}
+
+func (p *parser) setPeriod(t *timeOfDay, hour int) bool {
+	if t.hasPeriod {
+		return false
+	}
+	t.hasPeriod = true
+	t.period = hour
+
+	return true
+}
+
+// clock parses a time e.g. "at 8", "at 8:30", "at 8.30pm", "at 8 pm" or "at noon",
+// or in Vietnamese e.g. "lúc 8:00", "lúc 8 giờ 30", "8h30" or "8 giờ tối".
+// A number after "at" is always read as a time, so a time which is not valid fails the command
+// nolint:gomnd
+func (p *parser) clock(t *timeOfDay) bool {
+	if t.hasClock {
+		return false
+	}
+	from := p.pos
+	at := p.word("at") || (p.vi && p.word("luc"))
+
+	if w := p.peekWord(); at && (w == "noon" || w == "midnight") {
+		p.pos++
+		t.hasClock, t.hour, t.minute = true, periods[w], 0
+		return true
+	}
+
+	hour, _, ok := p.number()
+	if !ok {
+		return false
+	}
+
+	minute := 0
+	hasMinute := p.try(func() bool {
+		if !p.adjacentSymbol(":", ".") {
+			return false
+		}
+		minute, _, ok = p.adjacentNumber()
+		return ok
+	})
+
+	// a time written in Vietnamese does not need "lúc" before it e.g. "8 giờ sáng mai"
+	oClock := p.vi && !hasMinute && p.viOClock(&minute)
+	if !at && !oClock {
+		return false
+	}
+
+	ampm := p.peekWord()
+	switch {
+	case ampm == "am" || ampm == "pm":
+		p.pos++
+		if hour < 1 || hour > 12 {
+			return p.invalid(from)
+		}
+		hour, minute = date.ConvertTo24H(hour, minute, ampm)
+		t.hasMeridiem = true
+	case p.vi && hour >= 1 && hour <= 12 && p.viMeridiem(&hour):
+		t.hasMeridiem = true
+	}
+
+	if hour > 23 || minute > 59 {
+		return p.invalid(from)
+	}
+
+	t.hasClock, t.hour, t.minute = true, hour, minute
+
+	return true
+}
+
+// amount parses amounts of time e.g. "2 hours", "1 hour, 30 minutes" or "1 hour and 30 minutes".
+// An amount which adds up to no time at all fails the command, as the reminder would never come round
+func (p *parser) amount(amount *reminder.AmountDateTime) bool {
+	from := p.pos - 1
+	if !p.amountOfUnit(amount) {
+		return false
+	}
+
+	for p.try(func() bool { return p.separator() && p.amountOfUnit(amount) }) {
+		// each amount is added as it is parsed
+	}
+
+	if amount.Minutes == 0 && amount.Hours == 0 && amount.Days == 0 && amount.Weeks == 0 && amount.Months == 0 {
+		return p.invalid(from)
+	}
+
+	return true
+}
+
+// separator consumes what separates amounts of time e.g. ",", "and" or ", and"
+func (p *parser) separator() bool {
+	if p.word("and") || (p.vi && p.word("va")) {
+		return true
+	}
+
+	t, ok := p.peek()
+	if !ok || t.kind != symbolToken || t.text != "," {
+		return false
+	}
+	p.pos++
+	p.word("and")
+
+	return true
+}
+
+func (p *parser) amountOfUnit(amount *reminder.AmountDateTime) bool {
+	n, _, ok := p.number()
+	if !ok {
+		return false
+	}
+
+	u, ok := p.unitOf(p.peekWord())
+	if !ok {
+		return false
+	}
+	p.pos++
+	addAmount(amount, u, n)
+
+	return true
+}
+
+func addAmount(amount *reminder.AmountDateTime, u unit, n int) {
+	switch u {
+	case minutes:
+		amount.Minutes += n
+	case hours:
+		amount.Hours += n
+	case days:
+		amount.Days += n
+	case weeks:
+		amount.Weeks += n
+	case monthsUnit:
+		amount.Months += n
+	}
+}
+
+func (p *parser) unitOf(word string) (unit, bool) {
+	if u, ok := units[word]; ok {
+		return u, true
+	}
+	if !p.vi {
+		return 0, false
+	}
+	u, ok := viUnits[word]
+
+	return u, ok
+}
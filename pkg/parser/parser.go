@@ -0,0 +1,397 @@
+// Package parser reads the "/remind <who> <when> <what>" command.
+// The command is split into tokens which are parsed into a Remind whose When is a typed expression
+// reminder.Service can schedule e.g. "next Friday afternoon", "in 2 weeks at 9am" or "on the 3rd at noon".
+// Chats which read the bot in Vietnamese can also write the time in Vietnamese e.g. "/nhắc tôi lúc 8:00 ngày mai"
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
+	"github.com/husol/telegram-reminder-bot/pkg/reminder"
+)
+
+// maxDigits is the most digits a number can have
+const maxDigits = 6
+
+// usernamePattern is what Telegram allows as a username
+// nolint:gochecknoglobals
+var usernamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]{4,31}$`)
+
+// ErrMissingWhat is returned when a command says when to remind but not what,
+// along with the rest of the Remind as commands replying to a message don't need to say what
+var ErrMissingWhat = errors.New("error: the reminder message is missing")
+
+// NotUnderstoodError is returned with the words of a command which could not be read
+type NotUnderstoodError struct {
+	Words string
+}
+
+func (e *NotUnderstoodError) Error() string {
+	return fmt.Sprintf("could not understand '%s'", e.Words)
+}
+
+type parser struct {
+	input  string
+	tokens []token
+	pos    int
+	// mentions are the users mentioned by name in the input
+	mentions []Mention
+	// vi is set when Vietnamese words are read as well as English ones
+	vi bool
+	// err is set when a phrase is recognised but is not valid e.g. "at 25:00"
+	// rather than leaving the phrase to be read as the message of the reminder
+	err error
+	// failFrom and failAt are the first token and the failing token of the phrase which got the furthest
+	failFrom int
+	failAt   int
+}
+
+func newParser(input string) *parser {
+	return &parser{
+		input:    input,
+		tokens:   lex(input),
+		failFrom: -1,
+		failAt:   -1,
+	}
+}
+
+// Parse parses a "/remind <who> <when> <what>" command.
+// Vietnamese words are read when the chat reads the bot in Vietnamese or the command is "/nhac".
+// The users of the chat mentioned by name in the text are passed as mentions.
+// It returns a NotUnderstoodError with the words it could not read when it finds no time expression
+func Parse(text string, language i18n.Language, mentions ...Mention) (*Remind, error) {
+	p := newParser(text)
+	p.mentions = mentions
+	switch {
+	case p.phrase("/", "remind"):
+		p.vi = language == i18n.Vietnamese
+	case p.phrase("/", "nhac"):
+		p.vi = true
+	default:
+		return nil, &NotUnderstoodError{Words: strings.TrimSpace(text)}
+	}
+
+	remind := &Remind{Who: Me}
+	hasWho := p.try(func() bool { return p.who(remind) })
+
+	// a cron spec is written without saying who the reminder is for
+	ok := p.try(func() bool { return p.cron(&remind.When) })
+	if !ok && hasWho {
+		ok = p.try(func() bool { return p.every(&remind.When) }) ||
+			p.try(func() bool { return p.once(&remind.When) })
+	}
+
+	if p.err != nil {
+		return nil, p.err
+	}
+	if !ok {
+		return nil, p.notUnderstood()
+	}
+
+	remind.What = p.rest()
+	if remind.What == "" {
+		return remind, ErrMissingWhat
+	}
+
+	return remind, nil
+}
+
+func (p *parser) who(remind *Remind) bool {
+	switch {
+	case p.word("me") || (p.vi && p.word("toi", "minh")):
+		remind.Who = Me
+	case p.word("here") || (p.vi && (p.phrase("o", "day") || p.phrase("ca", "nhom"))):
+		remind.Who = Here
+	case p.username(&remind.Recipient) || p.mention(&remind.Recipient):
+		remind.Who = User
+	case p.roster(&remind.Roster):
+		remind.Who = Roster
+	default:
+		return false
+	}
+
+	remind.Private = p.word("privately") || p.phrase("in", "private") || p.phrase("by", "dm") ||
+		(p.vi && (p.phrase("nhan", "rieng") || p.word("rieng")))
+
+	return true
+}
+
+// roster consumes the users who take turns to be reminded e.g. "roster @alice @bob" or "roster @alice, @bob and @carol".
+// A roster needs at least two users
+func (p *parser) roster(members *[]reminder.Recipient) bool {
+	from := p.pos
+	if !p.word("roster") && !(p.vi && (p.phrase("luan", "phien") || p.phrase("lan", "luot"))) {
+		return false
+	}
+
+	separator := p.pos
+	for {
+		var member reminder.Recipient
+		if !p.username(&member) && !p.mention(&member) {
+			// a separator which isn't followed by a user is not part of the roster
+			p.pos = separator
+			break
+		}
+		*members = append(*members, member)
+
+		// users are separated by spaces, commas or "and"
+		separator = p.pos
+		_ = p.phrase(",") || p.word("and") || (p.vi && p.word("va"))
+	}
+
+	if p.err != nil || len(*members) < 2 {
+		return p.invalid(from)
+	}
+
+	return true
+}
+
+// username consumes a username e.g. "@alice_b"
+func (p *parser) username(recipient *reminder.Recipient) bool {
+	from := p.pos
+	if !p.phrase("@") {
+		return false
+	}
+
+	start := p.pos
+	for p.pos < len(p.tokens) && p.tokens[p.pos-1].adjacent(p.tokens[p.pos]) {
+		t := p.tokens[p.pos]
+		if t.kind != wordToken && t.kind != numberToken && t.text != "_" {
+			break
+		}
+		p.pos++
+	}
+
+	if p.pos == start {
+		return false
+	}
+
+	username := p.input[p.tokens[start].start:p.tokens[p.pos-1].end]
+	if !usernamePattern.MatchString(username) {
+		return p.invalid(from)
+	}
+
+	*recipient = reminder.Recipient{Username: username}
+
+	return true
+}
+
+// mention consumes the name of a user mentioned by name
+func (p *parser) mention(recipient *reminder.Recipient) bool {
+	t, ok := p.peek()
+	if !ok {
+		return false
+	}
+
+	for i := range p.mentions {
+		if p.mentions[i].Start != t.start {
+			continue
+		}
+
+		for p.pos < len(p.tokens) && p.tokens[p.pos].end <= p.mentions[i].End {
+			p.pos++
+		}
+		*recipient = reminder.Recipient{
+			UserID: p.mentions[i].UserID,
+			Name:   strings.TrimSpace(p.input[p.mentions[i].Start:p.mentions[i].End]),
+		}
+
+		return true
+	}
+
+	return false
+}
+
+func (p *parser) cron(when *Expression) bool {
+	if !p.word("cron") {
+		return false
+	}
+
+	t, ok := p.peek()
+	if !ok || t.kind != stringToken || strings.TrimSpace(t.text) == "" {
+		return false
+	}
+	p.pos++
+
+	*when = Cron{Spec: strings.Join(strings.Fields(t.text), " ")}
+
+	return true
+}
+
+// try runs a parse function and rewinds to where it started when it fails
+func (p *parser) try(parse func() bool) bool {
+	start := p.pos
+	if parse() && p.err == nil {
+		return true
+	}
+
+	p.miss(start)
+	p.pos = start
+
+	return false
+}
+
+// miss keeps the token which was the furthest to be read before failing, which is reported when nothing can be parsed,
+// along with where the outermost phrase it failed in started e.g. "every" in "every 3 fortnights"
+func (p *parser) miss(from int) {
+	if p.pos > p.failAt {
+		p.failFrom = from
+		p.failAt = p.pos
+	}
+
+	if p.failAt >= from && from < p.failFrom {
+		p.failFrom = from
+	}
+}
+
+// invalid fails the whole command with the phrase from the given token up to the current one
+func (p *parser) invalid(from int) bool {
+	if p.err == nil {
+		p.err = &NotUnderstoodError{Words: p.span(from, p.pos)}
+	}
+
+	return false
+}
+
+func (p *parser) notUnderstood() error {
+	to := p.failAt + 1
+	if to > len(p.tokens) {
+		to = len(p.tokens)
+	}
+
+	return &NotUnderstoodError{Words: p.span(p.failFrom, to)}
+}
+
+// span returns the input from the token at from up to the token before to
+func (p *parser) span(from, to int) string {
+	if from >= len(p.tokens) {
+		return strings.TrimSpace(p.input)
+	}
+	if to <= from {
+		to = from + 1
+	}
+
+	return p.input[p.tokens[from].start:p.tokens[to-1].end]
+}
+
+// rest returns the input after the parsed tokens without the punctuation which separates it from them
+func (p *parser) rest() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+
+	return strings.TrimSpace(strings.TrimLeft(p.input[p.tokens[p.pos].start:], ",.:;"))
+}
+
+func (p *parser) peek() (token, bool) {
+	if p.pos >= len(p.tokens) {
+		return token{}, false
+	}
+
+	return p.tokens[p.pos], true
+}
+
+// peekWord returns the current token if it is a word
+func (p *parser) peekWord() string {
+	t, ok := p.peek()
+	if !ok || t.kind != wordToken {
+		return ""
+	}
+
+	return t.text
+}
+
+// word consumes the current token if it is one of the words
+func (p *parser) word(words ...string) bool {
+	current := p.peekWord()
+	if current == "" {
+		return false
+	}
+
+	for i := range words {
+		if current == words[i] {
+			p.pos++
+			return true
+		}
+	}
+
+	return false
+}
+
+// phrase consumes the tokens if they are the words or symbols in order
+func (p *parser) phrase(texts ...string) bool {
+	start := p.pos
+	for i := range texts {
+		t, ok := p.peek()
+		if !ok || t.kind == numberToken || t.kind == stringToken || t.text != texts[i] {
+			p.pos = start
+			return false
+		}
+		p.pos++
+	}
+
+	return true
+}
+
+// adjacentSymbol consumes the current token if it is one of the symbols and it is adjacent to the previous token
+func (p *parser) adjacentSymbol(symbols ...string) bool {
+	t, ok := p.peek()
+	if !ok || t.kind != symbolToken || p.pos == 0 || !p.tokens[p.pos-1].adjacent(t) {
+		return false
+	}
+
+	for i := range symbols {
+		if t.text == symbols[i] {
+			p.pos++
+			return true
+		}
+	}
+
+	return false
+}
+
+// number consumes the current token if it is a number and returns its value and how many digits it has
+func (p *parser) number() (value, digits int, ok bool) {
+	t, ok := p.peek()
+	// numbers too long to be a date or an amount are not read
+	if !ok || t.kind != numberToken || len(t.text) > maxDigits {
+		return 0, 0, false
+	}
+
+	value, err := strconv.Atoi(t.text)
+	if err != nil {
+		return 0, 0, false
+	}
+	p.pos++
+
+	return value, len(t.text), true
+}
+
+// adjacentNumber consumes the current token if it is a number adjacent to the previous token
+func (p *parser) adjacentNumber() (value, digits int, ok bool) {
+	t, ok := p.peek()
+	if !ok || p.pos == 0 || !p.tokens[p.pos-1].adjacent(t) {
+		return 0, 0, false
+	}
+
+	return p.number()
+}
+
+// adjacentWord consumes the current token if it is one of the words and it is adjacent to the previous token
+func (p *parser) adjacentWord(words ...string) (string, bool) {
+	t, ok := p.peek()
+	if !ok || p.pos == 0 || !p.tokens[p.pos-1].adjacent(t) {
+		return "", false
+	}
+
+	if p.word(words...) {
+		return t.text, true
+	}
+
+	return "", false
+}
@@ -0,0 +1,785 @@
+package parser_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/husol/telegram-reminder-bot/pkg/cron"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
+	"github.com/husol/telegram-reminder-bot/pkg/parser"
+	"github.com/husol/telegram-reminder-bot/pkg/reminder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const what = "update weekly report"
+
+func TestParse_OnDay(t *testing.T) {
+	testCases := map[string]reminder.WordDateTime{
+		"/remind me at 20:45 update weekly report":                {When: reminder.Today, Hour: 20, Minute: 45},
+		"/remind me at 20.45 update weekly report":                {When: reminder.Today, Hour: 20, Minute: 45},
+		"/remind me at 8 update weekly report":                    {When: reminder.Today, Hour: 8},
+		"/remind me at 8pm update weekly report":                  {When: reminder.Today, Hour: 20},
+		"/remind me at 8 pm update weekly report":                 {When: reminder.Today, Hour: 20},
+		"/remind me at 12am update weekly report":                 {When: reminder.Today, Hour: 0},
+		"/remind me at 8:30pm update weekly report":               {When: reminder.Today, Hour: 20, Minute: 30},
+		"/remind me at 8.30pm update weekly report":               {When: reminder.Today, Hour: 20, Minute: 30},
+		"/remind me at noon update weekly report":                 {When: reminder.Today, Hour: 12},
+		"/remind me tonight update weekly report":                 {When: reminder.Today, Hour: 20},
+		"/remind me tonight at 19:45 update weekly report":        {When: reminder.Today, Hour: 19, Minute: 45},
+		"/remind me tonight at 19.45 update weekly report":        {When: reminder.Today, Hour: 19, Minute: 45},
+		"/remind me tonight at 19 update weekly report":           {When: reminder.Today, Hour: 19},
+		"/remind me tonight at 8pm update weekly report":          {When: reminder.Today, Hour: 20},
+		"/remind me tonight at 8:30pm update weekly report":       {When: reminder.Today, Hour: 20, Minute: 30},
+		"/remind me tonight at 8.30pm update weekly report":       {When: reminder.Today, Hour: 20, Minute: 30},
+		"/remind me this afternoon update weekly report":          {When: reminder.Today, Hour: 15},
+		"/remind me this evening update weekly report":            {When: reminder.Today, Hour: 20},
+		"/remind me today at 21:00 update weekly report":          {When: reminder.Today, Hour: 21},
+		"/remind me tomorrow update weekly report":                {When: reminder.Tomorrow, Hour: 9},
+		"/remind me tomorrow morning update weekly report":        {When: reminder.Tomorrow, Hour: 9},
+		"/remind me tomorrow afternoon update weekly report":      {When: reminder.Tomorrow, Hour: 15},
+		"/remind me tomorrow evening update weekly report":        {When: reminder.Tomorrow, Hour: 20},
+		"/remind me tomorrow at 20:45 update weekly report":       {When: reminder.Tomorrow, Hour: 20, Minute: 45},
+		"/remind me tomorrow at noon update weekly report":        {When: reminder.Tomorrow, Hour: 12},
+		"/remind me tomorrow in the evening update weekly report": {When: reminder.Tomorrow, Hour: 20},
+		"/remind me at 7am tomorrow update weekly report":         {When: reminder.Tomorrow, Hour: 7},
+		"/remind me Tomorrow At 7AM update weekly report":         {When: reminder.Tomorrow, Hour: 7},
+	}
+
+	for text := range testCases {
+		t.Run(text, func(t *testing.T) {
+			remind, err := parser.Parse(text, i18n.English)
+			require.NoError(t, err)
+			assert.Equal(t, &parser.Remind{
+				Who:  parser.Me,
+				When: parser.OnDay{WordDateTime: testCases[text]},
+				What: what,
+			}, remind)
+		})
+	}
+}
+
+func TestParse_OnDate(t *testing.T) {
+	testCases := map[string]reminder.DateTime{
+		"/remind me on the 4th of march update weekly report":               {DayOfMonth: 4, Month: 3, Hour: 9},
+		"/remind me on the 4th of March at 23:34 update weekly report":      {DayOfMonth: 4, Month: 3, Hour: 23, Minute: 34},
+		"/remind me on the 4th of March 2027 at 23:34 update weekly report": {Year: 2027, DayOfMonth: 4, Month: 3, Hour: 23, Minute: 34},
+		"/remind me on the 4th of March, 2027 update weekly report":         {Year: 2027, DayOfMonth: 4, Month: 3, Hour: 9},
+		"/remind me on the 4th of march at 23.34 update weekly report":      {DayOfMonth: 4, Month: 3, Hour: 23, Minute: 34},
+		"/remind me on the 4th of march at 23 update weekly report":         {DayOfMonth: 4, Month: 3, Hour: 23},
+		"/remind me on the 4th of march at 8pm update weekly report":        {DayOfMonth: 4, Month: 3, Hour: 20},
+		"/remind me on the 4th of march at 8:30pm update weekly report":     {DayOfMonth: 4, Month: 3, Hour: 20, Minute: 30},
+		"/remind me on the 4th of march at 8.30pm update weekly report":     {DayOfMonth: 4, Month: 3, Hour: 20, Minute: 30},
+		"/remind me on the 4 of dec update weekly report":                   {DayOfMonth: 4, Month: 12, Hour: 9},
+		"/remind me on the first of march update weekly report":             {DayOfMonth: 1, Month: 3, Hour: 9},
+		"/remind me on the 4th update weekly report":                        {DayOfMonth: 4, Hour: 9},
+		"/remind me on the 4th at 23:34 update weekly report":               {DayOfMonth: 4, Hour: 23, Minute: 34},
+		"/remind me on the 4th at 8.30pm update weekly report":              {DayOfMonth: 4, Hour: 20, Minute: 30},
+		"/remind me on the 3rd at noon update weekly report":                {DayOfMonth: 3, Hour: 12},
+		"/remind me on March 14th 2027 update weekly report":                {Year: 2027, DayOfMonth: 14, Month: 3, Hour: 9},
+		"/remind me on march 14, 2027 at 10:30 update weekly report":        {Year: 2027, DayOfMonth: 14, Month: 3, Hour: 10, Minute: 30},
+		"/remind me on March 14 update weekly report":                       {DayOfMonth: 14, Month: 3, Hour: 9},
+		"/remind me on 2027-03-14 at 10:00 update weekly report":            {Year: 2027, DayOfMonth: 14, Month: 3, Hour: 10},
+		"/remind me on 2027-03-14 update weekly report":                     {Year: 2027, DayOfMonth: 14, Month: 3, Hour: 9},
+		"/remind me on 14/03/2027 update weekly report":                     {Year: 2027, DayOfMonth: 14, Month: 3, Hour: 9, NumericDate: true},
+		"/remind me on 14.03.27 at 9pm update weekly report":                {Year: 2027, DayOfMonth: 14, Month: 3, Hour: 21, NumericDate: true},
+		"/remind me on 03/14 update weekly report":                          {DayOfMonth: 3, Month: 14, Hour: 9, NumericDate: true},
+		"/remind me on tuesday update weekly report":                        {DayOfWeek: "2", Hour: 9},
+		"/remind me on tuesday at 23:34 update weekly report":               {DayOfWeek: "2", Hour: 23, Minute: 34},
+		"/remind me on tuesday at 23.34 update weekly report":               {DayOfWeek: "2", Hour: 23, Minute: 34},
+		"/remind me on tuesday at 23 update weekly report":                  {DayOfWeek: "2", Hour: 23},
+		"/remind me on tuesday at 8pm update weekly report":                 {DayOfWeek: "2", Hour: 20},
+		"/remind me on tuesday at 8:30pm update weekly report":              {DayOfWeek: "2", Hour: 20, Minute: 30},
+		"/remind me on tuesday evening update weekly report":                {DayOfWeek: "2", Hour: 20},
+		"/remind me on tuesday evening at 23:34 update weekly report":       {DayOfWeek: "2", Hour: 23, Minute: 34},
+		"/remind me on tuesday night at 23.34 update weekly report":         {DayOfWeek: "2", Hour: 23, Minute: 34},
+		"/remind me on tuesday evening at 8pm update weekly report":         {DayOfWeek: "2", Hour: 20},
+		"/remind me on tuesday night at 8:30pm update weekly report":        {DayOfWeek: "2", Hour: 20, Minute: 30},
+		"/remind me tuesday evening update weekly report":                   {DayOfWeek: "2", Hour: 20},
+		"/remind me Sunday update weekly report":                            {DayOfWeek: "0", Hour: 9},
+		"/remind me next Friday afternoon update weekly report":             {DayOfWeek: "5", Hour: 15},
+		"/remind me next friday at 10am update weekly report":               {DayOfWeek: "5", Hour: 10},
+		"/remind me at 10:15 on the 1st of december update weekly report":   {DayOfMonth: 1, Month: 12, Hour: 10, Minute: 15},
+	}
+
+	for text := range testCases {
+		t.Run(text, func(t *testing.T) {
+			remind, err := parser.Parse(text, i18n.English)
+			require.NoError(t, err)
+			assert.Equal(t, &parser.Remind{
+				Who:  parser.Me,
+				When: parser.OnDate{DateTime: testCases[text]},
+				What: what,
+			}, remind)
+		})
+	}
+}
+
+func TestParse_In(t *testing.T) {
+	testCases := map[string]reminder.AmountDateTime{
+		"/remind me in 2 minutes update weekly report":                 {Minutes: 2},
+		"/remind me in 2 minutes, 3 days update weekly report":         {Minutes: 2, Days: 3},
+		"/remind me in 2 minutes, 1 hour, 3 days update weekly report": {Minutes: 2, Hours: 1, Days: 3},
+		"/remind me in 1 hour and 30 minutes update weekly report":     {Hours: 1, Minutes: 30},
+		"/remind me in 3 months update weekly report":                  {Months: 3},
+		"/remind me in 2 weeks at 9am update weekly report":            {Weeks: 2, At: &reminder.TimeOfDay{Hour: 9}},
+		"/remind me in 3 days in the afternoon update weekly report":   {Days: 3, At: &reminder.TimeOfDay{Hour: 15}},
+		"/remind me in 5 minutes, update weekly report":                {Minutes: 5},
+	}
+
+	for text := range testCases {
+		t.Run(text, func(t *testing.T) {
+			remind, err := parser.Parse(text, i18n.English)
+			require.NoError(t, err)
+			assert.Equal(t, &parser.Remind{
+				Who:  parser.Me,
+				When: parser.In{AmountDateTime: testCases[text]},
+				What: what,
+			}, remind)
+		})
+	}
+}
+
+func TestParse_Every(t *testing.T) {
+	testCases := map[string]reminder.AmountDateTime{
+		"/remind me every 2 minutes update weekly report":                 {Minutes: 2},
+		"/remind me every 2 minutes, 3 days update weekly report":         {Minutes: 2, Days: 3},
+		"/remind me every 2 minutes, 1 hour, 3 days update weekly report": {Minutes: 2, Hours: 1, Days: 3},
+		"/remind me every 3 months update weekly report":                  {Months: 3},
+		"/remind me every 2 weeks update weekly report":                   {Weeks: 2},
+		"/remind me every 1 month, 2 days update weekly report":           {Months: 1, Days: 2},
+		"/remind me every 120 minutes update weekly report":               {Minutes: 120},
+		"/remind me every minute update weekly report":                    {Minutes: 1},
+		"/remind me every hour update weekly report":                      {Hours: 1},
+		"/remind me every week update weekly report":                      {Weeks: 1},
+		"/remind me every month update weekly report":                     {Months: 1},
+		"/remind me every hour for 3 times update weekly report":          {Hours: 1, Ends: &reminder.EndCondition{Times: 3}},
+		"/remind me every 3 months skip holidays for 4 times update weekly report": {
+			Months:   3,
+			Holidays: cron.SkipHolidays,
+			Ends:     &reminder.EndCondition{Times: 4},
+		},
+	}
+
+	for text := range testCases {
+		t.Run(text, func(t *testing.T) {
+			remind, err := parser.Parse(text, i18n.English)
+			require.NoError(t, err)
+			assert.Equal(t, &parser.Remind{
+				Who:  parser.Me,
+				When: parser.Every{AmountDateTime: testCases[text]},
+				What: what,
+			}, remind)
+		})
+	}
+}
+
+func TestParse_EveryWeeks(t *testing.T) {
+	testCases := map[string]reminder.WeeklyDateTime{
+		"/remind me every 2 weeks on monday update weekly report":          {Weeks: 2, DayOfWeek: 1, Hour: 9},
+		"/remind me every 2 weeks on Monday at 23:34 update weekly report": {Weeks: 2, DayOfWeek: 1, Hour: 23, Minute: 34},
+		"/remind me every 1 week on friday evening update weekly report":   {Weeks: 1, DayOfWeek: 5, Hour: 20},
+		"/remind me every 3 weeks on sunday at 8pm for 4 times update weekly report": {
+			Weeks:     3,
+			DayOfWeek: 0,
+			Hour:      20,
+			Ends:      &reminder.EndCondition{Times: 4},
+		},
+	}
+
+	for text := range testCases {
+		t.Run(text, func(t *testing.T) {
+			remind, err := parser.Parse(text, i18n.English)
+			require.NoError(t, err)
+			assert.Equal(t, &parser.Remind{
+				Who:  parser.Me,
+				When: parser.EveryWeeks{WeeklyDateTime: testCases[text]},
+				What: what,
+			}, remind)
+		})
+	}
+}
+
+// nolint:funlen
+func TestParse_Repeat(t *testing.T) {
+	untilEndOfYear := &reminder.EndCondition{Until: &reminder.DateTime{DayOfMonth: 31, Month: 12}}
+	testCases := map[string]reminder.RepeatableDateTime{
+		"/remind me every day update weekly report":               {Hour: "9", Minute: "0"},
+		"/remind me every day at 23:34 update weekly report":      {Hour: "23", Minute: "34"},
+		"/remind me every day at 23.34 update weekly report":      {Hour: "23", Minute: "34"},
+		"/remind me every day at 23 update weekly report":         {Hour: "23", Minute: "0"},
+		"/remind me every day at 8pm update weekly report":        {Hour: "20", Minute: "0"},
+		"/remind me every day at 8:30pm update weekly report":     {Hour: "20", Minute: "30"},
+		"/remind me every morning update weekly report":           {Hour: "9", Minute: "0"},
+		"/remind me every evening update weekly report":           {Hour: "20", Minute: "0"},
+		"/remind me every evening at 23:34 update weekly report":  {Hour: "23", Minute: "34"},
+		"/remind me every night at 8.30pm update weekly report":   {Hour: "20", Minute: "30"},
+		"/remind me every weekday update weekly report":           {DayOfWeek: "1-5", Hour: "9", Minute: "0"},
+		"/remind me every weekday at 8pm update weekly report":    {DayOfWeek: "1-5", Hour: "20", Minute: "0"},
+		"/remind me every weekend update weekly report":           {DayOfWeek: "6,0", Hour: "9", Minute: "0"},
+		"/remind me every weekend at 8:30pm update weekly report": {DayOfWeek: "6,0", Hour: "20", Minute: "30"},
+		"/remind me every day at 8pm for 10 times update weekly report": {
+			Hour:   "20",
+			Minute: "0",
+			Ends:   &reminder.EndCondition{Times: 10},
+		},
+		"/remind me every day at 8pm 3 times update weekly report": {
+			Hour:   "20",
+			Minute: "0",
+			Ends:   &reminder.EndCondition{Times: 3},
+		},
+		"/remind me every day at 8pm until 31 december update weekly report": {
+			Hour:   "20",
+			Minute: "0",
+			Ends:   untilEndOfYear,
+		},
+		"/remind me every day at 8pm until December 31st, 2027 update weekly report": {
+			Hour:   "20",
+			Minute: "0",
+			Ends:   &reminder.EndCondition{Until: &reminder.DateTime{DayOfMonth: 31, Month: 12, Year: 2027}},
+		},
+		"/remind me every business day at 8:30 update weekly report": {
+			DayOfWeek: "1-5",
+			Hour:      "8",
+			Minute:    "30",
+			Holidays:  cron.SkipHolidays,
+		},
+		"/remind me every business day move to next business day update weekly report": {
+			DayOfWeek: "1-5",
+			Hour:      "9",
+			Minute:    "0",
+			Holidays:  cron.MoveToNextBusinessDay,
+		},
+		"/remind me every day at 8:30 move to next business day update weekly report": {
+			Hour:     "8",
+			Minute:   "30",
+			Holidays: cron.MoveToNextBusinessDay,
+		},
+		"/remind me every day move to the next business day at 8:30 update weekly report": {
+			Hour:     "8",
+			Minute:   "30",
+			Holidays: cron.MoveToNextBusinessDay,
+		},
+		"/remind me every 4th of the month update weekly report":          {DayOfMonth: "4", Month: "*", Hour: "9", Minute: "0"},
+		"/remind me every 4th of the month at 23:34 update weekly report": {DayOfMonth: "4", Month: "*", Hour: "23", Minute: "34"},
+		"/remind me every 4 of the month at 8.30pm update weekly report":  {DayOfMonth: "4", Month: "*", Hour: "20", Minute: "30"},
+		"/remind me every 4th of january update weekly report":            {DayOfMonth: "4", Month: "1", Hour: "9", Minute: "0"},
+		"/remind me every 4th of January at 23:34 update weekly report":   {DayOfMonth: "4", Month: "1", Hour: "23", Minute: "34"},
+		"/remind me every 4th of january at 8:30pm update weekly report":  {DayOfMonth: "4", Month: "1", Hour: "20", Minute: "30"},
+		"/remind me every monday update weekly report":                    {DayOfWeek: "1", Month: "*", Hour: "9", Minute: "0"},
+		"/remind me every monday at 23:34 update weekly report":           {DayOfWeek: "1", Month: "*", Hour: "23", Minute: "34"},
+		"/remind me every monday at 8.30pm update weekly report":          {DayOfWeek: "1", Month: "*", Hour: "20", Minute: "30"},
+		"/remind me every monday morning update weekly report":            {DayOfWeek: "1", Month: "*", Hour: "9", Minute: "0"},
+		"/remind me every monday evening update weekly report":            {DayOfWeek: "1", Month: "*", Hour: "20", Minute: "0"},
+		"/remind me every monday evening at 23 update weekly report":      {DayOfWeek: "1", Month: "*", Hour: "23", Minute: "0"},
+		"/remind me every monday night at 8pm update weekly report":       {DayOfWeek: "1", Month: "*", Hour: "20", Minute: "0"},
+		"/remind me every last day of the month update weekly report":     {DayOfMonth: "L", Month: "*", Hour: "9", Minute: "0"},
+		"/remind me every last day of the month at 18:15 update weekly report": {
+			DayOfMonth: "L",
+			Month:      "*",
+			Hour:       "18",
+			Minute:     "15",
+		},
+		"/remind me every first Monday of the month update weekly report": {DayOfWeek: "1#1", Month: "*", Hour: "9", Minute: "0"},
+		"/remind me every 3rd thursday at 18:30 update weekly report":     {DayOfWeek: "4#3", Month: "*", Hour: "18", Minute: "30"},
+		"/remind me every last Friday of the month at 5pm update weekly report": {
+			DayOfWeek: "5L",
+			Month:     "*",
+			Hour:      "17",
+			Minute:    "0",
+		},
+		"/remind me every second sunday evening for 3 times update weekly report": {
+			DayOfWeek: "0#2",
+			Month:     "*",
+			Hour:      "20",
+			Minute:    "0",
+			Ends:      &reminder.EndCondition{Times: 3},
+		},
+		"/remind me every monday at 9:00 until 31st of december update weekly report": {
+			DayOfWeek: "1",
+			Month:     "*",
+			Hour:      "9",
+			Minute:    "0",
+			Ends:      untilEndOfYear,
+		},
+		"/remind me every monday until December 31st at 9:00 update weekly report": {
+			DayOfWeek: "1",
+			Month:     "*",
+			Hour:      "9",
+			Minute:    "0",
+			Ends:      untilEndOfYear,
+		},
+		"/remind me every last day of the month afternoon until 31st of december update weekly report": {
+			DayOfMonth: "L",
+			Month:      "*",
+			Hour:       "15",
+			Minute:     "0",
+			Ends:       untilEndOfYear,
+		},
+		"/remind me every Monday at 9:00 skip holidays update weekly report": {
+			DayOfWeek: "1",
+			Month:     "*",
+			Hour:      "9",
+			Minute:    "0",
+			Holidays:  cron.SkipHolidays,
+		},
+	}
+
+	for text := range testCases {
+		t.Run(text, func(t *testing.T) {
+			remind, err := parser.Parse(text, i18n.English)
+			require.NoError(t, err)
+			assert.Equal(t, &parser.Remind{
+				Who:  parser.Me,
+				When: parser.Repeat{RepeatableDateTime: testCases[text]},
+				What: what,
+			}, remind)
+		})
+	}
+}
+
+func TestParse_Lunar(t *testing.T) {
+	testCases := map[string]reminder.LunarDateTime{
+		"/remind me every lunar 15th of the month update weekly report": {
+			DayOfMonth: 15, Hour: 9,
+		},
+		"/remind me every lunar 1st of the month at 7:30 update weekly report": {
+			DayOfMonth: 1, Hour: 7, Minute: 30,
+		},
+		"/remind me every lunar 10th of month 3 update weekly report": {
+			DayOfMonth: 10, Month: 3, Hour: 9,
+		},
+		"/remind me every lunar 10th of the 3rd month at 8am skip holidays update weekly report": {
+			DayOfMonth: 10, Month: 3, Hour: 8, Holidays: cron.SkipHolidays,
+		},
+		"/nhac toi ngày 15 âm lịch hàng tháng update weekly report": {
+			DayOfMonth: 15, Hour: 9,
+		},
+		"/nhac toi mỗi ngày rằm hàng tháng âm lịch lúc 6 giờ update weekly report": {
+			DayOfMonth: 15, Hour: 6,
+		},
+		"/nhac toi ngày 10 tháng 3 âm lịch update weekly report": {
+			DayOfMonth: 10, Month: 3, Hour: 9,
+		},
+		"/nhac toi ngày 10 tháng 3 âm lịch hàng năm lúc 8 giờ update weekly report": {
+			DayOfMonth: 10, Month: 3, Hour: 8,
+		},
+	}
+
+	for text := range testCases {
+		t.Run(text, func(t *testing.T) {
+			remind, err := parser.Parse(text, i18n.English)
+			require.NoError(t, err)
+			assert.Equal(t, &parser.Remind{
+				Who:  parser.Me,
+				When: parser.Lunar{LunarDateTime: testCases[text]},
+				What: what,
+			}, remind)
+		})
+	}
+}
+
+func TestParse_Cron(t *testing.T) {
+	testCases := map[string]string{
+		"with straight quotes": `/remind cron "*/15 9-17 * * 1-5" update weekly report`,
+		"with curly quotes":    `/remind cron “*/15 9-17 * * 1-5” update weekly report`,
+		"with extra spaces":    `/remind cron "*/15  9-17 * * 1-5 " update weekly report`,
+		"with who":             `/remind me cron "*/15 9-17 * * 1-5" update weekly report`,
+	}
+
+	for name := range testCases {
+		t.Run(name, func(t *testing.T) {
+			remind, err := parser.Parse(testCases[name], i18n.English)
+			require.NoError(t, err)
+			assert.Equal(t, &parser.Remind{
+				Who:  parser.Me,
+				When: parser.Cron{Spec: "*/15 9-17 * * 1-5"},
+				What: what,
+			}, remind)
+		})
+	}
+}
+
+func TestParse_What(t *testing.T) {
+	testCases := map[string]string{
+		"/remind me tomorrow at the office: update weekly report": "at the office: update weekly report",
+		"/remind me tomorrow, update weekly report":               "update weekly report",
+		"/remind me every day for the team update weekly report":  "for the team update weekly report",
+		"/remind me tomorrow today's report":                      "today's report",
+		"/remind me on Friday Mum's birthday":                     "Mum's birthday",
+		"/remind me in 2 hours In 2 hours":                        "In 2 hours",
+	}
+
+	for text := range testCases {
+		t.Run(text, func(t *testing.T) {
+			remind, err := parser.Parse(text, i18n.English)
+			require.NoError(t, err)
+			assert.Equal(t, testCases[text], remind.What)
+		})
+	}
+}
+
+// TestParse_README covers the examples the README has always given, which every version of the parser has to read
+// nolint:funlen
+func TestParse_README(t *testing.T) {
+	onDecemberFirst := parser.OnDate{DateTime: reminder.DateTime{DayOfMonth: 1, Month: 12, Hour: 9}}
+	onDecemberFirstAt := parser.OnDate{DateTime: reminder.DateTime{DayOfMonth: 1, Month: 12, Hour: 8, Minute: 23}}
+	everyDecemberFirst := parser.Repeat{
+		RepeatableDateTime: reminder.RepeatableDateTime{DayOfMonth: "1", Month: "12", Hour: "9", Minute: "0"},
+	}
+	everyDecemberFirstAt := parser.Repeat{
+		RepeatableDateTime: reminder.RepeatableDateTime{DayOfMonth: "1", Month: "12", Hour: "8", Minute: "23"},
+	}
+	everyFirstOfMonth := parser.Repeat{
+		RepeatableDateTime: reminder.RepeatableDateTime{DayOfMonth: "1", Month: "*", Hour: "9", Minute: "0"},
+	}
+	everyFirstOfMonthAt := parser.Repeat{
+		RepeatableDateTime: reminder.RepeatableDateTime{DayOfMonth: "1", Month: "*", Hour: "8", Minute: "23"},
+	}
+	testCases := map[string]struct {
+		when parser.Expression
+		what string
+	}{
+		"/remind me on the 1 of december Update your report":           {onDecemberFirst, "Update your report"},
+		"/remind me on the 1st of december Update your report":         {onDecemberFirst, "Update your report"},
+		"/remind me on the 1 of december at 8:23 Update your report":   {onDecemberFirstAt, "Update your report"},
+		"/remind me on the 1st of december at 8:23 Update your report": {onDecemberFirstAt, "Update your report"},
+		"/remind me tonight Update your report": {
+			parser.OnDay{WordDateTime: reminder.WordDateTime{When: reminder.Today, Hour: 20}},
+			"Update your report",
+		},
+		"/remind me tonight at 21:20 Update your report": {
+			parser.OnDay{WordDateTime: reminder.WordDateTime{When: reminder.Today, Hour: 21, Minute: 20}},
+			"Update your report",
+		},
+		"/remind me tomorrow morning Update your report": {
+			parser.OnDay{WordDateTime: reminder.WordDateTime{When: reminder.Tomorrow, Hour: 9}},
+			"Update your report",
+		},
+		"/remind me tomorrow at 16:45 Update your report": {
+			parser.OnDay{WordDateTime: reminder.WordDateTime{When: reminder.Tomorrow, Hour: 16, Minute: 45}},
+			"Update your report",
+		},
+		"/remind me on Tuesday Update your report": {
+			parser.OnDate{DateTime: reminder.DateTime{DayOfWeek: "2", Hour: 9}},
+			"Update your report",
+		},
+		"/remind me at 21:00 Update your report": {
+			parser.OnDay{WordDateTime: reminder.WordDateTime{When: reminder.Today, Hour: 21}},
+			"Update your report",
+		},
+		"/remind me in 3 days Update your report": {
+			parser.In{AmountDateTime: reminder.AmountDateTime{Days: 3}},
+			"Update your report",
+		},
+		"/remind me in 5 days, 3 hours, 4 minutes Update your report": {
+			parser.In{AmountDateTime: reminder.AmountDateTime{Days: 5, Hours: 3, Minutes: 4}},
+			"Update your report",
+		},
+		"/remind me in 5 hours Update your report": {
+			parser.In{AmountDateTime: reminder.AmountDateTime{Hours: 5}},
+			"Update your report",
+		},
+		"/remind me in 3 hours, 4 minutes Update your report": {
+			parser.In{AmountDateTime: reminder.AmountDateTime{Hours: 3, Minutes: 4}},
+			"Update your report",
+		},
+		"/remind me in 4 minutes Update your report": {
+			parser.In{AmountDateTime: reminder.AmountDateTime{Minutes: 4}},
+			"Update your report",
+		},
+		"/remind me every 1st of december Update yearly report":           {everyDecemberFirst, "Update yearly report"},
+		"/remind me every 1 of december Update yearly report":             {everyDecemberFirst, "Update yearly report"},
+		"/remind me every 1st of december at 8:23 Update yearly report":   {everyDecemberFirstAt, "Update yearly report"},
+		"/remind me every 1 of december at 8:23 Update yearly report":     {everyDecemberFirstAt, "Update yearly report"},
+		"/remind me every 1st of the month Update monthly report":         {everyFirstOfMonth, "Update monthly report"},
+		"/remind me every 1 of the month Update monthly report":           {everyFirstOfMonth, "Update monthly report"},
+		"/remind me every 1st of the month at 8:23 Update monthly report": {everyFirstOfMonthAt, "Update monthly report"},
+		"/remind me every 1 of the month at 8:23 Update monthly report":   {everyFirstOfMonthAt, "Update monthly report"},
+		"/remind me every Tuesday Update weekly report": {
+			parser.Repeat{RepeatableDateTime: reminder.RepeatableDateTime{DayOfWeek: "2", Month: "*", Hour: "9", Minute: "0"}},
+			"Update weekly report",
+		},
+		"/remind me every Tuesday at 8:23 Update weekly report": {
+			parser.Repeat{RepeatableDateTime: reminder.RepeatableDateTime{DayOfWeek: "2", Month: "*", Hour: "8", Minute: "23"}},
+			"Update weekly report",
+		},
+		"/remind me every day at 8pm Update daily report": {
+			parser.Repeat{RepeatableDateTime: reminder.RepeatableDateTime{Hour: "20", Minute: "0"}},
+			"Update daily report",
+		},
+		"/remind me every 5 days, 3 hours, 4 minutes Update your report": {
+			parser.Every{AmountDateTime: reminder.AmountDateTime{Days: 5, Hours: 3, Minutes: 4}},
+			"Update your report",
+		},
+		"/remind me every 3 hours, 4 minutes Update your report": {
+			parser.Every{AmountDateTime: reminder.AmountDateTime{Hours: 3, Minutes: 4}},
+			"Update your report",
+		},
+		"/remind me every 2 minutes Update your report": {
+			parser.Every{AmountDateTime: reminder.AmountDateTime{Minutes: 2}},
+			"Update your report",
+		},
+	}
+
+	for text := range testCases {
+		t.Run(text, func(t *testing.T) {
+			remind, err := parser.Parse(text, i18n.English)
+			require.NoError(t, err)
+			assert.Equal(t, &parser.Remind{
+				Who:  parser.Me,
+				When: testCases[text].when,
+				What: testCases[text].what,
+			}, remind)
+		})
+	}
+}
+
+func TestParse_Failure(t *testing.T) {
+	testCases := map[string]string{
+		"/remind me sometime soon update weekly report":                   "could not understand 'sometime'",
+		"/remind me on Fridy update weekly report":                        "could not understand 'on Fridy'",
+		"/remind me every 3 fortnights update weekly report":              "could not understand 'every 3 fortnights'",
+		"/remind me next week update weekly report":                       "could not understand 'next week'",
+		"/remind me tomorrow at 25 update weekly report":                  "could not understand 'at 25'",
+		"/remind me at 8:75 update weekly report":                         "could not understand 'at 8:75'",
+		"/remind me at 13pm update weekly report":                         "could not understand 'at 13pm'",
+		"/remind me on the 4th of marhc update weekly report":             "could not understand 'on the 4th of marhc'",
+		"/remind me on the 45th update weekly report":                     "could not understand '45th'",
+		"/remind me every day until 31st of decmber update weekly report": "could not understand 'until 31st of decmber'",
+		"/remind me every day until further notice update weekly report":  "could not understand 'until further'",
+		"/remind me in 0 minutes update weekly report":                    "could not understand 'in 0 minutes'",
+		"/remind me every 0 hours, 0 minutes update weekly report":        "could not understand 'every 0 hours, 0 minutes'",
+		"/remind me every day for 0 times update weekly report":           "could not understand 'for 0 times'",
+		"/nhac toi mỗi ngày đến ngày mai Họp nhóm":                        "could not understand 'đến ngày mai'",
+		"/remind me on the 31st of february update weekly report":         "could not understand 'on the 31st of february'",
+		"/remind me on April 31st update weekly report":                   "could not understand 'April 31st'",
+		"/remind me every 30th of february update weekly report":          "could not understand '30th of february'",
+		"/nhac toi ngày 31 tháng 4 Họp nhóm":                              "could not understand 'ngày 31 tháng 4'",
+		"/remind you tomorrow update weekly report":                       "could not understand 'you'",
+		`/remind cron "" update weekly report`:                            `could not understand 'cron ""'`,
+		"/remind me tomorrow":                                             "error: the reminder message is missing",
+		"/nhac toi ngày 5 tháng 13 Họp nhóm":                              "could not understand 'ngày 5 tháng 13'",
+		"/nhac toi lúc nào đó Họp nhóm":                                   "could not understand 'lúc nào'",
+		"/remind me every lunar 31st of the month update weekly report":   "could not understand 'lunar 31st of'",
+		"/remind me every lunar 10th of month 13 update weekly report":    "could not understand 'lunar 10th of month 13'",
+		"/nhac toi ngày 10 tháng 13 âm lịch Họp nhóm":                     "could not understand 'ngày 10 tháng 13 âm lịch'",
+	}
+
+	for text := range testCases {
+		t.Run(text, func(t *testing.T) {
+			_, err := parser.Parse(text, i18n.English)
+			require.EqualError(t, err, testCases[text])
+		})
+	}
+}
+
+func TestParse_MissingWhat(t *testing.T) {
+	remind, err := parser.Parse("/remind me tomorrow at 9", i18n.English)
+	require.Equal(t, parser.ErrMissingWhat, err)
+	require.NotNil(t, remind)
+	assert.Equal(t, parser.OnDay{WordDateTime: reminder.WordDateTime{When: reminder.Tomorrow, Hour: 9}}, remind.When)
+	assert.Equal(t, "", remind.What)
+}
+
+// nolint:funlen
+func TestParse_Vietnamese(t *testing.T) {
+	testCases := map[string]parser.Expression{
+		"/nhac toi luc 8:00 ngay mai Họp nhóm": parser.OnDay{
+			WordDateTime: reminder.WordDateTime{When: reminder.Tomorrow, Hour: 8},
+		},
+		"/nhắc tôi ngày mai lúc 8 giờ Họp nhóm": parser.OnDay{
+			WordDateTime: reminder.WordDateTime{When: reminder.Tomorrow, Hour: 8},
+		},
+		"/nhac toi 8 giờ tối nay Họp nhóm": parser.OnDay{
+			WordDateTime: reminder.WordDateTime{When: reminder.Today, Hour: 20},
+		},
+		"/nhac toi tối nay lúc 8 giờ Họp nhóm": parser.OnDay{
+			WordDateTime: reminder.WordDateTime{When: reminder.Today, Hour: 20},
+		},
+		"/nhac toi 11 giờ đêm nay Họp nhóm": parser.OnDay{
+			WordDateTime: reminder.WordDateTime{When: reminder.Today, Hour: 23},
+		},
+		"/nhac toi chiều mai Họp nhóm": parser.OnDay{
+			WordDateTime: reminder.WordDateTime{When: reminder.Tomorrow, Hour: 15},
+		},
+		"/nhac toi thứ sáu lúc 15h30 Họp nhóm": parser.OnDate{
+			DateTime: reminder.DateTime{DayOfWeek: "5", Hour: 15, Minute: 30},
+		},
+		"/nhac toi thứ 6 lúc 8 giờ rưỡi sáng Họp nhóm": parser.OnDate{
+			DateTime: reminder.DateTime{DayOfWeek: "5", Hour: 8, Minute: 30},
+		},
+		"/nhac toi chủ nhật Họp nhóm": parser.OnDate{
+			DateTime: reminder.DateTime{DayOfWeek: "0", Hour: 9},
+		},
+		"/nhac toi ngày 14/3 lúc 10:00 Họp nhóm": parser.OnDate{
+			DateTime: reminder.DateTime{DayOfMonth: 14, Month: 3, Hour: 10, NumericDate: true},
+		},
+		"/nhac toi ngày 14 tháng 3 năm 2027 Họp nhóm": parser.OnDate{
+			DateTime: reminder.DateTime{Year: 2027, DayOfMonth: 14, Month: 3, Hour: 9},
+		},
+		"/nhac toi sau 2 tiếng nữa Họp nhóm": parser.In{
+			AmountDateTime: reminder.AmountDateTime{Hours: 2},
+		},
+		"/nhac toi trong 5 ngày, 3 giờ, 4 phút Họp nhóm": parser.In{
+			AmountDateTime: reminder.AmountDateTime{Days: 5, Hours: 3, Minutes: 4},
+		},
+		"/nhac toi mỗi 3 tháng Họp nhóm": parser.Every{
+			AmountDateTime: reminder.AmountDateTime{Months: 3},
+		},
+		"/nhac toi mỗi 2 tuần vào thứ hai lúc 9:00 Họp nhóm": parser.EveryWeeks{
+			WeeklyDateTime: reminder.WeeklyDateTime{Weeks: 2, DayOfWeek: 1, Hour: 9},
+		},
+		"/nhac toi mỗi thứ hai lúc 9:00 Họp nhóm": parser.Repeat{
+			RepeatableDateTime: reminder.RepeatableDateTime{DayOfWeek: "1", Month: "*", Hour: "9", Minute: "0"},
+		},
+		"/nhac toi mỗi ngày làm việc lúc 9:00 Họp nhóm": parser.Repeat{
+			RepeatableDateTime: reminder.RepeatableDateTime{DayOfWeek: "1-5", Hour: "9", Minute: "0", Holidays: cron.SkipHolidays},
+		},
+		"/nhac toi mỗi cuối tuần Họp nhóm": parser.Repeat{
+			RepeatableDateTime: reminder.RepeatableDateTime{DayOfWeek: "6,0", Hour: "9", Minute: "0"},
+		},
+		"/nhac toi ngày 1 hàng tháng Họp nhóm": parser.Repeat{
+			RepeatableDateTime: reminder.RepeatableDateTime{DayOfMonth: "1", Month: "*", Hour: "9", Minute: "0"},
+		},
+		"/nhac toi mỗi ngày cuối tháng Họp nhóm": parser.Repeat{
+			RepeatableDateTime: reminder.RepeatableDateTime{DayOfMonth: "L", Month: "*", Hour: "9", Minute: "0"},
+		},
+		"/nhac toi mỗi giờ Họp nhóm": parser.Every{AmountDateTime: reminder.AmountDateTime{Hours: 1}},
+		"/nhac toi mỗi ngày lúc 20:00 10 lần Họp nhóm": parser.Repeat{
+			RepeatableDateTime: reminder.RepeatableDateTime{Hour: "20", Minute: "0", Ends: &reminder.EndCondition{Times: 10}},
+		},
+		"/nhac toi mỗi thứ hai lúc 9:00 đến ngày 31/12 Họp nhóm": parser.Repeat{
+			RepeatableDateTime: reminder.RepeatableDateTime{
+				DayOfWeek: "1",
+				Month:     "*",
+				Hour:      "9",
+				Minute:    "0",
+				Ends:      &reminder.EndCondition{Until: &reminder.DateTime{DayOfMonth: 31, Month: 12, NumericDate: true}},
+			},
+		},
+		"/nhac toi mỗi ngày lúc 20:00 đến ngày 31 tháng 12 năm 2027 Họp nhóm": parser.Repeat{
+			RepeatableDateTime: reminder.RepeatableDateTime{
+				Hour:   "20",
+				Minute: "0",
+				Ends:   &reminder.EndCondition{Until: &reminder.DateTime{DayOfMonth: 31, Month: 12, Year: 2027}},
+			},
+		},
+		"/nhac toi mỗi thứ hai lúc 9:00 bỏ qua ngày lễ Họp nhóm": parser.Repeat{
+			RepeatableDateTime: reminder.RepeatableDateTime{
+				DayOfWeek: "1",
+				Month:     "*",
+				Hour:      "9",
+				Minute:    "0",
+				Holidays:  cron.SkipHolidays,
+			},
+		},
+	}
+
+	for text := range testCases {
+		t.Run(text, func(t *testing.T) {
+			remind, err := parser.Parse(text, i18n.English)
+			require.NoError(t, err)
+			assert.Equal(t, &parser.Remind{
+				Who:  parser.Me,
+				When: testCases[text],
+				What: "Họp nhóm",
+			}, remind)
+		})
+	}
+}
+
+func TestParse_VietnameseChat(t *testing.T) {
+	text := "/remind me ngày mai lúc 8 giờ Họp nhóm"
+
+	remind, err := parser.Parse(text, i18n.Vietnamese)
+	require.NoError(t, err)
+	assert.Equal(t, &parser.Remind{
+		Who:  parser.Me,
+		When: parser.OnDay{WordDateTime: reminder.WordDateTime{When: reminder.Tomorrow, Hour: 8}},
+		What: "Họp nhóm",
+	}, remind)
+
+	_, err = parser.Parse(text, i18n.English)
+	require.EqualError(t, err, "could not understand 'ngày'")
+}
+
+func TestParse_Who(t *testing.T) {
+	alice := reminder.Recipient{Username: "alice_b2"}
+
+	testCases := map[string]struct {
+		who       parser.Who
+		recipient reminder.Recipient
+		private   bool
+	}{
+		"/remind me privately tomorrow update weekly report": {who: parser.Me, private: true},
+		"/remind here tomorrow update weekly report":         {who: parser.Here},
+		"/remind @alice_b2 tomorrow update weekly report":    {who: parser.User, recipient: alice},
+		"/remind @alice_b2 in private tomorrow update weekly report": {
+			who: parser.User, recipient: alice, private: true,
+		},
+		"/remind @alice_b2 by dm tomorrow update weekly report": {who: parser.User, recipient: alice, private: true},
+		"/nhac ở đây ngày mai update weekly report":             {who: parser.Here},
+		"/nhac @alice_b2 nhắn riêng ngày mai update weekly report": {
+			who: parser.User, recipient: alice, private: true,
+		},
+	}
+
+	for text := range testCases {
+		t.Run(text, func(t *testing.T) {
+			remind, err := parser.Parse(text, i18n.English)
+			require.NoError(t, err)
+			assert.Equal(t, &parser.Remind{
+				Who:       testCases[text].who,
+				Recipient: testCases[text].recipient,
+				Private:   testCases[text].private,
+				When:      parser.OnDay{WordDateTime: reminder.WordDateTime{When: reminder.Tomorrow, Hour: 9}},
+				What:      what,
+			}, remind)
+		})
+	}
+}
+
+func TestParse_WhoMention(t *testing.T) {
+	text := "/remind Bình An tomorrow update weekly report"
+
+	remind, err := parser.Parse(text, i18n.English, parser.Mention{Start: 8, End: 16, UserID: 42})
+	require.NoError(t, err)
+	assert.Equal(t, &parser.Remind{
+		Who:       parser.User,
+		Recipient: reminder.Recipient{UserID: 42, Name: "Bình An"},
+		When:      parser.OnDay{WordDateTime: reminder.WordDateTime{When: reminder.Tomorrow, Hour: 9}},
+		What:      what,
+	}, remind)
+}
+
+func TestParse_WhoInvalidUsername(t *testing.T) {
+	_, err := parser.Parse("/remind @bob tomorrow update weekly report", i18n.English)
+	require.EqualError(t, err, "could not understand '@bob'")
+}
+
+func TestParse_WhoRoster(t *testing.T) {
+	roster := []reminder.Recipient{{Username: "alice_b2"}, {UserID: 42, Name: "Bình An"}, {Username: "carol"}}
+
+	testCases := map[string]string{
+		"/remind roster @alice_b2 Bình An @carol every monday at 9 update weekly report":       "Bình An",
+		"/remind roster @alice_b2, Bình An and @carol every monday at 9 update weekly report":  "Bình An",
+		"/nhac luân phiên @alice_b2, Bình An và @carol every monday at 9 update weekly report": "Bình An",
+	}
+
+	for text := range testCases {
+		t.Run(text, func(t *testing.T) {
+			start := strings.Index(text, testCases[text])
+			mention := parser.Mention{Start: start, End: start + len(testCases[text]), UserID: 42}
+
+			remind, err := parser.Parse(text, i18n.English, mention)
+			require.NoError(t, err)
+			assert.Equal(t, parser.Roster, remind.Who)
+			assert.Equal(t, roster, remind.Roster)
+			assert.Equal(t, what, remind.What)
+		})
+	}
+
+	_, err := parser.Parse("/remind roster @alice_b2 every monday at 9 update weekly report", i18n.English)
+	require.EqualError(t, err, "could not understand 'roster @alice_b2'")
+}
@@ -0,0 +1,360 @@
+package parser
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/husol/telegram-reminder-bot/pkg/cron"
+	"github.com/husol/telegram-reminder-bot/pkg/date"
+	"github.com/husol/telegram-reminder-bot/pkg/reminder"
+)
+
+// Vietnamese words are matched without their accents, see fold
+
+// viPeriods are the hours parts of the day stand for e.g. "chiều mai" is tomorrow afternoon
+// nolint:gochecknoglobals,gomnd
+var viPeriods = map[string]int{
+	"sang":  9,
+	"trua":  12,
+	"chieu": 15,
+	"toi":   20,
+	"dem":   20,
+}
+
+// nolint:gochecknoglobals
+var viUnits = map[string]unit{
+	"phut":  minutes,
+	"gio":   hours,
+	"tieng": hours,
+	"ngay":  days,
+	"tuan":  weeks,
+	"thang": monthsUnit,
+}
+
+// viWeekdays are the days of the week after "thứ" e.g. "thứ hai" is Monday
+// nolint:gochecknoglobals
+var viWeekdays = map[string]time.Weekday{
+	"hai": time.Monday,
+	"ba":  time.Tuesday,
+	"tu":  time.Wednesday,
+	"nam": time.Thursday,
+	"sau": time.Friday,
+	"bay": time.Saturday,
+}
+
+// halfPast is the minute of "8 giờ rưỡi"
+const halfPast = 30
+
+// viDay parses a day written in Vietnamese e.g. "hôm nay", "ngày mai", "chiều mai", "tối nay", "thứ sáu",
+// "vào ngày 14/3", "ngày 14 tháng 3 năm 2027" or "sau 2 tiếng nữa"
+func (p *parser) viDay(m *moment) bool {
+	from := p.pos
+
+	switch {
+	case p.phrase("hom", "nay"):
+		m.day = dayToday
+	case p.phrase("ngay", "mai"), p.word("mai"):
+		m.day = dayTomorrow
+	case p.word("trong", "sau"):
+		m.day = dayIn
+		if !p.amount(&m.amount) {
+			return false
+		}
+		p.word("nua")
+	default:
+		if hour, ok := viPeriods[p.peekWord()]; ok {
+			p.pos++
+			return p.viDayOfPeriod(m) && p.setPeriod(&m.timeOfDay, hour)
+		}
+
+		p.word("vao")
+		if p.weekday(&m.weekday) {
+			m.day = dayWeekday
+			return true
+		}
+		if !p.word("ngay") {
+			return false
+		}
+		m.day = dayDate
+
+		return p.viDate(from, true, &m.date)
+	}
+
+	return true
+}
+
+// viDayOfPeriod parses the day after a part of the day e.g. "nay" in "tối nay" or "thứ sáu" in "chiều thứ sáu"
+func (p *parser) viDayOfPeriod(m *moment) bool {
+	switch {
+	case p.word("nay"):
+		m.day = dayToday
+	case p.phrase("ngay", "mai"), p.word("mai"):
+		m.day = dayTomorrow
+	case p.weekday(&m.weekday):
+		m.day = dayWeekday
+	default:
+		return false
+	}
+
+	return true
+}
+
+// viWeekday parses a day of the week e.g. "thứ hai", "thứ 2", "chủ nhật" or "CN"
+// nolint:gomnd
+func (p *parser) viWeekday(day *time.Weekday) bool {
+	if p.phrase("chu", "nhat") || p.word("cn") {
+		*day = time.Sunday
+		return true
+	}
+
+	start := p.pos
+	if !p.word("thu") {
+		return false
+	}
+
+	if d, ok := viWeekdays[p.peekWord()]; ok {
+		p.pos++
+		*day = d
+
+		return true
+	}
+
+	if n, _, ok := p.number(); ok && n >= 2 && n <= 7 {
+		*day = time.Weekday(n - 1)
+		return true
+	}
+	p.pos = start
+
+	return false
+}
+
+// viDate parses the date after "ngày" e.g. "14/3", "14/3/2027", "14", "14 tháng 3" or "14 tháng 3 năm 2027".
+// from is where the phrase started for the error when the month or the year are not valid
+// nolint:gomnd
+func (p *parser) viDate(from int, withYear bool, dateTime *reminder.DateTime) bool {
+	var numeric reminder.DateTime
+	if p.try(func() bool { return p.numericDate(&numeric) && (withYear || numeric.Year == 0) }) {
+		*dateTime = numeric
+		return true
+	}
+
+	day, ok := p.dayOfMonth()
+	if !ok {
+		return false
+	}
+	dateTime.DayOfMonth = day
+
+	if !p.word("thang") {
+		return true
+	}
+
+	month, _, ok := p.number()
+	if !ok || month < 1 || month > 12 || day > date.MaxDaysInMonth(month) {
+		return p.invalid(from)
+	}
+	dateTime.Month = month
+
+	if withYear && p.word("nam") {
+		year, digits, ok := p.number()
+		if !ok || digits != 4 {
+			return p.invalid(from)
+		}
+		dateTime.Year = year
+	}
+
+	return true
+}
+
+// viOClock parses the hours and minutes after the hour of a time written in Vietnamese e.g. "giờ", "giờ 30",
+// "giờ 30 phút" or "giờ rưỡi", as well as the short form e.g. "h" or "h30" in "8h30"
+func (p *parser) viOClock(minute *int) bool {
+	if !p.word("gio", "h") {
+		return false
+	}
+
+	if p.word("ruoi") {
+		*minute = halfPast
+		return true
+	}
+
+	if m, _, ok := p.number(); ok {
+		*minute = m
+		p.word("phut", "p")
+	}
+
+	return true
+}
+
+// viMeridiem parses the part of the day after a time which says whether it is before or after noon
+// e.g. "8 giờ tối" is 20:00. It is left to be read as a day when it is followed by one e.g. "8 giờ tối nay"
+// nolint:gomnd
+func (p *parser) viMeridiem(hour *int) bool {
+	word := p.peekWord()
+	if _, ok := viPeriods[word]; !ok {
+		return false
+	}
+	if p.pos+1 < len(p.tokens) {
+		if next := p.tokens[p.pos+1].text; next == "nay" || next == "mai" {
+			return false
+		}
+	}
+	p.pos++
+
+	switch {
+	case word == "sang":
+		if *hour == 12 {
+			*hour = 0
+		}
+	// "11 giờ trưa" is before noon and "11 giờ đêm" is after it
+	case word == "trua" && *hour >= 11, word == "dem" && *hour < 9:
+	default:
+		if *hour < 12 {
+			*hour += 12
+		}
+	}
+
+	return true
+}
+
+// viEveryDayOfMonth parses a day of every month e.g. "ngày 5 hàng tháng" or a day of every year e.g. "ngày 5 tháng 1"
+// nolint:gomnd
+func (p *parser) viEveryDayOfMonth(r *recurrence) bool {
+	from := p.pos
+	if !p.word("ngay") {
+		return false
+	}
+
+	day, ok := p.dayOfMonth()
+	if !ok {
+		return false
+	}
+
+	month := "*"
+	switch {
+	case p.phrase("hang", "thang"), p.phrase("moi", "thang"):
+	case p.word("thang"):
+		m, _, ok := p.number()
+		if !ok || m < 1 || m > 12 || day > date.MaxDaysInMonth(m) {
+			return p.invalid(from)
+		}
+		month = strconv.Itoa(m)
+	default:
+		return false
+	}
+
+	r.kind = recurRepeat
+	r.repeat = reminder.RepeatableDateTime{DayOfMonth: strconv.Itoa(day), Month: month}
+
+	return true
+}
+
+// viEveryLastDayOfMonth parses "ngày cuối tháng" or "ngày cuối cùng của tháng"
+func (p *parser) viEveryLastDayOfMonth(r *recurrence) bool {
+	if !p.phrase("ngay", "cuoi", "thang") && !p.phrase("ngay", "cuoi", "cung", "cua", "thang") {
+		return false
+	}
+
+	r.kind = recurRepeat
+	r.repeat = reminder.RepeatableDateTime{DayOfMonth: "L", Month: "*"}
+
+	return true
+}
+
+// fullMoonDay is the day of the lunar month "rằm" stands for
+const fullMoonDay = 15
+
+// viEveryLunarDay parses a day of every lunar month e.g. "ngày 15 âm lịch hàng tháng" or "ngày rằm hàng tháng âm lịch",
+// or a day of a lunar month every year e.g. "ngày 10 tháng 3 âm lịch" or "ngày 10 tháng 3 âm lịch hàng năm".
+// The day and the month are only checked once "âm lịch" is read, as solar dates are parsed the same way
+// nolint:gomnd
+func (p *parser) viEveryLunarDay(r *recurrence) bool {
+	from := p.pos
+	if !p.word("ngay") {
+		return false
+	}
+
+	day := fullMoonDay
+	if !p.word("ram") {
+		var ok bool
+		if day, _, ok = p.number(); !ok {
+			return false
+		}
+	}
+
+	valid := day >= 1 && day <= 30
+	month := 0
+	lunar := p.phrase("am", "lich")
+	switch {
+	case p.phrase("hang", "thang"), p.phrase("moi", "thang"):
+	case p.word("thang"):
+		var ok bool
+		if month, _, ok = p.number(); !ok {
+			return false
+		}
+		valid = valid && month >= 1 && month <= 12
+	default:
+		return false
+	}
+	if !lunar && !p.phrase("am", "lich") {
+		return false
+	}
+	if !valid {
+		return p.invalid(from)
+	}
+	if month != 0 {
+		_ = p.phrase("hang", "nam") || p.phrase("moi", "nam")
+	}
+
+	r.kind = recurLunar
+	r.lunar = reminder.LunarDateTime{DayOfMonth: day, Month: month}
+
+	return true
+}
+
+// viHolidays parses what a recurring reminder does on holidays e.g. "bỏ qua ngày lễ" or "dời sang ngày làm việc tiếp theo"
+func (p *parser) viHolidays(r *recurrence) bool {
+	switch {
+	case p.phrase("bo", "qua", "ngay", "le"):
+		r.holidays = cron.SkipHolidays
+	case p.phrase("doi", "sang", "ngay", "lam", "viec", "tiep", "theo"),
+		p.phrase("chuyen", "sang", "ngay", "lam", "viec", "tiep", "theo"):
+		r.holidays = cron.MoveToNextBusinessDay
+	default:
+		return false
+	}
+
+	return true
+}
+
+// viEnds parses how a recurring reminder ends e.g. "đến ngày 31/12", "đến ngày 31 tháng 12 năm 2027" or "10 lần".
+// A date after "đến ngày" which can't be read fails the command
+func (p *parser) viEnds(r *recurrence) bool {
+	from := p.pos
+	if p.word("den") || p.phrase("cho", "den") {
+		until := &reminder.DateTime{}
+		if !p.word("ngay") {
+			return false
+		}
+		if !p.try(func() bool { return p.viDate(from, true, until) && until.Month > 0 }) {
+			if p.pos < len(p.tokens) {
+				p.pos++
+			}
+			return p.invalid(from)
+		}
+		r.ends = &reminder.EndCondition{Until: until}
+
+		return true
+	}
+
+	p.word("trong")
+	times, _, ok := p.number()
+	if !ok || !p.word("lan") {
+		return false
+	}
+	if times < 1 {
+		return p.invalid(from)
+	}
+	r.ends = &reminder.EndCondition{Times: times}
+
+	return true
+}
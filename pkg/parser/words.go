@@ -0,0 +1,101 @@
+package parser
+
+import (
+	"time"
+)
+
+// defaultHour is when a reminder set on a day without a time is sent
+const defaultHour = 9
+
+// twoDigitYearBase is added to years written with two digits e.g. 14/03/27
+const twoDigitYearBase = 2000
+
+// nolint:gochecknoglobals
+var months = map[string]time.Month{
+	"january":   time.January,
+	"jan":       time.January,
+	"february":  time.February,
+	"feb":       time.February,
+	"march":     time.March,
+	"mar":       time.March,
+	"april":     time.April,
+	"apr":       time.April,
+	"may":       time.May,
+	"june":      time.June,
+	"jun":       time.June,
+	"july":      time.July,
+	"jul":       time.July,
+	"august":    time.August,
+	"aug":       time.August,
+	"september": time.September,
+	"sep":       time.September,
+	"sept":      time.September,
+	"october":   time.October,
+	"oct":       time.October,
+	"november":  time.November,
+	"nov":       time.November,
+	"december":  time.December,
+	"dec":       time.December,
+}
+
+// nolint:gochecknoglobals
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+// periods are the hours parts of the day stand for e.g. "tomorrow afternoon"
+// nolint:gochecknoglobals,gomnd
+var periods = map[string]int{
+	"morning":   9,
+	"noon":      12,
+	"afternoon": 15,
+	"evening":   20,
+	"night":     20,
+	"midnight":  0,
+}
+
+type unit int
+
+const (
+	minutes unit = iota
+	hours
+	days
+	weeks
+	monthsUnit
+)
+
+// nolint:gochecknoglobals
+var units = map[string]unit{
+	"minute":  minutes,
+	"minutes": minutes,
+	"min":     minutes,
+	"mins":    minutes,
+	"hour":    hours,
+	"hours":   hours,
+	"day":     days,
+	"days":    days,
+	"week":    weeks,
+	"weeks":   weeks,
+	"month":   monthsUnit,
+	"months":  monthsUnit,
+}
+
+// ordinals are the ordinals written as words. last is -1
+// nolint:gochecknoglobals,gomnd
+var ordinals = map[string]int{
+	"first":  1,
+	"second": 2,
+	"third":  3,
+	"fourth": 4,
+	"fifth":  5,
+	"last":   -1,
+}
+
+// nolint:gochecknoglobals
+var ordinalSuffixes = []string{"st", "nd", "rd", "th"}
@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: permission.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockChecker is a mock of Checker interface
+type MockChecker struct {
+	ctrl     *gomock.Controller
+	recorder *MockCheckerMockRecorder
+}
+
+// MockCheckerMockRecorder is the mock recorder for MockChecker
+type MockCheckerMockRecorder struct {
+	mock *MockChecker
+}
+
+// NewMockChecker creates a new mock instance
+func NewMockChecker(ctrl *gomock.Controller) *MockChecker {
+	mock := &MockChecker{ctrl: ctrl}
+	mock.recorder = &MockCheckerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockChecker) EXPECT() *MockCheckerMockRecorder {
+	return m.recorder
+}
+
+// CanManageReminder mocks base method
+func (m *MockChecker) CanManageReminder(chatID, userID, reminderID int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CanManageReminder", chatID, userID, reminderID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CanManageReminder indicates an expected call of CanManageReminder
+func (mr *MockCheckerMockRecorder) CanManageReminder(chatID, userID, reminderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanManageReminder", reflect.TypeOf((*MockChecker)(nil).CanManageReminder), chatID, userID, reminderID)
+}
+
+// CanManageChat mocks base method
+func (m *MockChecker) CanManageChat(chatID, userID int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CanManageChat", chatID, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CanManageChat indicates an expected call of CanManageChat
+func (mr *MockCheckerMockRecorder) CanManageChat(chatID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanManageChat", reflect.TypeOf((*MockChecker)(nil).CanManageChat), chatID, userID)
+}
+
+// CanSetTimeZone mocks base method
+func (m *MockChecker) CanSetTimeZone(chatID, userID int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CanSetTimeZone", chatID, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CanSetTimeZone indicates an expected call of CanSetTimeZone
+func (mr *MockCheckerMockRecorder) CanSetTimeZone(chatID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanSetTimeZone", reflect.TypeOf((*MockChecker)(nil).CanSetTimeZone), chatID, userID)
+}
+
+// IsAdmin mocks base method
+func (m *MockChecker) IsAdmin(chatID, userID int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsAdmin", chatID, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsAdmin indicates an expected call of IsAdmin
+func (mr *MockCheckerMockRecorder) IsAdmin(chatID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAdmin", reflect.TypeOf((*MockChecker)(nil).IsAdmin), chatID, userID)
+}
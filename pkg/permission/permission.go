@@ -0,0 +1,113 @@
+package permission
+
+//go:generate mockgen -source=$GOFILE -destination=mocks/${GOFILE} -package=mocks
+
+import (
+	"github.com/husol/telegram-reminder-bot/pkg/chatpreference"
+	"github.com/husol/telegram-reminder-bot/pkg/reminder"
+	"github.com/husol/telegram-reminder-bot/pkg/telegram"
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+// Checker tells whether a member of a chat can manage its reminders and settings under the permissions the chat set.
+// userID is 0 when the member is not known e.g. for messages sent on behalf of the group
+type Checker interface {
+	CanManageReminder(chatID, userID, reminderID int) (bool, error)
+	CanManageChat(chatID, userID int) (bool, error)
+	CanSetTimeZone(chatID, userID int) (bool, error)
+	IsAdmin(chatID, userID int) (bool, error)
+}
+
+type Service struct {
+	reminderStore       reminder.Storer
+	chatPreferenceStore chatpreference.Storer
+	members             telegram.ChatMemberGetter
+}
+
+func NewService(
+	reminderStore reminder.Storer,
+	chatPreferenceStore chatpreference.Storer,
+	members telegram.ChatMemberGetter,
+) *Service {
+	return &Service{
+		reminderStore:       reminderStore,
+		chatPreferenceStore: chatPreferenceStore,
+		members:             members,
+	}
+}
+
+// CanManageReminder tells whether the member can change, pause, skip, delete or finish a reminder of the chat
+func (s *Service) CanManageReminder(chatID, userID, reminderID int) (bool, error) {
+	if isPrivate(chatID) {
+		return true, nil
+	}
+
+	cp, err := s.chatPreferenceStore.GetChatPreference(chatID)
+	if err != nil {
+		return false, err
+	}
+
+	switch cp.Permissions {
+	case chatpreference.Everyone:
+		return true, nil
+	case chatpreference.CreatorOnly:
+		rem, err := s.reminderStore.GetReminder(chatID, reminderID)
+		if err != nil {
+			return false, err
+		}
+
+		// reminders set before their creator was recorded are left to the admins
+		if userID != 0 && rem.Data.CreatorID == userID {
+			return true, nil
+		}
+	}
+
+	return s.IsAdmin(chatID, userID)
+}
+
+// CanManageChat tells whether the member can change the settings of the chat,
+// which only its admins can whoever the chat lets manage its reminders
+func (s *Service) CanManageChat(chatID, userID int) (bool, error) {
+	return s.IsAdmin(chatID, userID)
+}
+
+// CanSetTimeZone tells whether the member can change the timezone of the chat,
+// which everyone can when everyone can manage reminders and only the admins can otherwise
+func (s *Service) CanSetTimeZone(chatID, userID int) (bool, error) {
+	if isPrivate(chatID) {
+		return true, nil
+	}
+
+	cp, err := s.chatPreferenceStore.GetChatPreference(chatID)
+	if err != nil {
+		return false, err
+	}
+
+	if cp.Permissions == chatpreference.Everyone {
+		return true, nil
+	}
+
+	return s.IsAdmin(chatID, userID)
+}
+
+// IsAdmin tells whether the member is the creator or an administrator of a group
+func (s *Service) IsAdmin(chatID, userID int) (bool, error) {
+	if isPrivate(chatID) {
+		return true, nil
+	}
+	if userID == 0 {
+		return false, nil
+	}
+
+	member, err := s.members.ChatMemberOf(&tb.Chat{ID: int64(chatID)}, &tb.User{ID: userID})
+	if err != nil {
+		return false, err
+	}
+
+	return member.Role == tb.Creator || member.Role == tb.Administrator, nil
+}
+
+// isPrivate tells whether the chat is with a single user, whose IDs are positive unlike those of groups
+func isPrivate(chatID int) bool {
+	return chatID > 0
+}
@@ -0,0 +1,137 @@
+package permission_test
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/husol/telegram-reminder-bot/pkg/chatpreference"
+	chatPreferenceMocks "github.com/husol/telegram-reminder-bot/pkg/chatpreference/mocks"
+	"github.com/husol/telegram-reminder-bot/pkg/cron"
+	"github.com/husol/telegram-reminder-bot/pkg/permission"
+	"github.com/husol/telegram-reminder-bot/pkg/reminder"
+	reminderMocks "github.com/husol/telegram-reminder-bot/pkg/reminder/mocks"
+	"github.com/husol/telegram-reminder-bot/pkg/telegram/fakes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+const (
+	groupID    = -100
+	reminderID = 3
+	creatorID  = 7
+	memberID   = 8
+	adminID    = 9
+)
+
+func newService(
+	mockCtrl *gomock.Controller, permissions chatpreference.Permissions,
+) (*permission.Service, *reminderMocks.MockStorer) {
+	reminderStore := reminderMocks.NewMockStorer(mockCtrl)
+	chatPreferenceStore := chatPreferenceMocks.NewMockStorer(mockCtrl)
+	chatPreferenceStore.EXPECT().GetChatPreference(groupID).Return(&chatpreference.ChatPreference{
+		ChatID:      groupID,
+		Permissions: permissions,
+	}, nil).AnyTimes()
+	members := fakes.NewChatMemberGetter()
+	members.Roles[adminID] = tb.Administrator
+
+	return permission.NewService(reminderStore, chatPreferenceStore, members), reminderStore
+}
+
+func TestService_CanManageReminder(t *testing.T) {
+	rem := &reminder.Reminder{
+		Job:  cron.Job{ID: reminderID, ChatID: groupID},
+		Data: reminder.Data{CreatorID: creatorID},
+	}
+
+	testCases := map[string]struct {
+		permissions chatpreference.Permissions
+		expected    map[int]bool
+	}{
+		"everyone": {
+			permissions: chatpreference.Everyone,
+			expected:    map[int]bool{creatorID: true, memberID: true, adminID: true, 0: true},
+		},
+		"creator only": {
+			permissions: chatpreference.CreatorOnly,
+			expected:    map[int]bool{creatorID: true, memberID: false, adminID: true, 0: false},
+		},
+		"admins only": {
+			permissions: chatpreference.AdminsOnly,
+			expected:    map[int]bool{creatorID: false, memberID: false, adminID: true, 0: false},
+		},
+	}
+
+	for name := range testCases {
+		t.Run(name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			service, reminderStore := newService(mockCtrl, testCases[name].permissions)
+			reminderStore.EXPECT().GetReminder(groupID, reminderID).Return(rem, nil).AnyTimes()
+
+			for userID, expected := range testCases[name].expected {
+				allowed, err := service.CanManageReminder(groupID, userID, reminderID)
+				require.NoError(t, err)
+				assert.Equal(t, expected, allowed, "user %d", userID)
+			}
+		})
+	}
+
+	t.Run("anyone in a private chat", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		service, _ := newService(mockCtrl, chatpreference.AdminsOnly)
+
+		allowed, err := service.CanManageReminder(memberID, memberID, reminderID)
+		require.NoError(t, err)
+		assert.True(t, allowed)
+	})
+}
+
+func TestService_CanManageChat(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	// the settings of the chat are left to the admins even when everyone can manage reminders
+	service, _ := newService(mockCtrl, chatpreference.Everyone)
+	allowed, err := service.CanManageChat(groupID, memberID)
+	require.NoError(t, err)
+	assert.False(t, allowed)
+	allowed, err = service.CanManageChat(groupID, adminID)
+	require.NoError(t, err)
+	assert.True(t, allowed)
+
+	// the member who set a reminder has no say over the settings of the chat
+	service, _ = newService(mockCtrl, chatpreference.CreatorOnly)
+	allowed, err = service.CanManageChat(groupID, creatorID)
+	require.NoError(t, err)
+	assert.False(t, allowed)
+	allowed, err = service.CanManageChat(groupID, adminID)
+	require.NoError(t, err)
+	assert.True(t, allowed)
+}
+
+func TestService_CanSetTimeZone(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	service, _ := newService(mockCtrl, chatpreference.Everyone)
+	allowed, err := service.CanSetTimeZone(groupID, memberID)
+	require.NoError(t, err)
+	assert.True(t, allowed)
+
+	// the timezone of the chat has no creator so only the admins can change it unless everyone can manage reminders
+	service, _ = newService(mockCtrl, chatpreference.CreatorOnly)
+	allowed, err = service.CanSetTimeZone(groupID, creatorID)
+	require.NoError(t, err)
+	assert.False(t, allowed)
+	allowed, err = service.CanSetTimeZone(groupID, adminID)
+	require.NoError(t, err)
+	assert.True(t, allowed)
+
+	service, _ = newService(mockCtrl, chatpreference.AdminsOnly)
+	allowed, err = service.CanSetTimeZone(groupID, memberID)
+	require.NoError(t, err)
+	assert.False(t, allowed)
+}
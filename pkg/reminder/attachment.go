@@ -0,0 +1,57 @@
+package reminder
+
+import (
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+// AttachmentType is the kind of media a reminder is sent with
+type AttachmentType int
+
+const (
+	AttachmentPhoto    AttachmentType = 1
+	AttachmentDocument AttachmentType = 2
+	AttachmentVoice    AttachmentType = 3
+	AttachmentLocation AttachmentType = 4
+)
+
+// Attachment is the photo, document, voice message or location a reminder is sent with e.g. a reminder
+// written as the caption of a photo. Files are kept by their ID on Telegram so they can be sent again without
+// downloading them
+type Attachment struct {
+	Type      AttachmentType `json:"type"`
+	FileID    string         `json:"file_id,omitempty"`
+	FileName  string         `json:"file_name,omitempty"` // only set for documents
+	Latitude  float32        `json:"latitude,omitempty"`
+	Longitude float32        `json:"longitude,omitempty"`
+}
+
+// NewAttachment returns the media of a message a reminder can be sent with, nil when it has none
+func NewAttachment(m *tb.Message) *Attachment {
+	switch {
+	case m.Photo != nil:
+		return &Attachment{Type: AttachmentPhoto, FileID: m.Photo.FileID}
+	case m.Document != nil:
+		return &Attachment{Type: AttachmentDocument, FileID: m.Document.FileID, FileName: m.Document.FileName}
+	case m.Voice != nil:
+		return &Attachment{Type: AttachmentVoice, FileID: m.Voice.FileID}
+	case m.Location != nil:
+		return &Attachment{Type: AttachmentLocation, Latitude: m.Location.Lat, Longitude: m.Location.Lng}
+	}
+
+	return nil
+}
+
+// sendable returns the attachment as it is sent with the message of a reminder as its caption.
+// Voice messages and locations are sent without it as they can't have one, which captioned reports
+func (a *Attachment) sendable(caption string) (what interface{}, captioned bool) {
+	switch a.Type {
+	case AttachmentPhoto:
+		return &tb.Photo{File: tb.File{FileID: a.FileID}, Caption: caption}, true
+	case AttachmentDocument:
+		return &tb.Document{File: tb.File{FileID: a.FileID}, Caption: caption, FileName: a.FileName}, true
+	case AttachmentVoice:
+		return &tb.Voice{File: tb.File{FileID: a.FileID}}, false
+	default:
+		return &tb.Location{Lat: a.Latitude, Lng: a.Longitude}, false
+	}
+}
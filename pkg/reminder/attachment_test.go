@@ -0,0 +1,42 @@
+package reminder_test
+
+import (
+	"testing"
+
+	"github.com/husol/telegram-reminder-bot/pkg/reminder"
+	"github.com/stretchr/testify/assert"
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+func TestNewAttachment(t *testing.T) {
+	testCases := map[string]struct {
+		message  *tb.Message
+		expected *reminder.Attachment
+	}{
+		"photo": {
+			message:  &tb.Message{Photo: &tb.Photo{File: tb.File{FileID: "photo-id"}}},
+			expected: &reminder.Attachment{Type: reminder.AttachmentPhoto, FileID: "photo-id"},
+		},
+		"document": {
+			message:  &tb.Message{Document: &tb.Document{File: tb.File{FileID: "document-id"}, FileName: "report.pdf"}},
+			expected: &reminder.Attachment{Type: reminder.AttachmentDocument, FileID: "document-id", FileName: "report.pdf"},
+		},
+		"voice message": {
+			message:  &tb.Message{Voice: &tb.Voice{File: tb.File{FileID: "voice-id"}}},
+			expected: &reminder.Attachment{Type: reminder.AttachmentVoice, FileID: "voice-id"},
+		},
+		"location": {
+			message:  &tb.Message{Location: &tb.Location{Lat: 10.77, Lng: 106.7}},
+			expected: &reminder.Attachment{Type: reminder.AttachmentLocation, Latitude: 10.77, Longitude: 106.7},
+		},
+		"text": {
+			message: &tb.Message{Text: "update weekly report"},
+		},
+	}
+
+	for name := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, testCases[name].expected, reminder.NewAttachment(testCases[name].message))
+		})
+	}
+}
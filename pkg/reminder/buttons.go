@@ -2,9 +2,12 @@ package reminder
 
 import (
 	"fmt"
-	"strconv"
+	"strings"
+	"time"
 
 	"github.com/enrico5b1b4/tbwrap"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
+	"github.com/husol/telegram-reminder-bot/pkg/telegram"
 	"gopkg.in/tucnak/telebot.v2"
 )
 
@@ -21,56 +24,82 @@ const (
 	SnoozeBtn                  = "SnoozeBtn"
 	SnoozeCloseBtn             = "SnoozeCloseBtn"
 	CompleteBtn                = "CompleteBtn"
+	DoneBtn                    = "DoneBtn"
+	SkipNextBtn                = "SkipNextBtn"
+	OnItBtn                    = "OnItBtn"
+	AckDoneBtn                 = "AckDoneBtn"
+	ChecklistItemBtn           = "ChecklistItemBtn"
 )
 
-func NewButtons() map[string]*telebot.InlineButton {
+// NewButtons returns the buttons sent with a reminder labelled in the language
+func NewButtons(lang i18n.Language) map[string]*telebot.InlineButton {
 	snooze10MinuteBtn := telebot.InlineButton{
 		Unique: Snooze10MinuteBtn,
-		Text:   "⏰ 10m",
+		Text:   i18n.T(lang, i18n.ButtonSnooze10Minutes),
 	}
 	snooze20MinuteBtn := telebot.InlineButton{
 		Unique: Snooze20MinuteBtn,
-		Text:   "⏰ 20m",
+		Text:   i18n.T(lang, i18n.ButtonSnooze20Minutes),
 	}
 	snooze30MinuteBtn := telebot.InlineButton{
 		Unique: Snooze30MinuteBtn,
-		Text:   "⏰ 30m",
+		Text:   i18n.T(lang, i18n.ButtonSnooze30Minutes),
 	}
 	snooze1HourBtn := telebot.InlineButton{
 		Unique: Snooze1HourBtn,
-		Text:   "⏰ 1h",
+		Text:   i18n.T(lang, i18n.ButtonSnooze1Hour),
 	}
 	snoozeThisAfternoonBtn := telebot.InlineButton{
 		Unique: SnoozeThisAfternoonBtn,
-		Text:   "⏰ This Afternoon",
+		Text:   i18n.T(lang, i18n.ButtonSnoozeThisAfternoon),
 	}
 	snoozeThisEveningBtn := telebot.InlineButton{
 		Unique: SnoozeThisEveningBtn,
-		Text:   "⏰ This Evening",
+		Text:   i18n.T(lang, i18n.ButtonSnoozeThisEvening),
 	}
 	snoozeTomorrowMorningBtn := telebot.InlineButton{
 		Unique: SnoozeTomorrowMorningBtn,
-		Text:   "⏰ Tomorrow Morning",
+		Text:   i18n.T(lang, i18n.ButtonSnoozeTomorrowMorning),
 	}
 	snoozeTomorrowAfternoonBtn := telebot.InlineButton{
 		Unique: SnoozeTomorrowAfternoonBtn,
-		Text:   "⏰ Tomorrow Afternoon",
+		Text:   i18n.T(lang, i18n.ButtonSnoozeTomorrowAfternoon),
 	}
 	snoozeTomorrowEveningBtn := telebot.InlineButton{
 		Unique: SnoozeTomorrowEveningBtn,
-		Text:   "⏰ Tomorrow Evening",
+		Text:   i18n.T(lang, i18n.ButtonSnoozeTomorrowEvening),
 	}
 	snoozeBtn := telebot.InlineButton{
 		Unique: SnoozeBtn,
-		Text:   "⏰ Snooze",
+		Text:   i18n.T(lang, i18n.ButtonSnooze),
 	}
 	snoozeCloseBtn := telebot.InlineButton{
 		Unique: SnoozeCloseBtn,
-		Text:   "❌ Close",
+		Text:   i18n.T(lang, i18n.ButtonClose),
 	}
 	completeBtn := telebot.InlineButton{
 		Unique: CompleteBtn,
-		Text:   "✅ Finish Schedule",
+		Text:   i18n.T(lang, i18n.ButtonFinishSchedule),
+	}
+	doneBtn := telebot.InlineButton{
+		Unique: DoneBtn,
+		Text:   i18n.T(lang, i18n.ButtonDone),
+	}
+	skipNextBtn := telebot.InlineButton{
+		Unique: SkipNextBtn,
+		Text:   i18n.T(lang, i18n.ButtonSkipNext),
+	}
+	onItBtn := telebot.InlineButton{
+		Unique: OnItBtn,
+		Text:   i18n.T(lang, i18n.ButtonOnIt),
+	}
+	ackDoneBtn := telebot.InlineButton{
+		Unique: AckDoneBtn,
+		Text:   i18n.T(lang, i18n.ButtonDone),
+	}
+	// labelled with the item of the checklist it ticks
+	checklistItemBtn := telebot.InlineButton{
+		Unique: ChecklistItemBtn,
 	}
 
 	return map[string]*telebot.InlineButton{
@@ -84,12 +113,18 @@ func NewButtons() map[string]*telebot.InlineButton {
 		SnoozeTomorrowAfternoonBtn: &snoozeTomorrowAfternoonBtn,
 		SnoozeTomorrowEveningBtn:   &snoozeTomorrowEveningBtn,
 		CompleteBtn:                &completeBtn,
+		DoneBtn:                    &doneBtn,
+		SkipNextBtn:                &skipNextBtn,
+		OnItBtn:                    &onItBtn,
+		AckDoneBtn:                 &ackDoneBtn,
+		ChecklistItemBtn:           &checklistItemBtn,
 		SnoozeBtn:                  &snoozeBtn,
 		SnoozeCloseBtn:             &snoozeCloseBtn,
 	}
 }
 
 type RemindDateServicer interface {
+	ForRecipient(recipient *Recipient) ServiceReminder
 	AddReminderIn(
 		chatID int,
 		command string,
@@ -104,37 +139,56 @@ type RemindDateServicer interface {
 	) (NextScheduleChatTime, error)
 }
 
+// HandleReminderSnoozeAmountDateTimeBtn sends a reminder again after an amount of time, which acknowledges it.
+// Members who are not allowed to manage the reminder are alerted instead
 // nolint:dupl
 func HandleReminderSnoozeAmountDateTimeBtn(
 	service RemindDateServicer,
+	cronFuncService CronFuncServicer,
 	store Storer,
+	checker PermissionChecker,
 	amountDateTime AmountDateTime,
 ) func(c tbwrap.Context) error {
 	return func(c tbwrap.Context) error {
-		err := c.Respond(c.Callback())
+		rem, err := callbackReminder(c, store)
 		if err != nil {
 			return err
 		}
 
-		chatID := int(c.ChatID())
-		reminderID, err := strconv.Atoi(c.Callback().Data)
+		lang := cronFuncService.ChatLanguage(rem.ChatID)
+		allowed, err := allowedToPress(c, checker, lang, rem)
+		if err != nil || !allowed {
+			return err
+		}
+
+		err = c.Respond(c.Callback())
 		if err != nil {
 			return err
 		}
 
-		rem, err := store.GetReminder(chatID, reminderID)
+		// the snoozed reminder is for the same user, belongs to whoever set the reminder, is read in its timezone
+		// and quotes the same message with the same attachment
+		snoozeService := service.ForRecipient(rem.Data.Recipient).
+			ForCreator(rem.Data.CreatorID).
+			InTimeZone(rem.TimeZone).
+			ReplyingTo(rem.Data.ReplyToMessageID).
+			WithAttachment(rem.Data.Attachment)
+		nextSchedule, err := snoozeService.AddReminderIn(
+			rem.ChatID, rem.Data.Command, amountDateTime, rem.Data.Message,
+		)
 		if err != nil {
 			return err
 		}
 
-		nextSchedule, err := service.AddReminderIn(chatID, rem.Data.Command, amountDateTime, rem.Data.Message)
+		// snoozing a reminder acknowledges it
+		err = cronFuncService.StopNag(rem)
 		if err != nil {
 			return err
 		}
 
-		_, err = c.Send(fmt.Sprintf("Reminder \"%s\" has been rescheduled for %s",
+		_, err = c.Send(i18n.T(lang, i18n.ReminderRescheduled,
 			rem.Data.Message,
-			nextSchedule.Time.In(nextSchedule.Location).Format("Mon, 02 Jan 2006 15:04 MST"),
+			i18n.FormatTime(lang, i18n.DateTimeZone, nextSchedule.Time.In(nextSchedule.Location)),
 		))
 		if err != nil {
 			return err
@@ -144,37 +198,56 @@ func HandleReminderSnoozeAmountDateTimeBtn(
 	}
 }
 
+// HandleReminderSnoozeWordDateTimeBtn sends a reminder again at a time of day such as tomorrow morning,
+// which acknowledges it. Members who are not allowed to manage the reminder are alerted instead
 // nolint:dupl
 func HandleReminderSnoozeWordDateTimeBtn(
 	service RemindDateServicer,
+	cronFuncService CronFuncServicer,
 	store Storer,
+	checker PermissionChecker,
 	wordDateTime WordDateTime,
 ) func(c tbwrap.Context) error {
 	return func(c tbwrap.Context) error {
-		err := c.Respond(c.Callback())
+		rem, err := callbackReminder(c, store)
 		if err != nil {
 			return err
 		}
 
-		chatID := int(c.ChatID())
-		reminderID, err := strconv.Atoi(c.Callback().Data)
+		lang := cronFuncService.ChatLanguage(rem.ChatID)
+		allowed, err := allowedToPress(c, checker, lang, rem)
+		if err != nil || !allowed {
+			return err
+		}
+
+		err = c.Respond(c.Callback())
 		if err != nil {
 			return err
 		}
 
-		rem, err := store.GetReminder(chatID, reminderID)
+		// the snoozed reminder is for the same user, belongs to whoever set the reminder, is read in its timezone
+		// and quotes the same message with the same attachment
+		snoozeService := service.ForRecipient(rem.Data.Recipient).
+			ForCreator(rem.Data.CreatorID).
+			InTimeZone(rem.TimeZone).
+			ReplyingTo(rem.Data.ReplyToMessageID).
+			WithAttachment(rem.Data.Attachment)
+		nextSchedule, err := snoozeService.AddReminderOnWordDateTime(
+			rem.ChatID, rem.Data.Command, wordDateTime, rem.Data.Message,
+		)
 		if err != nil {
 			return err
 		}
 
-		nextSchedule, err := service.AddReminderOnWordDateTime(chatID, rem.Data.Command, wordDateTime, rem.Data.Message)
+		// snoozing a reminder acknowledges it
+		err = cronFuncService.StopNag(rem)
 		if err != nil {
 			return err
 		}
 
-		_, err = c.Send(fmt.Sprintf("Reminder \"%s\" has been rescheduled for %s",
+		_, err = c.Send(i18n.T(lang, i18n.ReminderRescheduled,
 			rem.Data.Message,
-			nextSchedule.Time.In(nextSchedule.Location).Format("Mon, 02 Jan 2006 15:04 MST"),
+			i18n.FormatTime(lang, i18n.DateTimeZone, nextSchedule.Time.In(nextSchedule.Location)),
 		))
 		if err != nil {
 			return err
@@ -184,9 +257,114 @@ func HandleReminderSnoozeWordDateTimeBtn(
 	}
 }
 
+// PermissionChecker tells whether a member of a chat can manage a reminder under the permissions the chat set
+type PermissionChecker interface {
+	CanManageReminder(chatID, userID, reminderID int) (bool, error)
+}
+
+// allowedToPress tells whether the member who pressed a button for a reminder can manage it,
+// alerting them when they can't
+func allowedToPress(c tbwrap.Context, checker PermissionChecker, lang i18n.Language, rem *Reminder) (bool, error) {
+	var userID int
+	if sender := c.Callback().Sender; sender != nil {
+		userID = sender.ID
+	}
+
+	allowed, err := checker.CanManageReminder(rem.ChatID, userID, rem.ID)
+	if err != nil || allowed {
+		return allowed, err
+	}
+
+	return false, c.Respond(c.Callback(), &telebot.CallbackResponse{
+		Text:      i18n.T(lang, i18n.NotAllowed),
+		ShowAlert: true,
+	})
+}
+
+// HandleReminderCompleteBtn finishes the schedule of a reminder.
+// Members who are not allowed to are alerted instead
 func HandleReminderCompleteBtn(
 	service CronFuncServicer,
 	store Storer,
+	checker PermissionChecker,
+) func(c tbwrap.Context) error {
+	return func(c tbwrap.Context) error {
+		rem, err := callbackReminder(c, store)
+		if err != nil {
+			return err
+		}
+
+		lang := service.ChatLanguage(rem.ChatID)
+		allowed, err := allowedToPress(c, checker, lang, rem)
+		if err != nil || !allowed {
+			return err
+		}
+
+		err = c.Respond(c.Callback())
+		if err != nil {
+			return err
+		}
+
+		err = service.StopNag(rem)
+		if err != nil {
+			return err
+		}
+
+		err = service.Complete(rem)
+		if err != nil {
+			return err
+		}
+
+		_, err = c.Send(i18n.T(lang, i18n.ReminderCompleted, rem.Data.Message))
+
+		return err
+	}
+}
+
+// HandleReminderDoneBtn stops a reminder which nags.
+// Members who are not allowed to manage the reminder are alerted instead
+func HandleReminderDoneBtn(
+	service CronFuncServicer,
+	store Storer,
+	checker PermissionChecker,
+) func(c tbwrap.Context) error {
+	return func(c tbwrap.Context) error {
+		rem, err := callbackReminder(c, store)
+		if err != nil {
+			return err
+		}
+
+		lang := service.ChatLanguage(rem.ChatID)
+		allowed, err := allowedToPress(c, checker, lang, rem)
+		if err != nil || !allowed {
+			return err
+		}
+
+		err = c.Respond(c.Callback())
+		if err != nil {
+			return err
+		}
+
+		err = service.StopNag(rem)
+		if err != nil {
+			return err
+		}
+
+		_, err = c.Send(i18n.T(lang, i18n.ReminderDone, rem.Data.Message))
+
+		return err
+	}
+}
+
+// HandleReminderAcknowledgeBtn records that the member of a group who pressed the button is on it or done
+// with an occurrence of a reminder, and edits the reminder message to list who acknowledged it.
+// Being done with a reminder which nags also stops it
+func HandleReminderAcknowledgeBtn(
+	service CronFuncServicer,
+	store Storer,
+	occurrenceStore OccurrenceStorer,
+	editor telegram.MessageEditor,
+	status AckStatus,
 ) func(c tbwrap.Context) error {
 	return func(c tbwrap.Context) error {
 		err := c.Respond(c.Callback())
@@ -195,7 +373,7 @@ func HandleReminderCompleteBtn(
 		}
 
 		chatID := int(c.ChatID())
-		reminderID, err := strconv.Atoi(c.Callback().Data)
+		reminderID, occurrenceID, err := callbackOccurrence(c)
 		if err != nil {
 			return err
 		}
@@ -205,58 +383,206 @@ func HandleReminderCompleteBtn(
 			return err
 		}
 
-		err = service.Complete(rem)
+		occurrence, err := occurrenceStore.GetOccurrence(chatID, reminderID, occurrenceID)
 		if err != nil {
 			return err
 		}
 
-		_, err = c.Send(fmt.Sprintf("Reminder \"%s\" has been completed",
-			rem.Data.Message,
-		))
+		sender := c.Callback().Sender
+		changed := occurrence.Acknowledge(Acknowledgement{
+			UserID: sender.ID,
+			Name:   strings.TrimSpace(sender.FirstName + " " + sender.LastName),
+			Status: status,
+			At:     time.Now().In(time.UTC),
+		})
+		if !changed {
+			return nil
+		}
+
+		err = occurrenceStore.UpdateOccurrence(occurrence)
+		if err != nil {
+			return err
+		}
+
+		if status == AckDone {
+			err = service.StopNag(rem)
+			if err != nil {
+				return err
+			}
+		}
+
+		lang := service.ChatLanguage(chatID)
+		_, err = editor.Edit(c.Message(), acknowledgedMessage(lang, rem, occurrence), &telebot.SendOptions{
+			ParseMode: telebot.ModeMarkdown,
+			ReplyMarkup: &telebot.ReplyMarkup{
+				InlineKeyboard: reminderButtons(lang, rem, buttonData(rem, false), occurrence),
+			},
+		})
 
 		return err
 	}
 }
 
-func HandleReminderSnoozeBtn(store Storer) func(c tbwrap.Context) error {
+// HandleReminderChecklistItemBtn ticks the item of the checklist of an occurrence of a reminder whose button was pressed,
+// or unticks it if it was ticked, and edits the reminder message to show which items are ticked.
+// Ticking the last item gets the occurrence done, which also stops a reminder which nags
+// and completes a reminder which is only sent once
+func HandleReminderChecklistItemBtn(
+	service CronFuncServicer,
+	store Storer,
+	occurrenceStore OccurrenceStorer,
+	editor telegram.MessageEditor,
+) func(c tbwrap.Context) error {
 	return func(c tbwrap.Context) error {
 		err := c.Respond(c.Callback())
 		if err != nil {
 			return err
 		}
 
-		reminderID, err := strconv.Atoi(c.Callback().Data)
+		chatID, reminderID, occurrenceID, item, err := callbackChecklistItem(c)
+		if err != nil {
+			return err
+		}
+
+		rem, err := store.GetReminder(chatID, reminderID)
+		if err != nil {
+			return err
+		}
+
+		// the chat in the data of the button can be made up by whoever pressed it
+		if !pressedFor(c, rem) {
+			return i18n.Errorf(i18n.ErrNotInChat, reminderID)
+		}
+
+		occurrence, err := occurrenceStore.GetOccurrence(chatID, reminderID, occurrenceID)
+		if err != nil {
+			return err
+		}
+
+		sender := c.Callback().Sender
+		wasDone := occurrence.ChecklistDone()
+		err = occurrence.Tick(item, sender.ID, strings.TrimSpace(sender.FirstName+" "+sender.LastName))
+		if err != nil {
+			return err
+		}
+
+		err = occurrenceStore.UpdateOccurrence(occurrence)
+		if err != nil {
+			return err
+		}
+
+		if !wasDone && occurrence.ChecklistDone() {
+			err = service.StopNag(rem)
+			if err != nil {
+				return err
+			}
+
+			// a reminder which is only sent once is finished along with its checklist
+			if rem.RunOnlyOnce && rem.RepeatSchedule == nil {
+				err = service.Complete(rem)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		// the checklist of a reminder sent by private message is ticked in another chat, where no one is mentioned
+		private := int(c.ChatID()) != chatID
+		lang := service.ChatLanguage(chatID)
+		message := checklistMessage(lang, occurrence)
+		if !private {
+			message = groupMessage(rem, message)
+		}
+		_, err = editor.Edit(c.Message(), message, &telebot.SendOptions{
+			ParseMode: telebot.ModeMarkdown,
+			ReplyMarkup: &telebot.ReplyMarkup{
+				InlineKeyboard: reminderButtons(lang, rem, buttonData(rem, private), occurrence),
+			},
+		})
+
+		return err
+	}
+}
+
+type SkipReminderServicer interface {
+	SkipReminder(chatID, reminderID, times int) ([]time.Time, error)
+}
+
+// HandleReminderSkipNextBtn skips the next time a recurring reminder is due.
+// Members who are not allowed to manage the reminder are alerted instead
+func HandleReminderSkipNextBtn(
+	service SkipReminderServicer,
+	store Storer,
+	checker PermissionChecker,
+	languages i18n.Languages,
+) func(c tbwrap.Context) error {
+	return func(c tbwrap.Context) error {
+		rem, err := callbackReminder(c, store)
+		if err != nil {
+			return err
+		}
+
+		lang := languages.ChatLanguage(rem.ChatID)
+		allowed, err := allowedToPress(c, checker, lang, rem)
+		if err != nil || !allowed {
+			return err
+		}
+
+		err = c.Respond(c.Callback())
+		if err != nil {
+			return err
+		}
+
+		skipped, err := service.SkipReminder(rem.ChatID, rem.ID, 1)
+		if err != nil {
+			return err
+		}
+
+		_, err = c.Send(i18n.T(lang, i18n.ReminderSkippedNext,
+			rem.ID,
+			i18n.FormatTime(lang, i18n.DateTimeZone, skipped[0]),
+		))
+
+		return err
+	}
+}
+
+func HandleReminderSnoozeBtn(store Storer, languages i18n.Languages) func(c tbwrap.Context) error {
+	return func(c tbwrap.Context) error {
+		err := c.Respond(c.Callback())
 		if err != nil {
 			return err
 		}
 
-		rem, err := store.GetReminder(int(c.ChatID()), reminderID)
+		rem, err := callbackReminder(c, store)
 		if err != nil {
 			return err
 		}
 
-		buttons := NewButtons()
+		// the snooze buttons carry the same data, which has the chat of the reminder when it was sent privately
+		data := c.Callback().Data
+		buttons := NewButtons(languages.ChatLanguage(rem.ChatID))
 
 		snooze10MinuteBtn := *buttons[Snooze10MinuteBtn]
-		snooze10MinuteBtn.Data = strconv.Itoa(reminderID)
+		snooze10MinuteBtn.Data = data
 		snooze20MinuteBtn := *buttons[Snooze20MinuteBtn]
-		snooze20MinuteBtn.Data = strconv.Itoa(reminderID)
+		snooze20MinuteBtn.Data = data
 		snooze30MinuteBtn := *buttons[Snooze30MinuteBtn]
-		snooze30MinuteBtn.Data = strconv.Itoa(reminderID)
+		snooze30MinuteBtn.Data = data
 		snooze1HourBtn := *buttons[Snooze1HourBtn]
-		snooze1HourBtn.Data = strconv.Itoa(reminderID)
+		snooze1HourBtn.Data = data
 		snoozeThisAfternoonBtn := *buttons[SnoozeThisAfternoonBtn]
-		snoozeThisAfternoonBtn.Data = strconv.Itoa(reminderID)
+		snoozeThisAfternoonBtn.Data = data
 		snoozeThisEveningBtn := *buttons[SnoozeThisEveningBtn]
-		snoozeThisEveningBtn.Data = strconv.Itoa(reminderID)
+		snoozeThisEveningBtn.Data = data
 		snoozeTomorrowMorningBtn := *buttons[SnoozeTomorrowMorningBtn]
-		snoozeTomorrowMorningBtn.Data = strconv.Itoa(reminderID)
+		snoozeTomorrowMorningBtn.Data = data
 		snoozeTomorrowAfternoonBtn := *buttons[SnoozeTomorrowAfternoonBtn]
-		snoozeTomorrowAfternoonBtn.Data = strconv.Itoa(reminderID)
+		snoozeTomorrowAfternoonBtn.Data = data
 		snoozeTomorrowEveningBtn := *buttons[SnoozeTomorrowEveningBtn]
-		snoozeTomorrowEveningBtn.Data = strconv.Itoa(reminderID)
+		snoozeTomorrowEveningBtn.Data = data
 		snoozeBtn := *buttons[SnoozeBtn]
-		snoozeBtn.Data = strconv.Itoa(reminderID)
+		snoozeBtn.Data = data
 		snoozeCloseBtn := *buttons[SnoozeCloseBtn]
 
 		inlineKeys := [][]telebot.InlineButton{
@@ -0,0 +1,201 @@
+package reminder_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/enrico5b1b4/tbwrap"
+	"github.com/golang/mock/gomock"
+	"github.com/husol/telegram-reminder-bot/pkg/cron"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
+	permissionMocks "github.com/husol/telegram-reminder-bot/pkg/permission/mocks"
+	"github.com/husol/telegram-reminder-bot/pkg/reminder"
+	reminderMocks "github.com/husol/telegram-reminder-bot/pkg/reminder/mocks"
+	"github.com/husol/telegram-reminder-bot/pkg/telegram/fakes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+// nolint:funlen
+func TestHandleReminderDoneBtn(t *testing.T) {
+	newReminder := func() *reminder.Reminder {
+		return &reminder.Reminder{
+			Job:  cron.Job{ID: reminderID, ChatID: groupChatID, Schedule: "0 9 * * *"},
+			Data: reminder.Data{RecipientID: groupChatID, Message: message},
+		}
+	}
+	newContext := func(bot *fakes.TBWrapBot, chat int64, data string) tbwrap.Context {
+		msg := &tb.Message{ID: 10, Chat: &tb.Chat{ID: chat}}
+		callback := &tb.Callback{Data: data, Sender: &tb.User{ID: 7}, Message: msg}
+		return tbwrap.NewContext(bot, msg, callback, nil)
+	}
+
+	t.Run("stops the reminder", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakes.NewTBWrapBot()
+		cronFuncService := reminderMocks.NewMockCronFuncServicer(mockCtrl)
+		store := reminderMocks.NewMockStorer(mockCtrl)
+		checker := permissionMocks.NewMockChecker(mockCtrl)
+		rem := newReminder()
+		store.EXPECT().GetReminder(groupChatID, reminderID).Return(rem, nil)
+		cronFuncService.EXPECT().ChatLanguage(groupChatID).Return(i18n.English)
+		checker.EXPECT().CanManageReminder(groupChatID, 7, reminderID).Return(true, nil)
+		cronFuncService.EXPECT().StopNag(rem).Return(nil)
+
+		err := reminder.HandleReminderDoneBtn(cronFuncService, store, checker)(newContext(bot, groupChatID, "3"))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Reminder \"message\" has been marked as done"}, bot.OutboundSendMessages)
+	})
+
+	t.Run("stops the reminder sent by private message to the user who pressed it", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakes.NewTBWrapBot()
+		cronFuncService := reminderMocks.NewMockCronFuncServicer(mockCtrl)
+		store := reminderMocks.NewMockStorer(mockCtrl)
+		checker := permissionMocks.NewMockChecker(mockCtrl)
+		rem := newReminder()
+		rem.Data.Recipient = &reminder.Recipient{UserID: 7, Name: "Bob", Private: true}
+		store.EXPECT().GetReminder(groupChatID, reminderID).Return(rem, nil)
+		cronFuncService.EXPECT().ChatLanguage(groupChatID).Return(i18n.English)
+		checker.EXPECT().CanManageReminder(groupChatID, 7, reminderID).Return(true, nil)
+		cronFuncService.EXPECT().StopNag(rem).Return(nil)
+
+		err := reminder.HandleReminderDoneBtn(cronFuncService, store, checker)(newContext(bot, 7, "3:-1001"))
+		require.NoError(t, err)
+		assert.Len(t, bot.OutboundSendMessages, 1)
+	})
+
+	t.Run("failure when the button names the chat of a reminder which was not sent to the user", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakes.NewTBWrapBot()
+		cronFuncService := reminderMocks.NewMockCronFuncServicer(mockCtrl)
+		store := reminderMocks.NewMockStorer(mockCtrl)
+		checker := permissionMocks.NewMockChecker(mockCtrl)
+		store.EXPECT().GetReminder(groupChatID, reminderID).Return(newReminder(), nil)
+
+		err := reminder.HandleReminderDoneBtn(cronFuncService, store, checker)(newContext(bot, 7, "3:-1001"))
+		require.EqualError(t, err, "error: reminder 3 is not a reminder of this chat")
+		assert.Empty(t, bot.OutboundSendMessages)
+	})
+
+	t.Run("alerts a member who is not allowed to manage the reminder", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakes.NewTBWrapBot()
+		cronFuncService := reminderMocks.NewMockCronFuncServicer(mockCtrl)
+		store := reminderMocks.NewMockStorer(mockCtrl)
+		checker := permissionMocks.NewMockChecker(mockCtrl)
+		store.EXPECT().GetReminder(groupChatID, reminderID).Return(newReminder(), nil)
+		cronFuncService.EXPECT().ChatLanguage(groupChatID).Return(i18n.English)
+		checker.EXPECT().CanManageReminder(groupChatID, 7, reminderID).Return(false, nil)
+
+		err := reminder.HandleReminderDoneBtn(cronFuncService, store, checker)(newContext(bot, groupChatID, "3"))
+		require.NoError(t, err)
+		require.Len(t, bot.CallbackResponses, 1)
+		assert.True(t, bot.CallbackResponses[0].ShowAlert)
+		assert.Empty(t, bot.OutboundSendMessages)
+	})
+}
+
+func TestHandleReminderSnoozeBtns(t *testing.T) {
+	rem := &reminder.Reminder{
+		Job:  cron.Job{ID: reminderID, ChatID: groupChatID, Schedule: "0 9 * * *"},
+		Data: reminder.Data{RecipientID: groupChatID, CreatorID: 8, Message: message},
+	}
+	newContext := func(bot *fakes.TBWrapBot) tbwrap.Context {
+		msg := &tb.Message{ID: 10, Chat: &tb.Chat{ID: groupChatID}}
+		callback := &tb.Callback{Data: "3", Sender: &tb.User{ID: 7}, Message: msg}
+		return tbwrap.NewContext(bot, msg, callback, nil)
+	}
+	newMocks := func(
+		mockCtrl *gomock.Controller,
+	) (*reminderMocks.MockCronFuncServicer, *reminderMocks.MockStorer, *permissionMocks.MockChecker) {
+		cronFuncService := reminderMocks.NewMockCronFuncServicer(mockCtrl)
+		store := reminderMocks.NewMockStorer(mockCtrl)
+		checker := permissionMocks.NewMockChecker(mockCtrl)
+		store.EXPECT().GetReminder(groupChatID, reminderID).Return(rem, nil)
+		cronFuncService.EXPECT().ChatLanguage(groupChatID).Return(i18n.English)
+		checker.EXPECT().CanManageReminder(groupChatID, 7, reminderID).Return(false, nil)
+
+		return cronFuncService, store, checker
+	}
+
+	t.Run("alerts a member who is not allowed to snooze the reminder for an amount of time", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakes.NewTBWrapBot()
+		cronFuncService, store, checker := newMocks(mockCtrl)
+
+		err := reminder.HandleReminderSnoozeAmountDateTimeBtn(
+			nil, cronFuncService, store, checker, reminder.AmountDateTime{Minutes: 10},
+		)(newContext(bot))
+		require.NoError(t, err)
+		require.Len(t, bot.CallbackResponses, 1)
+		assert.True(t, bot.CallbackResponses[0].ShowAlert)
+		assert.Empty(t, bot.OutboundSendMessages)
+	})
+
+	t.Run("alerts a member who is not allowed to snooze the reminder until a time of day", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakes.NewTBWrapBot()
+		cronFuncService, store, checker := newMocks(mockCtrl)
+
+		err := reminder.HandleReminderSnoozeWordDateTimeBtn(
+			nil, cronFuncService, store, checker, reminder.WordDateTime{When: reminder.Tomorrow, Hour: 9},
+		)(newContext(bot))
+		require.NoError(t, err)
+		require.Len(t, bot.CallbackResponses, 1)
+		assert.True(t, bot.CallbackResponses[0].ShowAlert)
+		assert.Empty(t, bot.OutboundSendMessages)
+	})
+}
+
+func TestHandleReminderSkipNextBtn(t *testing.T) {
+	newContext := func(bot *fakes.TBWrapBot, chat int64, data string) tbwrap.Context {
+		msg := &tb.Message{ID: 10, Chat: &tb.Chat{ID: chat}}
+		callback := &tb.Callback{Data: data, Sender: &tb.User{ID: 7}, Message: msg}
+		return tbwrap.NewContext(bot, msg, callback, nil)
+	}
+	rem := &reminder.Reminder{
+		Job:  cron.Job{ID: reminderID, ChatID: groupChatID, Schedule: "0 9 * * *"},
+		Data: reminder.Data{RecipientID: groupChatID, Message: message},
+	}
+
+	t.Run("skips the next run", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakes.NewTBWrapBot()
+		service := reminderMocks.NewMockServicer(mockCtrl)
+		store := reminderMocks.NewMockStorer(mockCtrl)
+		checker := permissionMocks.NewMockChecker(mockCtrl)
+		store.EXPECT().GetReminder(groupChatID, reminderID).Return(rem, nil)
+		checker.EXPECT().CanManageReminder(groupChatID, 7, reminderID).Return(true, nil)
+		service.EXPECT().SkipReminder(groupChatID, reminderID, 1).Return([]time.Time{timeNow()}, nil)
+
+		err := reminder.HandleReminderSkipNextBtn(service, store, checker, i18n.English)(
+			newContext(bot, groupChatID, "3"),
+		)
+		require.NoError(t, err)
+		assert.Len(t, bot.OutboundSendMessages, 1)
+	})
+
+	t.Run("failure when the button names another chat", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakes.NewTBWrapBot()
+		service := reminderMocks.NewMockServicer(mockCtrl)
+		store := reminderMocks.NewMockStorer(mockCtrl)
+		checker := permissionMocks.NewMockChecker(mockCtrl)
+		store.EXPECT().GetReminder(groupChatID, reminderID).Return(rem, nil)
+
+		err := reminder.HandleReminderSkipNextBtn(service, store, checker, i18n.English)(
+			newContext(bot, -2002, "3:-1001"),
+		)
+		require.EqualError(t, err, "error: reminder 3 is not a reminder of this chat")
+	})
+}
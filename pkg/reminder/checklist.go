@@ -0,0 +1,117 @@
+package reminder
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+// ChecklistItem is an item of a checklist reminder and whether it has been ticked on an occurrence of the reminder
+type ChecklistItem struct {
+	Text     string `json:"text"`
+	Ticked   bool   `json:"ticked"`
+	UserID   int    `json:"user_id,omitempty"` // who ticked the item
+	UserName string `json:"user_name,omitempty"`
+}
+
+// checklistItemRegexp matches a line of a message which is an item of a checklist e.g. "- deploy to production"
+var checklistItemRegexp = regexp.MustCompile(`^\s*[-*•]\s+(\S.*)$`)
+
+// NewChecklist splits the message of a reminder into its title and the items of its checklist,
+// which are written one per line after the first one starting with "-", "*" or "•" e.g.
+// "Release checklist\n- tag the build\n- deploy". Items is nil when the message is not a checklist
+func NewChecklist(message string) (title string, items []ChecklistItem) {
+	lines := strings.Split(message, "\n")
+	titleLines := lines[:1]
+	for _, line := range lines[1:] {
+		match := checklistItemRegexp.FindStringSubmatch(line)
+		if match == nil {
+			titleLines = append(titleLines, line)
+			continue
+		}
+
+		items = append(items, ChecklistItem{Text: strings.TrimSpace(match[1])})
+	}
+
+	return strings.TrimSpace(strings.Join(titleLines, "\n")), items
+}
+
+// Tick ticks an item of the checklist of an occurrence for a member, or unticks it if it was ticked
+func (o *Occurrence) Tick(item, userID int, name string) error {
+	if item < 0 || item >= len(o.Checklist) {
+		return i18n.Errorf(i18n.ErrNotOnChecklist)
+	}
+
+	if o.Checklist[item].Ticked {
+		o.Checklist[item] = ChecklistItem{Text: o.Checklist[item].Text}
+		return nil
+	}
+
+	o.Checklist[item] = ChecklistItem{Text: o.Checklist[item].Text, Ticked: true, UserID: userID, UserName: name}
+
+	return nil
+}
+
+// Ticked is the number of items of the checklist of an occurrence which have been ticked
+func (o *Occurrence) Ticked() int {
+	ticked := 0
+	for i := range o.Checklist {
+		if o.Checklist[i].Ticked {
+			ticked++
+		}
+	}
+
+	return ticked
+}
+
+// ChecklistDone reports whether every item of the checklist of an occurrence has been ticked
+func (o *Occurrence) ChecklistDone() bool {
+	return len(o.Checklist) > 0 && o.Ticked() == len(o.Checklist)
+}
+
+// checklistIcon is the icon of an item of a checklist depending on whether it has been ticked
+func checklistIcon(item ChecklistItem) string {
+	if item.Ticked {
+		return "✅"
+	}
+
+	return "⬜"
+}
+
+// checklistMessage is the title an occurrence of a checklist reminder was sent with followed by its items,
+// each ticked one along with who ticked it, and whether the whole checklist is done
+func checklistMessage(lang i18n.Language, o *Occurrence) string {
+	var sb strings.Builder
+	sb.WriteString(o.Message)
+	sb.WriteString("\n")
+
+	for _, item := range o.Checklist {
+		sb.WriteString(fmt.Sprintf("\n%s %s", checklistIcon(item), item.Text))
+		if item.Ticked {
+			sb.WriteString(fmt.Sprintf(" — %s", (&Recipient{UserID: item.UserID, Name: item.UserName}).Mention()))
+		}
+	}
+
+	if o.ChecklistDone() {
+		sb.WriteString(fmt.Sprintf("\n\n%s", i18n.T(lang, i18n.ChecklistDone)))
+	}
+
+	return sb.String()
+}
+
+// checklistButtons are the buttons to tick each item of the checklist of an occurrence, one per row
+func checklistButtons(lang i18n.Language, r *Reminder, o *Occurrence) [][]tb.InlineButton {
+	itemBtn := *NewButtons(lang)[ChecklistItemBtn]
+	rows := make([][]tb.InlineButton, len(o.Checklist))
+	for i, item := range o.Checklist {
+		btn := itemBtn
+		btn.Text = fmt.Sprintf("%s %s", checklistIcon(item), item.Text)
+		btn.Data = checklistItemData(r, o.ID, i)
+		rows[i] = []tb.InlineButton{btn}
+	}
+
+	return rows
+}
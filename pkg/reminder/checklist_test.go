@@ -0,0 +1,313 @@
+package reminder_test
+
+import (
+	"testing"
+
+	"github.com/enrico5b1b4/tbwrap"
+	"github.com/golang/mock/gomock"
+	chatpreferenceMocks "github.com/husol/telegram-reminder-bot/pkg/chatpreference/mocks"
+	"github.com/husol/telegram-reminder-bot/pkg/cron"
+	cronMocks "github.com/husol/telegram-reminder-bot/pkg/cron/mocks"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
+	"github.com/husol/telegram-reminder-bot/pkg/reminder"
+	reminderMocks "github.com/husol/telegram-reminder-bot/pkg/reminder/mocks"
+	"github.com/husol/telegram-reminder-bot/pkg/telegram/fakes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+const checklist = "Release checklist\n- tag the build\n* deploy\n\nsee the runbook"
+
+func TestNewChecklist(t *testing.T) {
+	t.Run("items follow the title", func(t *testing.T) {
+		title, items := reminder.NewChecklist(checklist)
+		assert.Equal(t, "Release checklist\n\nsee the runbook", title)
+		assert.Equal(t, []reminder.ChecklistItem{{Text: "tag the build"}, {Text: "deploy"}}, items)
+	})
+
+	t.Run("a message without items is not a checklist", func(t *testing.T) {
+		title, items := reminder.NewChecklist("- take out the bins")
+		assert.Equal(t, "- take out the bins", title)
+		assert.Nil(t, items)
+	})
+}
+
+func TestOccurrence_Tick(t *testing.T) {
+	o := &reminder.Occurrence{Checklist: []reminder.ChecklistItem{{Text: "tag the build"}, {Text: "deploy"}}}
+
+	require.NoError(t, o.Tick(0, 7, "Bob"))
+	assert.Equal(t, reminder.ChecklistItem{Text: "tag the build", Ticked: true, UserID: 7, UserName: "Bob"}, o.Checklist[0])
+	assert.False(t, o.ChecklistDone())
+
+	require.NoError(t, o.Tick(1, 8, "Alice"))
+	assert.True(t, o.ChecklistDone())
+
+	require.NoError(t, o.Tick(0, 8, "Alice"))
+	assert.Equal(t, reminder.ChecklistItem{Text: "tag the build"}, o.Checklist[0])
+	assert.Equal(t, 1, o.Ticked())
+
+	assert.Error(t, o.Tick(2, 8, "Alice"))
+}
+
+func TestCronFuncService_AddOccurrence_Checklist(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	occurrenceStore := reminderMocks.NewMockOccurrenceStorer(mockCtrl)
+	occurrenceStore.EXPECT().CreateOccurrence(gomock.Any()).DoAndReturn(func(o *reminder.Occurrence) (int, error) {
+		assert.Equal(t, "🗓 Release checklist\n\nsee the runbook", o.Message)
+		assert.Len(t, o.Checklist, 2)
+		return 5, nil
+	})
+	service := reminder.NewCronFuncService(
+		&stubBot{},
+		fakes.NewMessageForwarder(),
+		cronMocks.NewMockScheduler(mockCtrl),
+		reminderMocks.NewMockStorer(mockCtrl),
+		occurrenceStore,
+		chatpreferenceMocks.NewMockStorer(mockCtrl),
+	)
+
+	// a checklist is recorded even when it is sent to a private chat
+	rem := &reminder.Reminder{Job: cron.Job{ID: reminderID, ChatID: chatID}}
+	assert.Equal(t, 5, service.AddOccurrence(rem, "🗓 "+checklist))
+}
+
+func TestNewCronFunc_Checklist(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	bot := &stubBot{}
+	cronFuncService := reminderMocks.NewMockCronFuncServicer(mockCtrl)
+	rem := &reminder.Reminder{
+		Job:  cron.Job{ID: reminderID, ChatID: groupChatID, Schedule: "0 16 * * 5", Status: cron.Active},
+		Data: reminder.Data{RecipientID: groupChatID, Message: "Release checklist\n- tag the build\n- deploy"},
+	}
+	cronFuncService.EXPECT().AddOccurrence(rem, "🗓 Release checklist\n- tag the build\n- deploy").Return(5)
+	cronFuncService.EXPECT().ChatLanguage(groupChatID).Return(i18n.English)
+	cronFuncService.EXPECT().UpdateReminderWithNextRun(rem).Return(nil)
+
+	reminder.NewCronFunc(cronFuncService, bot, rem)()
+	require.Equal(t, []string{"🗓 Release checklist\n\n⬜ tag the build\n⬜ deploy"}, bot.sent)
+	require.Len(t, bot.keyboards[0], 3)
+	assert.Equal(t, "⬜ tag the build", bot.keyboards[0][0][0].Text)
+	assert.Equal(t, "3:5:0:-1001", bot.keyboards[0][0][0].Data)
+	assert.Equal(t, "⬜ deploy", bot.keyboards[0][1][0].Text)
+	for _, btn := range bot.keyboards[0][2] {
+		// the items are ticked instead of acknowledging the reminder
+		assert.NotEqual(t, reminder.OnItBtn, btn.Unique)
+		assert.NotEqual(t, reminder.AckDoneBtn, btn.Unique)
+	}
+}
+
+func TestNewCronFunc_ChecklistNag(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	cronFuncService := reminderMocks.NewMockCronFuncServicer(mockCtrl)
+	rem := &reminder.Reminder{
+		Job: cron.Job{
+			ID:       reminderID,
+			ChatID:   groupChatID,
+			Schedule: "0 16 * * 5",
+			Status:   cron.Active,
+			Nag:      &cron.JobNag{Minutes: 10, MaxResends: 3},
+		},
+		Data: reminder.Data{RecipientID: groupChatID, Message: "Release checklist\n- tag the build\n- deploy"},
+	}
+	cronFuncService.EXPECT().AddOccurrence(rem, gomock.Any()).Return(5)
+	cronFuncService.EXPECT().ChatLanguage(groupChatID).Return(i18n.English)
+	cronFuncService.EXPECT().StartNag(rem).DoAndReturn(func(rem *reminder.Reminder) error {
+		// the occurrence which has just been sent is the one sent again
+		assert.Equal(t, 5, rem.Nag.OccurrenceID)
+		return nil
+	})
+	cronFuncService.EXPECT().UpdateReminderWithNextRun(rem).Return(nil)
+
+	reminder.NewCronFunc(cronFuncService, &stubBot{}, rem)()
+}
+
+func TestNewNagCronFunc_Checklist(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	bot := &stubBot{}
+	cronFuncService := reminderMocks.NewMockCronFuncServicer(mockCtrl)
+	rem := &reminder.Reminder{
+		Job: cron.Job{
+			ID:       reminderID,
+			ChatID:   groupChatID,
+			Schedule: "0 16 * * 5",
+			Status:   cron.Active,
+			Nag:      &cron.JobNag{Minutes: 10, MaxResends: 3, Resends: 1, OccurrenceID: 5},
+		},
+		Data: reminder.Data{RecipientID: groupChatID, Message: "Release checklist\n- tag the build\n- deploy"},
+	}
+	cronFuncService.EXPECT().ChatLanguage(groupChatID).Return(i18n.English)
+	cronFuncService.EXPECT().Occurrence(rem, 5).Return(&reminder.Occurrence{
+		ID:         5,
+		ChatID:     groupChatID,
+		ReminderID: reminderID,
+		Message:    "🗓 Release checklist",
+		Checklist:  []reminder.ChecklistItem{{Text: "tag the build", Ticked: true, UserID: 7, UserName: "Bob"}, {Text: "deploy"}},
+	})
+	cronFuncService.EXPECT().ContinueNag(rem).Return(nil)
+
+	reminder.NewNagCronFunc(cronFuncService, bot, rem)()
+	require.Equal(t, []string{
+		"🔁 Release checklist\n(reminder 2 of 3, press ✅ Done to stop)\n\n✅ tag the build — [Bob](tg://user?id=7)\n⬜ deploy",
+	}, bot.sent)
+	// the items are still ticked on the occurrence which is sent again
+	require.Len(t, bot.keyboards[0], 3)
+	assert.Equal(t, "✅ tag the build", bot.keyboards[0][0][0].Text)
+	assert.Equal(t, "3:5:0:-1001", bot.keyboards[0][0][0].Data)
+	assert.Equal(t, "⬜ deploy", bot.keyboards[0][1][0].Text)
+	assert.Equal(t, "3:5:1:-1001", bot.keyboards[0][1][0].Data)
+}
+
+// nolint:funlen
+func TestHandleReminderChecklistItemBtn(t *testing.T) {
+	newReminder := func() *reminder.Reminder {
+		return &reminder.Reminder{
+			Job:  cron.Job{ID: reminderID, ChatID: groupChatID, Schedule: "0 16 * * 5"},
+			Data: reminder.Data{RecipientID: groupChatID, Message: "Release checklist\n- tag the build\n- deploy"},
+		}
+	}
+	newOccurrence := func(items ...reminder.ChecklistItem) *reminder.Occurrence {
+		return &reminder.Occurrence{
+			ID:         5,
+			ChatID:     groupChatID,
+			ReminderID: reminderID,
+			Message:    "🗓 Release checklist",
+			Checklist:  items,
+		}
+	}
+	newContext := func(bot *fakes.TBWrapBot, chat int64, data string) tbwrap.Context {
+		msg := &tb.Message{ID: 10, Chat: &tb.Chat{ID: chat}}
+		callback := &tb.Callback{Data: data, Sender: &tb.User{ID: 7, FirstName: "Bob", LastName: "Smith"}, Message: msg}
+		return tbwrap.NewContext(bot, msg, callback, nil)
+	}
+
+	t.Run("ticks an item", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakes.NewTBWrapBot()
+		editor := fakes.NewMessageEditor()
+		cronFuncService := reminderMocks.NewMockCronFuncServicer(mockCtrl)
+		store := reminderMocks.NewMockStorer(mockCtrl)
+		occurrenceStore := reminderMocks.NewMockOccurrenceStorer(mockCtrl)
+		rem := newReminder()
+		occurrence := newOccurrence(reminder.ChecklistItem{Text: "tag the build"}, reminder.ChecklistItem{Text: "deploy"})
+		store.EXPECT().GetReminder(groupChatID, reminderID).Return(rem, nil)
+		occurrenceStore.EXPECT().GetOccurrence(groupChatID, reminderID, 5).Return(occurrence, nil)
+		occurrenceStore.EXPECT().UpdateOccurrence(occurrence).Return(nil)
+		cronFuncService.EXPECT().ChatLanguage(groupChatID).Return(i18n.English)
+
+		err := reminder.HandleReminderChecklistItemBtn(cronFuncService, store, occurrenceStore, editor)(
+			newContext(bot, groupChatID, "3:5:1:-1001"),
+		)
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"🗓 Release checklist\n\n⬜ tag the build\n✅ deploy — [Bob Smith](tg://user?id=7)",
+		}, editor.Edits)
+		assert.Equal(t, "✅ deploy", editor.Keyboards[0][1][0].Text)
+		assert.Empty(t, bot.OutboundSendMessages)
+	})
+
+	t.Run("ticking the last item gets the checklist done", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakes.NewTBWrapBot()
+		editor := fakes.NewMessageEditor()
+		cronFuncService := reminderMocks.NewMockCronFuncServicer(mockCtrl)
+		store := reminderMocks.NewMockStorer(mockCtrl)
+		occurrenceStore := reminderMocks.NewMockOccurrenceStorer(mockCtrl)
+		rem := newReminder()
+		occurrence := newOccurrence(
+			reminder.ChecklistItem{Text: "tag the build", Ticked: true, UserID: 8, UserName: "Alice"},
+			reminder.ChecklistItem{Text: "deploy"},
+		)
+		store.EXPECT().GetReminder(groupChatID, reminderID).Return(rem, nil)
+		occurrenceStore.EXPECT().GetOccurrence(groupChatID, reminderID, 5).Return(occurrence, nil)
+		occurrenceStore.EXPECT().UpdateOccurrence(occurrence).Return(nil)
+		cronFuncService.EXPECT().StopNag(rem).Return(nil)
+		cronFuncService.EXPECT().ChatLanguage(groupChatID).Return(i18n.English)
+
+		err := reminder.HandleReminderChecklistItemBtn(cronFuncService, store, occurrenceStore, editor)(
+			newContext(bot, groupChatID, "3:5:1:-1001"),
+		)
+		require.NoError(t, err)
+		require.Len(t, editor.Edits, 1)
+		assert.Contains(t, editor.Edits[0], "\n\nAll done ✅")
+	})
+
+	t.Run("ticking the last item completes a reminder which is only sent once", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakes.NewTBWrapBot()
+		editor := fakes.NewMessageEditor()
+		cronFuncService := reminderMocks.NewMockCronFuncServicer(mockCtrl)
+		store := reminderMocks.NewMockStorer(mockCtrl)
+		occurrenceStore := reminderMocks.NewMockOccurrenceStorer(mockCtrl)
+		rem := newReminder()
+		rem.Schedule = "0 16 3 4 *"
+		rem.RunOnlyOnce = true
+		occurrence := newOccurrence(
+			reminder.ChecklistItem{Text: "tag the build", Ticked: true, UserID: 8, UserName: "Alice"},
+			reminder.ChecklistItem{Text: "deploy"},
+		)
+		store.EXPECT().GetReminder(groupChatID, reminderID).Return(rem, nil)
+		occurrenceStore.EXPECT().GetOccurrence(groupChatID, reminderID, 5).Return(occurrence, nil)
+		occurrenceStore.EXPECT().UpdateOccurrence(occurrence).Return(nil)
+		cronFuncService.EXPECT().StopNag(rem).Return(nil)
+		cronFuncService.EXPECT().Complete(rem).Return(nil)
+		cronFuncService.EXPECT().ChatLanguage(groupChatID).Return(i18n.English)
+
+		err := reminder.HandleReminderChecklistItemBtn(cronFuncService, store, occurrenceStore, editor)(
+			newContext(bot, groupChatID, "3:5:1:-1001"),
+		)
+		require.NoError(t, err)
+		require.Len(t, editor.Edits, 1)
+	})
+
+	t.Run("ticks an item of a checklist sent by private message", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakes.NewTBWrapBot()
+		editor := fakes.NewMessageEditor()
+		cronFuncService := reminderMocks.NewMockCronFuncServicer(mockCtrl)
+		store := reminderMocks.NewMockStorer(mockCtrl)
+		occurrenceStore := reminderMocks.NewMockOccurrenceStorer(mockCtrl)
+		rem := newReminder()
+		rem.Data.Recipient = &reminder.Recipient{UserID: 7, Name: "Bob", Private: true}
+		occurrence := newOccurrence(reminder.ChecklistItem{Text: "tag the build"}, reminder.ChecklistItem{Text: "deploy"})
+		store.EXPECT().GetReminder(groupChatID, reminderID).Return(rem, nil)
+		occurrenceStore.EXPECT().GetOccurrence(groupChatID, reminderID, 5).Return(occurrence, nil)
+		occurrenceStore.EXPECT().UpdateOccurrence(occurrence).Return(nil)
+		cronFuncService.EXPECT().ChatLanguage(groupChatID).Return(i18n.English)
+
+		err := reminder.HandleReminderChecklistItemBtn(cronFuncService, store, occurrenceStore, editor)(
+			newContext(bot, 7, "3:5:0:-1001"),
+		)
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"🗓 Release checklist\n\n✅ tag the build — [Bob Smith](tg://user?id=7)\n⬜ deploy",
+		}, editor.Edits)
+		// the buttons of a reminder sent by private message carry its chat
+		assert.Equal(t, "3:-1001", editor.Keyboards[0][2][0].Data)
+	})
+
+	t.Run("failure when the button names the chat of a checklist which was not sent to the user", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakes.NewTBWrapBot()
+		editor := fakes.NewMessageEditor()
+		cronFuncService := reminderMocks.NewMockCronFuncServicer(mockCtrl)
+		store := reminderMocks.NewMockStorer(mockCtrl)
+		occurrenceStore := reminderMocks.NewMockOccurrenceStorer(mockCtrl)
+		store.EXPECT().GetReminder(groupChatID, reminderID).Return(newReminder(), nil)
+
+		err := reminder.HandleReminderChecklistItemBtn(cronFuncService, store, occurrenceStore, editor)(
+			newContext(bot, 7, "3:5:0:-1001"),
+		)
+		require.EqualError(t, err, "error: reminder 3 is not a reminder of this chat")
+		assert.Empty(t, editor.Edits)
+	})
+}
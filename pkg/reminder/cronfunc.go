@@ -6,10 +6,12 @@ import (
 	"fmt"
 	"log"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/husol/telegram-reminder-bot/pkg/chatpreference"
 	"github.com/husol/telegram-reminder-bot/pkg/cron"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
 	"github.com/husol/telegram-reminder-bot/pkg/telegram"
 	tb "gopkg.in/tucnak/telebot.v2"
 )
@@ -18,25 +20,38 @@ type CronFuncServicer interface {
 	Complete(r *Reminder) error
 	UpdateReminderWithNextRun(rem *Reminder) error
 	UpdateReminderWithRepeatSchedule(rem *Reminder) error
+	StartNag(rem *Reminder) error
+	ContinueNag(rem *Reminder) error
+	StopNag(rem *Reminder) error
+	ChatLanguage(chatID int) i18n.Language
+	AddOccurrence(rem *Reminder, message string) int
+	Occurrence(rem *Reminder, occurrenceID int) *Occurrence
+	ForwardRepliedMessage(rem *Reminder, to tb.Recipient) error
 }
 
 type CronFuncService struct {
 	b                   telegram.TBWrapBot
+	messageForwarder    telegram.MessageForwarder
 	scheduler           cron.Scheduler
 	reminderStore       Storer
+	occurrenceStore     OccurrenceStorer
 	chatPreferenceStore chatpreference.Storer
 }
 
 func NewCronFuncService(
 	b telegram.TBWrapBot,
+	messageForwarder telegram.MessageForwarder,
 	scheduler cron.Scheduler,
 	reminderStore Storer,
+	occurrenceStore OccurrenceStorer,
 	chatPreferenceStore chatpreference.Storer,
 ) *CronFuncService {
 	return &CronFuncService{
 		b:                   b,
+		messageForwarder:    messageForwarder,
 		scheduler:           scheduler,
 		reminderStore:       reminderStore,
+		occurrenceStore:     occurrenceStore,
 		chatPreferenceStore: chatPreferenceStore,
 	}
 }
@@ -56,6 +71,69 @@ func (s *CronFuncService) Complete(r *Reminder) error {
 	return nil
 }
 
+// ChatLanguage returns the language the chat reads the bot in, English when it has not set one
+func (s *CronFuncService) ChatLanguage(chatID int) i18n.Language {
+	chatPreference, err := s.chatPreferenceStore.GetChatPreference(chatID)
+	if err != nil || chatPreference.Language == "" {
+		return i18n.English
+	}
+
+	return chatPreference.Language
+}
+
+// AddOccurrence records that a reminder is being sent to a group so that its members can acknowledge it,
+// or that a checklist reminder is being sent so that its items can be ticked.
+// It returns the ID of the occurrence, or 0 when the reminder is neither or could not be recorded
+func (s *CronFuncService) AddOccurrence(rem *Reminder, message string) int {
+	title, checklist := NewChecklist(message)
+	if checklist == nil && (!isGroup(rem.ChatID) || (rem.Data.Recipient != nil && rem.Data.Recipient.Private)) {
+		return 0
+	}
+	if checklist != nil {
+		message = title
+	}
+
+	occurrenceID, err := s.occurrenceStore.CreateOccurrence(&Occurrence{
+		ChatID:     rem.ChatID,
+		ReminderID: rem.ID,
+		At:         time.Now().In(time.UTC),
+		Message:    message,
+		Checklist:  checklist,
+	})
+	if err != nil {
+		log.Printf("AddOccurrence err: %q", err)
+		return 0
+	}
+
+	return occurrenceID
+}
+
+// Occurrence returns an occurrence of a reminder which was recorded when it was sent,
+// nil when none was recorded or it could not be read
+func (s *CronFuncService) Occurrence(rem *Reminder, occurrenceID int) *Occurrence {
+	if occurrenceID == 0 {
+		return nil
+	}
+
+	occurrence, err := s.occurrenceStore.GetOccurrence(rem.ChatID, rem.ID, occurrenceID)
+	if err != nil {
+		log.Printf("Occurrence err: %q", err)
+		return nil
+	}
+
+	return occurrence
+}
+
+// ForwardRepliedMessage forwards the message of the chat a reminder was set by replying to
+func (s *CronFuncService) ForwardRepliedMessage(rem *Reminder, to tb.Recipient) error {
+	_, err := s.messageForwarder.Forward(to, tb.StoredMessage{
+		MessageID: strconv.Itoa(rem.Data.ReplyToMessageID),
+		ChatID:    int64(rem.ChatID),
+	})
+
+	return err
+}
+
 // NewCronFunc creates a function which is called when a reminder is due
 // Note: repeatable jobs can be of two kinds:
 // - Reminders set as "remind me every 31 april at 13:52" will have a cron job like "52 13 31 April *"
@@ -66,99 +144,411 @@ func (s *CronFuncService) Complete(r *Reminder) error {
 //   They will have a RepeatSchedule which will reschedule the job for the following occurrence (e.g. in 3 minutes from now)
 func NewCronFunc(s CronFuncServicer, b telegram.TBWrapBot, r *Reminder) func() {
 	return func() {
-		buttons := NewButtons()
-		var inlineKeys [][]tb.InlineButton
-		var inlineButtons []tb.InlineButton
-
-		snoozeBtn := *buttons[SnoozeBtn]
-		snoozeBtn.Data = strconv.Itoa(r.ID)
-		inlineButtons = append(
-			inlineButtons,
-			snoozeBtn,
-		)
-
-		// if repeatable job add button to complete it
-		if !r.Job.RunOnlyOnce || (r.Job.RunOnlyOnce && r.Job.RepeatSchedule != nil) {
-			completeBtn := *buttons[CompleteBtn]
-			completeBtn.Data = strconv.Itoa(r.ID)
-			inlineButtons = append(inlineButtons, completeBtn)
+		runReminder(s, b, r, fmt.Sprintf("🗓 %s", r.Data.Message))
+	}
+}
+
+func runReminder(s CronFuncServicer, b telegram.TBWrapBot, r *Reminder, messageWithIcon string) {
+	if takeSkippedRun(r, time.Now()) {
+		// a skipped occurrence is neither sent nor counted but the reminder still moves on to the next one
+		advanceReminder(s, r)
+		return
+	}
+
+	// the turn is saved along with the rest of the reminder below
+	takeRosterTurn(r)
+	occurrenceID := s.AddOccurrence(r, messageWithIcon)
+	err := sendReminder(s, b, s.ChatLanguage(r.ChatID), r, messageWithIcon, sentOccurrence(occurrenceID, messageWithIcon))
+	if err != nil {
+		log.Printf("NewReminderCronFunc err: %q", err)
+		return
+	}
+
+	timeNow := time.Now().In(time.UTC)
+	r.LastRunAt = &timeNow
+
+	if r.Job.RemainingRuns != nil {
+		*r.Job.RemainingRuns--
+	}
+
+	if r.Job.Nag != nil {
+		// the follow-up cycle gets saved along with the rest of the reminder below
+		r.Job.Nag.OccurrenceID = occurrenceID
+		err = s.StartNag(r)
+		if err != nil {
+			log.Printf("NewReminderCronFunc StartNag err: %q", err)
 		}
-		inlineKeys = append(inlineKeys, inlineButtons)
+	}
 
-		messageWithIcon := fmt.Sprintf("🗓 %s", r.Data.Message)
-		_, err := b.Send(&tb.Chat{ID: int64(r.Data.RecipientID)}, messageWithIcon, &tb.ReplyMarkup{
-			InlineKeyboard: inlineKeys,
-		})
+	if r.Job.RemainingRuns != nil && *r.Job.RemainingRuns <= 0 {
+		err = s.Complete(r)
 		if err != nil {
-			log.Printf("NewReminderCronFunc err: %q", err)
-			return
+			log.Printf("NewReminderCronFunc complete err: %q", err)
 		}
+		return
+	}
 
-		timeNow := time.Now().In(time.UTC)
-		r.LastRunAt = &timeNow
+	advanceReminder(s, r)
+}
 
-		if !r.Job.RunOnlyOnce {
-			// update the next run at field of the reminder if it is a recurring reminder
-			err = s.UpdateReminderWithNextRun(r)
-			if err != nil {
-				log.Printf("NewReminderCronFunc UpdateReminderWithNextRun err: %q", err)
-				return
-			}
+// advanceReminder moves a reminder which has just run on to its next occurrence, or completes it if it has none
+func advanceReminder(s CronFuncServicer, r *Reminder) {
+	if !r.Job.RunOnlyOnce {
+		// update the next run at field of the reminder if it is a recurring reminder
+		err := s.UpdateReminderWithNextRun(r)
+		if err != nil {
+			log.Printf("NewReminderCronFunc UpdateReminderWithNextRun err: %q", err)
 			return
 		}
+		return
+	}
+
+	if r.Job.RepeatSchedule != nil {
+		// if the reminder has a RepeatSchedule then we don't want to Complete() it
+		// but instead calculate the next time it should run and reschedule it
+		updateErr := s.UpdateReminderWithRepeatSchedule(r)
+		if updateErr != nil {
+			log.Printf("NewReminderCronFunc UpdateReminderWithRepeatSchedule err: %q", updateErr)
+			return
+		}
+		return
+	}
+
+	err := s.Complete(r)
+	if err != nil {
+		log.Printf("NewReminderCronFunc complete err: %q", err)
+		return
+	}
+}
+
+// skippedRunTolerance is how far from a skipped run a reminder can run and still be skipped
+const skippedRunTolerance = time.Minute
+
+// takeSkippedRun removes the skipped runs of a reminder which are due by t
+// and reports whether the run at t is one of them
+func takeSkippedRun(r *Reminder, t time.Time) bool {
+	if len(r.SkippedRuns) == 0 {
+		return false
+	}
+
+	skipped := false
+	var remaining []time.Time
+	for _, run := range r.SkippedRuns {
+		if run.After(t.Add(skippedRunTolerance)) {
+			remaining = append(remaining, run)
+			continue
+		}
+
+		if !run.Before(t.Add(-skippedRunTolerance)) {
+			skipped = true
+		}
+	}
+	r.SkippedRuns = remaining
 
-		if r.Job.RepeatSchedule != nil {
-			// if the reminder has a RepeatSchedule then we don't want to Complete() it
-			// but instead calculate the next time it should run and reschedule it
-			updateErr := s.UpdateReminderWithRepeatSchedule(r)
-			if updateErr != nil {
-				log.Printf("NewReminderCronFunc UpdateReminderWithRepeatSchedule err: %q", updateErr)
-				return
+	return skipped
+}
+
+// sendReminder sends the reminder message to its recipient along with the buttons to snooze or complete it
+// labelled in the language of the chat. A reminder for a user of a group mentions them,
+// or is sent to them by private message if they asked for it, falling back to the group when the bot can't reach them.
+// Reminders sent to a group as an occurrence, which is not nil, also have the buttons its members acknowledge it with,
+// while checklist reminders sent as an occurrence have the buttons to tick each of their items instead.
+// The checklist is shown as it has been ticked so far under the title of the message.
+// A reminder set by replying to a message quotes it in its chat and forwards it when it is sent by private message
+func sendReminder(
+	s CronFuncServicer, b telegram.TBWrapBot, lang i18n.Language, r *Reminder, messageWithIcon string, occurrence *Occurrence,
+) error {
+	if occurrence != nil && occurrence.Checklist != nil {
+		title, _ := NewChecklist(messageWithIcon)
+		messageWithIcon = checklistMessage(lang, &Occurrence{Message: title, Checklist: occurrence.Checklist})
+	}
+
+	recipient := r.Data.Recipient
+	if recipient != nil && recipient.Private && recipient.UserID != 0 {
+		user := &tb.User{ID: recipient.UserID}
+		err := sendMessage(b, user, r.Data.Attachment, messageWithIcon, &tb.SendOptions{
+			ParseMode:   tb.ModeMarkdown,
+			ReplyMarkup: &tb.ReplyMarkup{InlineKeyboard: reminderButtons(lang, r, buttonData(r, true), occurrence)},
+		})
+		if err == nil {
+			if r.Data.ReplyToMessageID != 0 {
+				if forwardErr := s.ForwardRepliedMessage(r, user); forwardErr != nil {
+					log.Printf("sendReminder forward replied message err: %q", forwardErr)
+				}
 			}
+			return nil
+		}
+		log.Printf("sendReminder private message err: %q", err)
+	}
+
+	// options replace the ones the bot sends messages with, so they have to be sent as Markdown again
+	options := &tb.SendOptions{ParseMode: tb.ModeMarkdown, ReplyMarkup: &tb.ReplyMarkup{
+		InlineKeyboard: reminderButtons(lang, r, buttonData(r, false), occurrence),
+	}}
+	if r.Data.ReplyToMessageID != 0 {
+		options.ReplyTo = &tb.Message{ID: r.Data.ReplyToMessageID}
+	}
+
+	chat := &tb.Chat{ID: int64(r.Data.RecipientID)}
+	err := sendMessage(b, chat, r.Data.Attachment, groupMessage(r, messageWithIcon), options)
+	if err != nil && options.ReplyTo != nil {
+		// the message may have been deleted since, in which case the reminder is sent without quoting it
+		log.Printf("sendReminder reply to message err: %q", err)
+		options.ReplyTo = nil
+		err = sendMessage(b, chat, r.Data.Attachment, groupMessage(r, messageWithIcon), options)
+	}
+
+	return err
+}
+
+// sentOccurrence is the occurrence of a reminder being sent with a message which has just been recorded,
+// nil when none was recorded
+func sentOccurrence(occurrenceID int, message string) *Occurrence {
+	if occurrenceID == 0 {
+		return nil
+	}
+
+	title, checklist := NewChecklist(message)
+
+	return &Occurrence{ID: occurrenceID, Message: title, Checklist: checklist}
+}
+
+// sendMessage sends the message of a reminder with its attachment, if it has one, as its caption.
+// Attachments which can't have a caption are sent on their own before the message
+func sendMessage(b telegram.TBWrapBot, to tb.Recipient, attachment *Attachment, message string, options *tb.SendOptions) error {
+	if attachment == nil {
+		_, err := b.Send(to, message, options)
+		return err
+	}
+
+	what, captioned := attachment.sendable(message)
+	if captioned {
+		_, err := b.Send(to, what, options)
+		return err
+	}
+
+	_, err := b.Send(to, what, &tb.SendOptions{ReplyTo: options.ReplyTo})
+	if err != nil {
+		return err
+	}
+
+	_, err = b.Send(to, message, &tb.SendOptions{ParseMode: options.ParseMode, ReplyMarkup: options.ReplyMarkup})
+
+	return err
+}
+
+// groupMessage is the message of a reminder sent to its chat, which mentions the user of the group it is for
+func groupMessage(r *Reminder, messageWithIcon string) string {
+	if r.Data.Recipient == nil {
+		return messageWithIcon
+	}
+
+	return fmt.Sprintf("%s %s", r.Data.Recipient.Mention(), messageWithIcon)
+}
+
+// reminderButtons are the buttons to snooze or complete a reminder, each carrying data.
+// The buttons to acknowledge an occurrence of a reminder are added when it is not nil,
+// whose Done button also acknowledges a reminder which nags.
+// An occurrence of a checklist reminder has the buttons to tick its items instead
+func reminderButtons(lang i18n.Language, r *Reminder, data string, o *Occurrence) [][]tb.InlineButton {
+	buttons := NewButtons(lang)
+	var inlineButtons []tb.InlineButton
+	acknowledged := o != nil && o.Checklist == nil
+
+	snoozeBtn := *buttons[SnoozeBtn]
+	snoozeBtn.Data = data
+	inlineButtons = append(
+		inlineButtons,
+		snoozeBtn,
+	)
+
+	// if the reminder nags add button to acknowledge it
+	if r.Job.Nag != nil && !acknowledged {
+		doneBtn := *buttons[DoneBtn]
+		doneBtn.Data = data
+		inlineButtons = append(inlineButtons, doneBtn)
+	}
+
+	// if repeatable job add button to complete it
+	if !r.Job.RunOnlyOnce || (r.Job.RunOnlyOnce && r.Job.RepeatSchedule != nil) {
+		completeBtn := *buttons[CompleteBtn]
+		completeBtn.Data = data
+		skipNextBtn := *buttons[SkipNextBtn]
+		skipNextBtn.Data = data
+		inlineButtons = append(inlineButtons, skipNextBtn, completeBtn)
+	}
+
+	if o != nil && o.Checklist != nil {
+		return append(checklistButtons(lang, r, o), inlineButtons)
+	}
+
+	if !acknowledged {
+		return [][]tb.InlineButton{inlineButtons}
+	}
+
+	onItBtn := *buttons[OnItBtn]
+	onItBtn.Data = occurrenceData(r, o.ID)
+	ackDoneBtn := *buttons[AckDoneBtn]
+	ackDoneBtn.Data = occurrenceData(r, o.ID)
+
+	return [][]tb.InlineButton{{onItBtn, ackDoneBtn}, inlineButtons}
+}
+
+// NewNagCronFunc creates a function which is called when a reminder
+// which has not been acknowledged is due to be sent again.
+// It is sent again as the occurrence which is followed up so that it can be acknowledged, or its checklist ticked, from there
+func NewNagCronFunc(s CronFuncServicer, b telegram.TBWrapBot, r *Reminder) func() {
+	return func() {
+		lang := s.ChatLanguage(r.ChatID)
+		err := sendReminder(s, b, lang, r, nagReminderMessage(lang, r), s.Occurrence(r, r.Nag.OccurrenceID))
+		if err != nil {
+			log.Printf("NewNagCronFunc err: %q", err)
 			return
 		}
 
-		err = s.Complete(r)
+		err = s.ContinueNag(r)
 		if err != nil {
-			log.Printf("NewReminderCronFunc complete err: %q", err)
+			log.Printf("NewNagCronFunc ContinueNag err: %q", err)
 			return
 		}
 	}
 }
 
+// nagReminderMessage is the message sent each time a reminder which has not been acknowledged is sent again
+func nagReminderMessage(lang i18n.Language, r *Reminder) string {
+	return fmt.Sprintf("🔁 %s\n%s", r.Data.Message, i18n.T(lang, i18n.ReminderNag, r.Nag.Resends+1, r.Nag.MaxResends))
+}
+
+// nagSchedule returns the schedule of a single follow-up of a reminder at t.
+// The schedule only has minute precision so t is truncated to the minute
+func nagSchedule(t time.Time) (time.Time, string) {
+	t = t.In(time.UTC).Truncate(time.Minute)
+
+	return t, fmt.Sprintf("CRON_TZ=UTC %d %d %d %d *", t.Minute(), t.Hour(), t.Day(), t.Month())
+}
+
+// StartNag starts the follow-up cycle of a reminder which has just been sent.
+// Any cycle still in progress is replaced.
+// The reminder is not saved as StartNag is called before the reminder gets updated after being sent
+func (s *CronFuncService) StartNag(rem *Reminder) error {
+	s.scheduler.Remove(rem.Nag.CronID)
+	rem.Nag.Resends = 0
+
+	return s.scheduleNag(rem, time.Now().Add(time.Duration(rem.Nag.Minutes)*time.Minute))
+}
+
+// ContinueNag schedules the following re-send of a reminder which has just been sent again.
+// The cycle ends once the reminder has been sent the maximum number of times
+func (s *CronFuncService) ContinueNag(rem *Reminder) error {
+	s.scheduler.Remove(rem.Nag.CronID)
+	rem.Nag.Resends++
+
+	if rem.Nag.Resends >= rem.Nag.MaxResends {
+		resetNag(rem)
+		return s.reminderStore.UpdateReminder(rem)
+	}
+
+	err := s.scheduleNag(rem, time.Now().Add(time.Duration(rem.Nag.Minutes)*time.Minute))
+	if err != nil {
+		return err
+	}
+
+	return s.reminderStore.UpdateReminder(rem)
+}
+
+// StopNag ends the follow-up cycle of a reminder once it has been acknowledged
+func (s *CronFuncService) StopNag(rem *Reminder) error {
+	if rem.Nag == nil {
+		return nil
+	}
+
+	s.scheduler.Remove(rem.Nag.CronID)
+	resetNag(rem)
+
+	return s.reminderStore.UpdateReminder(rem)
+}
+
+func (s *CronFuncService) scheduleNag(rem *Reminder, at time.Time) error {
+	nextRunAt, schedule := nagSchedule(at)
+	nagCronID, err := s.scheduler.Add(schedule, NewNagCronFunc(s, s.b, rem))
+	if err != nil {
+		return err
+	}
+
+	rem.Nag.CronID = nagCronID
+	rem.Nag.NextRunAt = &nextRunAt
+
+	return nil
+}
+
+// resetNag clears the follow-up cycle of a reminder while keeping its settings
+func resetNag(rem *Reminder) {
+	if rem.Nag == nil {
+		return
+	}
+
+	rem.Nag.Resends = 0
+	rem.Nag.CronID = 0
+	rem.Nag.NextRunAt = nil
+	rem.Nag.OccurrenceID = 0
+}
+
+// lateReminderMessage is the message sent for a reminder which was due while the bot was not running.
+// missed is the number of occurrences which were not delivered and lateBy how long ago the first of them was due
+func lateReminderMessage(lang i18n.Language, r *Reminder, missed int, lateBy time.Duration) string {
+	if missed > 1 {
+		return fmt.Sprintf("🗓 %s\n%s", r.Data.Message, i18n.T(lang, i18n.ReminderLateMissed, missed, formatDuration(lateBy)))
+	}
+
+	return fmt.Sprintf("🗓 %s\n%s", r.Data.Message, i18n.T(lang, i18n.ReminderLate, formatDuration(lateBy)))
+}
+
+// formatDuration formats a duration as days, hours and minutes e.g. "1d 2h 5m"
+func formatDuration(d time.Duration) string {
+	days := int(d / (24 * time.Hour))
+	hours := int(d % (24 * time.Hour) / time.Hour)
+	minutes := int(d % time.Hour / time.Minute)
+
+	var parts []string
+	if days > 0 {
+		parts = append(parts, fmt.Sprintf("%dd", days))
+	}
+	if hours > 0 {
+		parts = append(parts, fmt.Sprintf("%dh", hours))
+	}
+	if minutes > 0 || len(parts) == 0 {
+		parts = append(parts, fmt.Sprintf("%dm", minutes))
+	}
+
+	return strings.Join(parts, " ")
+}
+
 // UpdateReminderWithRepeatSchedule updates the reminder setting the schedule
 // date to be in the future according to the definition of RepeatSchedule.
 // The current reminder on the scheduler gets removed and a new one is created
-// with the newly calculated schedule
+// with the newly calculated schedule, unless the reminder has ended and is completed
 func (s *CronFuncService) UpdateReminderWithRepeatSchedule(rem *Reminder) error {
 	chatPreference, err := s.chatPreferenceStore.GetChatPreference(rem.Job.ChatID)
 	if err != nil {
 		return err
 	}
 
-	loc, err := time.LoadLocation(chatPreference.TimeZone)
+	timeZone := reminderTimeZone(chatPreference, rem)
+	loc, err := time.LoadLocation(timeZone)
 	if err != nil {
 		return err
 	}
 
-	addedTime := time.Now().In(loc).Add(
-		time.Duration(rem.RepeatSchedule.Days)*24*time.Hour +
-			time.Duration(rem.RepeatSchedule.Hours)*time.Hour +
-			time.Duration(rem.RepeatSchedule.Minutes)*time.Minute,
-	)
-
-	schedule := fmt.Sprintf("%d %d %d %d *",
-		addedTime.Minute(),
-		addedTime.Hour(),
-		addedTime.Day(),
-		addedTime.Month(),
-	)
-	rem.Job.Schedule = schedule
+	addedTime := addRepeatSchedule(repeatBase(rem, time.Now().In(loc)), rem.RepeatSchedule)
+	addedTime = scheduleRepeatAt(rem, chatPreference.Holidays, addedTime)
+	if hasEnded(rem, addedTime) {
+		return s.Complete(rem)
+	}
 
 	// remove previous cron job before scheduling new one
 	s.scheduler.Remove(rem.CronID)
 
-	scheduleWithTZ := fmt.Sprintf("CRON_TZ=%s %s", chatPreference.TimeZone, schedule)
+	scheduleWithTZ := fmt.Sprintf("CRON_TZ=%s %s", timeZone, rem.Job.Schedule)
 	reminderCronID, err := s.scheduler.Add(scheduleWithTZ, NewCronFunc(s, s.b, rem))
 	if err != nil {
 		return err
@@ -177,10 +567,29 @@ func (s *CronFuncService) UpdateReminderWithRepeatSchedule(rem *Reminder) error
 }
 
 // UpdateReminderWithNextRun updates the reminder NextRunAt field
-// with the newly calculated schedule
+// with the newly calculated schedule, unless the reminder has ended and is completed
 func (s *CronFuncService) UpdateReminderWithNextRun(rem *Reminder) error {
 	cronEntry := s.scheduler.GetEntryByID(rem.CronID)
-	rem.NextRunAt = &cronEntry.Next
+	nextRun := cronEntry.Next
+
+	// the scheduler moves the entry on to its next run only after starting the job
+	if timeNow := time.Now(); !nextRun.After(timeNow) {
+		chatPreference, err := s.chatPreferenceStore.GetChatPreference(rem.Job.ChatID)
+		if err != nil {
+			return err
+		}
+
+		schedule, err := reminderSchedule(chatPreference, rem)
+		if err != nil {
+			return err
+		}
+		nextRun = schedule.Next(timeNow)
+	}
+
+	if hasEnded(rem, nextRun) {
+		return s.Complete(rem)
+	}
+	rem.NextRunAt = &nextRun
 
 	err := s.reminderStore.UpdateReminder(rem)
 	if err != nil {
@@ -1,3 +1,432 @@
 package reminder_test
 
-// TODO tests
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/enrico5b1b4/tbwrap"
+	"github.com/golang/mock/gomock"
+	"github.com/husol/telegram-reminder-bot/pkg/allowlist"
+	allowlistMocks "github.com/husol/telegram-reminder-bot/pkg/allowlist/mocks"
+	chatpreferenceMocks "github.com/husol/telegram-reminder-bot/pkg/chatpreference/mocks"
+	"github.com/husol/telegram-reminder-bot/pkg/cron"
+	cronMocks "github.com/husol/telegram-reminder-bot/pkg/cron/mocks"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
+	permissionMocks "github.com/husol/telegram-reminder-bot/pkg/permission/mocks"
+	"github.com/husol/telegram-reminder-bot/pkg/reminder"
+	reminderMocks "github.com/husol/telegram-reminder-bot/pkg/reminder/mocks"
+	"github.com/husol/telegram-reminder-bot/pkg/telegram/fakes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+func TestNewCronFunc(t *testing.T) {
+	newReminder := func(remainingRuns int) *reminder.Reminder {
+		return &reminder.Reminder{
+			Job: cron.Job{
+				ID:            reminderID,
+				CronID:        cronID,
+				ChatID:        chatID,
+				Schedule:      "0 9 * * *",
+				Status:        cron.Active,
+				RemainingRuns: &remainingRuns,
+			},
+			Data: reminder.Data{RecipientID: chatID, Message: message},
+		}
+	}
+
+	t.Run("updates next run of reminder with runs remaining", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := &stubBot{}
+		cronFuncService := reminderMocks.NewMockCronFuncServicer(mockCtrl)
+		rem := newReminder(2)
+		cronFuncService.EXPECT().AddOccurrence(rem, gomock.Any()).Return(0)
+		cronFuncService.EXPECT().ChatLanguage(chatID).Return(i18n.English)
+		cronFuncService.EXPECT().UpdateReminderWithNextRun(rem).Return(nil)
+
+		reminder.NewCronFunc(cronFuncService, bot, rem)()
+		require.Len(t, bot.sent, 1)
+		assert.Equal(t, 1, *rem.RemainingRuns)
+	})
+
+	t.Run("completes reminder on its last run", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := &stubBot{}
+		cronFuncService := reminderMocks.NewMockCronFuncServicer(mockCtrl)
+		rem := newReminder(1)
+		cronFuncService.EXPECT().AddOccurrence(rem, gomock.Any()).Return(0)
+		cronFuncService.EXPECT().ChatLanguage(chatID).Return(i18n.English)
+		cronFuncService.EXPECT().Complete(rem).Return(nil)
+
+		reminder.NewCronFunc(cronFuncService, bot, rem)()
+		require.Len(t, bot.sent, 1)
+		assert.Equal(t, 0, *rem.RemainingRuns)
+	})
+
+	t.Run("does not send a skipped run but moves on to the next one", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := &stubBot{}
+		cronFuncService := reminderMocks.NewMockCronFuncServicer(mockCtrl)
+		rem := newReminder(2)
+		laterRun := time.Now().Add(24 * time.Hour)
+		rem.SkippedRuns = []time.Time{time.Now().Add(-2 * time.Hour), time.Now(), laterRun}
+		cronFuncService.EXPECT().UpdateReminderWithNextRun(rem).Return(nil)
+
+		reminder.NewCronFunc(cronFuncService, bot, rem)()
+		require.Len(t, bot.sent, 0)
+		assert.Equal(t, 2, *rem.RemainingRuns)
+		assert.Equal(t, []time.Time{laterRun}, rem.SkippedRuns)
+	})
+
+	t.Run("sends a run which is not skipped", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := &stubBot{}
+		cronFuncService := reminderMocks.NewMockCronFuncServicer(mockCtrl)
+		rem := newReminder(2)
+		rem.SkippedRuns = []time.Time{time.Now().Add(-2 * time.Hour)}
+		cronFuncService.EXPECT().AddOccurrence(rem, gomock.Any()).Return(0)
+		cronFuncService.EXPECT().ChatLanguage(chatID).Return(i18n.English)
+		cronFuncService.EXPECT().UpdateReminderWithNextRun(rem).Return(nil)
+
+		reminder.NewCronFunc(cronFuncService, bot, rem)()
+		require.Len(t, bot.sent, 1)
+		assert.Empty(t, rem.SkippedRuns)
+	})
+}
+
+func TestNewCronFunc_Recipient(t *testing.T) {
+	newReminder := func(recipient *reminder.Recipient) *reminder.Reminder {
+		return &reminder.Reminder{
+			Job: cron.Job{
+				ID:          reminderID,
+				CronID:      cronID,
+				ChatID:      chatID,
+				Schedule:    "0 9 * * *",
+				Status:      cron.Active,
+				RunOnlyOnce: true,
+			},
+			Data: reminder.Data{RecipientID: chatID, Recipient: recipient, Message: message},
+		}
+	}
+
+	t.Run("mentions the user in the chat", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := &stubBot{}
+		cronFuncService := reminderMocks.NewMockCronFuncServicer(mockCtrl)
+		rem := newReminder(&reminder.Recipient{UserID: 42, Username: "alice", Name: "Alice [dev]"})
+		cronFuncService.EXPECT().AddOccurrence(rem, gomock.Any()).Return(0)
+		cronFuncService.EXPECT().ChatLanguage(chatID).Return(i18n.English)
+		cronFuncService.EXPECT().Complete(rem).Return(nil)
+
+		reminder.NewCronFunc(cronFuncService, bot, rem)()
+		require.Len(t, bot.sent, 1)
+		assert.True(t, strings.HasPrefix(bot.sent[0], "[Alice dev](tg://user?id=42) "))
+		assert.Equal(t, []string{strconv.Itoa(chatID)}, bot.to)
+	})
+
+	t.Run("sends the reminder to the user privately", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := &stubBot{}
+		cronFuncService := reminderMocks.NewMockCronFuncServicer(mockCtrl)
+		rem := newReminder(&reminder.Recipient{UserID: 42, Name: "Alice", Private: true})
+		cronFuncService.EXPECT().AddOccurrence(rem, gomock.Any()).Return(0)
+		cronFuncService.EXPECT().ChatLanguage(chatID).Return(i18n.English)
+		cronFuncService.EXPECT().Complete(rem).Return(nil)
+
+		reminder.NewCronFunc(cronFuncService, bot, rem)()
+		require.Len(t, bot.sent, 1)
+		assert.NotContains(t, bot.sent[0], "tg://user")
+		assert.Equal(t, []string{"42"}, bot.to)
+	})
+
+	t.Run("sends the reminder in the chat when the user can't be sent it privately", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := &stubBot{userErr: errors.New("bot can't initiate conversation with a user")}
+		cronFuncService := reminderMocks.NewMockCronFuncServicer(mockCtrl)
+		rem := newReminder(&reminder.Recipient{UserID: 42, Name: "Alice", Private: true})
+		cronFuncService.EXPECT().AddOccurrence(rem, gomock.Any()).Return(0)
+		cronFuncService.EXPECT().ChatLanguage(chatID).Return(i18n.English)
+		cronFuncService.EXPECT().Complete(rem).Return(nil)
+
+		reminder.NewCronFunc(cronFuncService, bot, rem)()
+		require.Len(t, bot.sent, 1)
+		assert.True(t, strings.HasPrefix(bot.sent[0], "[Alice](tg://user?id=42) "))
+		assert.Equal(t, []string{strconv.Itoa(chatID)}, bot.to)
+	})
+}
+
+func TestNewCronFunc_PressedPrivately(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	allowedChats := allowlistMocks.NewMockStorer(mockCtrl)
+	allowedChats.EXPECT().GetChat(groupChatID).Return(&allowlist.Chat{ChatID: groupChatID, Status: allowlist.Pending}, nil)
+	allowedChats.EXPECT().UpsertChat(gomock.Any()).Return(nil)
+	allowList := allowlist.NewService(allowedChats, 0, timeNow)
+	_, err := allowList.Decide(groupChatID, allowlist.Approved)
+	require.NoError(t, err)
+	poller := allowlist.NewPoller(time.Second, allowList)
+
+	bot := &stubBot{}
+	cronFuncService := reminderMocks.NewMockCronFuncServicer(mockCtrl)
+	store := reminderMocks.NewMockStorer(mockCtrl)
+	checker := permissionMocks.NewMockChecker(mockCtrl)
+	rem := &reminder.Reminder{
+		Job: cron.Job{
+			ID:          reminderID,
+			CronID:      cronID,
+			ChatID:      groupChatID,
+			Schedule:    "0 9 * * *",
+			Status:      cron.Active,
+			RunOnlyOnce: true,
+			Nag:         &cron.JobNag{Minutes: 10, MaxResends: 3},
+		},
+		Data: reminder.Data{
+			RecipientID: groupChatID,
+			Recipient:   &reminder.Recipient{UserID: 42, Name: "Alice", Private: true},
+			Message:     message,
+		},
+	}
+	cronFuncService.EXPECT().AddOccurrence(rem, gomock.Any()).Return(0)
+	cronFuncService.EXPECT().ChatLanguage(groupChatID).Return(i18n.English).Times(2)
+	cronFuncService.EXPECT().StartNag(rem).Return(nil)
+	cronFuncService.EXPECT().Complete(rem).Return(nil)
+	store.EXPECT().GetReminder(groupChatID, reminderID).Return(rem, nil)
+	checker.EXPECT().CanManageReminder(groupChatID, 42, reminderID).Return(true, nil)
+	cronFuncService.EXPECT().StopNag(rem).Return(nil)
+
+	reminder.NewCronFunc(cronFuncService, bot, rem)()
+	require.Equal(t, []string{"42"}, bot.to)
+	require.Len(t, bot.keyboards, 1)
+	doneBtn := bot.keyboards[0][0][1]
+	require.Equal(t, reminder.DoneBtn, doneBtn.Unique)
+
+	// the press reaches the bot the way Telegram sends it, before the handler of the button is found
+	privateChat := &tb.Message{ID: 10, Chat: &tb.Chat{ID: 42, Type: tb.ChatPrivate}}
+	callback := &tb.Callback{Data: "\f" + doneBtn.Unique + "|" + doneBtn.Data, Sender: &tb.User{ID: 42}, Message: privateChat}
+	require.True(t, poller.Filter(&tb.Update{Callback: callback}))
+
+	callback.Data = doneBtn.Data
+	pressed := fakes.NewTBWrapBot()
+	err = reminder.HandleReminderDoneBtn(cronFuncService, store, checker)(
+		tbwrap.NewContext(pressed, privateChat, callback, nil),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Reminder \"message\" has been marked as done"}, pressed.OutboundSendMessages)
+}
+
+func TestNewCronFunc_ReplyTo(t *testing.T) {
+	newReminder := func(recipient *reminder.Recipient) *reminder.Reminder {
+		return &reminder.Reminder{
+			Job: cron.Job{
+				ID:          reminderID,
+				CronID:      cronID,
+				ChatID:      chatID,
+				Schedule:    "0 9 * * *",
+				Status:      cron.Active,
+				RunOnlyOnce: true,
+			},
+			Data: reminder.Data{RecipientID: chatID, Recipient: recipient, Message: message, ReplyToMessageID: 55},
+		}
+	}
+
+	t.Run("quotes the message in the chat", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := &stubBot{}
+		cronFuncService := reminderMocks.NewMockCronFuncServicer(mockCtrl)
+		rem := newReminder(nil)
+		cronFuncService.EXPECT().AddOccurrence(rem, gomock.Any()).Return(0)
+		cronFuncService.EXPECT().ChatLanguage(chatID).Return(i18n.English)
+		cronFuncService.EXPECT().Complete(rem).Return(nil)
+
+		reminder.NewCronFunc(cronFuncService, bot, rem)()
+		require.Len(t, bot.sent, 1)
+		assert.Equal(t, []int{55}, bot.replyTo)
+		assert.Equal(t, []tb.ParseMode{tb.ModeMarkdown}, bot.parseModes)
+	})
+
+	t.Run("sends the reminder without quoting a message which is gone", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := &stubBot{replyErr: errors.New("reply message not found")}
+		cronFuncService := reminderMocks.NewMockCronFuncServicer(mockCtrl)
+		rem := newReminder(nil)
+		cronFuncService.EXPECT().AddOccurrence(rem, gomock.Any()).Return(0)
+		cronFuncService.EXPECT().ChatLanguage(chatID).Return(i18n.English)
+		cronFuncService.EXPECT().Complete(rem).Return(nil)
+
+		reminder.NewCronFunc(cronFuncService, bot, rem)()
+		require.Len(t, bot.sent, 1)
+		assert.Equal(t, []int{0}, bot.replyTo)
+	})
+
+	t.Run("forwards the message to the user privately", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := &stubBot{}
+		cronFuncService := reminderMocks.NewMockCronFuncServicer(mockCtrl)
+		rem := newReminder(&reminder.Recipient{UserID: 42, Name: "Alice", Private: true})
+		cronFuncService.EXPECT().AddOccurrence(rem, gomock.Any()).Return(0)
+		cronFuncService.EXPECT().ChatLanguage(chatID).Return(i18n.English)
+		cronFuncService.EXPECT().ForwardRepliedMessage(rem, &tb.User{ID: 42}).Return(nil)
+		cronFuncService.EXPECT().Complete(rem).Return(nil)
+
+		reminder.NewCronFunc(cronFuncService, bot, rem)()
+		require.Len(t, bot.sent, 1)
+		assert.Equal(t, []string{"42"}, bot.to)
+	})
+}
+
+func TestNewCronFunc_Attachment(t *testing.T) {
+	newReminder := func(attachment *reminder.Attachment) *reminder.Reminder {
+		return &reminder.Reminder{
+			Job: cron.Job{
+				ID:          reminderID,
+				CronID:      cronID,
+				ChatID:      chatID,
+				Schedule:    "0 9 * * *",
+				Status:      cron.Active,
+				RunOnlyOnce: true,
+			},
+			Data: reminder.Data{RecipientID: chatID, Message: message, Attachment: attachment},
+		}
+	}
+
+	testCases := map[string]struct {
+		attachment    *reminder.Attachment
+		expectedMedia interface{}
+		expectedSent  []string
+	}{
+		"a photo with the message as its caption": {
+			attachment:    &reminder.Attachment{Type: reminder.AttachmentPhoto, FileID: "photo-id"},
+			expectedMedia: &tb.Photo{File: tb.File{FileID: "photo-id"}, Caption: "🗓 " + message},
+		},
+		"a document with the message as its caption": {
+			attachment:    &reminder.Attachment{Type: reminder.AttachmentDocument, FileID: "document-id", FileName: "report.pdf"},
+			expectedMedia: &tb.Document{File: tb.File{FileID: "document-id"}, Caption: "🗓 " + message, FileName: "report.pdf"},
+		},
+		"a voice message followed by the message": {
+			attachment:    &reminder.Attachment{Type: reminder.AttachmentVoice, FileID: "voice-id"},
+			expectedMedia: &tb.Voice{File: tb.File{FileID: "voice-id"}},
+			expectedSent:  []string{"🗓 " + message},
+		},
+		"a location followed by the message": {
+			attachment:    &reminder.Attachment{Type: reminder.AttachmentLocation, Latitude: 10.77, Longitude: 106.7},
+			expectedMedia: &tb.Location{Lat: 10.77, Lng: 106.7},
+			expectedSent:  []string{"🗓 " + message},
+		},
+	}
+
+	for name := range testCases {
+		t.Run(name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			testCase := testCases[name]
+			bot := &stubBot{}
+			cronFuncService := reminderMocks.NewMockCronFuncServicer(mockCtrl)
+			rem := newReminder(testCase.attachment)
+			cronFuncService.EXPECT().AddOccurrence(rem, gomock.Any()).Return(0)
+			cronFuncService.EXPECT().ChatLanguage(chatID).Return(i18n.English)
+			cronFuncService.EXPECT().Complete(rem).Return(nil)
+
+			reminder.NewCronFunc(cronFuncService, bot, rem)()
+			assert.Equal(t, []interface{}{testCase.expectedMedia}, bot.media)
+			assert.Equal(t, testCase.expectedSent, bot.sent)
+		})
+	}
+}
+
+func TestCronFuncService_ForwardRepliedMessage(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	forwarder := fakes.NewMessageForwarder()
+	service := reminder.NewCronFuncService(
+		&stubBot{},
+		forwarder,
+		cronMocks.NewMockScheduler(mockCtrl),
+		reminderMocks.NewMockStorer(mockCtrl),
+		reminderMocks.NewMockOccurrenceStorer(mockCtrl),
+		chatpreferenceMocks.NewMockStorer(mockCtrl),
+	)
+
+	err := service.ForwardRepliedMessage(&reminder.Reminder{
+		Job:  cron.Job{ChatID: -100},
+		Data: reminder.Data{ReplyToMessageID: 55},
+	}, &tb.User{ID: 42})
+	require.NoError(t, err)
+	assert.Equal(t, []tb.StoredMessage{{MessageID: "55", ChatID: -100}}, forwarder.Forwards)
+}
+
+func TestCronFuncService_UpdateReminderWithNextRun(t *testing.T) {
+	t.Run("completes reminder whose next run is after it ends", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := &stubBot{}
+		reminderStore := reminderMocks.NewMockStorer(mockCtrl)
+		chatPreferenceStore := chatpreferenceMocks.NewMockStorer(mockCtrl)
+		scheduler := cronMocks.NewMockScheduler(mockCtrl)
+		endsAt := time.Now().Add(time.Hour)
+		rem := &reminder.Reminder{
+			Job: cron.Job{
+				ID:       reminderID,
+				CronID:   cronID,
+				ChatID:   chatID,
+				Schedule: "0 9 * * *",
+				Status:   cron.Active,
+				EndsAt:   &endsAt,
+			},
+		}
+		scheduler.EXPECT().GetEntryByID(cronID).Return(cron.Entry{ID: cronID, Next: endsAt.Add(time.Hour)})
+		scheduler.EXPECT().Remove(cronID)
+		reminderStore.EXPECT().UpdateReminder(rem).DoAndReturn(func(rem *reminder.Reminder) error {
+			assert.Equal(t, cron.Completed, rem.Status)
+			return nil
+		})
+
+		service := reminder.NewCronFuncService(bot, fakes.NewMessageForwarder(), scheduler, reminderStore, reminderMocks.NewMockOccurrenceStorer(mockCtrl), chatPreferenceStore)
+		err := service.UpdateReminderWithNextRun(rem)
+		require.NoError(t, err)
+	})
+
+	t.Run("updates next run of reminder which has not ended", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := &stubBot{}
+		reminderStore := reminderMocks.NewMockStorer(mockCtrl)
+		chatPreferenceStore := chatpreferenceMocks.NewMockStorer(mockCtrl)
+		scheduler := cronMocks.NewMockScheduler(mockCtrl)
+		endsAt := time.Now().Add(48 * time.Hour)
+		nextRun := time.Now().Add(time.Hour)
+		rem := &reminder.Reminder{
+			Job: cron.Job{
+				ID:       reminderID,
+				CronID:   cronID,
+				ChatID:   chatID,
+				Schedule: "0 9 * * *",
+				Status:   cron.Active,
+				EndsAt:   &endsAt,
+			},
+		}
+		scheduler.EXPECT().GetEntryByID(cronID).Return(cron.Entry{ID: cronID, Next: nextRun})
+		reminderStore.EXPECT().UpdateReminder(rem).DoAndReturn(func(rem *reminder.Reminder) error {
+			assert.Equal(t, cron.Active, rem.Status)
+			assert.True(t, nextRun.Equal(*rem.NextRunAt))
+			return nil
+		})
+
+		service := reminder.NewCronFuncService(bot, fakes.NewMessageForwarder(), scheduler, reminderStore, reminderMocks.NewMockOccurrenceStorer(mockCtrl), chatPreferenceStore)
+		err := service.UpdateReminderWithNextRun(rem)
+		require.NoError(t, err)
+	})
+}
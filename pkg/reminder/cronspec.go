@@ -0,0 +1,64 @@
+package reminder
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/husol/telegram-reminder-bot/pkg/cron"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
+)
+
+// cronSpecCheckPeriod is how far ahead the runs of a cron spec are checked against its minimum interval
+const cronSpecCheckPeriod = 366 * 24 * time.Hour
+
+// maxCronSpecChecks limits how many runs of a cron spec are checked against its minimum interval
+const maxCronSpecChecks = 200000
+
+// parseCronSpec parses a standard 5 field cron spec in loc.
+// Descriptors such as "@every 1m" and timezone prefixes are not accepted as the timezone is the one of the chat
+func parseCronSpec(spec string, loc *time.Location) (cron.Schedule, error) {
+	if len(strings.Fields(spec)) != 5 || strings.ContainsAny(spec, "@=") {
+		return nil, i18n.Errorf(i18n.ErrCronFields, spec)
+	}
+
+	schedule, err := cron.ParseSchedule(fmt.Sprintf("CRON_TZ=%s %s", loc.String(), spec))
+	if err != nil {
+		return nil, i18n.Errorf(i18n.ErrCronInvalid, spec, err)
+	}
+
+	return schedule, nil
+}
+
+// validateCronSpec checks that a cron spec runs after t and never twice within its minimum interval
+func validateCronSpec(cronSpec CronSpec, schedule cron.Schedule, t time.Time) error {
+	previous := schedule.Next(t)
+	if previous.IsZero() {
+		return i18n.Errorf(i18n.ErrCronNeverRuns, cronSpec.Spec)
+	}
+
+	until := t.Add(cronSpecCheckPeriod)
+	for i := 0; i < maxCronSpecChecks && previous.Before(until); i++ {
+		next := schedule.Next(previous)
+		if next.IsZero() {
+			return nil
+		}
+
+		if gap := next.Sub(previous); gap < cronSpec.MinInterval {
+			return i18n.Errorf(i18n.ErrCronTooOften, cronSpec.Spec, formatDuration(gap), formatDuration(cronSpec.MinInterval))
+		}
+		previous = next
+	}
+
+	return nil
+}
+
+// nextRuns returns the first n runs of a schedule after t
+func nextRuns(schedule cron.Schedule, t time.Time, n int) []time.Time {
+	var runs []time.Time
+	for run := schedule.Next(t); !run.IsZero() && len(runs) < n; run = schedule.Next(run) {
+		runs = append(runs, run)
+	}
+
+	return runs
+}
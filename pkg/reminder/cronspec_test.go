@@ -0,0 +1,84 @@
+package reminder_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/husol/telegram-reminder-bot/pkg/chatpreference"
+	"github.com/husol/telegram-reminder-bot/pkg/reminder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_PreviewCronSpec(t *testing.T) {
+	loc, err := time.LoadLocation(timezone)
+	require.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mocks := createMocks(mockCtrl)
+		mocks.ChatPreferenceStore.EXPECT().GetChatPreference(chatID).Return(&chatpreference.ChatPreference{
+			ChatID:   chatID,
+			TimeZone: timezone,
+		}, nil)
+
+		service := reminder.NewService(mocks.Scheduler, mocks.ReminderStore, mocks.ChatPreferenceStore, timeNow)
+		runs, err := service.PreviewCronSpec(chatID, reminder.CronSpec{Spec: "*/15 9-17 * * 1-5", MinInterval: 5 * time.Minute}, 3)
+		require.NoError(t, err)
+		assert.Equal(t, []time.Time{
+			time.Date(2020, time.April, 1, 14, 0, 0, 0, loc),
+			time.Date(2020, time.April, 1, 14, 15, 0, 0, loc),
+			time.Date(2020, time.April, 1, 14, 30, 0, 0, loc),
+		}, runs)
+	})
+
+	testCases := map[string]string{
+		"not a cron spec":            "every day",
+		"too many fields":            "0 9 * * 1 2020",
+		"descriptor":                 "@every 1m",
+		"timezone prefix":            "CRON_TZ=UTC 0 9 * *",
+		"out of range field":         "0 25 * * *",
+		"never runs":                 "0 9 30 2 *",
+		"more often than allowed":    "*/2 * * * *",
+		"too close on the same hour": "0,3 9 * * *",
+	}
+
+	for name := range testCases {
+		t.Run(name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mocks := createMocks(mockCtrl)
+			mocks.ChatPreferenceStore.EXPECT().GetChatPreference(chatID).Return(&chatpreference.ChatPreference{
+				ChatID:   chatID,
+				TimeZone: timezone,
+			}, nil)
+
+			service := reminder.NewService(mocks.Scheduler, mocks.ReminderStore, mocks.ChatPreferenceStore, timeNow)
+			_, err := service.PreviewCronSpec(chatID, reminder.CronSpec{Spec: testCases[name], MinInterval: 5 * time.Minute}, 5)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestService_AddReminderOnCronSpec(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mocks := createMocks(mockCtrl)
+	mocks.ChatPreferenceStore.EXPECT().GetChatPreference(chatID).Return(&chatpreference.ChatPreference{
+		ChatID:   chatID,
+		TimeZone: timezone,
+	}, nil).Times(2)
+	mocks.Scheduler.EXPECT().AddReminder(gomock.Any()).DoAndReturn(func(rem *reminder.Reminder) (int, error) {
+		assert.Equal(t, "*/15 9-17 * * 1-5", rem.Schedule)
+		assert.False(t, rem.RunOnlyOnce)
+		return cronID, nil
+	})
+	mocks.Scheduler.EXPECT().GetNextScheduleTime(cronID).Return(stubNextScheduleTime, nil)
+	mocks.ReminderStore.EXPECT().CreateReminder(gomock.Any()).Return(reminderID, nil)
+
+	service := reminder.NewService(mocks.Scheduler, mocks.ReminderStore, mocks.ChatPreferenceStore, timeNow)
+	_, err := service.AddReminderOnCronSpec(chatID, command, reminder.CronSpec{Spec: "*/15 9-17 * * 1-5", MinInterval: 5 * time.Minute}, message)
+	require.NoError(t, err)
+}
@@ -0,0 +1,185 @@
+package reminder
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/husol/telegram-reminder-bot/pkg/chatpreference"
+	"github.com/husol/telegram-reminder-bot/pkg/cron"
+	"github.com/husol/telegram-reminder-bot/pkg/holiday"
+)
+
+// maxHolidayShiftDays limits how many days an occurrence can be moved on to reach a business day
+const maxHolidayShiftDays = 14
+
+// maxShiftedOccurrences limits how many occurrences are looked at to find the next run of a reminder following holidays
+const maxShiftedOccurrences = 100000
+
+// followsHolidays reports whether the schedule of a reminder is adjusted for holidays when it is added to the scheduler.
+// Reminders with a RepeatSchedule are adjusted instead when their next occurrence is worked out, see scheduleRepeatAt
+func followsHolidays(rem *Reminder) bool {
+	return rem.Holidays != cron.IgnoreHolidays && rem.RepeatSchedule == nil && !rem.RunOnlyOnce
+}
+
+// holidaySchedule is the schedule of a reminder with the occurrences which fall on holidays skipped or moved
+type holidaySchedule struct {
+	schedule cron.Schedule
+	calendar *holiday.Calendar
+	policy   cron.HolidayPolicy
+	loc      *time.Location
+}
+
+func (s *holidaySchedule) Next(t time.Time) time.Time {
+	next, _ := s.next(t)
+
+	return next
+}
+
+// next returns the first run after t and the occurrences which were skipped or moved on the way to it
+func (s *holidaySchedule) next(t time.Time) (time.Time, []cron.JobHolidayShift) {
+	if s.policy == cron.SkipHolidays {
+		var skipped []cron.JobHolidayShift
+		occurrence := s.schedule.Next(t)
+		for i := 0; !occurrence.IsZero() && i < maxShiftedOccurrences; i++ {
+			name, isHoliday := s.calendar.HolidayOn(occurrence.In(s.loc))
+			if !isHoliday {
+				return occurrence, skipped
+			}
+
+			skipped = append(skipped, cron.JobHolidayShift{At: occurrence, Holiday: name})
+			occurrence = s.schedule.Next(occurrence)
+		}
+
+		return time.Time{}, nil
+	}
+
+	// occurrences due shortly before t might have been moved after it
+	var next time.Time
+	var moved []cron.JobHolidayShift
+	occurrence := s.schedule.Next(t.AddDate(0, 0, -maxHolidayShiftDays))
+	for i := 0; !occurrence.IsZero() && i < maxShiftedOccurrences; i++ {
+		if !next.IsZero() && occurrence.After(next) {
+			break
+		}
+
+		run := moveToBusinessDay(s.calendar, occurrence.In(s.loc))
+		if run.After(t) && (next.IsZero() || run.Equal(next)) {
+			next = run
+			if !run.Equal(occurrence) {
+				name, _ := s.calendar.HolidayOn(occurrence.In(s.loc))
+				moved = append(moved, cron.JobHolidayShift{At: occurrence, Holiday: name, MovedTo: &next})
+			}
+		}
+
+		occurrence = s.schedule.Next(occurrence)
+	}
+
+	return next, moved
+}
+
+// moveToBusinessDay returns t, or the same time on the following business day if t is not on one
+func moveToBusinessDay(calendar *holiday.Calendar, t time.Time) time.Time {
+	for i := 0; i < maxHolidayShiftDays && !calendar.IsBusinessDay(t); i++ {
+		t = t.AddDate(0, 0, 1)
+	}
+
+	return t
+}
+
+// reminderSchedule returns the schedule of a reminder in its timezone,
+// adjusted for the chat's holidays if the reminder asks for it
+func reminderSchedule(chatPreference *chatpreference.ChatPreference, rem *Reminder) (cron.Schedule, error) {
+	if rem.RunAt != nil {
+		return onceSchedule{at: *rem.RunAt}, nil
+	}
+
+	schedule, err := parseSchedule(reminderTimeZone(chatPreference, rem), rem.Schedule, rem.Lunar)
+	if err != nil {
+		return nil, err
+	}
+
+	if !followsHolidays(rem) {
+		return schedule, nil
+	}
+
+	loc, err := time.LoadLocation(reminderTimeZone(chatPreference, rem))
+	if err != nil {
+		return nil, err
+	}
+
+	return &holidaySchedule{
+		schedule: schedule,
+		calendar: chatPreference.Holidays,
+		policy:   rem.Holidays,
+		loc:      loc,
+	}, nil
+}
+
+// scheduleRepeatAt sets the schedule of a reminder with a RepeatSchedule to its occurrence at t.
+// If t falls on a holiday the occurrence is skipped for the following one, or moved to the next business day,
+// as the reminder asks. It returns when the reminder runs next
+func scheduleRepeatAt(rem *Reminder, calendar *holiday.Calendar, t time.Time) time.Time {
+	rem.HolidayShifts = nil
+
+	switch rem.Holidays {
+	case cron.SkipHolidays:
+		for i := 0; i < maxShiftedOccurrences; i++ {
+			name, isHoliday := calendar.HolidayOn(t)
+			if !isHoliday {
+				break
+			}
+
+			rem.HolidayShifts = append(rem.HolidayShifts, cron.JobHolidayShift{At: t.In(time.UTC), Holiday: name})
+			t = addRepeatSchedule(t, rem.RepeatSchedule)
+		}
+
+	case cron.MoveToNextBusinessDay:
+		if run := moveToBusinessDay(calendar, t); !run.Equal(t) {
+			name, _ := calendar.HolidayOn(t)
+			runUTC := run.In(time.UTC)
+			rem.HolidayShifts = []cron.JobHolidayShift{{At: t.In(time.UTC), Holiday: name, MovedTo: &runUTC}}
+			t = run
+		}
+
+	default:
+	}
+
+	rem.Schedule = fmt.Sprintf("%d %d %d %d *", t.Minute(), t.Hour(), t.Day(), t.Month())
+
+	return t
+}
+
+// repeatBase returns the time the next occurrence of a reminder with a RepeatSchedule is counted from.
+// An occurrence which was moved to a business day is counted from when it was due,
+// so that the following occurrences are not moved along with it
+func repeatBase(rem *Reminder, t time.Time) time.Time {
+	for _, shift := range rem.HolidayShifts {
+		due := shift.At.In(t.Location())
+		if shift.MovedTo != nil && addRepeatSchedule(due, rem.RepeatSchedule).After(t) {
+			return due
+		}
+	}
+
+	return t
+}
+
+// HolidayShifts returns the occurrences of a reminder which are skipped or moved because of holidays
+// before its next run after t
+func HolidayShifts(chatPreference *chatpreference.ChatPreference, rem *Reminder, t time.Time) ([]cron.JobHolidayShift, error) {
+	if rem.RepeatSchedule != nil {
+		return rem.HolidayShifts, nil
+	}
+
+	if !followsHolidays(rem) {
+		return nil, nil
+	}
+
+	schedule, err := reminderSchedule(chatPreference, rem)
+	if err != nil {
+		return nil, err
+	}
+
+	_, shifts := schedule.(*holidaySchedule).next(t)
+
+	return shifts, nil
+}
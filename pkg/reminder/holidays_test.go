@@ -0,0 +1,162 @@
+package reminder_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/husol/telegram-reminder-bot/pkg/chatpreference"
+	"github.com/husol/telegram-reminder-bot/pkg/cron"
+	"github.com/husol/telegram-reminder-bot/pkg/holiday"
+	"github.com/husol/telegram-reminder-bot/pkg/reminder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestHolidayCalendar() *holiday.Calendar {
+	calendar := holiday.NewCalendar("Test")
+	calendar.Add(time.Date(2020, time.April, 2, 0, 0, 0, 0, time.UTC), "Hung Kings Commemoration Day")
+	calendar.AddYearly(time.Date(2020, time.April, 30, 0, 0, 0, 0, time.UTC), "Reunification Day")
+	calendar.AddYearly(time.Date(2020, time.May, 1, 0, 0, 0, 0, time.UTC), "International Workers' Day")
+
+	return calendar
+}
+
+// nolint:funlen
+func TestHolidayShifts(t *testing.T) {
+	loc, err := time.LoadLocation(timezone)
+	require.NoError(t, err)
+	chatPreference := &chatpreference.ChatPreference{ChatID: chatID, TimeZone: timezone, Holidays: newTestHolidayCalendar()}
+	movedTo := time.Date(2020, time.May, 4, 9, 0, 0, 0, loc)
+
+	testCases := map[string]struct {
+		Schedule       string
+		Holidays       cron.HolidayPolicy
+		From           time.Time
+		ExpectedShifts []cron.JobHolidayShift
+	}{
+		"skips a holiday": {
+			Schedule: "0 9 * * *",
+			Holidays: cron.SkipHolidays,
+			From:     timeNow(),
+			ExpectedShifts: []cron.JobHolidayShift{
+				{At: time.Date(2020, time.April, 2, 9, 0, 0, 0, loc), Holiday: "Hung Kings Commemoration Day"},
+			},
+		},
+		"moves an occurrence over holidays and a weekend": {
+			Schedule: "0 9 30 * *",
+			Holidays: cron.MoveToNextBusinessDay,
+			From:     timeNow(),
+			ExpectedShifts: []cron.JobHolidayShift{
+				{At: time.Date(2020, time.April, 30, 9, 0, 0, 0, loc), Holiday: "Reunification Day", MovedTo: &movedTo},
+			},
+		},
+		"occurrences moved to the same day are reported together": {
+			Schedule: "0 9 * * *",
+			Holidays: cron.MoveToNextBusinessDay,
+			From:     time.Date(2020, time.May, 2, 10, 0, 0, 0, loc),
+			ExpectedShifts: []cron.JobHolidayShift{
+				{At: time.Date(2020, time.April, 30, 9, 0, 0, 0, loc), Holiday: "Reunification Day", MovedTo: &movedTo},
+				{At: time.Date(2020, time.May, 1, 9, 0, 0, 0, loc), Holiday: "International Workers' Day", MovedTo: &movedTo},
+				{At: time.Date(2020, time.May, 2, 9, 0, 0, 0, loc), MovedTo: &movedTo},
+				{At: time.Date(2020, time.May, 3, 9, 0, 0, 0, loc), MovedTo: &movedTo},
+			},
+		},
+		"occurrence moved past the time it is looked from": {
+			Schedule: "0 9 1 * *",
+			Holidays: cron.MoveToNextBusinessDay,
+			From:     time.Date(2020, time.May, 2, 10, 0, 0, 0, loc),
+			ExpectedShifts: []cron.JobHolidayShift{
+				{At: time.Date(2020, time.May, 1, 9, 0, 0, 0, loc), Holiday: "International Workers' Day", MovedTo: &movedTo},
+			},
+		},
+		"next occurrence is on a business day": {
+			Schedule: "0 9 * * *",
+			Holidays: cron.MoveToNextBusinessDay,
+			From:     time.Date(2020, time.April, 6, 10, 0, 0, 0, loc),
+		},
+		"holidays are ignored": {
+			Schedule: "0 9 * * *",
+			Holidays: cron.IgnoreHolidays,
+			From:     timeNow(),
+		},
+	}
+
+	for name := range testCases {
+		t.Run(name, func(t *testing.T) {
+			rem := &reminder.Reminder{Job: cron.Job{
+				ChatID:   chatID,
+				Schedule: testCases[name].Schedule,
+				Holidays: testCases[name].Holidays,
+			}}
+
+			shifts, err := reminder.HolidayShifts(chatPreference, rem, testCases[name].From)
+			require.NoError(t, err)
+			require.Len(t, shifts, len(testCases[name].ExpectedShifts))
+			for i, expected := range testCases[name].ExpectedShifts {
+				assert.True(t, expected.At.Equal(shifts[i].At), "expected %s to be %s", shifts[i].At, expected.At)
+				assert.Equal(t, expected.Holiday, shifts[i].Holiday)
+				if expected.MovedTo == nil {
+					assert.Nil(t, shifts[i].MovedTo)
+					continue
+				}
+				require.NotNil(t, shifts[i].MovedTo)
+				assert.True(t, expected.MovedTo.Equal(*shifts[i].MovedTo), "expected %s to be %s", shifts[i].MovedTo, expected.MovedTo)
+			}
+		})
+	}
+}
+
+func TestService_AddReminderEvery_Holidays(t *testing.T) {
+	loc, err := time.LoadLocation(timezone)
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		amountDateTime   reminder.AmountDateTime
+		expectedSchedule string
+		expectedShifts   int
+	}{
+		"skips holidays": {
+			amountDateTime:   reminder.AmountDateTime{Days: 1, Holidays: cron.SkipHolidays},
+			expectedSchedule: "45 13 3 4 *",
+			expectedShifts:   1,
+		},
+		"moves to the next business day": {
+			amountDateTime:   reminder.AmountDateTime{Weeks: 1, Days: 3, Holidays: cron.MoveToNextBusinessDay},
+			expectedSchedule: "45 13 13 4 *",
+			expectedShifts:   1,
+		},
+		"ignores holidays": {
+			amountDateTime:   reminder.AmountDateTime{Days: 1},
+			expectedSchedule: "45 13 2 4 *",
+		},
+	}
+
+	for name := range testCases {
+		t.Run(name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mocks := createMocks(mockCtrl)
+			testCase := testCases[name]
+			mocks.ChatPreferenceStore.EXPECT().GetChatPreference(chatID).Return(&chatpreference.ChatPreference{
+				ChatID:   chatID,
+				TimeZone: timezone,
+				Holidays: newTestHolidayCalendar(),
+			}, nil).Times(2)
+			mocks.Scheduler.EXPECT().AddReminder(gomock.Any()).DoAndReturn(func(rem *reminder.Reminder) (int, error) {
+				assert.Equal(t, testCase.expectedSchedule, rem.Schedule)
+				assert.Equal(t, testCase.amountDateTime.Holidays, rem.Holidays)
+				assert.Len(t, rem.HolidayShifts, testCase.expectedShifts)
+				return cronID, nil
+			})
+			mocks.Scheduler.EXPECT().GetNextScheduleTime(cronID).Return(stubNextScheduleTime, nil)
+			mocks.ReminderStore.EXPECT().CreateReminder(gomock.Any()).Return(reminderID, nil)
+
+			service := reminder.NewService(mocks.Scheduler, mocks.ReminderStore, mocks.ChatPreferenceStore, func() time.Time {
+				return time.Date(2020, time.April, 1, 13, 45, 0, 0, loc)
+			})
+			_, err := service.AddReminderEvery(chatID, command, testCase.amountDateTime, message)
+			require.NoError(t, err)
+		})
+	}
+}
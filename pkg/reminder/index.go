@@ -0,0 +1,238 @@
+package reminder
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/husol/telegram-reminder-bot/pkg/cron"
+	bolt "go.etcd.io/bbolt"
+)
+
+// RemindersIndexBucket has a bucket for each chat, which indexes its reminders by tag, status and next run
+// so that they can be filtered without reading every reminder of the chat
+var RemindersIndexBucket = []byte("reminders_index")
+
+// Filter selects reminders of a chat. Fields which are not set select every reminder
+type Filter struct {
+	Tag    string         // without "#" e.g. "ops"
+	Status cron.JobStatus // 0 for any status
+	// DueFrom and DueBefore select the active reminders which are next due from DueFrom and before DueBefore
+	DueFrom   *time.Time
+	DueBefore *time.Time
+}
+
+// IsZero reports whether the filter selects every reminder
+func (f Filter) IsZero() bool {
+	return f.Tag == "" && f.Status == 0 && f.DueFrom == nil && f.DueBefore == nil
+}
+
+// the keys of the index each start with the kind of entry, followed by the value indexed and the ID of the reminder
+// e.g. "t:ops:3" for reminder 3 tagged #ops. The keys a reminder is indexed with are kept under "r:" and its ID
+const (
+	tagPrefix      = "t:"
+	statusPrefix   = "s:"
+	nextRunPrefix  = "n:"
+	reminderPrefix = "r:"
+)
+
+// nextRunKey is the key of a time in the index, which is the Unix time padded so that keys sort in time order
+func nextRunKey(t time.Time) string {
+	return fmt.Sprintf("%s%020d:", nextRunPrefix, t.Unix())
+}
+
+// indexKeys are the keys a reminder is indexed with
+func indexKeys(r *Reminder) []string {
+	id := itob(r.ID)
+	keys := []string{fmt.Sprintf("%s%d:%s", statusPrefix, r.Status, id)}
+	for _, tag := range r.Data.Tags {
+		keys = append(keys, fmt.Sprintf("%s%s:%s", tagPrefix, tag, id))
+	}
+	if r.Status == cron.Active && r.NextRunAt != nil {
+		keys = append(keys, nextRunKey(*r.NextRunAt)+string(id))
+	}
+
+	return keys
+}
+
+// indexReminder replaces the entries of a reminder in the index of its chat
+func indexReminder(tx *bolt.Tx, r *Reminder) error {
+	index, err := tx.CreateBucketIfNotExists(RemindersIndexBucket)
+	if err != nil {
+		return err
+	}
+
+	chatIndex, err := index.CreateBucketIfNotExists(itob(r.ChatID))
+	if err != nil {
+		return err
+	}
+
+	err = unindexReminder(chatIndex, r.ID)
+	if err != nil {
+		return err
+	}
+
+	keys := indexKeys(r)
+	for _, key := range keys {
+		err = chatIndex.Put([]byte(key), []byte{})
+		if err != nil {
+			return err
+		}
+	}
+
+	return chatIndex.Put([]byte(reminderPrefix+string(itob(r.ID))), []byte(strings.Join(keys, "\n")))
+}
+
+// unindexReminder removes the entries of a reminder from the index of its chat
+func unindexReminder(chatIndex *bolt.Bucket, id int) error {
+	reminderKey := []byte(reminderPrefix + string(itob(id)))
+	keys := chatIndex.Get(reminderKey)
+	if keys == nil {
+		return nil
+	}
+
+	for _, key := range bytes.Split(keys, []byte("\n")) {
+		err := chatIndex.Delete(key)
+		if err != nil {
+			return err
+		}
+	}
+
+	return chatIndex.Delete(reminderKey)
+}
+
+// IndexReminders builds the index of the reminders of every chat again,
+// which indexes the reminders stored before the index was kept.
+// The tags of reminders stored before they were kept are read from their message
+func IndexReminders(tx *bolt.Tx) error {
+	if tx.Bucket(RemindersIndexBucket) != nil {
+		err := tx.DeleteBucket(RemindersIndexBucket)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := tx.CreateBucket(RemindersIndexBucket)
+	if err != nil {
+		return err
+	}
+
+	reminders := tx.Bucket(RemindersBucket)
+	return reminders.ForEach(func(chatID, v []byte) error {
+		chatReminders := reminders.Bucket(chatID)
+		// a bucket can't be changed while going through it so the tagged reminders are saved afterwards
+		tagged := map[string][]byte{}
+		err := chatReminders.ForEach(func(k, v []byte) error {
+			var r Reminder
+			err := json.Unmarshal(v, &r)
+			if err != nil {
+				return err
+			}
+
+			if r.Data.Tags == nil {
+				r.Data.Tags = ParseTags(r.Data.Message)
+				if r.Data.Tags != nil {
+					buf, err := json.Marshal(r)
+					if err != nil {
+						return err
+					}
+					tagged[string(k)] = buf
+				}
+			}
+
+			return indexReminder(tx, &r)
+		})
+		if err != nil {
+			return err
+		}
+
+		for k, buf := range tagged {
+			err = chatReminders.Put([]byte(k), buf)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// chatIndexBucket is the index of the reminders of a chat, nil when none of them has been indexed
+func chatIndexBucket(tx *bolt.Tx, chatID int) *bolt.Bucket {
+	index := tx.Bucket(RemindersIndexBucket)
+	if index == nil {
+		return nil
+	}
+
+	return index.Bucket(itob(chatID))
+}
+
+// filteredIDs returns the IDs of the reminders of a chat the filter selects, in no particular order
+func filteredIDs(chatIndex *bolt.Bucket, filter Filter) []int {
+	var sets [][]int
+	if filter.Tag != "" {
+		sets = append(sets, prefixIDs(chatIndex, fmt.Sprintf("%s%s:", tagPrefix, filter.Tag)))
+	}
+	if filter.Status != 0 {
+		sets = append(sets, prefixIDs(chatIndex, fmt.Sprintf("%s%d:", statusPrefix, filter.Status)))
+	}
+	if filter.DueFrom != nil || filter.DueBefore != nil {
+		sets = append(sets, dueIDs(chatIndex, filter.DueFrom, filter.DueBefore))
+	}
+
+	ids := sets[0]
+	for _, set := range sets[1:] {
+		ids = intersect(ids, set)
+	}
+
+	return ids
+}
+
+// prefixIDs returns the IDs of the reminders whose index keys start with prefix
+func prefixIDs(chatIndex *bolt.Bucket, prefix string) []int {
+	var ids []int
+	c := chatIndex.Cursor()
+	for k, _ := c.Seek([]byte(prefix)); k != nil && bytes.HasPrefix(k, []byte(prefix)); k, _ = c.Next() {
+		ids = append(ids, btoi(k[len(prefix):]))
+	}
+
+	return ids
+}
+
+// dueIDs returns the IDs of the active reminders which are next due from from and before before
+func dueIDs(chatIndex *bolt.Bucket, from, before *time.Time) []int {
+	start := []byte(nextRunPrefix)
+	if from != nil {
+		start = []byte(nextRunKey(*from))
+	}
+	end := []byte(nextRunPrefix + "~")
+	if before != nil {
+		end = []byte(nextRunKey(*before))
+	}
+
+	var ids []int
+	c := chatIndex.Cursor()
+	for k, _ := c.Seek(start); k != nil && bytes.Compare(k, end) < 0; k, _ = c.Next() {
+		ids = append(ids, btoi(k[bytes.LastIndexByte(k, ':')+1:]))
+	}
+
+	return ids
+}
+
+func intersect(ids, other []int) []int {
+	in := map[int]bool{}
+	for _, id := range other {
+		in[id] = true
+	}
+
+	var both []int
+	for _, id := range ids {
+		if in[id] {
+			both = append(both, id)
+		}
+	}
+
+	return both
+}
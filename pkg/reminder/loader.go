@@ -3,7 +3,8 @@ package reminder
 //go:generate mockgen -source=$GOFILE -destination=mocks/${GOFILE} -package=mocks
 
 import (
-	"fmt"
+	"log"
+	"time"
 
 	"github.com/husol/telegram-reminder-bot/pkg/chatpreference"
 	"github.com/husol/telegram-reminder-bot/pkg/cron"
@@ -13,6 +14,7 @@ import (
 type LoaderServicer interface {
 	LoadSchedulesFromDB() (int, error)
 	ReloadSchedulesForChat(chatID int) (int, error)
+	UnloadSchedulesForChat(chatID int) (int, error)
 }
 
 type LoaderService struct {
@@ -21,14 +23,21 @@ type LoaderService struct {
 	reminderStore       Storer
 	reminderJobService  CronFuncServicer
 	chatPreferenceStore chatpreference.Storer
+	timeNow             func() time.Time
+	// isAllowed tells whether a chat can use the bot, every chat can when it is nil
+	isAllowed func(chatID int) bool
 }
 
+// maxMissedOccurrences limits how far back missed occurrences of a recurring reminder are counted
+const maxMissedOccurrences = 1000
+
 func NewLoaderService(
 	b telegram.TBWrapBot,
 	scheduler cron.Scheduler,
 	reminderStore Storer,
 	chatPreferenceStore chatpreference.Storer,
 	reminderJobService CronFuncServicer,
+	timeNow func() time.Time,
 ) *LoaderService {
 	return &LoaderService{
 		b:                   b,
@@ -36,12 +45,22 @@ func NewLoaderService(
 		reminderStore:       reminderStore,
 		chatPreferenceStore: chatPreferenceStore,
 		reminderJobService:  reminderJobService,
+		timeNow:             timeNow,
 	}
 }
 
+// ForAllowedChats makes the loader leave out the reminders of the chats which can't use the bot
+func (s *LoaderService) ForAllowedChats(isAllowed func(chatID int) bool) *LoaderService {
+	s.isAllowed = isAllowed
+
+	return s
+}
+
 // LoadSchedulesFromDB loads reminders from the DB
 // and creates schedules on the scheduler.
-// Only Active reminders will have a schedule created
+// Only Active reminders of the chats which can use the bot will have a schedule created.
+// Reminders which were due while the bot was not running are caught up on first
+// and reminders which were nagging carry on doing so
 func (s *LoaderService) LoadSchedulesFromDB() (int, error) {
 	remindersAdded := 0
 	rmdrListByChat, err := s.reminderStore.GetAllRemindersByChat()
@@ -50,19 +69,44 @@ func (s *LoaderService) LoadSchedulesFromDB() (int, error) {
 	}
 
 	for chatID := range rmdrListByChat {
+		if s.isAllowed != nil && !s.isAllowed(chatID) {
+			continue
+		}
+
 		chatPreference, err := s.chatPreferenceStore.GetChatPreference(chatID)
 		if err != nil {
 			return 0, err
 		}
 
 		for i := range rmdrListByChat[chatID] {
+			if hasPendingNag(&rmdrListByChat[chatID][i]) {
+				// the cron ID was assigned by the scheduler of the previous run
+				rmdrListByChat[chatID][i].Nag.CronID = 0
+				err = s.restoreNag(&rmdrListByChat[chatID][i])
+				if err != nil {
+					return 0, err
+				}
+			}
+
 			if rmdrListByChat[chatID][i].Status != cron.Active {
 				continue
 			}
 
-			schedule := fmt.Sprintf("CRON_TZ=%s %s", chatPreference.TimeZone, rmdrListByChat[chatID][i].Job.Schedule)
-			reminderCronID, err := s.scheduler.Add(
-				schedule,
+			if s.isOverdue(&rmdrListByChat[chatID][i]) {
+				stillActive, err := s.catchUpReminder(&rmdrListByChat[chatID][i], chatPreference)
+				if err != nil {
+					return 0, err
+				}
+
+				if !stillActive {
+					continue
+				}
+			}
+
+			reminderCronID, err := addToScheduler(
+				s.scheduler,
+				chatPreference,
+				&rmdrListByChat[chatID][i],
 				NewCronFunc(s.reminderJobService, s.b, &rmdrListByChat[chatID][i]),
 			)
 			if err != nil {
@@ -82,6 +126,170 @@ func (s *LoaderService) LoadSchedulesFromDB() (int, error) {
 	return len(rmdrListByChat), nil
 }
 
+// isOverdue reports whether the reminder was due while the bot was not running
+func (s *LoaderService) isOverdue(rem *Reminder) bool {
+	if rem.NextRunAt == nil || rem.NextRunAt.IsZero() || !rem.NextRunAt.Before(s.timeNow()) {
+		return false
+	}
+
+	// the occurrence at NextRunAt has already been delivered
+	return rem.LastRunAt == nil || rem.LastRunAt.Before(*rem.NextRunAt)
+}
+
+// catchUpReminder handles a reminder which was due while the bot was not running.
+// One-off reminders are delivered marked as late and completed.
+// Recurring reminders get a single message summarising the missed occurrences
+// and have their schedule moved on so that they can be added to the scheduler,
+// unless they have ended in which case they are completed.
+// Nothing is delivered if the chat has turned off late reminders or the missed occurrences were all skipped.
+// It returns whether the reminder still needs to be scheduled
+func (s *LoaderService) catchUpReminder(rem *Reminder, chatPreference *chatpreference.ChatPreference) (bool, error) {
+	timeNow := s.timeNow()
+	lateBy := timeNow.Sub(*rem.NextRunAt)
+	// the cron ID was assigned by the scheduler of the previous run
+	rem.CronID = 0
+
+	missed, err := s.countMissedOccurrences(rem, chatPreference, timeNow)
+	if err != nil {
+		return false, err
+	}
+
+	// occurrences which were skipped are not delivered late either
+	missed -= dropPassedSkippedRuns(rem, timeNow)
+
+	if !chatPreference.SkipLateReminders && missed > 0 {
+		message := lateReminderMessage(chatPreference.Language, rem, missed, lateBy)
+		// the missed occurrences are delivered together so they only take a single turn
+		takeRosterTurn(rem)
+		occurrence := sentOccurrence(s.reminderJobService.AddOccurrence(rem, message), message)
+		err = sendReminder(s.reminderJobService, s.b, chatPreference.Language, rem, message, occurrence)
+		if err != nil {
+			// the bot may have been blocked or removed from the chat, which must not stop the other reminders from loading.
+			// As when a reminder fails to send on time, the occurrence is not counted
+			log.Printf("catchUpReminder sendReminder err: %q", err)
+		} else {
+			timeNowUTC := timeNow.In(time.UTC)
+			rem.LastRunAt = &timeNowUTC
+
+			if rem.RemainingRuns != nil {
+				*rem.RemainingRuns--
+			}
+
+			if rem.Nag != nil {
+				err = s.reminderJobService.StartNag(rem)
+				if err != nil {
+					return false, err
+				}
+			}
+		}
+	}
+
+	if rem.RunOnlyOnce && rem.RepeatSchedule == nil {
+		return false, s.reminderJobService.Complete(rem)
+	}
+
+	loc, err := time.LoadLocation(reminderTimeZone(chatPreference, rem))
+	if err != nil {
+		return false, err
+	}
+
+	if rem.RepeatSchedule != nil {
+		addedTime := addRepeatSchedule(repeatBase(rem, timeNow.In(loc)), rem.RepeatSchedule)
+		scheduleRepeatAt(rem, chatPreference.Holidays, addedTime)
+	}
+
+	// the scheduler has not started yet so the next run is calculated from the schedule
+	schedule, err := reminderSchedule(chatPreference, rem)
+	if err != nil {
+		return false, err
+	}
+	nextRunAt := schedule.Next(timeNow)
+	if hasEnded(rem, nextRunAt) {
+		return false, s.reminderJobService.Complete(rem)
+	}
+	rem.NextRunAt = &nextRunAt
+
+	return true, nil
+}
+
+// countMissedOccurrences returns how many times the reminder should have been delivered
+// between its NextRunAt and timeNow
+func (s *LoaderService) countMissedOccurrences(
+	rem *Reminder,
+	chatPreference *chatpreference.ChatPreference,
+	timeNow time.Time,
+) (int, error) {
+	if rem.RunOnlyOnce && rem.RepeatSchedule == nil {
+		return 1, nil
+	}
+
+	if rem.RepeatSchedule != nil {
+		interval := addRepeatSchedule(*rem.NextRunAt, rem.RepeatSchedule).Sub(*rem.NextRunAt)
+		if interval <= 0 {
+			return 1, nil
+		}
+
+		missed := 1 + int(timeNow.Sub(*rem.NextRunAt)/interval)
+		if missed > maxMissedOccurrences {
+			return maxMissedOccurrences, nil
+		}
+
+		return missed, nil
+	}
+
+	schedule, err := reminderSchedule(chatPreference, rem)
+	if err != nil {
+		return 0, err
+	}
+
+	missed := 1
+	for next := schedule.Next(*rem.NextRunAt); !next.After(timeNow) && missed < maxMissedOccurrences; next = schedule.Next(next) {
+		missed++
+	}
+
+	return missed, nil
+}
+
+// dropPassedSkippedRuns removes the skipped runs of a reminder which were due by t and returns how many there were
+func dropPassedSkippedRuns(rem *Reminder, t time.Time) int {
+	var remaining []time.Time
+	for _, run := range rem.SkippedRuns {
+		if run.After(t) {
+			remaining = append(remaining, run)
+		}
+	}
+
+	dropped := len(rem.SkippedRuns) - len(remaining)
+	rem.SkippedRuns = remaining
+
+	return dropped
+}
+
+// hasPendingNag reports whether the reminder is waiting to be sent again as it has not been acknowledged
+func hasPendingNag(rem *Reminder) bool {
+	return rem.Nag != nil && rem.Nag.NextRunAt != nil
+}
+
+// restoreNag adds the pending re-send of a reminder to the scheduler.
+// A re-send which was due while the bot was not running is sent in a minute
+func (s *LoaderService) restoreNag(rem *Reminder) error {
+	at := *rem.Nag.NextRunAt
+	if soonest := s.timeNow().Add(time.Minute); at.Before(soonest) {
+		at = soonest
+	}
+
+	nextRunAt, schedule := nagSchedule(at)
+	nagCronID, err := s.scheduler.Add(schedule, NewNagCronFunc(s.reminderJobService, s.b, rem))
+	if err != nil {
+		return err
+	}
+
+	rem.Nag.CronID = nagCronID
+	rem.Nag.NextRunAt = &nextRunAt
+
+	return s.reminderStore.UpdateReminder(rem)
+}
+
 // ReloadSchedulesForChat reschedules reminders for a particular chat.
 // If a schedule is already present on the scheduler it is removed before being added again
 // This is needed as the timezone of the chat might have changed
@@ -98,17 +306,27 @@ func (s *LoaderService) ReloadSchedulesForChat(chatID int) (int, error) {
 	}
 
 	for i := range rmdrListByChat {
-		if entry := s.scheduler.GetEntryByID(rmdrListByChat[i].CronID); entry.ID != 0 {
-			s.scheduler.Remove(entry.ID)
+		if hasPendingNag(&rmdrListByChat[i]) {
+			s.scheduler.Remove(rmdrListByChat[i].Nag.CronID)
+			err = s.restoreNag(&rmdrListByChat[i])
+			if err != nil {
+				return 0, err
+			}
 		}
 
+		// the cron ID of a reminder which is not active is stale and may belong to another reminder by now
 		if rmdrListByChat[i].Status != cron.Active {
 			continue
 		}
 
-		schedule := fmt.Sprintf("CRON_TZ=%s %s", chatPreference.TimeZone, rmdrListByChat[i].Job.Schedule)
-		reminderID, err := s.scheduler.Add(
-			schedule,
+		if entry := s.scheduler.GetEntryByID(rmdrListByChat[i].CronID); entry.ID != 0 {
+			s.scheduler.Remove(entry.ID)
+		}
+
+		reminderID, err := addToScheduler(
+			s.scheduler,
+			chatPreference,
+			&rmdrListByChat[i],
 			NewCronFunc(s.reminderJobService, s.b, &rmdrListByChat[i]),
 		)
 		if err != nil {
@@ -126,3 +344,42 @@ func (s *LoaderService) ReloadSchedulesForChat(chatID int) (int, error) {
 
 	return len(rmdrListByChat), nil
 }
+
+// UnloadSchedulesForChat removes the reminders of a chat from the scheduler, along with their pending re-sends,
+// when it can no longer use the bot. They are left as they are otherwise so that they can be reloaded
+// if the chat is allowed again
+func (s *LoaderService) UnloadSchedulesForChat(chatID int) (int, error) {
+	rmdrList, err := s.reminderStore.GetAllRemindersByChatID(chatID)
+	if err != nil {
+		return 0, err
+	}
+
+	remindersUnloaded := 0
+	for i := range rmdrList {
+		changed := false
+		if hasPendingNag(&rmdrList[i]) && rmdrList[i].Nag.CronID != 0 {
+			s.scheduler.Remove(rmdrList[i].Nag.CronID)
+			rmdrList[i].Nag.CronID = 0
+			changed = true
+		}
+
+		// the cron ID of a reminder which is not active is stale and may belong to another reminder by now
+		if rmdrList[i].Status == cron.Active && rmdrList[i].CronID != 0 {
+			s.scheduler.Remove(rmdrList[i].CronID)
+			rmdrList[i].CronID = 0
+			changed = true
+			remindersUnloaded++
+		}
+
+		if !changed {
+			continue
+		}
+
+		err = s.reminderStore.UpdateReminder(&rmdrList[i])
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return remindersUnloaded, nil
+}
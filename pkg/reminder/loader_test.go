@@ -0,0 +1,475 @@
+package reminder_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/enrico5b1b4/tbwrap"
+	"github.com/golang/mock/gomock"
+	"github.com/husol/telegram-reminder-bot/pkg/chatpreference"
+	chatpreferenceMocks "github.com/husol/telegram-reminder-bot/pkg/chatpreference/mocks"
+	"github.com/husol/telegram-reminder-bot/pkg/cron"
+	cronMocks "github.com/husol/telegram-reminder-bot/pkg/cron/mocks"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
+	"github.com/husol/telegram-reminder-bot/pkg/reminder"
+	reminderMocks "github.com/husol/telegram-reminder-bot/pkg/reminder/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+// nolint:funlen
+func TestLoaderService_LoadSchedulesFromDB(t *testing.T) {
+	t.Run("delivers overdue one-off reminder and completes it", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := &stubBot{}
+		reminderStore := reminderMocks.NewMockStorer(mockCtrl)
+		chatPreferenceStore := chatpreferenceMocks.NewMockStorer(mockCtrl)
+		scheduler := cronMocks.NewMockScheduler(mockCtrl)
+		cronFuncService := reminderMocks.NewMockCronFuncServicer(mockCtrl)
+		nextRunAt := timeNow().Add(-2*time.Hour - 15*time.Minute)
+		reminderStore.EXPECT().GetAllRemindersByChat().Return(map[int][]reminder.Reminder{
+			chatID: {{
+				Job: cron.Job{
+					ID:          reminderID,
+					CronID:      cronID,
+					ChatID:      chatID,
+					Schedule:    "30 11 1 4 *",
+					Status:      cron.Active,
+					RunOnlyOnce: true,
+					NextRunAt:   &nextRunAt,
+				},
+				Data: reminder.Data{RecipientID: chatID, Message: message},
+			}},
+		}, nil)
+		chatPreferenceStore.EXPECT().GetChatPreference(chatID).Return(&chatpreference.ChatPreference{
+			ChatID:   chatID,
+			TimeZone: timezone,
+		}, nil)
+		cronFuncService.EXPECT().AddOccurrence(gomock.Any(), gomock.Any()).Return(0)
+		cronFuncService.EXPECT().Complete(gomock.Any()).Return(nil)
+
+		loader := reminder.NewLoaderService(bot, scheduler, reminderStore, chatPreferenceStore, cronFuncService, timeNow)
+		_, err := loader.LoadSchedulesFromDB()
+		require.NoError(t, err)
+		require.Len(t, bot.sent, 1)
+		assert.Equal(t, "🗓 message\n(late by 2h 15m)", bot.sent[0])
+	})
+
+	t.Run("delivers overdue reminder in the language of the chat", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := &stubBot{}
+		reminderStore := reminderMocks.NewMockStorer(mockCtrl)
+		chatPreferenceStore := chatpreferenceMocks.NewMockStorer(mockCtrl)
+		scheduler := cronMocks.NewMockScheduler(mockCtrl)
+		cronFuncService := reminderMocks.NewMockCronFuncServicer(mockCtrl)
+		nextRunAt := timeNow().Add(-2*time.Hour - 15*time.Minute)
+		reminderStore.EXPECT().GetAllRemindersByChat().Return(map[int][]reminder.Reminder{
+			chatID: {{
+				Job: cron.Job{
+					ID:          reminderID,
+					CronID:      cronID,
+					ChatID:      chatID,
+					Schedule:    "30 11 1 4 *",
+					Status:      cron.Active,
+					RunOnlyOnce: true,
+					NextRunAt:   &nextRunAt,
+				},
+				Data: reminder.Data{RecipientID: chatID, Message: message},
+			}},
+		}, nil)
+		chatPreferenceStore.EXPECT().GetChatPreference(chatID).Return(&chatpreference.ChatPreference{
+			ChatID:   chatID,
+			TimeZone: timezone,
+			Language: i18n.Vietnamese,
+		}, nil)
+		cronFuncService.EXPECT().AddOccurrence(gomock.Any(), gomock.Any()).Return(0)
+		cronFuncService.EXPECT().Complete(gomock.Any()).Return(nil)
+
+		loader := reminder.NewLoaderService(bot, scheduler, reminderStore, chatPreferenceStore, cronFuncService, timeNow)
+		_, err := loader.LoadSchedulesFromDB()
+		require.NoError(t, err)
+		require.Len(t, bot.sent, 1)
+		assert.Equal(t, "🗓 message\n(trễ 2h 15m)", bot.sent[0])
+	})
+
+	t.Run("completes overdue one-off reminder without delivering it when late reminders are off", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := &stubBot{}
+		reminderStore := reminderMocks.NewMockStorer(mockCtrl)
+		chatPreferenceStore := chatpreferenceMocks.NewMockStorer(mockCtrl)
+		scheduler := cronMocks.NewMockScheduler(mockCtrl)
+		cronFuncService := reminderMocks.NewMockCronFuncServicer(mockCtrl)
+		nextRunAt := timeNow().Add(-time.Hour)
+		reminderStore.EXPECT().GetAllRemindersByChat().Return(map[int][]reminder.Reminder{
+			chatID: {{
+				Job: cron.Job{
+					ID:          reminderID,
+					ChatID:      chatID,
+					Schedule:    "45 12 1 4 *",
+					Status:      cron.Active,
+					RunOnlyOnce: true,
+					NextRunAt:   &nextRunAt,
+				},
+				Data: reminder.Data{RecipientID: chatID, Message: message},
+			}},
+		}, nil)
+		chatPreferenceStore.EXPECT().GetChatPreference(chatID).Return(&chatpreference.ChatPreference{
+			ChatID:            chatID,
+			TimeZone:          timezone,
+			SkipLateReminders: true,
+		}, nil)
+		cronFuncService.EXPECT().Complete(gomock.Any()).Return(nil)
+
+		loader := reminder.NewLoaderService(bot, scheduler, reminderStore, chatPreferenceStore, cronFuncService, timeNow)
+		_, err := loader.LoadSchedulesFromDB()
+		require.NoError(t, err)
+		require.Len(t, bot.sent, 0)
+	})
+
+	t.Run("summarises missed occurrences of recurring reminder and schedules it", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := &stubBot{}
+		reminderStore := reminderMocks.NewMockStorer(mockCtrl)
+		chatPreferenceStore := chatpreferenceMocks.NewMockStorer(mockCtrl)
+		scheduler := cronMocks.NewMockScheduler(mockCtrl)
+		cronFuncService := reminderMocks.NewMockCronFuncServicer(mockCtrl)
+		loc, err := time.LoadLocation(timezone)
+		require.NoError(t, err)
+		nextRunAt := time.Date(2020, time.March, 30, 9, 0, 0, 0, loc)
+		expectedNextRunAt := time.Date(2020, time.April, 2, 9, 0, 0, 0, loc)
+		reminderStore.EXPECT().GetAllRemindersByChat().Return(map[int][]reminder.Reminder{
+			chatID: {{
+				Job: cron.Job{
+					ID:        reminderID,
+					ChatID:    chatID,
+					Schedule:  "0 9 * * *",
+					Status:    cron.Active,
+					NextRunAt: &nextRunAt,
+				},
+				Data: reminder.Data{RecipientID: chatID, Message: message},
+			}},
+		}, nil)
+		chatPreferenceStore.EXPECT().GetChatPreference(chatID).Return(&chatpreference.ChatPreference{
+			ChatID:   chatID,
+			TimeZone: timezone,
+		}, nil)
+		cronFuncService.EXPECT().AddOccurrence(gomock.Any(), gomock.Any()).Return(0)
+		scheduler.EXPECT().Add("CRON_TZ=Asia/Ho_Chi_Minh 0 9 * * *", gomock.Any()).Return(cronID, nil)
+		reminderStore.EXPECT().UpdateReminder(gomock.Any()).DoAndReturn(func(rem *reminder.Reminder) error {
+			assert.Equal(t, cronID, rem.CronID)
+			assert.True(t, expectedNextRunAt.Equal(*rem.NextRunAt))
+			assert.True(t, timeNow().Equal(*rem.LastRunAt))
+			return nil
+		})
+
+		loader := reminder.NewLoaderService(bot, scheduler, reminderStore, chatPreferenceStore, cronFuncService, timeNow)
+		_, err = loader.LoadSchedulesFromDB()
+		require.NoError(t, err)
+		require.Len(t, bot.sent, 1)
+		assert.Equal(t, "🗓 message\n(missed 3 times while offline, first one due 2d 4h 45m ago)", bot.sent[0])
+	})
+
+	t.Run("schedules reminder whose last occurrence was delivered", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := &stubBot{}
+		reminderStore := reminderMocks.NewMockStorer(mockCtrl)
+		chatPreferenceStore := chatpreferenceMocks.NewMockStorer(mockCtrl)
+		scheduler := cronMocks.NewMockScheduler(mockCtrl)
+		cronFuncService := reminderMocks.NewMockCronFuncServicer(mockCtrl)
+		nextRunAt := timeNow().Add(-time.Hour)
+		lastRunAt := nextRunAt.Add(time.Second)
+		reminderStore.EXPECT().GetAllRemindersByChat().Return(map[int][]reminder.Reminder{
+			chatID: {{
+				Job: cron.Job{
+					ID:        reminderID,
+					ChatID:    chatID,
+					Schedule:  "45 12 * * *",
+					Status:    cron.Active,
+					NextRunAt: &nextRunAt,
+					LastRunAt: &lastRunAt,
+				},
+				Data: reminder.Data{RecipientID: chatID, Message: message},
+			}},
+		}, nil)
+		chatPreferenceStore.EXPECT().GetChatPreference(chatID).Return(&chatpreference.ChatPreference{
+			ChatID:   chatID,
+			TimeZone: timezone,
+		}, nil)
+		scheduler.EXPECT().Add("CRON_TZ=Asia/Ho_Chi_Minh 45 12 * * *", gomock.Any()).Return(cronID, nil)
+		reminderStore.EXPECT().UpdateReminder(gomock.Any()).Return(nil)
+
+		loader := reminder.NewLoaderService(bot, scheduler, reminderStore, chatPreferenceStore, cronFuncService, timeNow)
+		_, err := loader.LoadSchedulesFromDB()
+		require.NoError(t, err)
+		require.Len(t, bot.sent, 0)
+	})
+
+	t.Run("restores follow-up of reminder which was nagging", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := &stubBot{}
+		reminderStore := reminderMocks.NewMockStorer(mockCtrl)
+		chatPreferenceStore := chatpreferenceMocks.NewMockStorer(mockCtrl)
+		scheduler := cronMocks.NewMockScheduler(mockCtrl)
+		cronFuncService := reminderMocks.NewMockCronFuncServicer(mockCtrl)
+		nagNextRunAt := timeNow().Add(-10 * time.Minute)
+		reminderStore.EXPECT().GetAllRemindersByChat().Return(map[int][]reminder.Reminder{
+			chatID: {{
+				Job: cron.Job{
+					ID:          reminderID,
+					CronID:      cronID,
+					ChatID:      chatID,
+					Schedule:    "30 11 1 4 *",
+					Status:      cron.Completed,
+					RunOnlyOnce: true,
+					Nag:         &cron.JobNag{Minutes: 15, MaxResends: 3, Resends: 1, CronID: cronID + 1, NextRunAt: &nagNextRunAt},
+				},
+				Data: reminder.Data{RecipientID: chatID, Message: message},
+			}},
+		}, nil)
+		chatPreferenceStore.EXPECT().GetChatPreference(chatID).Return(&chatpreference.ChatPreference{
+			ChatID:   chatID,
+			TimeZone: timezone,
+		}, nil)
+		scheduler.EXPECT().Add("CRON_TZ=UTC 46 6 1 4 *", gomock.Any()).Return(cronID+2, nil)
+		reminderStore.EXPECT().UpdateReminder(gomock.Any()).DoAndReturn(func(rem *reminder.Reminder) error {
+			assert.Equal(t, cronID+2, rem.Nag.CronID)
+			assert.Equal(t, 1, rem.Nag.Resends)
+			assert.True(t, timeNow().Add(time.Minute).Equal(*rem.Nag.NextRunAt))
+			return nil
+		})
+
+		loader := reminder.NewLoaderService(bot, scheduler, reminderStore, chatPreferenceStore, cronFuncService, timeNow)
+		_, err := loader.LoadSchedulesFromDB()
+		require.NoError(t, err)
+		require.Len(t, bot.sent, 0)
+	})
+
+	t.Run("schedules the reminders of other chats when a late reminder can't be delivered", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := &stubBot{chatErr: errors.New("Forbidden: bot was blocked by the user")}
+		reminderStore := reminderMocks.NewMockStorer(mockCtrl)
+		chatPreferenceStore := chatpreferenceMocks.NewMockStorer(mockCtrl)
+		scheduler := cronMocks.NewMockScheduler(mockCtrl)
+		cronFuncService := reminderMocks.NewMockCronFuncServicer(mockCtrl)
+		nextRunAt := timeNow().Add(-time.Hour)
+		remainingRuns := 3
+		reminderStore.EXPECT().GetAllRemindersByChat().Return(map[int][]reminder.Reminder{
+			chatID: {{
+				Job: cron.Job{
+					ID:          reminderID,
+					ChatID:      chatID,
+					Schedule:    "45 12 1 4 *",
+					Status:      cron.Active,
+					RunOnlyOnce: true,
+					NextRunAt:   &nextRunAt,
+				},
+				Data: reminder.Data{RecipientID: chatID, Message: message},
+			}, {
+				Job: cron.Job{
+					ID:            reminderID + 1,
+					ChatID:        chatID,
+					Schedule:      "45 12 * * *",
+					Status:        cron.Active,
+					NextRunAt:     &nextRunAt,
+					RemainingRuns: &remainingRuns,
+				},
+				Data: reminder.Data{RecipientID: chatID, Message: message},
+			}},
+		}, nil)
+		chatPreferenceStore.EXPECT().GetChatPreference(chatID).Return(&chatpreference.ChatPreference{
+			ChatID:   chatID,
+			TimeZone: timezone,
+		}, nil)
+		cronFuncService.EXPECT().AddOccurrence(gomock.Any(), gomock.Any()).Return(0).Times(2)
+		cronFuncService.EXPECT().Complete(gomock.Any()).Return(nil)
+		scheduler.EXPECT().Add("CRON_TZ=Asia/Ho_Chi_Minh 45 12 * * *", gomock.Any()).Return(cronID, nil)
+		reminderStore.EXPECT().UpdateReminder(gomock.Any()).DoAndReturn(func(rem *reminder.Reminder) error {
+			assert.Equal(t, reminderID+1, rem.ID)
+			assert.Equal(t, cronID, rem.CronID)
+			assert.Equal(t, 3, *rem.RemainingRuns)
+			assert.Nil(t, rem.LastRunAt)
+			return nil
+		})
+
+		loader := reminder.NewLoaderService(bot, scheduler, reminderStore, chatPreferenceStore, cronFuncService, timeNow)
+		_, err := loader.LoadSchedulesFromDB()
+		require.NoError(t, err)
+		require.Len(t, bot.sent, 0)
+	})
+}
+
+func TestLoaderService_LoadSchedulesFromDB_ForAllowedChats(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	bot := &stubBot{}
+	reminderStore := reminderMocks.NewMockStorer(mockCtrl)
+	chatPreferenceStore := chatpreferenceMocks.NewMockStorer(mockCtrl)
+	scheduler := cronMocks.NewMockScheduler(mockCtrl)
+	cronFuncService := reminderMocks.NewMockCronFuncServicer(mockCtrl)
+	reminderStore.EXPECT().GetAllRemindersByChat().Return(map[int][]reminder.Reminder{
+		groupChatID: {{
+			Job:  cron.Job{ID: reminderID, ChatID: groupChatID, Schedule: "0 9 * * *", Status: cron.Active},
+			Data: reminder.Data{RecipientID: groupChatID, Message: message},
+		}},
+	}, nil)
+
+	loader := reminder.NewLoaderService(bot, scheduler, reminderStore, chatPreferenceStore, cronFuncService, timeNow).
+		ForAllowedChats(func(chatID int) bool { return chatID != groupChatID })
+	_, err := loader.LoadSchedulesFromDB()
+	require.NoError(t, err)
+	assert.Empty(t, bot.sent)
+}
+
+func TestLoaderService_ReloadSchedulesForChat(t *testing.T) {
+	t.Run("leaves the entries of the cron IDs of reminders which are not active", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := &stubBot{}
+		reminderStore := reminderMocks.NewMockStorer(mockCtrl)
+		chatPreferenceStore := chatpreferenceMocks.NewMockStorer(mockCtrl)
+		scheduler := cronMocks.NewMockScheduler(mockCtrl)
+		cronFuncService := reminderMocks.NewMockCronFuncServicer(mockCtrl)
+		reminderStore.EXPECT().GetAllRemindersByChatID(chatID).Return([]reminder.Reminder{{
+			Job: cron.Job{
+				ID:       reminderID,
+				CronID:   cronID,
+				ChatID:   chatID,
+				Schedule: "45 12 * * *",
+				Status:   cron.Inactive,
+			},
+			Data: reminder.Data{RecipientID: chatID, Message: message},
+		}, {
+			Job: cron.Job{
+				ID:       reminderID + 1,
+				CronID:   cronID + 1,
+				ChatID:   chatID,
+				Schedule: "0 9 * * *",
+				Status:   cron.Active,
+			},
+			Data: reminder.Data{RecipientID: chatID, Message: message},
+		}}, nil)
+		chatPreferenceStore.EXPECT().GetChatPreference(chatID).Return(&chatpreference.ChatPreference{
+			ChatID:   chatID,
+			TimeZone: timezone,
+		}, nil)
+		scheduler.EXPECT().GetEntryByID(cronID + 1).Return(cron.Entry{ID: cronID + 1})
+		scheduler.EXPECT().Remove(cronID + 1)
+		scheduler.EXPECT().Add("CRON_TZ=Asia/Ho_Chi_Minh 0 9 * * *", gomock.Any()).Return(cronID+2, nil)
+		reminderStore.EXPECT().UpdateReminder(gomock.Any()).DoAndReturn(func(rem *reminder.Reminder) error {
+			assert.Equal(t, reminderID+1, rem.ID)
+			assert.Equal(t, cronID+2, rem.CronID)
+			return nil
+		})
+
+		loader := reminder.NewLoaderService(bot, scheduler, reminderStore, chatPreferenceStore, cronFuncService, timeNow)
+		_, err := loader.ReloadSchedulesForChat(chatID)
+		require.NoError(t, err)
+	})
+}
+
+func TestLoaderService_UnloadSchedulesForChat(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	bot := &stubBot{}
+	reminderStore := reminderMocks.NewMockStorer(mockCtrl)
+	chatPreferenceStore := chatpreferenceMocks.NewMockStorer(mockCtrl)
+	scheduler := cronMocks.NewMockScheduler(mockCtrl)
+	cronFuncService := reminderMocks.NewMockCronFuncServicer(mockCtrl)
+	reminderStore.EXPECT().GetAllRemindersByChatID(groupChatID).Return([]reminder.Reminder{{
+		Job:  cron.Job{ID: reminderID, CronID: cronID, ChatID: groupChatID, Schedule: "0 9 * * *", Status: cron.Active},
+		Data: reminder.Data{RecipientID: groupChatID, Message: message},
+	}, {
+		Job:  cron.Job{ID: reminderID + 1, CronID: cronID + 1, ChatID: groupChatID, Schedule: "0 9 * * *", Status: cron.Inactive},
+		Data: reminder.Data{RecipientID: groupChatID, Message: message},
+	}}, nil)
+	scheduler.EXPECT().Remove(cronID)
+	reminderStore.EXPECT().UpdateReminder(gomock.Any()).DoAndReturn(func(rem *reminder.Reminder) error {
+		assert.Equal(t, reminderID, rem.ID)
+		assert.Equal(t, 0, rem.CronID)
+		assert.Equal(t, cron.Active, rem.Status)
+		return nil
+	})
+
+	loader := reminder.NewLoaderService(bot, scheduler, reminderStore, chatPreferenceStore, cronFuncService, timeNow)
+	unloaded, err := loader.UnloadSchedulesForChat(groupChatID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, unloaded)
+}
+
+// stubBot records the messages sent by the loader
+type stubBot struct {
+	sent []string
+	// media are the photos, documents, voice messages and locations which were sent
+	media []interface{}
+	to    []string
+	// replyTo are the messages each message which was sent replies to, 0 when it does not reply to one
+	replyTo []int
+	// parseModes are how each message which was sent with its own send options is read, "" when it was not
+	parseModes []tb.ParseMode
+	// keyboards are the inline buttons each message which was sent with its own send options has
+	keyboards [][][]tb.InlineButton
+	// userErr is returned when sending to a user rather than a chat
+	userErr error
+	// replyErr is returned when sending a message which replies to another one
+	replyErr error
+	// chatErr is returned when sending to a chat
+	chatErr error
+}
+
+func (b *stubBot) Handle(path string, handler tbwrap.HandlerFunc)                 {}
+func (b *stubBot) HandleButton(path *tb.InlineButton, handler tbwrap.HandlerFunc) {}
+func (b *stubBot) HandleRegExp(path string, handler tbwrap.HandlerFunc)           {}
+func (b *stubBot) HandleMultiRegExp(paths []string, handler tbwrap.HandlerFunc)   {}
+func (b *stubBot) Start()                                                         {}
+
+func (b *stubBot) Respond(callback *tb.Callback, responseOptional ...*tb.CallbackResponse) error {
+	return nil
+}
+
+func (b *stubBot) Send(to tb.Recipient, what interface{}, options ...interface{}) (*tb.Message, error) {
+	if _, ok := to.(*tb.User); ok && b.userErr != nil {
+		return nil, b.userErr
+	}
+
+	replyTo := 0
+	var parseMode tb.ParseMode
+	var keyboard [][]tb.InlineButton
+	for _, option := range options {
+		if sendOptions, ok := option.(*tb.SendOptions); ok {
+			parseMode = sendOptions.ParseMode
+			if sendOptions.ReplyMarkup != nil {
+				keyboard = sendOptions.ReplyMarkup.InlineKeyboard
+			}
+			if sendOptions.ReplyTo != nil {
+				replyTo = sendOptions.ReplyTo.ID
+			}
+		}
+	}
+	if replyTo != 0 && b.replyErr != nil {
+		return nil, b.replyErr
+	}
+	if _, ok := to.(*tb.Chat); ok && b.chatErr != nil {
+		return nil, b.chatErr
+	}
+
+	b.replyTo = append(b.replyTo, replyTo)
+	b.parseModes = append(b.parseModes, parseMode)
+	b.keyboards = append(b.keyboards, keyboard)
+	b.to = append(b.to, to.Recipient())
+	if message, ok := what.(string); ok {
+		b.sent = append(b.sent, message)
+	} else {
+		b.media = append(b.media, what)
+	}
+
+	return &tb.Message{}, nil
+}
@@ -0,0 +1,81 @@
+package reminder
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/husol/telegram-reminder-bot/pkg/date"
+)
+
+// LunarRecurrence is a schedule on a day of every lunar month e.g. the full moon on the 15th,
+// or on a day of a lunar month every year e.g. a death anniversary on the 10th of the 3rd month.
+// It is written as a cron spec whose day of the month and month are lunar e.g. "0 9 15 * *" or "0 9 10 3 *",
+// for reminders with Lunar set. Reminders on every month also run in leap months,
+// reminders on a month every year only in the month which is not the leap one.
+// The 30th falls on the 29th in months with 29 days
+type LunarRecurrence struct {
+	Minute   int
+	Hour     int
+	Day      int
+	Month    int // 0 for every month
+	Location *time.Location
+}
+
+// ParseLunarRecurrence parses a cron spec whose day of the month and month are lunar
+func ParseLunarRecurrence(schedule string, loc *time.Location) (*LunarRecurrence, error) {
+	fields := strings.Fields(schedule)
+	if len(fields) != 5 || fields[4] != "*" {
+		return nil, fmt.Errorf("error: unsupported lunar schedule '%s'", schedule)
+	}
+
+	minute, err := parseRecurrenceField(fields[0], 0, 59)
+	if err != nil {
+		return nil, err
+	}
+
+	hour, err := parseRecurrenceField(fields[1], 0, 23)
+	if err != nil {
+		return nil, err
+	}
+
+	day, err := parseRecurrenceField(fields[2], 1, 30)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &LunarRecurrence{Minute: minute, Hour: hour, Day: day, Location: loc}
+
+	if fields[3] != "*" {
+		r.Month, err = parseRecurrenceField(fields[3], 1, 12)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+// Next returns the first occurrence after t, or the zero time if there is none within the next few years
+func (r *LunarRecurrence) Next(t time.Time) time.Time {
+	t = t.In(r.Location)
+	month := date.LunarMonthOf(t)
+
+	for i := 0; i < maxRecurrenceMonths; i, month = i+1, month.Next() {
+		if r.Month != 0 && (month.Month != r.Month || month.Leap) {
+			continue
+		}
+
+		day := r.Day
+		if day > month.Days {
+			day = month.Days
+		}
+
+		occurrence := time.Date(month.Start.Year(), month.Start.Month(), month.Start.Day()+day-1, r.Hour, r.Minute, 0, 0, r.Location)
+		if occurrence.After(t) {
+			return occurrence
+		}
+	}
+
+	return time.Time{}
+}
@@ -0,0 +1,70 @@
+package reminder_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/husol/telegram-reminder-bot/pkg/reminder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLunarRecurrence_Next(t *testing.T) {
+	loc, err := time.LoadLocation(timezone)
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		Schedule     string
+		From         time.Time
+		ExpectedNext time.Time
+	}{
+		"15th of every lunar month": {
+			Schedule:     "0 9 15 * *",
+			From:         timeNow(),
+			ExpectedNext: time.Date(2020, 4, 7, 9, 0, 0, 0, loc),
+		},
+		"15th of every lunar month after it has fired": {
+			Schedule:     "0 9 15 * *",
+			From:         time.Date(2020, 4, 7, 9, 0, 0, 0, loc),
+			ExpectedNext: time.Date(2020, 5, 7, 9, 0, 0, 0, loc),
+		},
+		"every lunar month includes the leap month": {
+			Schedule:     "0 9 15 * *",
+			From:         time.Date(2020, 5, 8, 9, 0, 0, 0, loc),
+			ExpectedNext: time.Date(2020, 6, 6, 9, 0, 0, 0, loc),
+		},
+		"a lunar month every year later this year": {
+			Schedule:     "0 9 10 3 *",
+			From:         timeNow(),
+			ExpectedNext: time.Date(2020, 4, 2, 9, 0, 0, 0, loc),
+		},
+		"a lunar month every year skips the leap month": {
+			Schedule:     "0 9 15 4 *",
+			From:         time.Date(2020, 5, 8, 9, 0, 0, 0, loc),
+			ExpectedNext: time.Date(2021, 5, 26, 9, 0, 0, 0, loc),
+		},
+		"30th of a lunar month with 29 days": {
+			Schedule:     "0 9 30 12 *",
+			From:         time.Date(2024, 12, 31, 9, 0, 0, 0, loc),
+			ExpectedNext: time.Date(2025, 1, 28, 9, 0, 0, 0, loc),
+		},
+	}
+
+	for name := range testCases {
+		t.Run(name, func(t *testing.T) {
+			recurrence, err := reminder.ParseLunarRecurrence(testCases[name].Schedule, loc)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCases[name].ExpectedNext, recurrence.Next(testCases[name].From))
+		})
+	}
+}
+
+func TestParseLunarRecurrence_Invalid(t *testing.T) {
+	for _, schedule := range []string{"0 9 15 *", "0 9 31 * *", "0 9 0 * *", "0 9 15 13 *", "0 9 15 * 1", "0 9 L * *"} {
+		t.Run(schedule, func(t *testing.T) {
+			_, err := reminder.ParseLunarRecurrence(schedule, time.UTC)
+			require.Error(t, err)
+		})
+	}
+}
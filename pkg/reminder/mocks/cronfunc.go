@@ -7,8 +7,10 @@ package mocks
 import (
 	reflect "reflect"
 
+	i18n "github.com/husol/telegram-reminder-bot/pkg/i18n"
 	reminder "github.com/husol/telegram-reminder-bot/pkg/reminder"
 	gomock "github.com/golang/mock/gomock"
+	telebot "gopkg.in/tucnak/telebot.v2"
 )
 
 // MockCronFuncServicer is a mock of CronFuncServicer interface
@@ -48,6 +50,20 @@ func (mr *MockCronFuncServicerMockRecorder) Complete(r interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Complete", reflect.TypeOf((*MockCronFuncServicer)(nil).Complete), r)
 }
 
+// UpdateReminderWithNextRun mocks base method
+func (m *MockCronFuncServicer) UpdateReminderWithNextRun(rem *reminder.Reminder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateReminderWithNextRun", rem)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateReminderWithNextRun indicates an expected call of UpdateReminderWithNextRun
+func (mr *MockCronFuncServicerMockRecorder) UpdateReminderWithNextRun(rem interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateReminderWithNextRun", reflect.TypeOf((*MockCronFuncServicer)(nil).UpdateReminderWithNextRun), rem)
+}
+
 // UpdateReminderWithRepeatSchedule mocks base method
 func (m *MockCronFuncServicer) UpdateReminderWithRepeatSchedule(rem *reminder.Reminder) error {
 	m.ctrl.T.Helper()
@@ -61,3 +77,101 @@ func (mr *MockCronFuncServicerMockRecorder) UpdateReminderWithRepeatSchedule(rem
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateReminderWithRepeatSchedule", reflect.TypeOf((*MockCronFuncServicer)(nil).UpdateReminderWithRepeatSchedule), rem)
 }
+
+// StartNag mocks base method
+func (m *MockCronFuncServicer) StartNag(rem *reminder.Reminder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartNag", rem)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartNag indicates an expected call of StartNag
+func (mr *MockCronFuncServicerMockRecorder) StartNag(rem interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartNag", reflect.TypeOf((*MockCronFuncServicer)(nil).StartNag), rem)
+}
+
+// ContinueNag mocks base method
+func (m *MockCronFuncServicer) ContinueNag(rem *reminder.Reminder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ContinueNag", rem)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ContinueNag indicates an expected call of ContinueNag
+func (mr *MockCronFuncServicerMockRecorder) ContinueNag(rem interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ContinueNag", reflect.TypeOf((*MockCronFuncServicer)(nil).ContinueNag), rem)
+}
+
+// StopNag mocks base method
+func (m *MockCronFuncServicer) StopNag(rem *reminder.Reminder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopNag", rem)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StopNag indicates an expected call of StopNag
+func (mr *MockCronFuncServicerMockRecorder) StopNag(rem interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopNag", reflect.TypeOf((*MockCronFuncServicer)(nil).StopNag), rem)
+}
+
+// ChatLanguage mocks base method
+func (m *MockCronFuncServicer) ChatLanguage(chatID int) i18n.Language {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChatLanguage", chatID)
+	ret0, _ := ret[0].(i18n.Language)
+	return ret0
+}
+
+// ChatLanguage indicates an expected call of ChatLanguage
+func (mr *MockCronFuncServicerMockRecorder) ChatLanguage(chatID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChatLanguage", reflect.TypeOf((*MockCronFuncServicer)(nil).ChatLanguage), chatID)
+}
+
+// AddOccurrence mocks base method
+func (m *MockCronFuncServicer) AddOccurrence(rem *reminder.Reminder, message string) int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddOccurrence", rem, message)
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// AddOccurrence indicates an expected call of AddOccurrence
+func (mr *MockCronFuncServicerMockRecorder) AddOccurrence(rem, message interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddOccurrence", reflect.TypeOf((*MockCronFuncServicer)(nil).AddOccurrence), rem, message)
+}
+
+// Occurrence mocks base method
+func (m *MockCronFuncServicer) Occurrence(rem *reminder.Reminder, occurrenceID int) *reminder.Occurrence {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Occurrence", rem, occurrenceID)
+	ret0, _ := ret[0].(*reminder.Occurrence)
+	return ret0
+}
+
+// Occurrence indicates an expected call of Occurrence
+func (mr *MockCronFuncServicerMockRecorder) Occurrence(rem, occurrenceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Occurrence", reflect.TypeOf((*MockCronFuncServicer)(nil).Occurrence), rem, occurrenceID)
+}
+
+// ForwardRepliedMessage mocks base method
+func (m *MockCronFuncServicer) ForwardRepliedMessage(rem *reminder.Reminder, to telebot.Recipient) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForwardRepliedMessage", rem, to)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForwardRepliedMessage indicates an expected call of ForwardRepliedMessage
+func (mr *MockCronFuncServicerMockRecorder) ForwardRepliedMessage(rem, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForwardRepliedMessage", reflect.TypeOf((*MockCronFuncServicer)(nil).ForwardRepliedMessage), rem, to)
+}
@@ -62,3 +62,18 @@ func (mr *MockLoaderServicerMockRecorder) ReloadSchedulesForChat(chatID interfac
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReloadSchedulesForChat", reflect.TypeOf((*MockLoaderServicer)(nil).ReloadSchedulesForChat), chatID)
 }
+
+// UnloadSchedulesForChat mocks base method
+func (m *MockLoaderServicer) UnloadSchedulesForChat(chatID int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnloadSchedulesForChat", chatID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnloadSchedulesForChat indicates an expected call of UnloadSchedulesForChat
+func (mr *MockLoaderServicerMockRecorder) UnloadSchedulesForChat(chatID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnloadSchedulesForChat", reflect.TypeOf((*MockLoaderServicer)(nil).UnloadSchedulesForChat), chatID)
+}
@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: occurrence_store.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	reminder "github.com/husol/telegram-reminder-bot/pkg/reminder"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockOccurrenceStorer is a mock of OccurrenceStorer interface
+type MockOccurrenceStorer struct {
+	ctrl     *gomock.Controller
+	recorder *MockOccurrenceStorerMockRecorder
+}
+
+// MockOccurrenceStorerMockRecorder is the mock recorder for MockOccurrenceStorer
+type MockOccurrenceStorerMockRecorder struct {
+	mock *MockOccurrenceStorer
+}
+
+// NewMockOccurrenceStorer creates a new mock instance
+func NewMockOccurrenceStorer(ctrl *gomock.Controller) *MockOccurrenceStorer {
+	mock := &MockOccurrenceStorer{ctrl: ctrl}
+	mock.recorder = &MockOccurrenceStorerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockOccurrenceStorer) EXPECT() *MockOccurrenceStorerMockRecorder {
+	return m.recorder
+}
+
+// CreateOccurrence mocks base method
+func (m *MockOccurrenceStorer) CreateOccurrence(o *reminder.Occurrence) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOccurrence", o)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOccurrence indicates an expected call of CreateOccurrence
+func (mr *MockOccurrenceStorerMockRecorder) CreateOccurrence(o interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOccurrence", reflect.TypeOf((*MockOccurrenceStorer)(nil).CreateOccurrence), o)
+}
+
+// UpdateOccurrence mocks base method
+func (m *MockOccurrenceStorer) UpdateOccurrence(o *reminder.Occurrence) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOccurrence", o)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateOccurrence indicates an expected call of UpdateOccurrence
+func (mr *MockOccurrenceStorerMockRecorder) UpdateOccurrence(o interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOccurrence", reflect.TypeOf((*MockOccurrenceStorer)(nil).UpdateOccurrence), o)
+}
+
+// GetOccurrence mocks base method
+func (m *MockOccurrenceStorer) GetOccurrence(chatID, reminderID, ID int) (*reminder.Occurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOccurrence", chatID, reminderID, ID)
+	ret0, _ := ret[0].(*reminder.Occurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOccurrence indicates an expected call of GetOccurrence
+func (mr *MockOccurrenceStorerMockRecorder) GetOccurrence(chatID, reminderID, ID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOccurrence", reflect.TypeOf((*MockOccurrenceStorer)(nil).GetOccurrence), chatID, reminderID, ID)
+}
+
+// GetOccurrencesByReminder mocks base method
+func (m *MockOccurrenceStorer) GetOccurrencesByReminder(chatID, reminderID int) ([]reminder.Occurrence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOccurrencesByReminder", chatID, reminderID)
+	ret0, _ := ret[0].([]reminder.Occurrence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOccurrencesByReminder indicates an expected call of GetOccurrencesByReminder
+func (mr *MockOccurrenceStorerMockRecorder) GetOccurrencesByReminder(chatID, reminderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOccurrencesByReminder", reflect.TypeOf((*MockOccurrenceStorer)(nil).GetOccurrencesByReminder), chatID, reminderID)
+}
@@ -50,6 +50,32 @@ func (mr *MockSchedulerMockRecorder) AddReminder(r interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReminder", reflect.TypeOf((*MockScheduler)(nil).AddReminder), r)
 }
 
+// RemoveReminder mocks base method
+func (m *MockScheduler) RemoveReminder(r *reminder.Reminder) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RemoveReminder", r)
+}
+
+// RemoveReminder indicates an expected call of RemoveReminder
+func (mr *MockSchedulerMockRecorder) RemoveReminder(r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveReminder", reflect.TypeOf((*MockScheduler)(nil).RemoveReminder), r)
+}
+
+// RefreshReminder mocks base method
+func (m *MockScheduler) RefreshReminder(r *reminder.Reminder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshReminder", r)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RefreshReminder indicates an expected call of RefreshReminder
+func (mr *MockSchedulerMockRecorder) RefreshReminder(r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshReminder", reflect.TypeOf((*MockScheduler)(nil).RefreshReminder), r)
+}
+
 // GetNextScheduleTime mocks base method
 func (m *MockScheduler) GetNextScheduleTime(cronID int) (time.Time, error) {
 	m.ctrl.T.Helper()
@@ -6,7 +6,9 @@ package mocks
 
 import (
 	reflect "reflect"
+	time "time"
 
+	cron "github.com/husol/telegram-reminder-bot/pkg/cron"
 	reminder "github.com/husol/telegram-reminder-bot/pkg/reminder"
 	gomock "github.com/golang/mock/gomock"
 )
@@ -34,6 +36,90 @@ func (m *MockServicer) EXPECT() *MockServicerMockRecorder {
 	return m.recorder
 }
 
+// ForRecipient mocks base method
+func (m *MockServicer) ForRecipient(recipient *reminder.Recipient) reminder.ServiceReminder {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForRecipient", recipient)
+	ret0, _ := ret[0].(reminder.ServiceReminder)
+	return ret0
+}
+
+// ForRecipient indicates an expected call of ForRecipient
+func (mr *MockServicerMockRecorder) ForRecipient(recipient interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForRecipient", reflect.TypeOf((*MockServicer)(nil).ForRecipient), recipient)
+}
+
+// ForRoster mocks base method
+func (m *MockServicer) ForRoster(roster *reminder.Roster) reminder.ServiceReminder {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForRoster", roster)
+	ret0, _ := ret[0].(reminder.ServiceReminder)
+	return ret0
+}
+
+// ForRoster indicates an expected call of ForRoster
+func (mr *MockServicerMockRecorder) ForRoster(roster interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForRoster", reflect.TypeOf((*MockServicer)(nil).ForRoster), roster)
+}
+
+// ForCreator mocks base method
+func (m *MockServicer) ForCreator(userID int) reminder.ServiceReminder {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForCreator", userID)
+	ret0, _ := ret[0].(reminder.ServiceReminder)
+	return ret0
+}
+
+// ForCreator indicates an expected call of ForCreator
+func (mr *MockServicerMockRecorder) ForCreator(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForCreator", reflect.TypeOf((*MockServicer)(nil).ForCreator), userID)
+}
+
+// InTimeZone mocks base method
+func (m *MockServicer) InTimeZone(timeZone string) reminder.ServiceReminder {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InTimeZone", timeZone)
+	ret0, _ := ret[0].(reminder.ServiceReminder)
+	return ret0
+}
+
+// InTimeZone indicates an expected call of InTimeZone
+func (mr *MockServicerMockRecorder) InTimeZone(timeZone interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InTimeZone", reflect.TypeOf((*MockServicer)(nil).InTimeZone), timeZone)
+}
+
+// ReplyingTo mocks base method
+func (m *MockServicer) ReplyingTo(messageID int) reminder.ServiceReminder {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplyingTo", messageID)
+	ret0, _ := ret[0].(reminder.ServiceReminder)
+	return ret0
+}
+
+// ReplyingTo indicates an expected call of ReplyingTo
+func (mr *MockServicerMockRecorder) ReplyingTo(messageID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplyingTo", reflect.TypeOf((*MockServicer)(nil).ReplyingTo), messageID)
+}
+
+// WithAttachment mocks base method
+func (m *MockServicer) WithAttachment(attachment *reminder.Attachment) reminder.ServiceReminder {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithAttachment", attachment)
+	ret0, _ := ret[0].(reminder.ServiceReminder)
+	return ret0
+}
+
+// WithAttachment indicates an expected call of WithAttachment
+func (mr *MockServicerMockRecorder) WithAttachment(attachment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithAttachment", reflect.TypeOf((*MockServicer)(nil).WithAttachment), attachment)
+}
+
 // AddReminderOnDateTime mocks base method
 func (m *MockServicer) AddReminderOnDateTime(chatID int, command string, dateTime reminder.DateTime, message string) (reminder.NextScheduleChatTime, error) {
 	m.ctrl.T.Helper()
@@ -108,3 +194,286 @@ func (mr *MockServicerMockRecorder) AddReminderEvery(chatID, command, amountDate
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReminderEvery", reflect.TypeOf((*MockServicer)(nil).AddReminderEvery), chatID, command, amountDateTime, message)
 }
+
+// EditReminderOnDateTime mocks base method
+func (m *MockServicer) EditReminderOnDateTime(chatID, reminderID int, command string, dateTime reminder.DateTime, message string) (reminder.NextScheduleChatTime, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EditReminderOnDateTime", chatID, reminderID, command, dateTime, message)
+	ret0, _ := ret[0].(reminder.NextScheduleChatTime)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EditReminderOnDateTime indicates an expected call of EditReminderOnDateTime
+func (mr *MockServicerMockRecorder) EditReminderOnDateTime(chatID, reminderID, command, dateTime, message interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditReminderOnDateTime", reflect.TypeOf((*MockServicer)(nil).EditReminderOnDateTime), chatID, reminderID, command, dateTime, message)
+}
+
+// EditReminderOnWordDateTime mocks base method
+func (m *MockServicer) EditReminderOnWordDateTime(chatID, reminderID int, command string, dateTime reminder.WordDateTime, message string) (reminder.NextScheduleChatTime, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EditReminderOnWordDateTime", chatID, reminderID, command, dateTime, message)
+	ret0, _ := ret[0].(reminder.NextScheduleChatTime)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EditReminderOnWordDateTime indicates an expected call of EditReminderOnWordDateTime
+func (mr *MockServicerMockRecorder) EditReminderOnWordDateTime(chatID, reminderID, command, dateTime, message interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditReminderOnWordDateTime", reflect.TypeOf((*MockServicer)(nil).EditReminderOnWordDateTime), chatID, reminderID, command, dateTime, message)
+}
+
+// EditRepeatableReminderOnDateTime mocks base method
+func (m *MockServicer) EditRepeatableReminderOnDateTime(chatID, reminderID int, command string, dateTime *reminder.RepeatableDateTime, message string) (reminder.NextScheduleChatTime, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EditRepeatableReminderOnDateTime", chatID, reminderID, command, dateTime, message)
+	ret0, _ := ret[0].(reminder.NextScheduleChatTime)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EditRepeatableReminderOnDateTime indicates an expected call of EditRepeatableReminderOnDateTime
+func (mr *MockServicerMockRecorder) EditRepeatableReminderOnDateTime(chatID, reminderID, command, dateTime, message interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditRepeatableReminderOnDateTime", reflect.TypeOf((*MockServicer)(nil).EditRepeatableReminderOnDateTime), chatID, reminderID, command, dateTime, message)
+}
+
+// EditReminderIn mocks base method
+func (m *MockServicer) EditReminderIn(chatID, reminderID int, command string, amountDateTime reminder.AmountDateTime, message string) (reminder.NextScheduleChatTime, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EditReminderIn", chatID, reminderID, command, amountDateTime, message)
+	ret0, _ := ret[0].(reminder.NextScheduleChatTime)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EditReminderIn indicates an expected call of EditReminderIn
+func (mr *MockServicerMockRecorder) EditReminderIn(chatID, reminderID, command, amountDateTime, message interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditReminderIn", reflect.TypeOf((*MockServicer)(nil).EditReminderIn), chatID, reminderID, command, amountDateTime, message)
+}
+
+// EditReminderEvery mocks base method
+func (m *MockServicer) EditReminderEvery(chatID, reminderID int, command string, amountDateTime reminder.AmountDateTime, message string) (reminder.NextScheduleChatTime, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EditReminderEvery", chatID, reminderID, command, amountDateTime, message)
+	ret0, _ := ret[0].(reminder.NextScheduleChatTime)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EditReminderEvery indicates an expected call of EditReminderEvery
+func (mr *MockServicerMockRecorder) EditReminderEvery(chatID, reminderID, command, amountDateTime, message interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditReminderEvery", reflect.TypeOf((*MockServicer)(nil).EditReminderEvery), chatID, reminderID, command, amountDateTime, message)
+}
+
+// AddReminderEveryWeeks mocks base method
+func (m *MockServicer) AddReminderEveryWeeks(chatID int, command string, weeklyDateTime reminder.WeeklyDateTime, message string) (reminder.NextScheduleChatTime, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddReminderEveryWeeks", chatID, command, weeklyDateTime, message)
+	ret0, _ := ret[0].(reminder.NextScheduleChatTime)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddReminderEveryWeeks indicates an expected call of AddReminderEveryWeeks
+func (mr *MockServicerMockRecorder) AddReminderEveryWeeks(chatID, command, weeklyDateTime, message interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReminderEveryWeeks", reflect.TypeOf((*MockServicer)(nil).AddReminderEveryWeeks), chatID, command, weeklyDateTime, message)
+}
+
+// AddReminderOnLunarDate mocks base method
+func (m *MockServicer) AddReminderOnLunarDate(chatID int, command string, lunarDateTime reminder.LunarDateTime, message string) (reminder.NextScheduleChatTime, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddReminderOnLunarDate", chatID, command, lunarDateTime, message)
+	ret0, _ := ret[0].(reminder.NextScheduleChatTime)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddReminderOnLunarDate indicates an expected call of AddReminderOnLunarDate
+func (mr *MockServicerMockRecorder) AddReminderOnLunarDate(chatID, command, lunarDateTime, message interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReminderOnLunarDate", reflect.TypeOf((*MockServicer)(nil).AddReminderOnLunarDate), chatID, command, lunarDateTime, message)
+}
+
+// EditReminderOnLunarDate mocks base method
+func (m *MockServicer) EditReminderOnLunarDate(chatID, reminderID int, command string, lunarDateTime reminder.LunarDateTime, message string) (reminder.NextScheduleChatTime, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EditReminderOnLunarDate", chatID, reminderID, command, lunarDateTime, message)
+	ret0, _ := ret[0].(reminder.NextScheduleChatTime)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EditReminderOnLunarDate indicates an expected call of EditReminderOnLunarDate
+func (mr *MockServicerMockRecorder) EditReminderOnLunarDate(chatID, reminderID, command, lunarDateTime, message interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditReminderOnLunarDate", reflect.TypeOf((*MockServicer)(nil).EditReminderOnLunarDate), chatID, reminderID, command, lunarDateTime, message)
+}
+
+// AddReminderOnCronSpec mocks base method
+func (m *MockServicer) AddReminderOnCronSpec(chatID int, command string, cronSpec reminder.CronSpec, message string) (reminder.NextScheduleChatTime, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddReminderOnCronSpec", chatID, command, cronSpec, message)
+	ret0, _ := ret[0].(reminder.NextScheduleChatTime)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddReminderOnCronSpec indicates an expected call of AddReminderOnCronSpec
+func (mr *MockServicerMockRecorder) AddReminderOnCronSpec(chatID, command, cronSpec, message interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReminderOnCronSpec", reflect.TypeOf((*MockServicer)(nil).AddReminderOnCronSpec), chatID, command, cronSpec, message)
+}
+
+// EditReminderOnCronSpec mocks base method
+func (m *MockServicer) EditReminderOnCronSpec(chatID, reminderID int, command string, cronSpec reminder.CronSpec, message string) (reminder.NextScheduleChatTime, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EditReminderOnCronSpec", chatID, reminderID, command, cronSpec, message)
+	ret0, _ := ret[0].(reminder.NextScheduleChatTime)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EditReminderOnCronSpec indicates an expected call of EditReminderOnCronSpec
+func (mr *MockServicerMockRecorder) EditReminderOnCronSpec(chatID, reminderID, command, cronSpec, message interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditReminderOnCronSpec", reflect.TypeOf((*MockServicer)(nil).EditReminderOnCronSpec), chatID, reminderID, command, cronSpec, message)
+}
+
+// PreviewCronSpec mocks base method
+func (m *MockServicer) PreviewCronSpec(chatID int, cronSpec reminder.CronSpec, n int) ([]time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreviewCronSpec", chatID, cronSpec, n)
+	ret0, _ := ret[0].([]time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreviewCronSpec indicates an expected call of PreviewCronSpec
+func (mr *MockServicerMockRecorder) PreviewCronSpec(chatID, cronSpec, n interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewCronSpec", reflect.TypeOf((*MockServicer)(nil).PreviewCronSpec), chatID, cronSpec, n)
+}
+
+// EditReminderEveryWeeks mocks base method
+func (m *MockServicer) EditReminderEveryWeeks(chatID, reminderID int, command string, weeklyDateTime reminder.WeeklyDateTime, message string) (reminder.NextScheduleChatTime, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EditReminderEveryWeeks", chatID, reminderID, command, weeklyDateTime, message)
+	ret0, _ := ret[0].(reminder.NextScheduleChatTime)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EditReminderEveryWeeks indicates an expected call of EditReminderEveryWeeks
+func (mr *MockServicerMockRecorder) EditReminderEveryWeeks(chatID, reminderID, command, weeklyDateTime, message interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditReminderEveryWeeks", reflect.TypeOf((*MockServicer)(nil).EditReminderEveryWeeks), chatID, reminderID, command, weeklyDateTime, message)
+}
+
+// EditReminderMessage mocks base method
+func (m *MockServicer) EditReminderMessage(chatID, reminderID int, message string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EditReminderMessage", chatID, reminderID, message)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EditReminderMessage indicates an expected call of EditReminderMessage
+func (mr *MockServicerMockRecorder) EditReminderMessage(chatID, reminderID, message interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EditReminderMessage", reflect.TypeOf((*MockServicer)(nil).EditReminderMessage), chatID, reminderID, message)
+}
+
+// PauseReminder mocks base method
+func (m *MockServicer) PauseReminder(chatID, reminderID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PauseReminder", chatID, reminderID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PauseReminder indicates an expected call of PauseReminder
+func (mr *MockServicerMockRecorder) PauseReminder(chatID, reminderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PauseReminder", reflect.TypeOf((*MockServicer)(nil).PauseReminder), chatID, reminderID)
+}
+
+// ResumeReminder mocks base method
+func (m *MockServicer) ResumeReminder(chatID, reminderID int) (reminder.NextScheduleChatTime, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResumeReminder", chatID, reminderID)
+	ret0, _ := ret[0].(reminder.NextScheduleChatTime)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResumeReminder indicates an expected call of ResumeReminder
+func (mr *MockServicerMockRecorder) ResumeReminder(chatID, reminderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeReminder", reflect.TypeOf((*MockServicer)(nil).ResumeReminder), chatID, reminderID)
+}
+
+// SetReminderNag mocks base method
+func (m *MockServicer) SetReminderNag(chatID, reminderID int, nag *cron.JobNag) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetReminderNag", chatID, reminderID, nag)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetReminderNag indicates an expected call of SetReminderNag
+func (mr *MockServicerMockRecorder) SetReminderNag(chatID, reminderID, nag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetReminderNag", reflect.TypeOf((*MockServicer)(nil).SetReminderNag), chatID, reminderID, nag)
+}
+
+// SkipReminder mocks base method
+func (m *MockServicer) SkipReminder(chatID, reminderID, times int) ([]time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SkipReminder", chatID, reminderID, times)
+	ret0, _ := ret[0].([]time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SkipReminder indicates an expected call of SkipReminder
+func (mr *MockServicerMockRecorder) SkipReminder(chatID, reminderID, times interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SkipReminder", reflect.TypeOf((*MockServicer)(nil).SkipReminder), chatID, reminderID, times)
+}
+
+// SkipRosterTurn mocks base method
+func (m *MockServicer) SkipRosterTurn(chatID, reminderID int) (reminder.Recipient, reminder.Recipient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SkipRosterTurn", chatID, reminderID)
+	ret0, _ := ret[0].(reminder.Recipient)
+	ret1, _ := ret[1].(reminder.Recipient)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SkipRosterTurn indicates an expected call of SkipRosterTurn
+func (mr *MockServicerMockRecorder) SkipRosterTurn(chatID, reminderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SkipRosterTurn", reflect.TypeOf((*MockServicer)(nil).SkipRosterTurn), chatID, reminderID)
+}
+
+// SwapRosterTurn mocks base method
+func (m *MockServicer) SwapRosterTurn(chatID, reminderID int, member string) (reminder.Recipient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SwapRosterTurn", chatID, reminderID, member)
+	ret0, _ := ret[0].(reminder.Recipient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SwapRosterTurn indicates an expected call of SwapRosterTurn
+func (mr *MockServicerMockRecorder) SwapRosterTurn(chatID, reminderID, member interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SwapRosterTurn", reflect.TypeOf((*MockServicer)(nil).SwapRosterTurn), chatID, reminderID, member)
+}
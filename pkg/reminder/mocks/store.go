@@ -121,3 +121,18 @@ func (mr *MockStorerMockRecorder) GetAllRemindersByChatID(chatID interface{}) *g
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllRemindersByChatID", reflect.TypeOf((*MockStorer)(nil).GetAllRemindersByChatID), chatID)
 }
+
+// GetRemindersByFilter mocks base method
+func (m *MockStorer) GetRemindersByFilter(chatID int, filter reminder.Filter) ([]reminder.Reminder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRemindersByFilter", chatID, filter)
+	ret0, _ := ret[0].([]reminder.Reminder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRemindersByFilter indicates an expected call of GetRemindersByFilter
+func (mr *MockStorerMockRecorder) GetRemindersByFilter(chatID, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRemindersByFilter", reflect.TypeOf((*MockStorer)(nil).GetRemindersByFilter), chatID, filter)
+}
@@ -0,0 +1,97 @@
+package reminder
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
+)
+
+// AckStatus is how far a member of a group has got with a reminder they acknowledged
+type AckStatus string
+
+const (
+	AckOnIt AckStatus = "on_it"
+	AckDone AckStatus = "done"
+)
+
+// Occurrence is a time a reminder was sent to a group, along with the members who acknowledged it,
+// or a time a checklist reminder was sent, along with the items which were ticked
+type Occurrence struct {
+	ID         int               `json:"id"`
+	ChatID     int               `json:"chat_id"`
+	ReminderID int               `json:"reminder_id"`
+	At         time.Time         `json:"at"`
+	Message    string            `json:"message"` // the message the reminder was sent with, without who acknowledged it
+	Acks       []Acknowledgement `json:"acks"`
+	Checklist  []ChecklistItem   `json:"checklist,omitempty"`
+}
+
+// Acknowledgement is the latest button a member of a group pressed on an occurrence of a reminder
+type Acknowledgement struct {
+	UserID int       `json:"user_id"`
+	Name   string    `json:"name"`
+	Status AckStatus `json:"status"`
+	At     time.Time `json:"at"`
+}
+
+// Acknowledge records the button a member pressed, replacing the one they pressed before.
+// It reports whether the acknowledgements changed
+func (o *Occurrence) Acknowledge(ack Acknowledgement) bool {
+	for i := range o.Acks {
+		if o.Acks[i].UserID != ack.UserID {
+			continue
+		}
+
+		if o.Acks[i].Status == ack.Status {
+			return false
+		}
+		o.Acks[i] = ack
+
+		return true
+	}
+
+	o.Acks = append(o.Acks, ack)
+
+	return true
+}
+
+// nolint:gochecknoglobals
+var ackIcons = map[AckStatus]string{
+	AckOnIt: "👋",
+	AckDone: "✅",
+}
+
+// nolint:gochecknoglobals
+var ackKeys = map[AckStatus]i18n.Key{
+	AckOnIt: i18n.AckOnIt,
+	AckDone: i18n.AckDone,
+}
+
+// String is the member and how far they have got e.g. "✅ Alice"
+func (a Acknowledgement) String() string {
+	return fmt.Sprintf("%s %s", ackIcons[a.Status], a.Name)
+}
+
+// acknowledgedMessage is the message an occurrence of a reminder was sent with followed by who acknowledged it,
+// each of them mentioned e.g. "👋 Alice is on it"
+func acknowledgedMessage(lang i18n.Language, r *Reminder, o *Occurrence) string {
+	var sb strings.Builder
+	sb.WriteString(groupMessage(r, o.Message))
+	if len(o.Acks) > 0 {
+		sb.WriteString("\n")
+	}
+
+	for _, ack := range o.Acks {
+		mention := (&Recipient{UserID: ack.UserID, Name: ack.Name}).Mention()
+		sb.WriteString(fmt.Sprintf("\n%s %s", ackIcons[ack.Status], i18n.T(lang, ackKeys[ack.Status], mention)))
+	}
+
+	return sb.String()
+}
+
+// isGroup reports whether a chat is a group, whose IDs are negative unlike those of private chats
+func isGroup(chatID int) bool {
+	return chatID < 0
+}
@@ -0,0 +1,170 @@
+package reminder
+
+//go:generate mockgen -source=$GOFILE -destination=mocks/${GOFILE} -package=mocks
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// OccurrencesBucket has a bucket for each chat, which has a bucket of occurrences for each reminder
+var OccurrencesBucket = []byte("occurrences")
+var ErrOccurrenceNotFound = errors.New("occurrence not found")
+
+// maxOccurrences is how many of the latest occurrences of a reminder are kept
+const maxOccurrences = 10
+
+type OccurrenceStorer interface {
+	CreateOccurrence(o *Occurrence) (int, error)
+	UpdateOccurrence(o *Occurrence) error
+	GetOccurrence(chatID, reminderID, ID int) (*Occurrence, error)
+	GetOccurrencesByReminder(chatID, reminderID int) ([]Occurrence, error)
+}
+
+type OccurrenceStore struct {
+	db *bolt.DB
+}
+
+func NewOccurrenceStore(db *bolt.DB) *OccurrenceStore {
+	return &OccurrenceStore{db: db}
+}
+
+// CreateOccurrence adds an occurrence of a reminder, dropping the oldest one once there are more than maxOccurrences
+func (s *OccurrenceStore) CreateOccurrence(o *Occurrence) (int, error) {
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		chatBucket := tx.Bucket(OccurrencesBucket).Bucket(itob(o.ChatID))
+		reminderBucket, err := chatBucket.CreateBucketIfNotExists(itob(o.ReminderID))
+		if err != nil {
+			return err
+		}
+
+		id, err := reminderBucket.NextSequence()
+		if err != nil {
+			return err
+		}
+		o.ID = int(id)
+
+		buf, err := json.Marshal(o)
+		if err != nil {
+			return err
+		}
+
+		err = reminderBucket.Put(itob(o.ID), buf)
+		if err != nil {
+			return err
+		}
+
+		// keys are ordered as text so the oldest occurrence is the one with the lowest ID rather than the first key
+		var ids []int
+		err = reminderBucket.ForEach(func(k, v []byte) error {
+			ids = append(ids, btoi(k))
+			return nil
+		})
+		if err != nil || len(ids) <= maxOccurrences {
+			return err
+		}
+
+		sort.Ints(ids)
+		for _, oldID := range ids[:len(ids)-maxOccurrences] {
+			err = reminderBucket.Delete(itob(oldID))
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return o.ID, nil
+}
+
+func (s *OccurrenceStore) UpdateOccurrence(o *Occurrence) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		chatBucket := tx.Bucket(OccurrencesBucket).Bucket(itob(o.ChatID))
+		if chatBucket == nil {
+			return ErrOccurrenceNotFound
+		}
+
+		reminderBucket := chatBucket.Bucket(itob(o.ReminderID))
+		if reminderBucket == nil || reminderBucket.Get(itob(o.ID)) == nil {
+			return ErrOccurrenceNotFound
+		}
+
+		buf, err := json.Marshal(o)
+		if err != nil {
+			return err
+		}
+
+		return reminderBucket.Put(itob(o.ID), buf)
+	})
+}
+
+func (s *OccurrenceStore) GetOccurrence(chatID, reminderID, id int) (*Occurrence, error) {
+	var occurrence Occurrence
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		chatBucket := tx.Bucket(OccurrencesBucket).Bucket(itob(chatID))
+		if chatBucket == nil {
+			return ErrOccurrenceNotFound
+		}
+
+		reminderBucket := chatBucket.Bucket(itob(reminderID))
+		if reminderBucket == nil {
+			return ErrOccurrenceNotFound
+		}
+
+		v := reminderBucket.Get(itob(id))
+		if v == nil {
+			return ErrOccurrenceNotFound
+		}
+
+		return json.Unmarshal(v, &occurrence)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &occurrence, nil
+}
+
+// GetOccurrencesByReminder returns the occurrences of a reminder from the oldest to the latest
+func (s *OccurrenceStore) GetOccurrencesByReminder(chatID, reminderID int) ([]Occurrence, error) {
+	occurrences := []Occurrence{}
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		chatBucket := tx.Bucket(OccurrencesBucket).Bucket(itob(chatID))
+		if chatBucket == nil {
+			return nil
+		}
+
+		reminderBucket := chatBucket.Bucket(itob(reminderID))
+		if reminderBucket == nil {
+			return nil
+		}
+
+		return reminderBucket.ForEach(func(k, v []byte) error {
+			var occurrence Occurrence
+
+			err := json.Unmarshal(v, &occurrence)
+			if err != nil {
+				return err
+			}
+
+			occurrences = append(occurrences, occurrence)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(occurrences, func(i, j int) bool { return occurrences[i].ID < occurrences[j].ID })
+
+	return occurrences, nil
+}
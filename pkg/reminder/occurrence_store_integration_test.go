@@ -0,0 +1,56 @@
+package reminder_test
+
+import (
+	"testing"
+
+	"github.com/husol/telegram-reminder-bot/pkg/db"
+	"github.com/husol/telegram-reminder-bot/pkg/reminder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOccurrenceStore(t *testing.T) {
+	checkSkip(t)
+
+	chatID := -generateRandomInt()
+	database, err := db.SetupDB(testDBFile(), []int{chatID})
+	require.NoError(t, err)
+	defer database.Close()
+	occurrenceStore := reminder.NewOccurrenceStore(database)
+
+	t.Run("keeps the latest occurrences of a reminder", func(t *testing.T) {
+		for i := 0; i < 12; i++ {
+			_, err := occurrenceStore.CreateOccurrence(&reminder.Occurrence{ChatID: chatID, ReminderID: 1})
+			require.NoError(t, err)
+		}
+
+		occurrences, err := occurrenceStore.GetOccurrencesByReminder(chatID, 1)
+		require.NoError(t, err)
+		require.Len(t, occurrences, 10)
+		assert.Equal(t, 3, occurrences[0].ID)
+		assert.Equal(t, 12, occurrences[9].ID)
+	})
+
+	t.Run("updates an occurrence", func(t *testing.T) {
+		id, err := occurrenceStore.CreateOccurrence(&reminder.Occurrence{ChatID: chatID, ReminderID: 2})
+		require.NoError(t, err)
+
+		occurrence, err := occurrenceStore.GetOccurrence(chatID, 2, id)
+		require.NoError(t, err)
+		occurrence.Acknowledge(reminder.Acknowledgement{UserID: 7, Name: "Bob", Status: reminder.AckDone})
+		require.NoError(t, occurrenceStore.UpdateOccurrence(occurrence))
+
+		occurrence, err = occurrenceStore.GetOccurrence(chatID, 2, id)
+		require.NoError(t, err)
+		assert.Equal(t, []reminder.Acknowledgement{{UserID: 7, Name: "Bob", Status: reminder.AckDone}}, occurrence.Acks)
+	})
+
+	t.Run("a reminder which was never sent has no occurrences", func(t *testing.T) {
+		occurrences, err := occurrenceStore.GetOccurrencesByReminder(chatID, 3)
+		require.NoError(t, err)
+		assert.Empty(t, occurrences)
+
+		_, err = occurrenceStore.GetOccurrence(chatID, 3, 1)
+		assert.Equal(t, reminder.ErrOccurrenceNotFound, err)
+	})
+}
@@ -0,0 +1,150 @@
+package reminder_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/enrico5b1b4/tbwrap"
+	"github.com/golang/mock/gomock"
+	chatpreferenceMocks "github.com/husol/telegram-reminder-bot/pkg/chatpreference/mocks"
+	"github.com/husol/telegram-reminder-bot/pkg/cron"
+	cronMocks "github.com/husol/telegram-reminder-bot/pkg/cron/mocks"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
+	"github.com/husol/telegram-reminder-bot/pkg/reminder"
+	reminderMocks "github.com/husol/telegram-reminder-bot/pkg/reminder/mocks"
+	"github.com/husol/telegram-reminder-bot/pkg/telegram/fakes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+const groupChatID = -1001
+
+func TestOccurrence_Acknowledge(t *testing.T) {
+	o := &reminder.Occurrence{}
+
+	assert.True(t, o.Acknowledge(reminder.Acknowledgement{UserID: 7, Name: "Bob", Status: reminder.AckOnIt}))
+	assert.True(t, o.Acknowledge(reminder.Acknowledgement{UserID: 8, Name: "Alice", Status: reminder.AckDone}))
+	assert.False(t, o.Acknowledge(reminder.Acknowledgement{UserID: 7, Name: "Bob", Status: reminder.AckOnIt}))
+	assert.True(t, o.Acknowledge(reminder.Acknowledgement{UserID: 7, Name: "Bob", Status: reminder.AckDone}))
+
+	assert.Equal(t, []reminder.Acknowledgement{
+		{UserID: 7, Name: "Bob", Status: reminder.AckDone},
+		{UserID: 8, Name: "Alice", Status: reminder.AckDone},
+	}, o.Acks)
+}
+
+func TestCronFuncService_AddOccurrence(t *testing.T) {
+	newService := func(mockCtrl *gomock.Controller, occurrenceStore reminder.OccurrenceStorer) *reminder.CronFuncService {
+		return reminder.NewCronFuncService(
+			&stubBot{},
+			fakes.NewMessageForwarder(),
+			cronMocks.NewMockScheduler(mockCtrl),
+			reminderMocks.NewMockStorer(mockCtrl),
+			occurrenceStore,
+			chatpreferenceMocks.NewMockStorer(mockCtrl),
+		)
+	}
+
+	t.Run("records a reminder sent to a group", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		occurrenceStore := reminderMocks.NewMockOccurrenceStorer(mockCtrl)
+		occurrenceStore.EXPECT().CreateOccurrence(gomock.Any()).DoAndReturn(func(o *reminder.Occurrence) (int, error) {
+			assert.Equal(t, groupChatID, o.ChatID)
+			assert.Equal(t, reminderID, o.ReminderID)
+			assert.Equal(t, "🗓 message", o.Message)
+			return 5, nil
+		})
+
+		rem := &reminder.Reminder{Job: cron.Job{ID: reminderID, ChatID: groupChatID}}
+		assert.Equal(t, 5, newService(mockCtrl, occurrenceStore).AddOccurrence(rem, "🗓 message"))
+	})
+
+	t.Run("does not record a reminder sent to a private chat", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		rem := &reminder.Reminder{Job: cron.Job{ID: reminderID, ChatID: chatID}}
+		assert.Equal(t, 0, newService(mockCtrl, reminderMocks.NewMockOccurrenceStorer(mockCtrl)).AddOccurrence(rem, "🗓 message"))
+	})
+
+	t.Run("does not record a reminder sent to a user of a group by private message", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		rem := &reminder.Reminder{
+			Job:  cron.Job{ID: reminderID, ChatID: groupChatID},
+			Data: reminder.Data{Recipient: &reminder.Recipient{UserID: 42, Private: true}},
+		}
+		assert.Equal(t, 0, newService(mockCtrl, reminderMocks.NewMockOccurrenceStorer(mockCtrl)).AddOccurrence(rem, "🗓 message"))
+	})
+}
+
+func TestHandleReminderAcknowledgeBtn(t *testing.T) {
+	rem := &reminder.Reminder{
+		Job:  cron.Job{ID: reminderID, ChatID: groupChatID, RunOnlyOnce: true},
+		Data: reminder.Data{RecipientID: groupChatID, Message: message},
+	}
+	newContext := func(bot *fakes.TBWrapBot, sender *tb.User) tbwrap.Context {
+		msg := &tb.Message{ID: 10, Chat: &tb.Chat{ID: groupChatID}}
+		return tbwrap.NewContext(bot, msg, &tb.Callback{Data: "3:5", Sender: sender, Message: msg}, nil)
+	}
+
+	t.Run("lists who acknowledged the reminder", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakes.NewTBWrapBot()
+		editor := fakes.NewMessageEditor()
+		cronFuncService := reminderMocks.NewMockCronFuncServicer(mockCtrl)
+		store := reminderMocks.NewMockStorer(mockCtrl)
+		occurrenceStore := reminderMocks.NewMockOccurrenceStorer(mockCtrl)
+		occurrence := &reminder.Occurrence{
+			ID:         5,
+			ChatID:     groupChatID,
+			ReminderID: reminderID,
+			At:         time.Now(),
+			Message:    "🗓 message",
+			Acks:       []reminder.Acknowledgement{{UserID: 8, Name: "Alice", Status: reminder.AckOnIt}},
+		}
+		store.EXPECT().GetReminder(groupChatID, reminderID).Return(rem, nil)
+		occurrenceStore.EXPECT().GetOccurrence(groupChatID, reminderID, 5).Return(occurrence, nil)
+		occurrenceStore.EXPECT().UpdateOccurrence(occurrence).Return(nil)
+		cronFuncService.EXPECT().StopNag(rem).Return(nil)
+		cronFuncService.EXPECT().ChatLanguage(groupChatID).Return(i18n.English)
+
+		err := reminder.HandleReminderAcknowledgeBtn(cronFuncService, store, occurrenceStore, editor, reminder.AckDone)(
+			newContext(bot, &tb.User{ID: 7, FirstName: "Bob", LastName: "Smith"}),
+		)
+		require.NoError(t, err)
+		require.Equal(t, []string{
+			"🗓 message\n\n👋 [Alice](tg://user?id=8) is on it\n✅ [Bob Smith](tg://user?id=7) is done",
+		}, editor.Edits)
+		assert.Empty(t, bot.OutboundSendMessages)
+	})
+
+	t.Run("does not edit the message when the member pressed the same button again", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		bot := fakes.NewTBWrapBot()
+		editor := fakes.NewMessageEditor()
+		cronFuncService := reminderMocks.NewMockCronFuncServicer(mockCtrl)
+		store := reminderMocks.NewMockStorer(mockCtrl)
+		occurrenceStore := reminderMocks.NewMockOccurrenceStorer(mockCtrl)
+		occurrence := &reminder.Occurrence{
+			ID:         5,
+			ChatID:     groupChatID,
+			ReminderID: reminderID,
+			Message:    "🗓 message",
+			Acks:       []reminder.Acknowledgement{{UserID: 7, Name: "Bob", Status: reminder.AckOnIt}},
+		}
+		store.EXPECT().GetReminder(groupChatID, reminderID).Return(rem, nil)
+		occurrenceStore.EXPECT().GetOccurrence(groupChatID, reminderID, 5).Return(occurrence, nil)
+
+		err := reminder.HandleReminderAcknowledgeBtn(cronFuncService, store, occurrenceStore, editor, reminder.AckOnIt)(
+			newContext(bot, &tb.User{ID: 7, FirstName: "Bob"}),
+		)
+		require.NoError(t, err)
+		assert.Empty(t, editor.Edits)
+	})
+}
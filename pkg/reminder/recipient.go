@@ -0,0 +1,166 @@
+package reminder
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/enrico5b1b4/tbwrap"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
+)
+
+// Recipient is the user of a group a reminder is for e.g. "/remind @alice tomorrow at 9 ...".
+// They are mentioned when the reminder is sent in the group,
+// or sent the reminder by private message if they asked for it and have started a chat with the bot
+type Recipient struct {
+	UserID   int    `json:"user_id"` // 0 when the user was only named by their username
+	Username string `json:"username"`
+	Name     string `json:"name"`
+	Private  bool   `json:"private"`
+}
+
+// mentionNameEscaper removes the characters which would end the text of a link in a message sent as Markdown
+// nolint:gochecknoglobals
+var mentionNameEscaper = strings.NewReplacer("[", "", "]", "")
+
+// Mention is how the recipient is mentioned in a message sent as Markdown.
+// Users whose ID is known get a text mention of their ID, which notifies them even if they have no username
+func (r *Recipient) Mention() string {
+	if r.UserID == 0 {
+		return "@" + strings.ReplaceAll(r.Username, "_", `\_`)
+	}
+
+	name := r.Name
+	if name == "" {
+		name = r.Username
+	}
+
+	return fmt.Sprintf("[%s](tg://user?id=%d)", mentionNameEscaper.Replace(name), r.UserID)
+}
+
+// String is how the recipient is written in replies which are not sent as mentions e.g. "@alice" or "Alice"
+func (r *Recipient) String() string {
+	if r.Username != "" {
+		return "@" + r.Username
+	}
+
+	return r.Name
+}
+
+// buttonData is the data of the buttons sent with a reminder, which is the ID of the reminder.
+// Reminders sent by private message also carry the chat they belong to e.g. "3:-1001234",
+// as their buttons are pressed in another chat
+func buttonData(r *Reminder, private bool) string {
+	if !private {
+		return strconv.Itoa(r.ID)
+	}
+
+	return fmt.Sprintf("%d:%d", r.ID, r.ChatID)
+}
+
+// callbackReminder returns the reminder a button sent with a reminder was pressed for.
+// The chat in the data of the button can be made up by whoever pressed it,
+// so a reminder of another chat is only returned to the user it was sent to by private message
+func callbackReminder(c tbwrap.Context, store Storer) (*Reminder, error) {
+	chatID, reminderID, err := callbackReminderIDs(c)
+	if err != nil {
+		return nil, err
+	}
+
+	rem, err := store.GetReminder(chatID, reminderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !pressedFor(c, rem) {
+		return nil, i18n.Errorf(i18n.ErrNotInChat, reminderID)
+	}
+
+	return rem, nil
+}
+
+// pressedFor tells whether a button was pressed in the chat of the reminder,
+// or by the user the reminder was sent to by private message
+func pressedFor(c tbwrap.Context, rem *Reminder) bool {
+	if int(c.ChatID()) == rem.ChatID {
+		return true
+	}
+
+	sender := c.Callback().Sender
+	recipient := rem.Data.Recipient
+
+	return sender != nil && recipient != nil && recipient.UserID != 0 && recipient.UserID == sender.ID
+}
+
+// callbackReminderIDs returns the chat and the ID of the reminder in the data of a button sent with a reminder
+func callbackReminderIDs(c tbwrap.Context) (chatID, reminderID int, err error) {
+	parts := strings.SplitN(c.Callback().Data, ":", 2)
+
+	reminderID, err = strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if len(parts) == 1 {
+		return int(c.ChatID()), reminderID, nil
+	}
+
+	chatID, err = strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, i18n.Errorf(i18n.ErrUnknownButton)
+	}
+
+	return chatID, reminderID, nil
+}
+
+// occurrenceData is the data of the buttons an occurrence of a reminder sent to a group is acknowledged with
+// e.g. "3:12" for the 12th occurrence of reminder 3
+func occurrenceData(r *Reminder, occurrenceID int) string {
+	return fmt.Sprintf("%d:%d", r.ID, occurrenceID)
+}
+
+// callbackOccurrence returns the reminder and the occurrence a button to acknowledge a reminder was pressed for
+func callbackOccurrence(c tbwrap.Context) (reminderID, occurrenceID int, err error) {
+	parts := strings.SplitN(c.Callback().Data, ":", 2)
+	if len(parts) != 2 {
+		return 0, 0, i18n.Errorf(i18n.ErrUnknownButton)
+	}
+
+	reminderID, err = strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, err
+	}
+
+	occurrenceID, err = strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return reminderID, occurrenceID, nil
+}
+
+// checklistItemData is the data of the button an item of the checklist of an occurrence is ticked with,
+// which carries the chat of the reminder as the checklist can be sent by private message
+// e.g. "3:12:0:-1001234" for the first item of the 12th occurrence of reminder 3
+func checklistItemData(r *Reminder, occurrenceID, item int) string {
+	return fmt.Sprintf("%d:%d:%d:%d", r.ID, occurrenceID, item, r.ChatID)
+}
+
+// callbackChecklistItem returns the chat, the reminder, the occurrence and the item of a checklist
+// a button to tick an item was pressed for
+func callbackChecklistItem(c tbwrap.Context) (chatID, reminderID, occurrenceID, item int, err error) {
+	parts := strings.Split(c.Callback().Data, ":")
+	if len(parts) != 4 {
+		return 0, 0, 0, 0, i18n.Errorf(i18n.ErrUnknownButton)
+	}
+
+	ids := make([]int, len(parts))
+	for i := range parts {
+		ids[i], err = strconv.Atoi(parts[i])
+		if err != nil {
+			return 0, 0, 0, 0, err
+		}
+	}
+
+	return ids[3], ids[0], ids[1], ids[2], nil
+}
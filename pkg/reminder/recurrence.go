@@ -0,0 +1,200 @@
+package reminder
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/husol/telegram-reminder-bot/pkg/chatpreference"
+	"github.com/husol/telegram-reminder-bot/pkg/cron"
+)
+
+// lastOccurrence is the value of Recurrence.Nth for the last occurrence of a day of the week in the month
+const lastOccurrence = -1
+
+// maxRecurrenceMonths limits how many months ahead the next occurrence of a Recurrence is looked for
+const maxRecurrenceMonths = 12 * 5
+
+// Recurrence is a schedule which standard cron specs can't express:
+// the last day of the month, the nth day of the week of the month or the last day of the week of the month.
+// They are written as cron specs with the extensions
+// - "L" as day of the month for the last day of the month e.g. "0 9 L * *"
+// - "D#N" as day of the week for the Nth day D of the month e.g. "0 9 * * 1#1" for the first Monday
+// - "DL" as day of the week for the last day D of the month e.g. "0 9 * * 5L" for the last Friday
+type Recurrence struct {
+	Minute   int
+	Hour     int
+	Month    int // 0 for every month
+	LastDay  bool
+	Weekday  time.Weekday
+	Nth      int // 1 to 5 or lastOccurrence
+	Location *time.Location
+}
+
+// isRecurrence reports whether a schedule needs a Recurrence rather than a standard cron spec
+func isRecurrence(schedule string) bool {
+	fields := strings.Fields(schedule)
+
+	return len(fields) == 5 && (strings.ContainsAny(fields[2], "L") || strings.ContainsAny(fields[4], "L#"))
+}
+
+// ParseRecurrence parses a cron spec using the extensions described on Recurrence
+func ParseRecurrence(schedule string, loc *time.Location) (*Recurrence, error) {
+	fields := strings.Fields(schedule)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("error: expected 5 fields in schedule '%s'", schedule)
+	}
+
+	minute, err := parseRecurrenceField(fields[0], 0, 59)
+	if err != nil {
+		return nil, err
+	}
+
+	hour, err := parseRecurrenceField(fields[1], 0, 23)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Recurrence{Minute: minute, Hour: hour, Location: loc}
+
+	if fields[3] != "*" {
+		r.Month, err = parseRecurrenceField(fields[3], 1, 12)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	switch {
+	case fields[2] == "L" && fields[4] == "*":
+		r.LastDay = true
+
+	case fields[2] == "*" && strings.HasSuffix(fields[4], "L"):
+		weekday, err := parseRecurrenceField(strings.TrimSuffix(fields[4], "L"), 0, 6)
+		if err != nil {
+			return nil, err
+		}
+		r.Weekday = time.Weekday(weekday)
+		r.Nth = lastOccurrence
+
+	case fields[2] == "*" && strings.Contains(fields[4], "#"):
+		parts := strings.SplitN(fields[4], "#", 2)
+		weekday, err := parseRecurrenceField(parts[0], 0, 6)
+		if err != nil {
+			return nil, err
+		}
+		r.Weekday = time.Weekday(weekday)
+
+		r.Nth, err = parseRecurrenceField(parts[1], 1, 5)
+		if err != nil {
+			return nil, err
+		}
+
+	default:
+		return nil, fmt.Errorf("error: unsupported schedule '%s'", schedule)
+	}
+
+	return r, nil
+}
+
+func parseRecurrenceField(field string, min, max int) (int, error) {
+	value, err := strconv.Atoi(field)
+	if err != nil {
+		return 0, err
+	}
+
+	if value < min || value > max {
+		return 0, fmt.Errorf("error: %d is not between %d and %d", value, min, max)
+	}
+
+	return value, nil
+}
+
+// Next returns the first occurrence after t, or the zero time if there is none within the next few years
+func (r *Recurrence) Next(t time.Time) time.Time {
+	t = t.In(r.Location)
+	firstOfMonth := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, r.Location)
+
+	for i := 0; i < maxRecurrenceMonths; i++ {
+		month := firstOfMonth.AddDate(0, i, 0)
+		if r.Month != 0 && int(month.Month()) != r.Month {
+			continue
+		}
+
+		day, ok := r.dayOfMonth(month)
+		if !ok {
+			continue
+		}
+
+		occurrence := time.Date(month.Year(), month.Month(), day, r.Hour, r.Minute, 0, 0, r.Location)
+		if occurrence.After(t) {
+			return occurrence
+		}
+	}
+
+	return time.Time{}
+}
+
+// dayOfMonth returns the day the recurrence falls on in the month starting on firstOfMonth.
+// It returns false if there is none e.g. the fifth Monday of a month with four
+func (r *Recurrence) dayOfMonth(firstOfMonth time.Time) (int, bool) {
+	lastOfMonth := firstOfMonth.AddDate(0, 1, -1).Day()
+
+	if r.LastDay {
+		return lastOfMonth, true
+	}
+
+	if r.Nth == lastOccurrence {
+		lastWeekday := firstOfMonth.AddDate(0, 0, lastOfMonth-1).Weekday()
+		return lastOfMonth - (int(lastWeekday)-int(r.Weekday)+7)%7, true
+	}
+
+	firstDay := 1 + (int(r.Weekday)-int(firstOfMonth.Weekday())+7)%7
+	day := firstDay + (r.Nth-1)*7
+
+	return day, day <= lastOfMonth
+}
+
+// parseSchedule returns the schedule of a reminder in the timezone of its chat.
+// lunar is set for schedules on lunar dates, see LunarRecurrence
+func parseSchedule(timezone, schedule string, lunar bool) (cron.Schedule, error) {
+	if lunar || isRecurrence(schedule) {
+		loc, err := time.LoadLocation(timezone)
+		if err != nil {
+			return nil, err
+		}
+
+		if lunar {
+			return ParseLunarRecurrence(schedule, loc)
+		}
+
+		return ParseRecurrence(schedule, loc)
+	}
+
+	return cron.ParseSchedule(fmt.Sprintf("CRON_TZ=%s %s", timezone, schedule))
+}
+
+// reminderTimeZone returns the timezone the schedule of a reminder is read in,
+// which is the one of its chat unless the reminder was set in another
+func reminderTimeZone(chatPreference *chatpreference.ChatPreference, rem *Reminder) string {
+	if rem.TimeZone != "" {
+		return rem.TimeZone
+	}
+
+	return chatPreference.TimeZone
+}
+
+// addToScheduler adds the schedule of a reminder in its timezone to the scheduler.
+// Standard cron specs are left to the scheduler to parse, unless the reminder runs at a date with a year or on lunar dates
+func addToScheduler(scheduler cron.Scheduler, chatPreference *chatpreference.ChatPreference, rem *Reminder, cmd func()) (int, error) {
+	if !isRecurrence(rem.Schedule) && !followsHolidays(rem) && rem.RunAt == nil && !rem.Lunar {
+		return scheduler.Add(fmt.Sprintf("CRON_TZ=%s %s", reminderTimeZone(chatPreference, rem), rem.Schedule), cmd)
+	}
+
+	schedule, err := reminderSchedule(chatPreference, rem)
+	if err != nil {
+		return 0, err
+	}
+
+	return scheduler.AddSchedule(schedule, cmd)
+}
@@ -0,0 +1,85 @@
+package reminder_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/husol/telegram-reminder-bot/pkg/reminder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecurrence_Next(t *testing.T) {
+	loc, err := time.LoadLocation(timezone)
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		Schedule     string
+		From         time.Time
+		ExpectedNext time.Time
+	}{
+		"first Monday of the month": {
+			Schedule:     "0 9 * * 1#1",
+			From:         timeNow(),
+			ExpectedNext: time.Date(2020, 4, 6, 9, 0, 0, 0, loc),
+		},
+		"first Wednesday of the month later on the same day": {
+			Schedule:     "0 18 * * 3#1",
+			From:         timeNow(),
+			ExpectedNext: time.Date(2020, 4, 1, 18, 0, 0, 0, loc),
+		},
+		"first Wednesday of the month after it has fired": {
+			Schedule:     "0 9 * * 3#1",
+			From:         timeNow(),
+			ExpectedNext: time.Date(2020, 5, 6, 9, 0, 0, 0, loc),
+		},
+		"fifth Friday skips months with four": {
+			Schedule:     "30 17 * * 5#5",
+			From:         timeNow(),
+			ExpectedNext: time.Date(2020, 5, 29, 17, 30, 0, 0, loc),
+		},
+		"last Friday of the month": {
+			Schedule:     "0 17 * * 5L",
+			From:         timeNow(),
+			ExpectedNext: time.Date(2020, 4, 24, 17, 0, 0, 0, loc),
+		},
+		"last Sunday of the month": {
+			Schedule:     "0 10 * * 0L",
+			From:         time.Date(2020, 5, 31, 11, 0, 0, 0, loc),
+			ExpectedNext: time.Date(2020, 6, 28, 10, 0, 0, 0, loc),
+		},
+		"last day of the month": {
+			Schedule:     "0 9 L * *",
+			From:         timeNow(),
+			ExpectedNext: time.Date(2020, 4, 30, 9, 0, 0, 0, loc),
+		},
+		"last day of february in a leap year": {
+			Schedule:     "0 9 L * *",
+			From:         time.Date(2020, 2, 1, 9, 0, 0, 0, loc),
+			ExpectedNext: time.Date(2020, 2, 29, 9, 0, 0, 0, loc),
+		},
+		"last day of a given month": {
+			Schedule:     "0 9 L 2 *",
+			From:         timeNow(),
+			ExpectedNext: time.Date(2021, 2, 28, 9, 0, 0, 0, loc),
+		},
+	}
+
+	for name := range testCases {
+		t.Run(name, func(t *testing.T) {
+			recurrence, err := reminder.ParseRecurrence(testCases[name].Schedule, loc)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCases[name].ExpectedNext, recurrence.Next(testCases[name].From))
+		})
+	}
+}
+
+func TestParseRecurrence_Invalid(t *testing.T) {
+	for _, schedule := range []string{"0 9 * *", "0 9 L * 1", "0 9 * * 1#6", "0 9 * * 8L", "0 25 L * *", "0 9 1 * *"} {
+		t.Run(schedule, func(t *testing.T) {
+			_, err := reminder.ParseRecurrence(schedule, time.UTC)
+			require.Error(t, err)
+		})
+	}
+}
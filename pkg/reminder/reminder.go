@@ -12,17 +12,30 @@ type Reminder struct {
 }
 
 type Data struct {
-	RecipientID int    `json:"recipient_id"`
-	Command     string `json:"command"`
-	Message     string `json:"message"`
+	RecipientID int        `json:"recipient_id"`
+	Recipient   *Recipient `json:"recipient"`  // the user of the chat the reminder is for, nil for the whole chat
+	Roster      *Roster    `json:"roster"`     // the users of the chat who take turns to be reminded, nil if they don't
+	CreatorID   int        `json:"creator_id"` // the user who set the reminder, 0 for reminders set before it was recorded
+	Command     string     `json:"command"`
+	Message     string     `json:"message"`
+	// ReplyToMessageID is the message of the chat the reminder was set by replying to, 0 if it was not
+	ReplyToMessageID int `json:"reply_to_message_id"`
+	// Attachment is the media the reminder is sent with, nil when it is only sent as text
+	Attachment *Attachment `json:"attachment"`
+	// Tags are the hashtags of the message in lower case without "#" e.g. "ops" for "#Ops"
+	Tags []string `json:"tags,omitempty"`
 }
 
 type DateTime struct {
+	Year       int // 0 for the next time the day and month come round
 	DayOfMonth int
 	DayOfWeek  string
 	Month      int
 	Hour       int
 	Minute     int
+	// NumericDate is set for dates written with numbers e.g. 14/03/2027.
+	// DayOfMonth and Month are then in the order they were written and swapped for chats which read month first
+	NumericDate bool
 }
 
 type RepeatableDateTime struct {
@@ -31,12 +44,58 @@ type RepeatableDateTime struct {
 	Month      string
 	Hour       string
 	Minute     string
+	Ends       *EndCondition
+	Holidays   cron.HolidayPolicy
 }
 
 type AmountDateTime struct {
-	Minutes int
-	Hours   int
-	Days    int
+	Minutes  int
+	Hours    int
+	Days     int
+	Weeks    int
+	Months   int
+	At       *TimeOfDay         // only used by reminders sent once e.g. in 2 weeks at 9am
+	Ends     *EndCondition      // only used by recurring reminders
+	Holidays cron.HolidayPolicy // only used by recurring reminders
+}
+
+// TimeOfDay is the hour and minute a reminder is sent at
+type TimeOfDay struct {
+	Hour   int
+	Minute int
+}
+
+// WeeklyDateTime is a day of the week and time which comes round every few weeks
+type WeeklyDateTime struct {
+	Weeks     int
+	DayOfWeek int // 0 is Sunday
+	Hour      int
+	Minute    int
+	Ends      *EndCondition
+	Holidays  cron.HolidayPolicy
+}
+
+// LunarDateTime is a day of every lunar month, or of a lunar month every year, and the time it is sent at
+type LunarDateTime struct {
+	DayOfMonth int // 1 to 30
+	Month      int // 0 for every month
+	Hour       int
+	Minute     int
+	Ends       *EndCondition
+	Holidays   cron.HolidayPolicy
+}
+
+// CronSpec is a standard 5 field cron spec written by the user
+// along with how often a reminder set with it can be sent at most
+type CronSpec struct {
+	Spec        string
+	MinInterval time.Duration
+}
+
+// EndCondition stops a recurring reminder after a date or a number of times
+type EndCondition struct {
+	Until *DateTime // only DayOfMonth, Month and Year, which is optional, are used
+	Times int
 }
 
 // TODO: Find a better name for these variables...
@@ -60,4 +119,8 @@ type WordDateTime struct {
 type NextScheduleChatTime struct {
 	Time     time.Time
 	Location *time.Location
+	// ReminderLocation is the timezone the reminder was set in when it is not the one of the chat, nil otherwise
+	ReminderLocation *time.Location
+	// Paused is set for a paused reminder, which is sent at Time once it is resumed
+	Paused bool
 }
@@ -0,0 +1,148 @@
+package reminder
+
+import (
+	"strings"
+	"time"
+
+	"github.com/husol/telegram-reminder-bot/pkg/chatpreference"
+	"github.com/husol/telegram-reminder-bot/pkg/cron"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
+)
+
+// Roster is a rotation of users of a group who take turns to be reminded e.g. who is on call this week.
+// Each run of the reminder is for the member whose turn is next, then the turn passes to the following one
+type Roster struct {
+	Members []Recipient `json:"members"`
+	Next    int         `json:"next"` // the index of the member whose turn is next
+}
+
+// RosterTurn is a run of a reminder with a roster and the member it is for
+type RosterTurn struct {
+	At     time.Time
+	Member Recipient
+}
+
+// takeTurn returns the member whose turn is next and passes the turn to the following one
+func (r *Roster) takeTurn() *Recipient {
+	member := r.Members[r.Next%len(r.Members)]
+	r.Next = (r.Next + 1) % len(r.Members)
+
+	return &member
+}
+
+// nextMember returns the member whose turn comes after n more turns
+func (r *Roster) nextMember(n int) Recipient {
+	return r.Members[(r.Next+n)%len(r.Members)]
+}
+
+// find returns the index of a member written as their username e.g. "@alice", or their name
+func (r *Roster) find(member string) (int, bool) {
+	member = strings.TrimSpace(member)
+	for i := range r.Members {
+		if r.Members[i].Username != "" && strings.EqualFold("@"+r.Members[i].Username, member) {
+			return i, true
+		}
+		if r.Members[i].Name != "" && strings.EqualFold(r.Members[i].Name, member) {
+			return i, true
+		}
+	}
+
+	return 0, false
+}
+
+// takeRosterTurn makes a run of a reminder with a roster for the member whose turn it is.
+// The member is kept as the recipient of the reminder, which its follow-ups and snoozes are for
+func takeRosterTurn(r *Reminder) {
+	if r.Data.Roster == nil || len(r.Data.Roster.Members) == 0 {
+		return
+	}
+
+	r.Data.Recipient = r.Data.Roster.takeTurn()
+}
+
+// UpcomingRosterTurns returns who the next n runs of a reminder with a roster after t are for.
+// Runs which are skipped are left out, as they do not take a turn
+func UpcomingRosterTurns(chatPreference *chatpreference.ChatPreference, rem *Reminder, t time.Time, n int) ([]RosterTurn, error) {
+	if rem.Data.Roster == nil || len(rem.Data.Roster.Members) == 0 {
+		return nil, nil
+	}
+
+	if rem.RunOnlyOnce && rem.RepeatSchedule == nil {
+		n = 1
+	}
+	if rem.RemainingRuns != nil && *rem.RemainingRuns < n {
+		n = *rem.RemainingRuns
+	}
+
+	runs, err := upcomingRuns(chatPreference, rem, t, n)
+	if err != nil {
+		return nil, err
+	}
+
+	turns := make([]RosterTurn, len(runs))
+	for i := range runs {
+		turns[i] = RosterTurn{At: runs[i], Member: rem.Data.Roster.nextMember(i)}
+	}
+
+	return turns, nil
+}
+
+// SkipRosterTurn skips the member of the roster of a reminder whose turn is next, the following member takes it.
+// It returns the member who was skipped and the one whose turn is next
+func (s *Service) SkipRosterTurn(chatID, reminderID int) (skipped, next Recipient, err error) {
+	rem, err := s.rosterReminder(chatID, reminderID)
+	if err != nil {
+		return Recipient{}, Recipient{}, err
+	}
+
+	skipped = *rem.Data.Roster.takeTurn()
+	next = rem.Data.Roster.nextMember(0)
+
+	return skipped, next, s.refreshRoster(rem)
+}
+
+// SwapRosterTurn gives the next turn of the roster of a reminder to a member, who swaps places with the member
+// whose turn it was. It returns the member whose turn it was
+func (s *Service) SwapRosterTurn(chatID, reminderID int, member string) (Recipient, error) {
+	rem, err := s.rosterReminder(chatID, reminderID)
+	if err != nil {
+		return Recipient{}, err
+	}
+
+	roster := rem.Data.Roster
+	i, ok := roster.find(member)
+	if !ok {
+		return Recipient{}, i18n.Errorf(i18n.ErrNotInRoster, member, reminderID)
+	}
+
+	next := roster.Next % len(roster.Members)
+	swapped := roster.Members[next]
+	roster.Members[next], roster.Members[i] = roster.Members[i], roster.Members[next]
+
+	return swapped, s.refreshRoster(rem)
+}
+
+func (s *Service) rosterReminder(chatID, reminderID int) (*Reminder, error) {
+	rem, err := s.reminderStore.GetReminder(chatID, reminderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if rem.Data.Roster == nil || len(rem.Data.Roster.Members) == 0 {
+		return nil, i18n.Errorf(i18n.ErrNoRoster, reminderID)
+	}
+
+	return rem, nil
+}
+
+// refreshRoster saves a change to the roster of a reminder, which its scheduled run also needs to see
+func (s *Service) refreshRoster(rem *Reminder) error {
+	if rem.Status == cron.Active {
+		err := s.reminderScheduler.RefreshReminder(rem)
+		if err != nil {
+			return err
+		}
+	}
+
+	return s.reminderStore.UpdateReminder(rem)
+}
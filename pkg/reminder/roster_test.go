@@ -0,0 +1,141 @@
+package reminder_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/husol/telegram-reminder-bot/pkg/chatpreference"
+	"github.com/husol/telegram-reminder-bot/pkg/cron"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
+	"github.com/husol/telegram-reminder-bot/pkg/reminder"
+	reminderMocks "github.com/husol/telegram-reminder-bot/pkg/reminder/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	alice = reminder.Recipient{Username: "alice"}
+	bob   = reminder.Recipient{UserID: 7, Name: "Bob"}
+	carol = reminder.Recipient{Username: "carol"}
+)
+
+func newRosterReminder() *reminder.Reminder {
+	return &reminder.Reminder{
+		Job: cron.Job{
+			ID:       reminderID,
+			CronID:   cronID,
+			ChatID:   chatID,
+			Schedule: "0 9 * * 1",
+			Status:   cron.Active,
+		},
+		Data: reminder.Data{
+			RecipientID: chatID,
+			Message:     message,
+			Roster:      &reminder.Roster{Members: []reminder.Recipient{alice, bob, carol}},
+		},
+	}
+}
+
+func TestNewCronFunc_Roster(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	bot := &stubBot{}
+	cronFuncService := reminderMocks.NewMockCronFuncServicer(mockCtrl)
+	rem := newRosterReminder()
+	rem.Data.Roster.Next = 2
+	cronFuncService.EXPECT().AddOccurrence(rem, gomock.Any()).Return(0).Times(2)
+	cronFuncService.EXPECT().ChatLanguage(chatID).Return(i18n.English).Times(2)
+	cronFuncService.EXPECT().UpdateReminderWithNextRun(rem).Return(nil).Times(2)
+
+	reminder.NewCronFunc(cronFuncService, bot, rem)()
+	reminder.NewCronFunc(cronFuncService, bot, rem)()
+	require.Len(t, bot.sent, 2)
+	assert.Equal(t, "@carol 🗓 message", bot.sent[0])
+	assert.Equal(t, "@alice 🗓 message", bot.sent[1])
+	assert.Equal(t, &alice, rem.Data.Recipient)
+	assert.Equal(t, 1, rem.Data.Roster.Next)
+}
+
+func TestUpcomingRosterTurns(t *testing.T) {
+	loc, err := time.LoadLocation(timezone)
+	require.NoError(t, err)
+	chatPreference := &chatpreference.ChatPreference{ChatID: chatID, TimeZone: timezone}
+	rem := newRosterReminder()
+	rem.Data.Roster.Next = 1
+	rem.SkippedRuns = []time.Time{time.Date(2020, time.April, 13, 9, 0, 0, 0, loc).In(time.UTC)}
+
+	turns, err := reminder.UpcomingRosterTurns(chatPreference, rem, timeNow(), 3)
+	require.NoError(t, err)
+	require.Len(t, turns, 3)
+	// the skipped run does not take a turn
+	assert.True(t, time.Date(2020, time.April, 6, 9, 0, 0, 0, loc).Equal(turns[0].At))
+	assert.Equal(t, bob, turns[0].Member)
+	assert.True(t, time.Date(2020, time.April, 20, 9, 0, 0, 0, loc).Equal(turns[1].At))
+	assert.Equal(t, carol, turns[1].Member)
+	assert.True(t, time.Date(2020, time.April, 27, 9, 0, 0, 0, loc).Equal(turns[2].At))
+	assert.Equal(t, alice, turns[2].Member)
+}
+
+func TestService_SkipRosterTurn(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mocks := createMocks(mockCtrl)
+		rem := newRosterReminder()
+		rem.Data.Roster.Next = 2
+		mocks.ReminderStore.EXPECT().GetReminder(chatID, reminderID).Return(rem, nil)
+		mocks.Scheduler.EXPECT().RefreshReminder(rem).Return(nil)
+		mocks.ReminderStore.EXPECT().UpdateReminder(rem).Return(nil)
+
+		service := reminder.NewService(mocks.Scheduler, mocks.ReminderStore, mocks.ChatPreferenceStore, timeNow)
+		skipped, next, err := service.SkipRosterTurn(chatID, reminderID)
+		require.NoError(t, err)
+		assert.Equal(t, carol, skipped)
+		assert.Equal(t, alice, next)
+		assert.Equal(t, 0, rem.Data.Roster.Next)
+	})
+
+	t.Run("failure when reminder has no roster", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mocks := createMocks(mockCtrl)
+		mocks.ReminderStore.EXPECT().GetReminder(chatID, reminderID).Return(&reminder.Reminder{
+			Job: cron.Job{ID: reminderID, ChatID: chatID, Schedule: "0 9 * * 1", Status: cron.Active},
+		}, nil)
+
+		service := reminder.NewService(mocks.Scheduler, mocks.ReminderStore, mocks.ChatPreferenceStore, timeNow)
+		_, _, err := service.SkipRosterTurn(chatID, reminderID)
+		assert.EqualError(t, err, "error: reminder 3 has no roster")
+	})
+}
+
+func TestService_SwapRosterTurn(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mocks := createMocks(mockCtrl)
+		rem := newRosterReminder()
+		rem.Status = cron.Inactive
+		mocks.ReminderStore.EXPECT().GetReminder(chatID, reminderID).Return(rem, nil)
+		mocks.ReminderStore.EXPECT().UpdateReminder(rem).Return(nil)
+
+		service := reminder.NewService(mocks.Scheduler, mocks.ReminderStore, mocks.ChatPreferenceStore, timeNow)
+		swapped, err := service.SwapRosterTurn(chatID, reminderID, "@Carol")
+		require.NoError(t, err)
+		assert.Equal(t, alice, swapped)
+		assert.Equal(t, []reminder.Recipient{carol, bob, alice}, rem.Data.Roster.Members)
+		assert.Equal(t, 0, rem.Data.Roster.Next)
+	})
+
+	t.Run("failure when user is not in the roster", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mocks := createMocks(mockCtrl)
+		mocks.ReminderStore.EXPECT().GetReminder(chatID, reminderID).Return(newRosterReminder(), nil)
+
+		service := reminder.NewService(mocks.Scheduler, mocks.ReminderStore, mocks.ChatPreferenceStore, timeNow)
+		_, err := service.SwapRosterTurn(chatID, reminderID, "@dave")
+		assert.EqualError(t, err, "error: @dave is not in the roster of reminder 3")
+	})
+}
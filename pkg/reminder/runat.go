@@ -0,0 +1,18 @@
+package reminder
+
+import "time"
+
+// onceSchedule is the schedule of a reminder which runs once at a date with a year.
+// Cron specs have no year so a one-off reminder far in the future is scheduled at its absolute time instead
+type onceSchedule struct {
+	at time.Time
+}
+
+// Next returns the time of the reminder if it is after t, or the zero time which the scheduler never runs
+func (s onceSchedule) Next(t time.Time) time.Time {
+	if s.at.After(t) {
+		return s.at
+	}
+
+	return time.Time{}
+}
@@ -3,7 +3,6 @@ package reminder
 //go:generate mockgen -source=$GOFILE -destination=mocks/${GOFILE} -package=mocks
 
 import (
-	"fmt"
 	"time"
 
 	"github.com/husol/telegram-reminder-bot/pkg/chatpreference"
@@ -13,6 +12,8 @@ import (
 
 type Scheduler interface {
 	AddReminder(r *Reminder) (int, error)
+	RemoveReminder(r *Reminder)
+	RefreshReminder(r *Reminder) error
 	GetNextScheduleTime(cronID int) (time.Time, error)
 }
 
@@ -46,8 +47,7 @@ func (s *SchedulerManager) AddReminder(rem *Reminder) (int, error) {
 		return 0, err
 	}
 
-	schedule := fmt.Sprintf("CRON_TZ=%s %s", chatPreference.TimeZone, rem.Job.Schedule)
-	reminderCronID, err := s.scheduler.Add(schedule, NewCronFunc(s.reminderCronFuncService, s.bot, rem))
+	reminderCronID, err := addToScheduler(s.scheduler, chatPreference, rem, NewCronFunc(s.reminderCronFuncService, s.bot, rem))
 	if err != nil {
 		return 0, err
 	}
@@ -55,6 +55,41 @@ func (s *SchedulerManager) AddReminder(rem *Reminder) (int, error) {
 	return reminderCronID, nil
 }
 
+// RemoveReminder removes the reminder's entries from the scheduler.
+// Any follow-up cycle in progress is cleared from the reminder
+func (s *SchedulerManager) RemoveReminder(rem *Reminder) {
+	s.scheduler.Remove(rem.CronID)
+
+	if rem.Nag != nil {
+		s.scheduler.Remove(rem.Nag.CronID)
+		resetNag(rem)
+	}
+}
+
+// RefreshReminder replaces the entries of an active reminder on the scheduler with ones for rem.
+// The scheduled functions hold on to the reminder they were created with
+// so this is needed for them to see changes made to it, without stopping a follow-up cycle in progress
+func (s *SchedulerManager) RefreshReminder(rem *Reminder) error {
+	cronID, err := s.AddReminder(rem)
+	if err != nil {
+		return err
+	}
+	s.scheduler.Remove(rem.CronID)
+	rem.CronID = cronID
+
+	if rem.Nag != nil && rem.Nag.NextRunAt != nil {
+		_, schedule := nagSchedule(*rem.Nag.NextRunAt)
+		nagCronID, err := s.scheduler.Add(schedule, NewNagCronFunc(s.reminderCronFuncService, s.bot, rem))
+		if err != nil {
+			return err
+		}
+		s.scheduler.Remove(rem.Nag.CronID)
+		rem.Nag.CronID = nagCronID
+	}
+
+	return nil
+}
+
 func (s *SchedulerManager) GetNextScheduleTime(cronID int) (time.Time, error) {
 	cronEntry := s.scheduler.GetEntryByID(cronID)
 
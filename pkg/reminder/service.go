@@ -3,16 +3,24 @@ package reminder
 //go:generate mockgen -source=$GOFILE -destination=mocks/${GOFILE} -package=mocks
 
 import (
-	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"time"
 
 	"github.com/husol/telegram-reminder-bot/pkg/chatpreference"
 	"github.com/husol/telegram-reminder-bot/pkg/cron"
+	"github.com/husol/telegram-reminder-bot/pkg/date"
+	"github.com/husol/telegram-reminder-bot/pkg/i18n"
 )
 
 type ServiceReminder interface {
+	ForRecipient(recipient *Recipient) ServiceReminder
+	ForRoster(roster *Roster) ServiceReminder
+	ForCreator(userID int) ServiceReminder
+	InTimeZone(timeZone string) ServiceReminder
+	ReplyingTo(messageID int) ServiceReminder
+	WithAttachment(attachment *Attachment) ServiceReminder
 	AddReminderOnDateTime(chatID int, command string, dateTime DateTime, message string) (NextScheduleChatTime, error)
 	AddReminderOnWordDateTime(
 		chatID int,
@@ -28,6 +36,45 @@ type ServiceReminder interface {
 	) (NextScheduleChatTime, error)
 	AddReminderIn(chatID int, command string, amountDateTime AmountDateTime, message string) (NextScheduleChatTime, error)
 	AddReminderEvery(chatID int, command string, amountDateTime AmountDateTime, message string) (NextScheduleChatTime, error)
+	EditReminderOnDateTime(chatID, reminderID int, command string, dateTime DateTime, message string) (NextScheduleChatTime, error)
+	EditReminderOnWordDateTime(
+		chatID, reminderID int,
+		command string,
+		dateTime WordDateTime,
+		message string,
+	) (NextScheduleChatTime, error)
+	EditRepeatableReminderOnDateTime(
+		chatID, reminderID int,
+		command string,
+		dateTime *RepeatableDateTime,
+		message string,
+	) (NextScheduleChatTime, error)
+	EditReminderIn(chatID, reminderID int, command string, amountDateTime AmountDateTime, message string) (NextScheduleChatTime, error)
+	EditReminderEvery(chatID, reminderID int, command string, amountDateTime AmountDateTime, message string) (NextScheduleChatTime, error)
+	AddReminderEveryWeeks(chatID int, command string, weeklyDateTime WeeklyDateTime, message string) (NextScheduleChatTime, error)
+	AddReminderOnLunarDate(chatID int, command string, lunarDateTime LunarDateTime, message string) (NextScheduleChatTime, error)
+	EditReminderOnLunarDate(
+		chatID, reminderID int,
+		command string,
+		lunarDateTime LunarDateTime,
+		message string,
+	) (NextScheduleChatTime, error)
+	AddReminderOnCronSpec(chatID int, command string, cronSpec CronSpec, message string) (NextScheduleChatTime, error)
+	EditReminderOnCronSpec(chatID, reminderID int, command string, cronSpec CronSpec, message string) (NextScheduleChatTime, error)
+	PreviewCronSpec(chatID int, cronSpec CronSpec, n int) ([]time.Time, error)
+	EditReminderEveryWeeks(
+		chatID, reminderID int,
+		command string,
+		weeklyDateTime WeeklyDateTime,
+		message string,
+	) (NextScheduleChatTime, error)
+	EditReminderMessage(chatID, reminderID int, message string) error
+	PauseReminder(chatID, reminderID int) error
+	ResumeReminder(chatID, reminderID int) (NextScheduleChatTime, error)
+	SetReminderNag(chatID, reminderID int, nag *cron.JobNag) error
+	SkipReminder(chatID, reminderID, times int) ([]time.Time, error)
+	SkipRosterTurn(chatID, reminderID int) (skipped, next Recipient, err error)
+	SwapRosterTurn(chatID, reminderID int, member string) (Recipient, error)
 }
 
 type Service struct {
@@ -35,6 +82,12 @@ type Service struct {
 	reminderScheduler   Scheduler
 	chatPreferenceStore chatpreference.Storer
 	timeNow             func() time.Time
+	recipient           *Recipient
+	roster              *Roster
+	creatorID           int
+	timeZone            string
+	replyToMessageID    int
+	attachment          *Attachment
 }
 
 func NewService(
@@ -51,16 +104,107 @@ func NewService(
 	}
 }
 
+// ForRecipient returns a service which adds reminders for a user of the chat rather than the whole chat.
+// Reminders which are edited keep their recipient unless the service has one
+func (s *Service) ForRecipient(recipient *Recipient) ServiceReminder {
+	forRecipient := *s
+	forRecipient.recipient = recipient
+
+	return &forRecipient
+}
+
+// ForRoster returns a service which adds reminders whose members of the chat take turns to be reminded.
+// Reminders which are edited keep their roster unless the service has one
+func (s *Service) ForRoster(roster *Roster) ServiceReminder {
+	forRoster := *s
+	forRoster.roster = roster
+
+	return &forRoster
+}
+
+// ForCreator returns a service which records the user who set the reminders it adds.
+// Reminders which are edited keep the user who set them
+func (s *Service) ForCreator(userID int) ServiceReminder {
+	forCreator := *s
+	forCreator.creatorID = userID
+
+	return &forCreator
+}
+
+// InTimeZone returns a service which reads the times of the reminders it adds and edits in a timezone
+// other than the one of the chat e.g. the one of the member who set them. An empty timeZone is the one of the chat
+func (s *Service) InTimeZone(timeZone string) ServiceReminder {
+	inTimeZone := *s
+	inTimeZone.timeZone = timeZone
+
+	return &inTimeZone
+}
+
+// ReplyingTo returns a service which adds reminders about a message of the chat, which is quoted when they are sent.
+// Reminders which are edited keep the message they are about unless the service has one
+func (s *Service) ReplyingTo(messageID int) ServiceReminder {
+	replyingTo := *s
+	replyingTo.replyToMessageID = messageID
+
+	return &replyingTo
+}
+
+// WithAttachment returns a service which adds reminders which are sent with a photo, document, voice message or location.
+// Reminders which are edited keep their attachment unless the service has one
+func (s *Service) WithAttachment(attachment *Attachment) ServiceReminder {
+	withAttachment := *s
+	withAttachment.attachment = attachment
+
+	return &withAttachment
+}
+
+// chatPreference returns the preferences of a chat with the timezone the service reads times in
+func (s *Service) chatPreference(chatID int) (*chatpreference.ChatPreference, error) {
+	chatPreference, err := s.chatPreferenceStore.GetChatPreference(chatID)
+	if err != nil || s.timeZone == "" {
+		return chatPreference, err
+	}
+
+	inTimeZone := *chatPreference
+	inTimeZone.TimeZone = s.timeZone
+
+	return &inTimeZone, nil
+}
+
 func (s *Service) AddReminderOnDateTime(
 	chatID int,
 	command string,
 	dateTime DateTime,
 	message string,
 ) (NextScheduleChatTime, error) {
-	newReminder := &Reminder{
+	newReminder, err := s.newReminderOnDateTime(chatID, command, dateTime, message)
+	if err != nil {
+		return NextScheduleChatTime{}, err
+	}
+
+	return s.ScheduleAndAddReminder(newReminder)
+}
+
+func (s *Service) EditReminderOnDateTime(
+	chatID, reminderID int,
+	command string,
+	dateTime DateTime,
+	message string,
+) (NextScheduleChatTime, error) {
+	newReminder, err := s.newReminderOnDateTime(chatID, command, dateTime, message)
+	if err != nil {
+		return NextScheduleChatTime{}, err
+	}
+
+	return s.ScheduleAndReplaceReminder(reminderID, newReminder)
+}
+
+// newReminderOnDateTime creates a one-off reminder.
+// Dates with a year are run at their absolute time as the schedule has no year
+func (s *Service) newReminderOnDateTime(chatID int, command string, dateTime DateTime, message string) (*Reminder, error) {
+	rem := &Reminder{
 		Job: cron.Job{
 			ChatID:      chatID,
-			Schedule:    buildScheduleForDateTime(&dateTime),
 			Type:        cron.Reminder,
 			Status:      cron.Active,
 			RunOnlyOnce: true,
@@ -72,7 +216,49 @@ func (s *Service) AddReminderOnDateTime(
 		},
 	}
 
-	return s.ScheduleAndAddReminder(newReminder)
+	if !dateTime.NumericDate && dateTime.Year == 0 {
+		rem.Schedule = buildScheduleForDateTime(&dateTime)
+		return rem, nil
+	}
+
+	chatPreference, err := s.chatPreference(chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	if dateTime.NumericDate && chatPreference.DateOrder == chatpreference.MonthDay {
+		dateTime.DayOfMonth, dateTime.Month = dateTime.Month, dateTime.DayOfMonth
+	}
+
+	if dateTime.DayOfMonth < 1 || dateTime.Month < 1 || dateTime.Month > 12 ||
+		dateTime.DayOfMonth > date.MaxDaysInMonth(dateTime.Month) {
+		return nil, i18n.Errorf(i18n.ErrInvalidDate, dateTime.DayOfMonth, dateTime.Month)
+	}
+	rem.Schedule = buildScheduleForDateTime(&dateTime)
+
+	if dateTime.Year == 0 {
+		return rem, nil
+	}
+
+	loc, err := time.LoadLocation(chatPreference.TimeZone)
+	if err != nil {
+		return nil, err
+	}
+
+	runAt := time.Date(dateTime.Year, time.Month(dateTime.Month), dateTime.DayOfMonth, dateTime.Hour, dateTime.Minute, 0, 0, loc)
+	if runAt.Day() != dateTime.DayOfMonth {
+		return nil, i18n.Errorf(i18n.ErrDaysInMonth, dateTime.Month, dateTime.Year, dateTime.DayOfMonth)
+	}
+
+	err = s.validateInFuture(runAt)
+	if err != nil {
+		return nil, err
+	}
+
+	runAtUTC := runAt.In(time.UTC)
+	rem.RunAt = &runAtUTC
+
+	return rem, nil
 }
 
 func (s *Service) AddReminderOnWordDateTime(chatID int,
@@ -80,18 +266,47 @@ func (s *Service) AddReminderOnWordDateTime(chatID int,
 	dateTime WordDateTime,
 	message string,
 ) (NextScheduleChatTime, error) {
-	chatLocalTime, err := s.convertWordDateTimeToChatLocalDateTime(chatID, dateTime)
+	newReminder, err := s.newReminderOnWordDateTime(chatID, command, dateTime, message)
 	if err != nil {
 		return NextScheduleChatTime{}, err
 	}
 
-	err = s.validateInFuture(chatLocalTime.In(time.UTC))
+	return s.ScheduleAndAddReminder(newReminder)
+}
+
+func (s *Service) EditReminderOnWordDateTime(
+	chatID, reminderID int,
+	command string,
+	dateTime WordDateTime,
+	message string,
+) (NextScheduleChatTime, error) {
+	newReminder, err := s.newReminderOnWordDateTime(chatID, command, dateTime, message)
 	if err != nil {
 		return NextScheduleChatTime{}, err
 	}
 
+	return s.ScheduleAndReplaceReminder(reminderID, newReminder)
+}
+
+func (s *Service) newReminderOnWordDateTime(
+	chatID int,
+	command string,
+	dateTime WordDateTime,
+	message string,
+) (*Reminder, error) {
+	chatLocalTime, err := s.convertWordDateTimeToChatLocalDateTime(chatID, dateTime)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.validateInFuture(chatLocalTime.In(time.UTC))
+	if err != nil {
+		return nil, err
+	}
+
 	schedule := fmt.Sprintf("%d %d %d %d *", chatLocalTime.Minute(), chatLocalTime.Hour(), chatLocalTime.Day(), chatLocalTime.Month())
-	newReminder := &Reminder{
+
+	return &Reminder{
 		Job: cron.Job{
 			ChatID:      chatID,
 			Schedule:    schedule,
@@ -104,13 +319,11 @@ func (s *Service) AddReminderOnWordDateTime(chatID int,
 			Message:     message,
 			Command:     command,
 		},
-	}
-
-	return s.ScheduleAndAddReminder(newReminder)
+	}, nil
 }
 
 func (s *Service) convertWordDateTimeToChatLocalDateTime(chatID int, dateTime WordDateTime) (time.Time, error) {
-	chatPreference, err := s.chatPreferenceStore.GetChatPreference(chatID)
+	chatPreference, err := s.chatPreference(chatID)
 	if err != nil {
 		return s.timeNow(), err
 	}
@@ -142,13 +355,36 @@ func (s *Service) convertWordDateTimeToChatLocalDateTime(chatID int, dateTime Wo
 func (s *Service) AddRepeatableReminderOnDateTime(
 	chatID int, command string, repeatDateTime *RepeatableDateTime, message string,
 ) (NextScheduleChatTime, error) {
-	newReminder := &Reminder{
+	newReminder, err := s.newRepeatableReminderOnDateTime(chatID, command, repeatDateTime, message)
+	if err != nil {
+		return NextScheduleChatTime{}, err
+	}
+
+	return s.ScheduleAndAddReminder(newReminder)
+}
+
+func (s *Service) EditRepeatableReminderOnDateTime(
+	chatID, reminderID int, command string, repeatDateTime *RepeatableDateTime, message string,
+) (NextScheduleChatTime, error) {
+	newReminder, err := s.newRepeatableReminderOnDateTime(chatID, command, repeatDateTime, message)
+	if err != nil {
+		return NextScheduleChatTime{}, err
+	}
+
+	return s.ScheduleAndReplaceReminder(reminderID, newReminder)
+}
+
+func (s *Service) newRepeatableReminderOnDateTime(
+	chatID int, command string, repeatDateTime *RepeatableDateTime, message string,
+) (*Reminder, error) {
+	rem := &Reminder{
 		Job: cron.Job{
 			ChatID:      chatID,
 			Schedule:    buildScheduleForRepeatableDateTime(repeatDateTime),
 			Type:        cron.Reminder,
 			Status:      cron.Active,
 			RunOnlyOnce: false,
+			Holidays:    repeatDateTime.Holidays,
 		},
 		Data: Data{
 			RecipientID: chatID,
@@ -157,30 +393,64 @@ func (s *Service) AddRepeatableReminderOnDateTime(
 		},
 	}
 
-	return s.ScheduleAndAddReminder(newReminder)
+	return rem, s.setEndCondition(rem, repeatDateTime.Ends)
 }
 
 func (s *Service) AddReminderIn(
 	chatID int, command string, amountDateTime AmountDateTime, message string,
 ) (NextScheduleChatTime, error) {
-	chatPreference, err := s.chatPreferenceStore.GetChatPreference(chatID)
+	newReminder, err := s.newReminderIn(chatID, command, amountDateTime, message)
 	if err != nil {
 		return NextScheduleChatTime{}, err
 	}
 
-	loc, err := time.LoadLocation(chatPreference.TimeZone)
+	return s.ScheduleAndAddReminder(newReminder)
+}
+
+func (s *Service) EditReminderIn(
+	chatID, reminderID int, command string, amountDateTime AmountDateTime, message string,
+) (NextScheduleChatTime, error) {
+	newReminder, err := s.newReminderIn(chatID, command, amountDateTime, message)
 	if err != nil {
 		return NextScheduleChatTime{}, err
 	}
 
-	addedTime := s.timeNow().In(loc).Add(
-		time.Duration(amountDateTime.Days)*24*time.Hour +
+	return s.ScheduleAndReplaceReminder(reminderID, newReminder)
+}
+
+func (s *Service) newReminderIn(chatID int, command string, amountDateTime AmountDateTime, message string) (*Reminder, error) {
+	chatPreference, err := s.chatPreference(chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := time.LoadLocation(chatPreference.TimeZone)
+	if err != nil {
+		return nil, err
+	}
+
+	timeNow := s.timeNow().In(loc)
+	addedTime := timeNow
+	if amountDateTime.Months > 0 {
+		addedTime = addMonths(addedTime, amountDateTime.Months, 0)
+	}
+	addedTime = addedTime.Add(
+		time.Duration(amountDateTime.Weeks*7+amountDateTime.Days)*24*time.Hour +
 			time.Duration(amountDateTime.Hours)*time.Hour +
 			time.Duration(amountDateTime.Minutes)*time.Minute,
 	)
 
+	if amountDateTime.At != nil {
+		addedTime = time.Date(addedTime.Year(), addedTime.Month(), addedTime.Day(), amountDateTime.At.Hour, amountDateTime.At.Minute, 0, 0, loc)
+		err = s.validateInFuture(addedTime)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	schedule := fmt.Sprintf("%d %d %d %d *", addedTime.Minute(), addedTime.Hour(), addedTime.Day(), addedTime.Month())
-	newReminder := &Reminder{
+
+	rem := &Reminder{
 		Job: cron.Job{
 			ChatID:      chatID,
 			Schedule:    schedule,
@@ -195,41 +465,134 @@ func (s *Service) AddReminderIn(
 		},
 	}
 
-	return s.ScheduleAndAddReminder(newReminder)
+	// the schedule has no year so a reminder a year or more away runs at the time instead
+	if !addedTime.Before(timeNow.AddDate(1, 0, 0)) {
+		runAtUTC := addedTime.In(time.UTC)
+		rem.RunAt = &runAtUTC
+	}
+
+	return rem, nil
 }
 
 func (s *Service) AddReminderEvery(
 	chatID int, command string, amountDateTime AmountDateTime, message string,
 ) (NextScheduleChatTime, error) {
-	chatPreference, err := s.chatPreferenceStore.GetChatPreference(chatID)
+	newReminder, err := s.newReminderEvery(chatID, command, amountDateTime, message)
+	if err != nil {
+		return NextScheduleChatTime{}, err
+	}
+
+	return s.ScheduleAndAddReminder(newReminder)
+}
+
+func (s *Service) EditReminderEvery(
+	chatID, reminderID int, command string, amountDateTime AmountDateTime, message string,
+) (NextScheduleChatTime, error) {
+	newReminder, err := s.newReminderEvery(chatID, command, amountDateTime, message)
 	if err != nil {
 		return NextScheduleChatTime{}, err
 	}
 
+	return s.ScheduleAndReplaceReminder(reminderID, newReminder)
+}
+
+func (s *Service) newReminderEvery(chatID int, command string, amountDateTime AmountDateTime, message string) (*Reminder, error) {
+	chatPreference, err := s.chatPreference(chatID)
+	if err != nil {
+		return nil, err
+	}
+
 	loc, err := time.LoadLocation(chatPreference.TimeZone)
+	if err != nil {
+		return nil, err
+	}
+
+	timeNow := s.timeNow().In(loc)
+	repeatSchedule := &cron.JobRepeatSchedule{
+		Hours:   amountDateTime.Hours,
+		Days:    amountDateTime.Weeks*7 + amountDateTime.Days,
+		Minutes: amountDateTime.Minutes,
+		Months:  amountDateTime.Months,
+	}
+	if repeatSchedule.Months > 0 {
+		repeatSchedule.DayOfMonth = timeNow.Day()
+	}
+
+	rem := &Reminder{
+		Job: cron.Job{
+			ChatID:         chatID,
+			Type:           cron.Reminder,
+			Status:         cron.Active,
+			RunOnlyOnce:    true,
+			RepeatSchedule: repeatSchedule,
+			Holidays:       amountDateTime.Holidays,
+		},
+		Data: Data{
+			RecipientID: chatID,
+			Message:     message,
+			Command:     command,
+		},
+	}
+	scheduleRepeatAt(rem, chatPreference.Holidays, addRepeatSchedule(timeNow, repeatSchedule))
+
+	return rem, s.setEndCondition(rem, amountDateTime.Ends)
+}
+
+func (s *Service) AddReminderEveryWeeks(
+	chatID int, command string, weeklyDateTime WeeklyDateTime, message string,
+) (NextScheduleChatTime, error) {
+	newReminder, err := s.newReminderEveryWeeks(chatID, command, weeklyDateTime, message)
 	if err != nil {
 		return NextScheduleChatTime{}, err
 	}
 
-	addedTime := s.timeNow().In(loc).Add(
-		time.Duration(amountDateTime.Days)*24*time.Hour +
-			time.Duration(amountDateTime.Hours)*time.Hour +
-			time.Duration(amountDateTime.Minutes)*time.Minute,
-	)
+	return s.ScheduleAndAddReminder(newReminder)
+}
 
-	schedule := fmt.Sprintf("%d %d %d %d *", addedTime.Minute(), addedTime.Hour(), addedTime.Day(), addedTime.Month())
-	newReminder := &Reminder{
+func (s *Service) EditReminderEveryWeeks(
+	chatID, reminderID int, command string, weeklyDateTime WeeklyDateTime, message string,
+) (NextScheduleChatTime, error) {
+	newReminder, err := s.newReminderEveryWeeks(chatID, command, weeklyDateTime, message)
+	if err != nil {
+		return NextScheduleChatTime{}, err
+	}
+
+	return s.ScheduleAndReplaceReminder(reminderID, newReminder)
+}
+
+// newReminderEveryWeeks creates a reminder scheduled on the next occurrence of the day of the week
+// which then repeats every few weeks
+func (s *Service) newReminderEveryWeeks(chatID int, command string, weeklyDateTime WeeklyDateTime, message string) (*Reminder, error) {
+	if weeklyDateTime.Weeks < 1 {
+		return nil, i18n.Errorf(i18n.ErrRepeatWeekly)
+	}
+
+	chatPreference, err := s.chatPreference(chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := time.LoadLocation(chatPreference.TimeZone)
+	if err != nil {
+		return nil, err
+	}
+
+	timeNow := s.timeNow().In(loc)
+	firstTime := time.Date(timeNow.Year(), timeNow.Month(), timeNow.Day(), weeklyDateTime.Hour, weeklyDateTime.Minute, 0, 0, loc)
+	for firstTime.Weekday() != time.Weekday(weeklyDateTime.DayOfWeek) || !firstTime.After(timeNow) {
+		firstTime = firstTime.AddDate(0, 0, 1)
+	}
+
+	rem := &Reminder{
 		Job: cron.Job{
 			ChatID:      chatID,
-			Schedule:    schedule,
 			Type:        cron.Reminder,
 			Status:      cron.Active,
 			RunOnlyOnce: true,
 			RepeatSchedule: &cron.JobRepeatSchedule{
-				Hours:   amountDateTime.Hours,
-				Days:    amountDateTime.Days,
-				Minutes: amountDateTime.Minutes,
+				Days: weeklyDateTime.Weeks * 7,
 			},
+			Holidays: weeklyDateTime.Holidays,
 		},
 		Data: Data{
 			RecipientID: chatID,
@@ -237,11 +600,251 @@ func (s *Service) AddReminderEvery(
 			Command:     command,
 		},
 	}
+	scheduleRepeatAt(rem, chatPreference.Holidays, firstTime)
+
+	return rem, s.setEndCondition(rem, weeklyDateTime.Ends)
+}
+
+func (s *Service) AddReminderOnLunarDate(
+	chatID int, command string, lunarDateTime LunarDateTime, message string,
+) (NextScheduleChatTime, error) {
+	newReminder, err := s.newReminderOnLunarDate(chatID, command, lunarDateTime, message)
+	if err != nil {
+		return NextScheduleChatTime{}, err
+	}
 
 	return s.ScheduleAndAddReminder(newReminder)
 }
 
+func (s *Service) EditReminderOnLunarDate(
+	chatID, reminderID int, command string, lunarDateTime LunarDateTime, message string,
+) (NextScheduleChatTime, error) {
+	newReminder, err := s.newReminderOnLunarDate(chatID, command, lunarDateTime, message)
+	if err != nil {
+		return NextScheduleChatTime{}, err
+	}
+
+	return s.ScheduleAndReplaceReminder(reminderID, newReminder)
+}
+
+// newReminderOnLunarDate creates a recurring reminder whose schedule is on lunar dates, see LunarRecurrence.
+// The scheduler works out the solar date of each run from the schedule after the previous one
+// nolint:gomnd
+func (s *Service) newReminderOnLunarDate(chatID int, command string, lunarDateTime LunarDateTime, message string) (*Reminder, error) {
+	if lunarDateTime.DayOfMonth < 1 || lunarDateTime.DayOfMonth > 30 {
+		return nil, i18n.Errorf(i18n.ErrLunarDay, lunarDateTime.DayOfMonth)
+	}
+	if lunarDateTime.Month < 0 || lunarDateTime.Month > 12 {
+		return nil, i18n.Errorf(i18n.ErrLunarMonth, lunarDateTime.Month)
+	}
+
+	rem := &Reminder{
+		Job: cron.Job{
+			ChatID: chatID,
+			Schedule: fmt.Sprintf("%d %d %d %s *",
+				lunarDateTime.Minute,
+				lunarDateTime.Hour,
+				lunarDateTime.DayOfMonth,
+				asteriskIfZero(lunarDateTime.Month),
+			),
+			Type:        cron.Reminder,
+			Status:      cron.Active,
+			RunOnlyOnce: false,
+			Lunar:       true,
+			Holidays:    lunarDateTime.Holidays,
+		},
+		Data: Data{
+			RecipientID: chatID,
+			Message:     message,
+			Command:     command,
+		},
+	}
+
+	return rem, s.setEndCondition(rem, lunarDateTime.Ends)
+}
+
+func (s *Service) AddReminderOnCronSpec(
+	chatID int, command string, cronSpec CronSpec, message string,
+) (NextScheduleChatTime, error) {
+	newReminder, err := s.newReminderOnCronSpec(chatID, command, cronSpec, message)
+	if err != nil {
+		return NextScheduleChatTime{}, err
+	}
+
+	return s.ScheduleAndAddReminder(newReminder)
+}
+
+func (s *Service) EditReminderOnCronSpec(
+	chatID, reminderID int, command string, cronSpec CronSpec, message string,
+) (NextScheduleChatTime, error) {
+	newReminder, err := s.newReminderOnCronSpec(chatID, command, cronSpec, message)
+	if err != nil {
+		return NextScheduleChatTime{}, err
+	}
+
+	return s.ScheduleAndReplaceReminder(reminderID, newReminder)
+}
+
+func (s *Service) newReminderOnCronSpec(chatID int, command string, cronSpec CronSpec, message string) (*Reminder, error) {
+	if _, err := s.PreviewCronSpec(chatID, cronSpec, 1); err != nil {
+		return nil, err
+	}
+
+	return &Reminder{
+		Job: cron.Job{
+			ChatID:      chatID,
+			Schedule:    cronSpec.Spec,
+			Type:        cron.Reminder,
+			Status:      cron.Active,
+			RunOnlyOnce: false,
+		},
+		Data: Data{
+			RecipientID: chatID,
+			Message:     message,
+			Command:     command,
+		},
+	}, nil
+}
+
+// PreviewCronSpec validates a cron spec and returns its next n runs in the timezone the service reads times in
+func (s *Service) PreviewCronSpec(chatID int, cronSpec CronSpec, n int) ([]time.Time, error) {
+	chatPreference, err := s.chatPreference(chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := time.LoadLocation(chatPreference.TimeZone)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule, err := parseCronSpec(cronSpec.Spec, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	timeNow := s.timeNow().In(loc)
+	err = validateCronSpec(cronSpec, schedule, timeNow)
+	if err != nil {
+		return nil, err
+	}
+
+	return nextRuns(schedule, timeNow, n), nil
+}
+
+// setEndCondition sets when a recurring reminder stops.
+// A reminder set until a date runs up to the end of that day in the timezone it is set in,
+// in the year it is given or otherwise the next time the date comes round
+func (s *Service) setEndCondition(rem *Reminder, ends *EndCondition) error {
+	if ends == nil {
+		return nil
+	}
+
+	if ends.Times > 0 {
+		remainingRuns := ends.Times
+		rem.RemainingRuns = &remainingRuns
+	}
+
+	if ends.Until == nil {
+		return nil
+	}
+
+	chatPreference, err := s.chatPreference(rem.ChatID)
+	if err != nil {
+		return err
+	}
+
+	loc, err := time.LoadLocation(chatPreference.TimeZone)
+	if err != nil {
+		return err
+	}
+
+	until := *ends.Until
+	if until.NumericDate && chatPreference.DateOrder == chatpreference.MonthDay {
+		until.DayOfMonth, until.Month = until.Month, until.DayOfMonth
+	}
+	if until.Month < 1 || until.Month > 12 {
+		return i18n.Errorf(i18n.ErrInvalidDate, until.DayOfMonth, until.Month)
+	}
+
+	timeNow := s.timeNow().In(loc)
+	year := until.Year
+	if year == 0 {
+		year = timeNow.Year()
+	}
+
+	endsAt := time.Date(year, time.Month(until.Month), until.DayOfMonth, 23, 59, 59, 0, loc)
+	if endsAt.Day() != until.DayOfMonth {
+		if until.Year != 0 {
+			return i18n.Errorf(i18n.ErrDaysInMonth, until.Month, until.Year, until.DayOfMonth)
+		}
+		return i18n.Errorf(i18n.ErrInvalidDate, until.DayOfMonth, until.Month)
+	}
+	// a date given with its year which has passed is reported as ending before the reminder starts
+	if until.Year == 0 && endsAt.Before(timeNow) {
+		endsAt = endsAt.AddDate(1, 0, 0)
+	}
+
+	endsAtUTC := endsAt.In(time.UTC)
+	rem.EndsAt = &endsAtUTC
+
+	return nil
+}
+
+// isRecurring reports whether a reminder is sent more than once, which reminders repeating every few
+// minutes, days or months are while they are scheduled to run only once at a time
+func isRecurring(rem *Reminder) bool {
+	return !rem.RunOnlyOnce || rem.RepeatSchedule != nil
+}
+
+// hasEnded reports whether a recurring reminder should no longer run at nextRun
+func hasEnded(rem *Reminder, nextRun time.Time) bool {
+	if rem.RemainingRuns != nil && *rem.RemainingRuns <= 0 {
+		return true
+	}
+
+	return rem.EndsAt != nil && nextRun.After(*rem.EndsAt)
+}
+
+// EditReminderMessage changes the message of a reminder.
+// Active reminders have their entry on the scheduler replaced as the scheduled
+// function holds on to the reminder it was created with
+func (s *Service) EditReminderMessage(chatID, reminderID int, message string) error {
+	rem, err := s.reminderStore.GetReminder(chatID, reminderID)
+	if err != nil {
+		return err
+	}
+
+	rem.Data.Message = message
+	rem.Data.Tags = ParseTags(message)
+	if rem.Status != cron.Active {
+		return s.reminderStore.UpdateReminder(rem)
+	}
+
+	cronID, err := s.reminderScheduler.AddReminder(rem)
+	if err != nil {
+		return err
+	}
+
+	s.reminderScheduler.RemoveReminder(rem)
+	rem.CronID = cronID
+
+	return s.reminderStore.UpdateReminder(rem)
+}
+
 func (s *Service) ScheduleAndAddReminder(rem *Reminder) (NextScheduleChatTime, error) {
+	rem.TimeZone = s.timeZone
+	if s.recipient != nil {
+		rem.Data.Recipient = s.recipient
+	}
+	if s.roster != nil {
+		rem.Data.Roster = s.roster
+	}
+	rem.Data.CreatorID = s.creatorID
+	rem.Data.ReplyToMessageID = s.replyToMessageID
+	rem.Data.Attachment = s.attachment
+	rem.Data.Tags = ParseTags(rem.Data.Message)
+
 	cronID, err := s.reminderScheduler.AddReminder(rem)
 	if err != nil {
 		return NextScheduleChatTime{}, err
@@ -252,6 +855,12 @@ func (s *Service) ScheduleAndAddReminder(rem *Reminder) (NextScheduleChatTime, e
 	if err != nil {
 		return NextScheduleChatTime{}, err
 	}
+
+	if hasEnded(rem, nextScheduleTime) {
+		rem.CronID = cronID
+		s.reminderScheduler.RemoveReminder(rem)
+		return NextScheduleChatTime{}, i18n.Errorf(i18n.ErrEndsBeforeStart)
+	}
 	rem.NextRunAt = &nextScheduleTime
 
 	rem.CronID = cronID
@@ -260,24 +869,338 @@ func (s *Service) ScheduleAndAddReminder(rem *Reminder) (NextScheduleChatTime, e
 		return NextScheduleChatTime{}, err
 	}
 
+	return s.nextScheduleChatTime(rem, nextScheduleTime)
+}
+
+// ScheduleAndReplaceReminder schedules rem in place of an existing reminder.
+// The existing reminder keeps its ID and creation date while its entry on the scheduler is swapped for the new one.
+// A paused reminder stays paused with its new time, and a recurring one keeps the runs it skips
+// and when it ends unless the edit ends it differently
+func (s *Service) ScheduleAndReplaceReminder(reminderID int, rem *Reminder) (NextScheduleChatTime, error) {
+	existing, err := s.reminderStore.GetReminder(rem.ChatID, reminderID)
+	if err != nil {
+		return NextScheduleChatTime{}, err
+	}
+
+	rem.Data.Recipient = existing.Data.Recipient
+	rem.Data.Roster = existing.Data.Roster
+	rem.Data.CreatorID = existing.Data.CreatorID
+	rem.Data.ReplyToMessageID = existing.Data.ReplyToMessageID
+	if s.replyToMessageID != 0 {
+		rem.Data.ReplyToMessageID = s.replyToMessageID
+	}
+	rem.Data.Attachment = existing.Data.Attachment
+	if s.attachment != nil {
+		rem.Data.Attachment = s.attachment
+	}
+	rem.Data.Tags = ParseTags(rem.Data.Message)
+	// the times of the edit were read in the timezone of the service rather than the one the reminder was set in
+	rem.TimeZone = s.timeZone
+	// a reminder edited to be for someone else or for a new roster stops taking turns or has its turns start over
+	if s.recipient != nil || s.roster != nil {
+		rem.Data.Recipient = s.recipient
+		rem.Data.Roster = s.roster
+	}
+
+	if isRecurring(rem) && isRecurring(existing) {
+		rem.SkippedRuns = existing.SkippedRuns
+		if rem.EndsAt == nil && rem.RemainingRuns == nil {
+			rem.EndsAt = existing.EndsAt
+			rem.RemainingRuns = existing.RemainingRuns
+		}
+	}
+
+	cronID, err := s.reminderScheduler.AddReminder(rem)
+	if err != nil {
+		return NextScheduleChatTime{}, err
+	}
+
+	nextScheduleTime, err := s.reminderScheduler.GetNextScheduleTime(cronID)
+	if err != nil {
+		return NextScheduleChatTime{}, err
+	}
+
+	if hasEnded(rem, nextScheduleTime) {
+		rem.CronID = cronID
+		s.reminderScheduler.RemoveReminder(rem)
+		return NextScheduleChatTime{}, i18n.Errorf(i18n.ErrEndsBeforeStart)
+	}
+
+	if existing.Status != cron.Active {
+		// the cron ID of a reminder which is not active may have been assigned to another entry
+		existing.CronID = 0
+	}
+	s.reminderScheduler.RemoveReminder(existing)
+
+	paused := existing.Status == cron.Inactive
+	if paused {
+		// the new time was only scheduled to find when it is next due, which is when it is sent once resumed
+		rem.CronID = cronID
+		s.reminderScheduler.RemoveReminder(rem)
+		cronID = 0
+		rem.Status = cron.Inactive
+	}
+
+	rem.ID = existing.ID
+	rem.CronID = cronID
+	rem.CreatedAt = existing.CreatedAt
+	rem.LastRunAt = existing.LastRunAt
+	rem.NextRunAt = &nextScheduleTime
+	rem.Nag = existing.Nag
+	err = s.reminderStore.UpdateReminder(rem)
+	if err != nil {
+		return NextScheduleChatTime{}, err
+	}
+
+	nextSchedule, err := s.nextScheduleChatTime(rem, nextScheduleTime)
+	nextSchedule.Paused = paused
+
+	return nextSchedule, err
+}
+
+// PauseReminder removes an active reminder from the scheduler and marks it as Inactive
+// so that it is not scheduled again when reminders are loaded from the DB
+func (s *Service) PauseReminder(chatID, reminderID int) error {
+	rem, err := s.reminderStore.GetReminder(chatID, reminderID)
+	if err != nil {
+		return err
+	}
+
+	if rem.Status != cron.Active {
+		return i18n.Errorf(i18n.ErrNotActive, reminderID)
+	}
+
+	s.reminderScheduler.RemoveReminder(rem)
+	rem.CronID = 0
+	rem.Status = cron.Inactive
+
+	return s.reminderStore.UpdateReminder(rem)
+}
+
+// ResumeReminder schedules a paused reminder again.
+// Reminders with a RepeatSchedule have their schedule recalculated from the current time
+// as the stored one refers to an occurrence which has most likely passed
+func (s *Service) ResumeReminder(chatID, reminderID int) (NextScheduleChatTime, error) {
+	rem, err := s.reminderStore.GetReminder(chatID, reminderID)
+	if err != nil {
+		return NextScheduleChatTime{}, err
+	}
+
+	if rem.Status != cron.Inactive {
+		return NextScheduleChatTime{}, i18n.Errorf(i18n.ErrNotPaused, reminderID)
+	}
+
+	chatPreference, err := s.chatPreferenceStore.GetChatPreference(chatID)
+	if err != nil {
+		return NextScheduleChatTime{}, err
+	}
+
+	loc, err := time.LoadLocation(reminderTimeZone(chatPreference, rem))
+	if err != nil {
+		return NextScheduleChatTime{}, err
+	}
+
+	if rem.RepeatSchedule != nil {
+		addedTime := addRepeatSchedule(repeatBase(rem, s.timeNow().In(loc)), rem.RepeatSchedule)
+		scheduleRepeatAt(rem, chatPreference.Holidays, addedTime)
+	} else if rem.RunOnlyOnce && rem.NextRunAt != nil && rem.NextRunAt.Before(s.timeNow()) {
+		return NextScheduleChatTime{}, i18n.Errorf(i18n.ErrResumePassed, reminderID)
+	}
+
+	cronID, err := s.reminderScheduler.AddReminder(rem)
+	if err != nil {
+		return NextScheduleChatTime{}, err
+	}
+
+	nextScheduleTime, err := s.reminderScheduler.GetNextScheduleTime(cronID)
+	if err != nil {
+		return NextScheduleChatTime{}, err
+	}
+
+	if hasEnded(rem, nextScheduleTime) {
+		rem.CronID = cronID
+		s.reminderScheduler.RemoveReminder(rem)
+		return NextScheduleChatTime{}, i18n.Errorf(i18n.ErrResumeEnded, reminderID)
+	}
+
+	rem.CronID = cronID
+	rem.Status = cron.Active
+	rem.NextRunAt = &nextScheduleTime
+	err = s.reminderStore.UpdateReminder(rem)
+	if err != nil {
+		return NextScheduleChatTime{}, err
+	}
+
+	return nextScheduleInChat(chatPreference, rem, nextScheduleTime)
+}
+
+// nextScheduleChatTime returns when a reminder is next sent in the timezone of its chat,
+// along with the timezone it was set in when that is another one
+func (s *Service) nextScheduleChatTime(rem *Reminder, next time.Time) (NextScheduleChatTime, error) {
 	cp, err := s.chatPreferenceStore.GetChatPreference(rem.ChatID)
 	if err != nil {
 		return NextScheduleChatTime{}, err
 	}
 
+	return nextScheduleInChat(cp, rem, next)
+}
+
+// nextScheduleInChat is nextScheduleChatTime for a chat whose preferences have already been read
+func nextScheduleInChat(cp *chatpreference.ChatPreference, rem *Reminder, next time.Time) (NextScheduleChatTime, error) {
 	loc, err := time.LoadLocation(cp.TimeZone)
 	if err != nil {
 		return NextScheduleChatTime{}, err
 	}
 
-	return NextScheduleChatTime{Time: nextScheduleTime, Location: loc}, nil
+	nextSchedule := NextScheduleChatTime{Time: next, Location: loc}
+	if timeZone := reminderTimeZone(cp, rem); timeZone != cp.TimeZone {
+		nextSchedule.ReminderLocation, err = time.LoadLocation(timeZone)
+		if err != nil {
+			return NextScheduleChatTime{}, err
+		}
+	}
+
+	return nextSchedule, nil
+}
+
+// SetReminderNag makes a reminder be sent again until it is acknowledged, or stops it when nag is nil.
+// Any follow-up cycle in progress is stopped and active reminders have their entry on the scheduler
+// replaced as the scheduled function holds on to the reminder it was created with
+func (s *Service) SetReminderNag(chatID, reminderID int, nag *cron.JobNag) error {
+	rem, err := s.reminderStore.GetReminder(chatID, reminderID)
+	if err != nil {
+		return err
+	}
+
+	previous := *rem
+	rem.Nag = nag
+	if rem.Status == cron.Active {
+		cronID, err := s.reminderScheduler.AddReminder(rem)
+		if err != nil {
+			return err
+		}
+		rem.CronID = cronID
+	} else {
+		// the cron ID of a reminder which is not active may have been assigned to another entry
+		previous.CronID = 0
+	}
+	s.reminderScheduler.RemoveReminder(&previous)
+
+	return s.reminderStore.UpdateReminder(rem)
+}
+
+// SkipReminder makes a recurring reminder pass over its next few runs which are not already skipped.
+// The reminder still moves on to its following run each time one is skipped.
+// It returns the runs which have been skipped in the timezone of the chat
+func (s *Service) SkipReminder(chatID, reminderID, times int) ([]time.Time, error) {
+	rem, err := s.reminderStore.GetReminder(chatID, reminderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if rem.Status != cron.Active {
+		return nil, i18n.Errorf(i18n.ErrNotActive, reminderID)
+	}
+
+	if rem.RunOnlyOnce && rem.RepeatSchedule == nil {
+		return nil, i18n.Errorf(i18n.ErrNotRecurring, reminderID)
+	}
+
+	chatPreference, err := s.chatPreferenceStore.GetChatPreference(chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := time.LoadLocation(chatPreference.TimeZone)
+	if err != nil {
+		return nil, err
+	}
+
+	runs, err := upcomingRuns(chatPreference, rem, s.timeNow().In(loc), times)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(runs) == 0 {
+		return nil, i18n.Errorf(i18n.ErrNoRunsToSkip, reminderID)
+	}
+
+	for i := range runs {
+		rem.SkippedRuns = append(rem.SkippedRuns, runs[i].In(time.UTC))
+		runs[i] = runs[i].In(loc)
+	}
+	sort.Slice(rem.SkippedRuns, func(i, j int) bool { return rem.SkippedRuns[i].Before(rem.SkippedRuns[j]) })
+
+	err = s.reminderScheduler.RefreshReminder(rem)
+	if err != nil {
+		return nil, err
+	}
+
+	return runs, s.reminderStore.UpdateReminder(rem)
+}
+
+// upcomingRuns returns up to n runs of a reminder after t which are not already skipped and before it ends
+func upcomingRuns(chatPreference *chatpreference.ChatPreference, rem *Reminder, t time.Time, n int) ([]time.Time, error) {
+	next, err := nextRunFunc(chatPreference, rem)
+	if err != nil {
+		return nil, err
+	}
+
+	var runs []time.Time
+	run := next(t)
+	for i := 0; len(runs) < n && !run.IsZero() && i < maxShiftedOccurrences; i++ {
+		if rem.EndsAt != nil && run.After(*rem.EndsAt) {
+			break
+		}
+
+		if !isSkippedRun(rem, run) {
+			runs = append(runs, run)
+		}
+		run = next(run)
+	}
+
+	return runs, nil
+}
+
+// nextRunFunc returns a function giving the run of a reminder following t.
+// Reminders with a RepeatSchedule are worked out from their NextRunAt on a copy of the reminder
+// as their schedule only holds their next run
+func nextRunFunc(chatPreference *chatpreference.ChatPreference, rem *Reminder) (func(t time.Time) time.Time, error) {
+	if rem.RepeatSchedule == nil {
+		schedule, err := reminderSchedule(chatPreference, rem)
+		if err != nil {
+			return nil, err
+		}
+
+		return schedule.Next, nil
+	}
+
+	simulated := *rem
+	return func(t time.Time) time.Time {
+		if rem.NextRunAt != nil && rem.NextRunAt.After(t) {
+			return rem.NextRunAt.In(t.Location())
+		}
+
+		return scheduleRepeatAt(&simulated, chatPreference.Holidays, addRepeatSchedule(repeatBase(&simulated, t), rem.RepeatSchedule))
+	}, nil
+}
+
+// isSkippedRun reports whether run is one of the skipped runs of a reminder
+func isSkippedRun(rem *Reminder, run time.Time) bool {
+	for _, skipped := range rem.SkippedRuns {
+		if skipped.Equal(run) {
+			return true
+		}
+	}
+
+	return false
 }
 
 func (s *Service) validateInFuture(t time.Time) error {
 	minutesInFutureBeforeInvalid := 2 * time.Minute
 	currentTimeUTC := s.timeNow().Add(minutesInFutureBeforeInvalid).In(time.UTC)
 	if t.Before(currentTimeUTC) {
-		return errors.New("error: time must be at least 3 minutes in the future")
+		return i18n.Errorf(i18n.ErrTooSoon)
 	}
 
 	return nil
@@ -293,16 +1216,46 @@ func buildScheduleForRepeatableDateTime(repeatDateTime *RepeatableDateTime) stri
 	)
 }
 
+// buildScheduleForDateTime returns the schedule of a reminder on a date at a time.
+// Midnight and times on the hour are times like any other, while a date without a month is on every month
 func buildScheduleForDateTime(repeatDateTime *DateTime) string {
-	return fmt.Sprintf("%s %s %s %s %s",
-		asteriskIfZero(repeatDateTime.Minute),
-		asteriskIfZero(repeatDateTime.Hour),
+	return fmt.Sprintf("%d %d %s %s %s",
+		repeatDateTime.Minute,
+		repeatDateTime.Hour,
 		asteriskIfZero(repeatDateTime.DayOfMonth),
 		asteriskIfZero(repeatDateTime.Month),
 		asteriskIfEmpty(repeatDateTime.DayOfWeek),
 	)
 }
 
+// addRepeatSchedule returns the time of the occurrence following t according to the RepeatSchedule.
+// Months and days are added to the calendar date of t so that the time of day is kept
+func addRepeatSchedule(t time.Time, repeatSchedule *cron.JobRepeatSchedule) time.Time {
+	if repeatSchedule.Months > 0 {
+		t = addMonths(t, repeatSchedule.Months, repeatSchedule.DayOfMonth)
+	}
+
+	return t.AddDate(0, 0, repeatSchedule.Days).Add(
+		time.Duration(repeatSchedule.Hours)*time.Hour +
+			time.Duration(repeatSchedule.Minutes)*time.Minute,
+	)
+}
+
+// addMonths returns t moved on by a number of months to dayOfMonth, or to the day of t if dayOfMonth is 0.
+// The day is clamped to the last day of the month e.g. a month after 31st January is 28th or 29th February
+func addMonths(t time.Time, months, dayOfMonth int) time.Time {
+	if dayOfMonth == 0 {
+		dayOfMonth = t.Day()
+	}
+
+	firstOfMonth := time.Date(t.Year(), t.Month()+time.Month(months), 1, 0, 0, 0, 0, t.Location())
+	if lastOfMonth := firstOfMonth.AddDate(0, 1, -1).Day(); dayOfMonth > lastOfMonth {
+		dayOfMonth = lastOfMonth
+	}
+
+	return time.Date(firstOfMonth.Year(), firstOfMonth.Month(), dayOfMonth, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+}
+
 func asteriskIfZero(val int) string {
 	if val == 0 {
 		return "*"
@@ -91,6 +91,44 @@ func TestService_AddReminderOnDateTime(t *testing.T) {
 		assert.Equal(t, reminder.NextScheduleChatTime{Time: timeNow(), Location: loc}, nextScheduleTime)
 	})
 
+	t.Run("success at a time on the hour or after midnight", func(t *testing.T) {
+		testCases := map[string]struct {
+			hour     int
+			minute   int
+			schedule string
+		}{
+			"at noon": {hour: 12, minute: 0, schedule: "0 12 3 4 *"},
+			"at 0:30": {hour: 0, minute: 30, schedule: "30 0 3 4 *"},
+		}
+
+		for name := range testCases {
+			t.Run(name, func(t *testing.T) {
+				mockCtrl := gomock.NewController(t)
+				defer mockCtrl.Finish()
+				mocks := createMocks(mockCtrl)
+				mocks.Scheduler.EXPECT().AddReminder(gomock.Any()).DoAndReturn(func(rem *reminder.Reminder) (int, error) {
+					assert.Equal(t, testCases[name].schedule, rem.Schedule)
+					return cronID, nil
+				})
+				mocks.ReminderStore.EXPECT().CreateReminder(gomock.Any()).Return(reminderID, nil)
+				mocks.Scheduler.EXPECT().GetNextScheduleTime(cronID).Return(stubNextScheduleTime, nil)
+				mocks.ChatPreferenceStore.EXPECT().GetChatPreference(chatID).Return(&chatpreference.ChatPreference{
+					ChatID:   chatID,
+					TimeZone: timezone,
+				}, nil)
+
+				service := reminder.NewService(mocks.Scheduler, mocks.ReminderStore, mocks.ChatPreferenceStore, timeNow)
+				_, err := service.AddReminderOnDateTime(chatID, command, reminder.DateTime{
+					DayOfMonth: 3,
+					Month:      date.ToNumericMonth(time.April.String()),
+					Hour:       testCases[name].hour,
+					Minute:     testCases[name].minute,
+				}, message)
+				assert.NoError(t, err)
+			})
+		}
+	})
+
 	t.Run("success with day of month without month", func(t *testing.T) {
 		mockCtrl := gomock.NewController(t)
 		defer mockCtrl.Finish()
@@ -195,6 +233,90 @@ func TestService_AddReminderOnDateTime(t *testing.T) {
 	})
 }
 
+func TestService_AddReminderOnDateTime_WithYearOrNumbers(t *testing.T) {
+	loc, err := time.LoadLocation(timezone)
+	require.NoError(t, err)
+	runAt := time.Date(2027, time.March, 14, 10, 30, 0, 0, loc).In(time.UTC)
+
+	testCases := map[string]struct {
+		dateTime         reminder.DateTime
+		dateOrder        chatpreference.DateOrder
+		expectedSchedule string
+		expectedRunAt    *time.Time
+	}{
+		"date with a year runs at its absolute time": {
+			dateTime:         reminder.DateTime{Year: 2027, DayOfMonth: 14, Month: 3, Hour: 10, Minute: 30},
+			expectedSchedule: "30 10 14 3 *",
+			expectedRunAt:    &runAt,
+		},
+		"numeric date in a chat which reads day first": {
+			dateTime:         reminder.DateTime{Year: 2027, DayOfMonth: 14, Month: 3, Hour: 10, Minute: 30, NumericDate: true},
+			expectedSchedule: "30 10 14 3 *",
+			expectedRunAt:    &runAt,
+		},
+		"numeric date in a chat which reads month first": {
+			dateTime:         reminder.DateTime{Year: 2027, DayOfMonth: 3, Month: 14, Hour: 10, Minute: 30, NumericDate: true},
+			dateOrder:        chatpreference.MonthDay,
+			expectedSchedule: "30 10 14 3 *",
+			expectedRunAt:    &runAt,
+		},
+		"numeric date without a year": {
+			dateTime:         reminder.DateTime{DayOfMonth: 14, Month: 3, Hour: 10, Minute: 30, NumericDate: true},
+			expectedSchedule: "30 10 14 3 *",
+		},
+	}
+
+	for name := range testCases {
+		t.Run(name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mocks := createMocks(mockCtrl)
+			testCase := testCases[name]
+			mocks.ChatPreferenceStore.EXPECT().GetChatPreference(chatID).Return(&chatpreference.ChatPreference{
+				ChatID:    chatID,
+				TimeZone:  timezone,
+				DateOrder: testCase.dateOrder,
+			}, nil).Times(2)
+			mocks.Scheduler.EXPECT().AddReminder(gomock.Any()).DoAndReturn(func(rem *reminder.Reminder) (int, error) {
+				assert.Equal(t, testCase.expectedSchedule, rem.Schedule)
+				assert.Equal(t, testCase.expectedRunAt, rem.RunAt)
+				assert.True(t, rem.RunOnlyOnce)
+				return cronID, nil
+			})
+			mocks.Scheduler.EXPECT().GetNextScheduleTime(cronID).Return(stubNextScheduleTime, nil)
+			mocks.ReminderStore.EXPECT().CreateReminder(gomock.Any()).Return(reminderID, nil)
+
+			service := reminder.NewService(mocks.Scheduler, mocks.ReminderStore, mocks.ChatPreferenceStore, timeNow)
+			_, err := service.AddReminderOnDateTime(chatID, command, testCase.dateTime, message)
+			require.NoError(t, err)
+		})
+	}
+
+	failureCases := map[string]reminder.DateTime{
+		"date in the past":           {Year: 2019, DayOfMonth: 14, Month: 3, Hour: 10},
+		"day which the month lacks":  {Year: 2027, DayOfMonth: 29, Month: 2, Hour: 10},
+		"month which does not exist": {Year: 2027, DayOfMonth: 14, Month: 3, Hour: 10, NumericDate: true},
+		"31st of february":           {DayOfMonth: 2, Month: 31, Hour: 10, NumericDate: true},
+	}
+
+	for name := range failureCases {
+		t.Run(name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mocks := createMocks(mockCtrl)
+			mocks.ChatPreferenceStore.EXPECT().GetChatPreference(chatID).Return(&chatpreference.ChatPreference{
+				ChatID:    chatID,
+				TimeZone:  timezone,
+				DateOrder: chatpreference.MonthDay,
+			}, nil)
+
+			service := reminder.NewService(mocks.Scheduler, mocks.ReminderStore, mocks.ChatPreferenceStore, timeNow)
+			_, err := service.AddReminderOnDateTime(chatID, command, failureCases[name], message)
+			require.Error(t, err)
+		})
+	}
+}
+
 func TestService_AddReminderOnWordDateTime(t *testing.T) {
 	loc, err := time.LoadLocation(timezone)
 	require.NoError(t, err)
@@ -370,6 +492,39 @@ func TestService_AddReminderIn(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, reminder.NextScheduleChatTime{Time: timeNow(), Location: loc}, nextScheduleTime)
 	})
+
+	t.Run("success with weeks at a time of day", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mocks := createMocks(mockCtrl)
+		mocks.ChatPreferenceStore.EXPECT().GetChatPreference(chatID).Return(&chatpreference.ChatPreference{
+			ChatID:   chatID,
+			TimeZone: timezone,
+		}, nil).Times(2)
+		mocks.Scheduler.EXPECT().AddReminder(&reminder.Reminder{
+			Job: cron.Job{
+				ChatID:      chatID,
+				Schedule:    "0 9 15 4 *",
+				Type:        cron.Reminder,
+				Status:      cron.Active,
+				RunOnlyOnce: true,
+			},
+			Data: reminder.Data{
+				RecipientID: chatID,
+				Message:     message,
+				Command:     command,
+			},
+		}).Return(cronID, nil)
+		mocks.ReminderStore.EXPECT().CreateReminder(gomock.Any()).Return(reminderID, nil)
+		mocks.Scheduler.EXPECT().GetNextScheduleTime(cronID).Return(stubNextScheduleTime, nil)
+
+		service := reminder.NewService(mocks.Scheduler, mocks.ReminderStore, mocks.ChatPreferenceStore, timeNow)
+		_, err := service.AddReminderIn(chatID, command, reminder.AmountDateTime{
+			Weeks: 2,
+			At:    &reminder.TimeOfDay{Hour: 9},
+		}, message)
+		assert.NoError(t, err)
+	})
 }
 
 func TestService_AddReminderEvery(t *testing.T) {
@@ -442,6 +597,938 @@ func TestService_AddReminderEvery(t *testing.T) {
 	})
 }
 
+func TestService_EditReminderOnDateTime(t *testing.T) {
+	loc, err := time.LoadLocation(timezone)
+	require.NoError(t, err)
+
+	t.Run("success", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mocks := createMocks(mockCtrl)
+		createdAt := timeNow().Add(-48 * time.Hour)
+		existing := &reminder.Reminder{
+			Job: cron.Job{
+				ID:          reminderID,
+				CronID:      1,
+				ChatID:      chatID,
+				Schedule:    "0 9 2 4 *",
+				Type:        cron.Reminder,
+				Status:      cron.Active,
+				RunOnlyOnce: true,
+				CreatedAt:   createdAt,
+			},
+			Data: reminder.Data{
+				RecipientID: chatID,
+				Message:     "old message",
+				Command:     "old command",
+			},
+		}
+		mocks.ReminderStore.EXPECT().GetReminder(chatID, reminderID).Return(existing, nil)
+		mocks.Scheduler.EXPECT().AddReminder(&reminder.Reminder{
+			Job: cron.Job{
+				ChatID:      chatID,
+				Schedule:    "52 13 1 4 *",
+				Type:        cron.Reminder,
+				Status:      cron.Active,
+				RunOnlyOnce: true,
+			},
+			Data: reminder.Data{
+				RecipientID: chatID,
+				Message:     message,
+				Command:     command,
+			},
+		}).Return(cronID, nil)
+		mocks.Scheduler.EXPECT().RemoveReminder(existing)
+		mocks.Scheduler.EXPECT().GetNextScheduleTime(cronID).Return(stubNextScheduleTime, nil)
+		mocks.ReminderStore.EXPECT().UpdateReminder(&reminder.Reminder{
+			Job: cron.Job{
+				ID:          reminderID,
+				CronID:      cronID,
+				ChatID:      chatID,
+				Schedule:    "52 13 1 4 *",
+				Type:        cron.Reminder,
+				Status:      cron.Active,
+				RunOnlyOnce: true,
+				CreatedAt:   createdAt,
+				NextRunAt:   &stubNextScheduleTime,
+			},
+			Data: reminder.Data{
+				RecipientID: chatID,
+				Message:     message,
+				Command:     command,
+			},
+		}).Return(nil)
+		mocks.ChatPreferenceStore.EXPECT().GetChatPreference(chatID).Return(&chatpreference.ChatPreference{
+			ChatID:   chatID,
+			TimeZone: timezone,
+		}, nil)
+
+		service := reminder.NewService(mocks.Scheduler, mocks.ReminderStore, mocks.ChatPreferenceStore, timeNow)
+		nextScheduleTime, err := service.EditReminderOnDateTime(chatID, reminderID, command, reminder.DateTime{
+			DayOfMonth: 1,
+			Month:      date.ToNumericMonth(time.April.String()),
+			Hour:       13,
+			Minute:     52,
+		}, message)
+		assert.NoError(t, err)
+		assert.Equal(t, reminder.NextScheduleChatTime{Time: timeNow(), Location: loc}, nextScheduleTime)
+	})
+}
+
+func TestService_EditReminderEvery(t *testing.T) {
+	t.Run("a paused reminder stays paused and keeps its skipped and remaining runs", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mocks := createMocks(mockCtrl)
+		remainingRuns := 4
+		skippedRuns := []time.Time{timeNow().Add(72 * time.Hour)}
+		existing := &reminder.Reminder{
+			Job: cron.Job{
+				ID:            reminderID,
+				ChatID:        chatID,
+				Schedule:      "0 9 * * *",
+				Type:          cron.Reminder,
+				Status:        cron.Inactive,
+				RemainingRuns: &remainingRuns,
+				SkippedRuns:   skippedRuns,
+			},
+			Data: reminder.Data{RecipientID: chatID, Message: "old message"},
+		}
+		mocks.ReminderStore.EXPECT().GetReminder(chatID, reminderID).Return(existing, nil)
+		mocks.ChatPreferenceStore.EXPECT().GetChatPreference(chatID).Return(&chatpreference.ChatPreference{
+			ChatID:   chatID,
+			TimeZone: timezone,
+		}, nil).AnyTimes()
+		mocks.Scheduler.EXPECT().AddReminder(gomock.Any()).Return(cronID, nil)
+		mocks.Scheduler.EXPECT().GetNextScheduleTime(cronID).Return(stubNextScheduleTime, nil)
+		// the entries of the paused reminder and of its new time are both removed
+		mocks.Scheduler.EXPECT().RemoveReminder(gomock.Any()).Times(2)
+		mocks.ReminderStore.EXPECT().UpdateReminder(gomock.Any()).DoAndReturn(func(r *reminder.Reminder) error {
+			assert.Equal(t, cron.Inactive, r.Status)
+			assert.Zero(t, r.CronID)
+			assert.Equal(t, &remainingRuns, r.RemainingRuns)
+			assert.Equal(t, skippedRuns, r.SkippedRuns)
+			assert.Equal(t, message, r.Data.Message)
+			return nil
+		})
+
+		service := reminder.NewService(mocks.Scheduler, mocks.ReminderStore, mocks.ChatPreferenceStore, timeNow)
+		nextSchedule, err := service.EditReminderEvery(chatID, reminderID, command, reminder.AmountDateTime{Days: 2}, message)
+		require.NoError(t, err)
+		assert.True(t, nextSchedule.Paused)
+	})
+}
+
+func TestService_EditReminderMessage(t *testing.T) {
+	t.Run("success with active reminder", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mocks := createMocks(mockCtrl)
+		mocks.ReminderStore.EXPECT().GetReminder(chatID, reminderID).Return(&reminder.Reminder{
+			Job:  cron.Job{ID: reminderID, CronID: 1, ChatID: chatID, Schedule: "52 13 * * 2", Status: cron.Active},
+			Data: reminder.Data{RecipientID: chatID, Message: "old message"},
+		}, nil)
+		mocks.Scheduler.EXPECT().AddReminder(gomock.Any()).Return(cronID, nil)
+		mocks.Scheduler.EXPECT().RemoveReminder(gomock.Any())
+		mocks.ReminderStore.EXPECT().UpdateReminder(&reminder.Reminder{
+			Job:  cron.Job{ID: reminderID, CronID: cronID, ChatID: chatID, Schedule: "52 13 * * 2", Status: cron.Active},
+			Data: reminder.Data{RecipientID: chatID, Message: message},
+		}).Return(nil)
+
+		service := reminder.NewService(mocks.Scheduler, mocks.ReminderStore, mocks.ChatPreferenceStore, timeNow)
+		err := service.EditReminderMessage(chatID, reminderID, message)
+		assert.NoError(t, err)
+	})
+
+	t.Run("success with completed reminder", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mocks := createMocks(mockCtrl)
+		mocks.ReminderStore.EXPECT().GetReminder(chatID, reminderID).Return(&reminder.Reminder{
+			Job:  cron.Job{ID: reminderID, ChatID: chatID, Status: cron.Completed},
+			Data: reminder.Data{RecipientID: chatID, Message: "old message"},
+		}, nil)
+		mocks.ReminderStore.EXPECT().UpdateReminder(&reminder.Reminder{
+			Job:  cron.Job{ID: reminderID, ChatID: chatID, Status: cron.Completed},
+			Data: reminder.Data{RecipientID: chatID, Message: message},
+		}).Return(nil)
+
+		service := reminder.NewService(mocks.Scheduler, mocks.ReminderStore, mocks.ChatPreferenceStore, timeNow)
+		err := service.EditReminderMessage(chatID, reminderID, message)
+		assert.NoError(t, err)
+	})
+}
+
+func TestService_PauseReminder(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mocks := createMocks(mockCtrl)
+		rem := &reminder.Reminder{
+			Job: cron.Job{
+				ID:       reminderID,
+				CronID:   cronID,
+				ChatID:   chatID,
+				Schedule: "52 13 * * 2",
+				Type:     cron.Reminder,
+				Status:   cron.Active,
+			},
+		}
+		mocks.ReminderStore.EXPECT().GetReminder(chatID, reminderID).Return(rem, nil)
+		mocks.Scheduler.EXPECT().RemoveReminder(rem)
+		mocks.ReminderStore.EXPECT().UpdateReminder(&reminder.Reminder{
+			Job: cron.Job{
+				ID:       reminderID,
+				ChatID:   chatID,
+				Schedule: "52 13 * * 2",
+				Type:     cron.Reminder,
+				Status:   cron.Inactive,
+			},
+		}).Return(nil)
+
+		service := reminder.NewService(mocks.Scheduler, mocks.ReminderStore, mocks.ChatPreferenceStore, timeNow)
+		err := service.PauseReminder(chatID, reminderID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("failure when reminder is not active", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mocks := createMocks(mockCtrl)
+		mocks.ReminderStore.EXPECT().GetReminder(chatID, reminderID).Return(&reminder.Reminder{
+			Job: cron.Job{ID: reminderID, ChatID: chatID, Status: cron.Completed},
+		}, nil)
+
+		service := reminder.NewService(mocks.Scheduler, mocks.ReminderStore, mocks.ChatPreferenceStore, timeNow)
+		err := service.PauseReminder(chatID, reminderID)
+		assert.Error(t, err)
+	})
+}
+
+func TestService_ResumeReminder(t *testing.T) {
+	loc, err := time.LoadLocation(timezone)
+	require.NoError(t, err)
+
+	t.Run("success with repeat schedule", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mocks := createMocks(mockCtrl)
+		mocks.ReminderStore.EXPECT().GetReminder(chatID, reminderID).Return(&reminder.Reminder{
+			Job: cron.Job{
+				ID:             reminderID,
+				ChatID:         chatID,
+				Schedule:       "10 9 1 3 *",
+				Type:           cron.Reminder,
+				Status:         cron.Inactive,
+				RunOnlyOnce:    true,
+				RepeatSchedule: &cron.JobRepeatSchedule{Minutes: 1, Hours: 2, Days: 3},
+			},
+		}, nil)
+		mocks.ChatPreferenceStore.EXPECT().GetChatPreference(chatID).Return(&chatpreference.ChatPreference{
+			ChatID:   chatID,
+			TimeZone: timezone,
+		}, nil)
+		mocks.Scheduler.EXPECT().AddReminder(&reminder.Reminder{
+			Job: cron.Job{
+				ID:             reminderID,
+				ChatID:         chatID,
+				Schedule:       "46 15 4 4 *",
+				Type:           cron.Reminder,
+				Status:         cron.Inactive,
+				RunOnlyOnce:    true,
+				RepeatSchedule: &cron.JobRepeatSchedule{Minutes: 1, Hours: 2, Days: 3},
+			},
+		}).Return(cronID, nil)
+		mocks.Scheduler.EXPECT().GetNextScheduleTime(cronID).Return(stubNextScheduleTime, nil)
+		mocks.ReminderStore.EXPECT().UpdateReminder(&reminder.Reminder{
+			Job: cron.Job{
+				ID:             reminderID,
+				CronID:         cronID,
+				ChatID:         chatID,
+				Schedule:       "46 15 4 4 *",
+				Type:           cron.Reminder,
+				Status:         cron.Active,
+				RunOnlyOnce:    true,
+				RepeatSchedule: &cron.JobRepeatSchedule{Minutes: 1, Hours: 2, Days: 3},
+				NextRunAt:      &stubNextScheduleTime,
+			},
+		}).Return(nil)
+
+		service := reminder.NewService(mocks.Scheduler, mocks.ReminderStore, mocks.ChatPreferenceStore, timeNow)
+		nextScheduleTime, err := service.ResumeReminder(chatID, reminderID)
+		assert.NoError(t, err)
+		assert.Equal(t, reminder.NextScheduleChatTime{Time: timeNow(), Location: loc}, nextScheduleTime)
+	})
+
+	t.Run("failure when one-off reminder time has passed", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mocks := createMocks(mockCtrl)
+		pastRunAt := timeNow().Add(-time.Hour)
+		mocks.ReminderStore.EXPECT().GetReminder(chatID, reminderID).Return(&reminder.Reminder{
+			Job: cron.Job{
+				ID:          reminderID,
+				ChatID:      chatID,
+				Schedule:    "45 12 1 4 *",
+				Status:      cron.Inactive,
+				RunOnlyOnce: true,
+				NextRunAt:   &pastRunAt,
+			},
+		}, nil)
+		mocks.ChatPreferenceStore.EXPECT().GetChatPreference(chatID).Return(&chatpreference.ChatPreference{
+			ChatID:   chatID,
+			TimeZone: timezone,
+		}, nil)
+
+		service := reminder.NewService(mocks.Scheduler, mocks.ReminderStore, mocks.ChatPreferenceStore, timeNow)
+		_, err := service.ResumeReminder(chatID, reminderID)
+		assert.Error(t, err)
+	})
+}
+
+func TestService_SetReminderNag(t *testing.T) {
+	t.Run("success when reminder is active", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mocks := createMocks(mockCtrl)
+		nag := &cron.JobNag{Minutes: 10, MaxResends: 3}
+		mocks.ReminderStore.EXPECT().GetReminder(chatID, reminderID).Return(&reminder.Reminder{
+			Job: cron.Job{
+				ID:       reminderID,
+				CronID:   cronID,
+				ChatID:   chatID,
+				Schedule: "52 13 * * 2",
+				Type:     cron.Reminder,
+				Status:   cron.Active,
+			},
+		}, nil)
+		mocks.Scheduler.EXPECT().AddReminder(gomock.Any()).Return(cronID+1, nil)
+		mocks.Scheduler.EXPECT().RemoveReminder(&reminder.Reminder{
+			Job: cron.Job{
+				ID:       reminderID,
+				CronID:   cronID,
+				ChatID:   chatID,
+				Schedule: "52 13 * * 2",
+				Type:     cron.Reminder,
+				Status:   cron.Active,
+			},
+		})
+		mocks.ReminderStore.EXPECT().UpdateReminder(&reminder.Reminder{
+			Job: cron.Job{
+				ID:       reminderID,
+				CronID:   cronID + 1,
+				ChatID:   chatID,
+				Schedule: "52 13 * * 2",
+				Type:     cron.Reminder,
+				Status:   cron.Active,
+				Nag:      nag,
+			},
+		}).Return(nil)
+
+		service := reminder.NewService(mocks.Scheduler, mocks.ReminderStore, mocks.ChatPreferenceStore, timeNow)
+		err := service.SetReminderNag(chatID, reminderID, nag)
+		assert.NoError(t, err)
+	})
+
+	t.Run("success when reminder is completed", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mocks := createMocks(mockCtrl)
+		nextRunAt := timeNow().Add(5 * time.Minute)
+		mocks.ReminderStore.EXPECT().GetReminder(chatID, reminderID).Return(&reminder.Reminder{
+			Job: cron.Job{
+				ID:     reminderID,
+				CronID: cronID,
+				ChatID: chatID,
+				Status: cron.Completed,
+				Nag:    &cron.JobNag{Minutes: 10, MaxResends: 3, Resends: 1, CronID: cronID + 1, NextRunAt: &nextRunAt},
+			},
+		}, nil)
+		mocks.Scheduler.EXPECT().RemoveReminder(&reminder.Reminder{
+			Job: cron.Job{
+				ID:     reminderID,
+				ChatID: chatID,
+				Status: cron.Completed,
+				Nag:    &cron.JobNag{Minutes: 10, MaxResends: 3, Resends: 1, CronID: cronID + 1, NextRunAt: &nextRunAt},
+			},
+		})
+		mocks.ReminderStore.EXPECT().UpdateReminder(&reminder.Reminder{
+			Job: cron.Job{
+				ID:     reminderID,
+				CronID: cronID,
+				ChatID: chatID,
+				Status: cron.Completed,
+			},
+		}).Return(nil)
+
+		service := reminder.NewService(mocks.Scheduler, mocks.ReminderStore, mocks.ChatPreferenceStore, timeNow)
+		err := service.SetReminderNag(chatID, reminderID, nil)
+		assert.NoError(t, err)
+	})
+}
+
+func TestService_AddRepeatableReminderOnDateTime_EndCondition(t *testing.T) {
+	loc, err := time.LoadLocation(timezone)
+	require.NoError(t, err)
+	repeatableDateTime := func(ends *reminder.EndCondition) *reminder.RepeatableDateTime {
+		return &reminder.RepeatableDateTime{DayOfWeek: "2", Hour: "9", Minute: "0", Ends: ends}
+	}
+
+	t.Run("success until a date", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mocks := createMocks(mockCtrl)
+		expectedEndsAt := time.Date(2020, time.December, 31, 23, 59, 59, 0, loc).In(time.UTC)
+		mocks.ChatPreferenceStore.EXPECT().GetChatPreference(chatID).Return(&chatpreference.ChatPreference{
+			ChatID:   chatID,
+			TimeZone: timezone,
+		}, nil).Times(2)
+		mocks.Scheduler.EXPECT().AddReminder(gomock.Any()).Return(cronID, nil)
+		mocks.Scheduler.EXPECT().GetNextScheduleTime(cronID).Return(stubNextScheduleTime, nil)
+		mocks.ReminderStore.EXPECT().CreateReminder(gomock.Any()).DoAndReturn(func(rem *reminder.Reminder) (int, error) {
+			require.NotNil(t, rem.EndsAt)
+			assert.True(t, expectedEndsAt.Equal(*rem.EndsAt))
+			assert.Nil(t, rem.RemainingRuns)
+			return reminderID, nil
+		})
+
+		service := reminder.NewService(mocks.Scheduler, mocks.ReminderStore, mocks.ChatPreferenceStore, timeNow)
+		_, err := service.AddRepeatableReminderOnDateTime(chatID, command, repeatableDateTime(&reminder.EndCondition{
+			Until: &reminder.DateTime{DayOfMonth: 31, Month: 12},
+		}), message)
+		require.NoError(t, err)
+	})
+
+	t.Run("success until a date which has passed this year", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mocks := createMocks(mockCtrl)
+		expectedEndsAt := time.Date(2021, time.March, 31, 23, 59, 59, 0, loc).In(time.UTC)
+		mocks.ChatPreferenceStore.EXPECT().GetChatPreference(chatID).Return(&chatpreference.ChatPreference{
+			ChatID:   chatID,
+			TimeZone: timezone,
+		}, nil).Times(2)
+		mocks.Scheduler.EXPECT().AddReminder(gomock.Any()).Return(cronID, nil)
+		mocks.Scheduler.EXPECT().GetNextScheduleTime(cronID).Return(stubNextScheduleTime, nil)
+		mocks.ReminderStore.EXPECT().CreateReminder(gomock.Any()).DoAndReturn(func(rem *reminder.Reminder) (int, error) {
+			require.NotNil(t, rem.EndsAt)
+			assert.True(t, expectedEndsAt.Equal(*rem.EndsAt))
+			return reminderID, nil
+		})
+
+		service := reminder.NewService(mocks.Scheduler, mocks.ReminderStore, mocks.ChatPreferenceStore, timeNow)
+		_, err := service.AddRepeatableReminderOnDateTime(chatID, command, repeatableDateTime(&reminder.EndCondition{
+			Until: &reminder.DateTime{DayOfMonth: 31, Month: 3},
+		}), message)
+		require.NoError(t, err)
+	})
+
+	t.Run("success until a date with its year", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mocks := createMocks(mockCtrl)
+		expectedEndsAt := time.Date(2022, time.January, 31, 23, 59, 59, 0, loc).In(time.UTC)
+		mocks.ChatPreferenceStore.EXPECT().GetChatPreference(chatID).Return(&chatpreference.ChatPreference{
+			ChatID:   chatID,
+			TimeZone: timezone,
+		}, nil).Times(2)
+		mocks.Scheduler.EXPECT().AddReminder(gomock.Any()).Return(cronID, nil)
+		mocks.Scheduler.EXPECT().GetNextScheduleTime(cronID).Return(stubNextScheduleTime, nil)
+		mocks.ReminderStore.EXPECT().CreateReminder(gomock.Any()).DoAndReturn(func(rem *reminder.Reminder) (int, error) {
+			require.NotNil(t, rem.EndsAt)
+			assert.True(t, expectedEndsAt.Equal(*rem.EndsAt))
+			return reminderID, nil
+		})
+
+		service := reminder.NewService(mocks.Scheduler, mocks.ReminderStore, mocks.ChatPreferenceStore, timeNow)
+		_, err := service.AddRepeatableReminderOnDateTime(chatID, command, repeatableDateTime(&reminder.EndCondition{
+			Until: &reminder.DateTime{DayOfMonth: 31, Month: 1, Year: 2022},
+		}), message)
+		require.NoError(t, err)
+	})
+
+	t.Run("failure until a date with its year which has passed", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mocks := createMocks(mockCtrl)
+		mocks.ChatPreferenceStore.EXPECT().GetChatPreference(chatID).Return(&chatpreference.ChatPreference{
+			ChatID:   chatID,
+			TimeZone: timezone,
+		}, nil)
+		mocks.Scheduler.EXPECT().AddReminder(gomock.Any()).Return(cronID, nil)
+		mocks.Scheduler.EXPECT().GetNextScheduleTime(cronID).Return(stubNextScheduleTime, nil)
+		mocks.Scheduler.EXPECT().RemoveReminder(gomock.Any())
+
+		service := reminder.NewService(mocks.Scheduler, mocks.ReminderStore, mocks.ChatPreferenceStore, timeNow)
+		_, err := service.AddRepeatableReminderOnDateTime(chatID, command, repeatableDateTime(&reminder.EndCondition{
+			Until: &reminder.DateTime{DayOfMonth: 31, Month: 3, Year: 2020},
+		}), message)
+		require.EqualError(t, err, "error: reminder would end before it is first sent")
+	})
+
+	t.Run("success for a number of times", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mocks := createMocks(mockCtrl)
+		mocks.ChatPreferenceStore.EXPECT().GetChatPreference(chatID).Return(&chatpreference.ChatPreference{
+			ChatID:   chatID,
+			TimeZone: timezone,
+		}, nil)
+		mocks.Scheduler.EXPECT().AddReminder(gomock.Any()).Return(cronID, nil)
+		mocks.Scheduler.EXPECT().GetNextScheduleTime(cronID).Return(stubNextScheduleTime, nil)
+		mocks.ReminderStore.EXPECT().CreateReminder(gomock.Any()).DoAndReturn(func(rem *reminder.Reminder) (int, error) {
+			require.NotNil(t, rem.RemainingRuns)
+			assert.Equal(t, 10, *rem.RemainingRuns)
+			assert.Nil(t, rem.EndsAt)
+			return reminderID, nil
+		})
+
+		service := reminder.NewService(mocks.Scheduler, mocks.ReminderStore, mocks.ChatPreferenceStore, timeNow)
+		_, err := service.AddRepeatableReminderOnDateTime(chatID, command, repeatableDateTime(&reminder.EndCondition{
+			Times: 10,
+		}), message)
+		require.NoError(t, err)
+	})
+
+	t.Run("failure when reminder ends before it is first sent", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mocks := createMocks(mockCtrl)
+		mocks.ChatPreferenceStore.EXPECT().GetChatPreference(chatID).Return(&chatpreference.ChatPreference{
+			ChatID:   chatID,
+			TimeZone: timezone,
+		}, nil)
+		mocks.Scheduler.EXPECT().AddReminder(gomock.Any()).Return(cronID, nil)
+		mocks.Scheduler.EXPECT().GetNextScheduleTime(cronID).Return(timeNow().Add(48*time.Hour), nil)
+		mocks.Scheduler.EXPECT().RemoveReminder(gomock.Any())
+
+		service := reminder.NewService(mocks.Scheduler, mocks.ReminderStore, mocks.ChatPreferenceStore, timeNow)
+		_, err := service.AddRepeatableReminderOnDateTime(chatID, command, repeatableDateTime(&reminder.EndCondition{
+			Until: &reminder.DateTime{DayOfMonth: 1, Month: 4},
+		}), message)
+		require.Error(t, err)
+	})
+
+	t.Run("failure when date is not valid", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mocks := createMocks(mockCtrl)
+		mocks.ChatPreferenceStore.EXPECT().GetChatPreference(chatID).Return(&chatpreference.ChatPreference{
+			ChatID:   chatID,
+			TimeZone: timezone,
+		}, nil)
+
+		service := reminder.NewService(mocks.Scheduler, mocks.ReminderStore, mocks.ChatPreferenceStore, timeNow)
+		_, err := service.AddRepeatableReminderOnDateTime(chatID, command, repeatableDateTime(&reminder.EndCondition{
+			Until: &reminder.DateTime{DayOfMonth: 31, Month: 2},
+		}), message)
+		require.Error(t, err)
+	})
+}
+
+func TestService_AddReminderEvery_Months(t *testing.T) {
+	loc, err := time.LoadLocation(timezone)
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		timeNow                time.Time
+		amountDateTime         reminder.AmountDateTime
+		expectedSchedule       string
+		expectedRepeatSchedule *cron.JobRepeatSchedule
+	}{
+		"every month": {
+			timeNow:                time.Date(2020, time.April, 1, 13, 45, 0, 0, loc),
+			amountDateTime:         reminder.AmountDateTime{Months: 1},
+			expectedSchedule:       "45 13 1 5 *",
+			expectedRepeatSchedule: &cron.JobRepeatSchedule{Months: 1, DayOfMonth: 1},
+		},
+		"every month from the end of a long month": {
+			timeNow:                time.Date(2020, time.January, 31, 13, 45, 0, 0, loc),
+			amountDateTime:         reminder.AmountDateTime{Months: 1},
+			expectedSchedule:       "45 13 29 2 *",
+			expectedRepeatSchedule: &cron.JobRepeatSchedule{Months: 1, DayOfMonth: 31},
+		},
+		"every 3 months across the end of the year": {
+			timeNow:                time.Date(2020, time.November, 30, 13, 45, 0, 0, loc),
+			amountDateTime:         reminder.AmountDateTime{Months: 3},
+			expectedSchedule:       "45 13 28 2 *",
+			expectedRepeatSchedule: &cron.JobRepeatSchedule{Months: 3, DayOfMonth: 30},
+		},
+		"every 2 weeks": {
+			timeNow:                time.Date(2020, time.April, 1, 13, 45, 0, 0, loc),
+			amountDateTime:         reminder.AmountDateTime{Weeks: 2},
+			expectedSchedule:       "45 13 15 4 *",
+			expectedRepeatSchedule: &cron.JobRepeatSchedule{Days: 14},
+		},
+	}
+
+	for name := range testCases {
+		t.Run(name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mocks := createMocks(mockCtrl)
+			testCase := testCases[name]
+			mocks.ChatPreferenceStore.EXPECT().GetChatPreference(chatID).Return(&chatpreference.ChatPreference{
+				ChatID:   chatID,
+				TimeZone: timezone,
+			}, nil).Times(2)
+			mocks.Scheduler.EXPECT().AddReminder(gomock.Any()).DoAndReturn(func(rem *reminder.Reminder) (int, error) {
+				assert.Equal(t, testCase.expectedSchedule, rem.Schedule)
+				assert.Equal(t, testCase.expectedRepeatSchedule, rem.RepeatSchedule)
+				return cronID, nil
+			})
+			mocks.Scheduler.EXPECT().GetNextScheduleTime(cronID).Return(stubNextScheduleTime, nil)
+			mocks.ReminderStore.EXPECT().CreateReminder(gomock.Any()).Return(reminderID, nil)
+
+			service := reminder.NewService(mocks.Scheduler, mocks.ReminderStore, mocks.ChatPreferenceStore, func() time.Time {
+				return testCase.timeNow
+			})
+			_, err := service.AddReminderEvery(chatID, command, testCase.amountDateTime, message)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestService_ResumeReminder_MonthsKeepDayOfMonth(t *testing.T) {
+	loc, err := time.LoadLocation(timezone)
+	require.NoError(t, err)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mocks := createMocks(mockCtrl)
+	mocks.ReminderStore.EXPECT().GetReminder(chatID, reminderID).Return(&reminder.Reminder{
+		Job: cron.Job{
+			ID:             reminderID,
+			ChatID:         chatID,
+			Schedule:       "45 13 29 2 *",
+			Status:         cron.Inactive,
+			RunOnlyOnce:    true,
+			RepeatSchedule: &cron.JobRepeatSchedule{Months: 1, DayOfMonth: 31},
+		},
+	}, nil)
+	mocks.ChatPreferenceStore.EXPECT().GetChatPreference(chatID).Return(&chatpreference.ChatPreference{
+		ChatID:   chatID,
+		TimeZone: timezone,
+	}, nil)
+	mocks.Scheduler.EXPECT().AddReminder(gomock.Any()).DoAndReturn(func(rem *reminder.Reminder) (int, error) {
+		assert.Equal(t, "45 13 31 3 *", rem.Schedule)
+		return cronID, nil
+	})
+	mocks.Scheduler.EXPECT().GetNextScheduleTime(cronID).Return(stubNextScheduleTime, nil)
+	mocks.ReminderStore.EXPECT().UpdateReminder(gomock.Any()).Return(nil)
+
+	service := reminder.NewService(mocks.Scheduler, mocks.ReminderStore, mocks.ChatPreferenceStore, func() time.Time {
+		return time.Date(2020, time.February, 29, 13, 45, 0, 0, loc)
+	})
+	_, err = service.ResumeReminder(chatID, reminderID)
+	require.NoError(t, err)
+}
+
+func TestService_AddReminderEveryWeeks(t *testing.T) {
+	testCases := map[string]struct {
+		weeklyDateTime   reminder.WeeklyDateTime
+		expectedSchedule string
+	}{
+		"on a later day of the week": {
+			weeklyDateTime:   reminder.WeeklyDateTime{Weeks: 2, DayOfWeek: 1, Hour: 9},
+			expectedSchedule: "0 9 6 4 *",
+		},
+		"on the same day of the week later on": {
+			weeklyDateTime:   reminder.WeeklyDateTime{Weeks: 2, DayOfWeek: 3, Hour: 14, Minute: 30},
+			expectedSchedule: "30 14 1 4 *",
+		},
+		"on the same day of the week at a time which has passed": {
+			weeklyDateTime:   reminder.WeeklyDateTime{Weeks: 2, DayOfWeek: 3, Hour: 9},
+			expectedSchedule: "0 9 8 4 *",
+		},
+	}
+
+	for name := range testCases {
+		t.Run(name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mocks := createMocks(mockCtrl)
+			testCase := testCases[name]
+			mocks.ChatPreferenceStore.EXPECT().GetChatPreference(chatID).Return(&chatpreference.ChatPreference{
+				ChatID:   chatID,
+				TimeZone: timezone,
+			}, nil).Times(2)
+			mocks.Scheduler.EXPECT().AddReminder(gomock.Any()).DoAndReturn(func(rem *reminder.Reminder) (int, error) {
+				assert.Equal(t, testCase.expectedSchedule, rem.Schedule)
+				assert.True(t, rem.RunOnlyOnce)
+				assert.Equal(t, &cron.JobRepeatSchedule{Days: 14}, rem.RepeatSchedule)
+				return cronID, nil
+			})
+			mocks.Scheduler.EXPECT().GetNextScheduleTime(cronID).Return(stubNextScheduleTime, nil)
+			mocks.ReminderStore.EXPECT().CreateReminder(gomock.Any()).Return(reminderID, nil)
+
+			service := reminder.NewService(mocks.Scheduler, mocks.ReminderStore, mocks.ChatPreferenceStore, timeNow)
+			_, err := service.AddReminderEveryWeeks(chatID, command, testCase.weeklyDateTime, message)
+			require.NoError(t, err)
+		})
+	}
+
+	t.Run("failure when weeks is zero", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mocks := createMocks(mockCtrl)
+
+		service := reminder.NewService(mocks.Scheduler, mocks.ReminderStore, mocks.ChatPreferenceStore, timeNow)
+		_, err := service.AddReminderEveryWeeks(chatID, command, reminder.WeeklyDateTime{DayOfWeek: 1, Hour: 9}, message)
+		require.Error(t, err)
+	})
+}
+
+func TestService_AddReminderOnLunarDate(t *testing.T) {
+	testCases := map[string]struct {
+		lunarDateTime    reminder.LunarDateTime
+		expectedSchedule string
+	}{
+		"every lunar month": {
+			lunarDateTime:    reminder.LunarDateTime{DayOfMonth: 15, Hour: 9},
+			expectedSchedule: "0 9 15 * *",
+		},
+		"a lunar month every year": {
+			lunarDateTime:    reminder.LunarDateTime{DayOfMonth: 10, Month: 3, Hour: 8, Minute: 30},
+			expectedSchedule: "30 8 10 3 *",
+		},
+	}
+
+	for name := range testCases {
+		t.Run(name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mocks := createMocks(mockCtrl)
+			testCase := testCases[name]
+			mocks.ChatPreferenceStore.EXPECT().GetChatPreference(chatID).Return(&chatpreference.ChatPreference{
+				ChatID:   chatID,
+				TimeZone: timezone,
+			}, nil)
+			mocks.Scheduler.EXPECT().AddReminder(gomock.Any()).DoAndReturn(func(rem *reminder.Reminder) (int, error) {
+				assert.Equal(t, testCase.expectedSchedule, rem.Schedule)
+				assert.True(t, rem.Lunar)
+				assert.False(t, rem.RunOnlyOnce)
+				return cronID, nil
+			})
+			mocks.Scheduler.EXPECT().GetNextScheduleTime(cronID).Return(stubNextScheduleTime, nil)
+			mocks.ReminderStore.EXPECT().CreateReminder(gomock.Any()).Return(reminderID, nil)
+
+			service := reminder.NewService(mocks.Scheduler, mocks.ReminderStore, mocks.ChatPreferenceStore, timeNow)
+			_, err := service.AddReminderOnLunarDate(chatID, command, testCase.lunarDateTime, message)
+			require.NoError(t, err)
+		})
+	}
+
+	t.Run("failure when the day is not in a lunar month", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mocks := createMocks(mockCtrl)
+
+		service := reminder.NewService(mocks.Scheduler, mocks.ReminderStore, mocks.ChatPreferenceStore, timeNow)
+		_, err := service.AddReminderOnLunarDate(chatID, command, reminder.LunarDateTime{DayOfMonth: 31, Hour: 9}, message)
+		require.Error(t, err)
+	})
+}
+
+func TestService_ForCreator(t *testing.T) {
+	t.Run("records who set the reminder", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mocks := createMocks(mockCtrl)
+		mocks.ChatPreferenceStore.EXPECT().GetChatPreference(chatID).Return(&chatpreference.ChatPreference{
+			ChatID:   chatID,
+			TimeZone: timezone,
+		}, nil).Times(2)
+		mocks.Scheduler.EXPECT().AddReminder(gomock.Any()).DoAndReturn(func(rem *reminder.Reminder) (int, error) {
+			assert.Equal(t, 7, rem.Data.CreatorID)
+			return cronID, nil
+		})
+		mocks.Scheduler.EXPECT().GetNextScheduleTime(cronID).Return(stubNextScheduleTime, nil)
+		mocks.ReminderStore.EXPECT().CreateReminder(gomock.Any()).Return(reminderID, nil)
+
+		service := reminder.NewService(mocks.Scheduler, mocks.ReminderStore, mocks.ChatPreferenceStore, timeNow)
+		_, err := service.ForCreator(7).AddReminderIn(chatID, command, reminder.AmountDateTime{Hours: 2}, message)
+		require.NoError(t, err)
+	})
+
+	t.Run("keeps who set an edited reminder", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mocks := createMocks(mockCtrl)
+		existing := &reminder.Reminder{
+			Job: cron.Job{
+				ID:          reminderID,
+				CronID:      1,
+				ChatID:      chatID,
+				Schedule:    "0 9 2 4 *",
+				Type:        cron.Reminder,
+				Status:      cron.Active,
+				RunOnlyOnce: true,
+			},
+			Data: reminder.Data{RecipientID: chatID, CreatorID: 7, Message: "old message"},
+		}
+		mocks.ReminderStore.EXPECT().GetReminder(chatID, reminderID).Return(existing, nil)
+		mocks.ChatPreferenceStore.EXPECT().GetChatPreference(chatID).Return(&chatpreference.ChatPreference{
+			ChatID:   chatID,
+			TimeZone: timezone,
+		}, nil).AnyTimes()
+		mocks.Scheduler.EXPECT().AddReminder(gomock.Any()).DoAndReturn(func(rem *reminder.Reminder) (int, error) {
+			assert.Equal(t, 7, rem.Data.CreatorID)
+			return cronID, nil
+		})
+		mocks.Scheduler.EXPECT().RemoveReminder(existing)
+		mocks.Scheduler.EXPECT().GetNextScheduleTime(cronID).Return(stubNextScheduleTime, nil)
+		mocks.ReminderStore.EXPECT().UpdateReminder(gomock.Any()).Return(nil)
+
+		service := reminder.NewService(mocks.Scheduler, mocks.ReminderStore, mocks.ChatPreferenceStore, timeNow)
+		_, err := service.ForCreator(9).EditReminderIn(chatID, reminderID, command, reminder.AmountDateTime{Hours: 2}, message)
+		require.NoError(t, err)
+	})
+}
+
+func TestService_ReplyingTo(t *testing.T) {
+	t.Run("records the message the reminder is about", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mocks := createMocks(mockCtrl)
+		mocks.ChatPreferenceStore.EXPECT().GetChatPreference(chatID).Return(&chatpreference.ChatPreference{
+			ChatID:   chatID,
+			TimeZone: timezone,
+		}, nil).Times(2)
+		mocks.Scheduler.EXPECT().AddReminder(gomock.Any()).DoAndReturn(func(rem *reminder.Reminder) (int, error) {
+			assert.Equal(t, 55, rem.Data.ReplyToMessageID)
+			return cronID, nil
+		})
+		mocks.Scheduler.EXPECT().GetNextScheduleTime(cronID).Return(stubNextScheduleTime, nil)
+		mocks.ReminderStore.EXPECT().CreateReminder(gomock.Any()).Return(reminderID, nil)
+
+		service := reminder.NewService(mocks.Scheduler, mocks.ReminderStore, mocks.ChatPreferenceStore, timeNow)
+		_, err := service.ReplyingTo(55).AddReminderIn(chatID, command, reminder.AmountDateTime{Hours: 2}, message)
+		require.NoError(t, err)
+	})
+
+	t.Run("keeps the message an edited reminder is about", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mocks := createMocks(mockCtrl)
+		existing := &reminder.Reminder{
+			Job: cron.Job{
+				ID:          reminderID,
+				CronID:      1,
+				ChatID:      chatID,
+				Schedule:    "0 9 2 4 *",
+				Type:        cron.Reminder,
+				Status:      cron.Active,
+				RunOnlyOnce: true,
+			},
+			Data: reminder.Data{RecipientID: chatID, Message: "old message", ReplyToMessageID: 55},
+		}
+		mocks.ReminderStore.EXPECT().GetReminder(chatID, reminderID).Return(existing, nil)
+		mocks.ChatPreferenceStore.EXPECT().GetChatPreference(chatID).Return(&chatpreference.ChatPreference{
+			ChatID:   chatID,
+			TimeZone: timezone,
+		}, nil).AnyTimes()
+		mocks.Scheduler.EXPECT().AddReminder(gomock.Any()).DoAndReturn(func(rem *reminder.Reminder) (int, error) {
+			assert.Equal(t, 55, rem.Data.ReplyToMessageID)
+			return cronID, nil
+		})
+		mocks.Scheduler.EXPECT().RemoveReminder(existing)
+		mocks.Scheduler.EXPECT().GetNextScheduleTime(cronID).Return(stubNextScheduleTime, nil)
+		mocks.ReminderStore.EXPECT().UpdateReminder(gomock.Any()).Return(nil)
+
+		service := reminder.NewService(mocks.Scheduler, mocks.ReminderStore, mocks.ChatPreferenceStore, timeNow)
+		_, err := service.EditReminderIn(chatID, reminderID, command, reminder.AmountDateTime{Hours: 2}, message)
+		require.NoError(t, err)
+	})
+}
+
+func TestService_InTimeZone(t *testing.T) {
+	london, err := time.LoadLocation("Europe/London")
+	require.NoError(t, err)
+	runAt := time.Date(2027, time.March, 14, 10, 30, 0, 0, london).In(time.UTC)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mocks := createMocks(mockCtrl)
+	mocks.ChatPreferenceStore.EXPECT().GetChatPreference(chatID).Return(&chatpreference.ChatPreference{
+		ChatID:   chatID,
+		TimeZone: timezone,
+	}, nil).Times(2)
+	mocks.Scheduler.EXPECT().AddReminder(gomock.Any()).DoAndReturn(func(rem *reminder.Reminder) (int, error) {
+		assert.Equal(t, "30 10 14 3 *", rem.Schedule)
+		assert.Equal(t, "Europe/London", rem.TimeZone)
+		assert.Equal(t, &runAt, rem.RunAt)
+		return cronID, nil
+	})
+	mocks.Scheduler.EXPECT().GetNextScheduleTime(cronID).Return(runAt, nil)
+	mocks.ReminderStore.EXPECT().CreateReminder(gomock.Any()).Return(reminderID, nil)
+
+	service := reminder.NewService(mocks.Scheduler, mocks.ReminderStore, mocks.ChatPreferenceStore, timeNow)
+	nextSchedule, err := service.InTimeZone("Europe/London").AddReminderOnDateTime(
+		chatID, command, reminder.DateTime{Year: 2027, DayOfMonth: 14, Month: 3, Hour: 10, Minute: 30}, message)
+	require.NoError(t, err)
+	require.Equal(t, timezone, nextSchedule.Location.String())
+	require.Equal(t, london, nextSchedule.ReminderLocation)
+}
+
+func TestService_ForRecipient(t *testing.T) {
+	alice := &reminder.Recipient{UserID: 42, Username: "alice"}
+
+	t.Run("adds the reminder for the user", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mocks := createMocks(mockCtrl)
+		mocks.ChatPreferenceStore.EXPECT().GetChatPreference(chatID).Return(&chatpreference.ChatPreference{
+			ChatID:   chatID,
+			TimeZone: timezone,
+		}, nil).Times(2)
+		mocks.Scheduler.EXPECT().AddReminder(gomock.Any()).DoAndReturn(func(rem *reminder.Reminder) (int, error) {
+			assert.Equal(t, alice, rem.Data.Recipient)
+			assert.Equal(t, chatID, rem.Data.RecipientID)
+			return cronID, nil
+		})
+		mocks.Scheduler.EXPECT().GetNextScheduleTime(cronID).Return(stubNextScheduleTime, nil)
+		mocks.ReminderStore.EXPECT().CreateReminder(gomock.Any()).Return(reminderID, nil)
+
+		service := reminder.NewService(mocks.Scheduler, mocks.ReminderStore, mocks.ChatPreferenceStore, timeNow)
+		_, err := service.ForRecipient(alice).AddReminderIn(chatID, command, reminder.AmountDateTime{Hours: 2}, message)
+		require.NoError(t, err)
+	})
+
+	t.Run("keeps the user of an edited reminder", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mocks := createMocks(mockCtrl)
+		existing := &reminder.Reminder{
+			Job: cron.Job{
+				ID:          reminderID,
+				CronID:      1,
+				ChatID:      chatID,
+				Schedule:    "0 9 2 4 *",
+				Type:        cron.Reminder,
+				Status:      cron.Active,
+				RunOnlyOnce: true,
+			},
+			Data: reminder.Data{RecipientID: chatID, Recipient: alice, Message: "old message"},
+		}
+		mocks.ReminderStore.EXPECT().GetReminder(chatID, reminderID).Return(existing, nil)
+		mocks.ChatPreferenceStore.EXPECT().GetChatPreference(chatID).Return(&chatpreference.ChatPreference{
+			ChatID:   chatID,
+			TimeZone: timezone,
+		}, nil).AnyTimes()
+		mocks.Scheduler.EXPECT().AddReminder(gomock.Any()).DoAndReturn(func(rem *reminder.Reminder) (int, error) {
+			assert.Equal(t, alice, rem.Data.Recipient)
+			return cronID, nil
+		})
+		mocks.Scheduler.EXPECT().RemoveReminder(existing)
+		mocks.Scheduler.EXPECT().GetNextScheduleTime(cronID).Return(stubNextScheduleTime, nil)
+		mocks.ReminderStore.EXPECT().UpdateReminder(gomock.Any()).Return(nil)
+
+		service := reminder.NewService(mocks.Scheduler, mocks.ReminderStore, mocks.ChatPreferenceStore, timeNow)
+		_, err := service.EditReminderIn(chatID, reminderID, command, reminder.AmountDateTime{Hours: 2}, message)
+		require.NoError(t, err)
+	})
+}
+
 func createMocks(mockCtrl *gomock.Controller) Mocks {
 	return Mocks{
 		ReminderStore:       reminderMocks.NewMockStorer(mockCtrl),
@@ -0,0 +1,125 @@
+package reminder_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/husol/telegram-reminder-bot/pkg/chatpreference"
+	"github.com/husol/telegram-reminder-bot/pkg/cron"
+	"github.com/husol/telegram-reminder-bot/pkg/reminder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_SkipReminder(t *testing.T) {
+	loc, err := time.LoadLocation(timezone)
+	require.NoError(t, err)
+	chatPreference := &chatpreference.ChatPreference{ChatID: chatID, TimeZone: timezone}
+	endsAt := time.Date(2020, time.April, 3, 23, 59, 0, 0, loc)
+	nextRunAt := time.Date(2020, time.April, 6, 9, 0, 0, 0, loc).In(time.UTC)
+
+	testCases := map[string]struct {
+		job          cron.Job
+		times        int
+		expectedRuns []time.Time
+	}{
+		"next run of a reminder on a schedule": {
+			job:   cron.Job{Schedule: "0 9 * * *"},
+			times: 1,
+			expectedRuns: []time.Time{
+				time.Date(2020, time.April, 2, 9, 0, 0, 0, loc),
+			},
+		},
+		"runs which are already skipped are passed over": {
+			job: cron.Job{
+				Schedule:    "0 9 * * *",
+				SkippedRuns: []time.Time{time.Date(2020, time.April, 2, 9, 0, 0, 0, loc).In(time.UTC)},
+			},
+			times: 2,
+			expectedRuns: []time.Time{
+				time.Date(2020, time.April, 3, 9, 0, 0, 0, loc),
+				time.Date(2020, time.April, 4, 9, 0, 0, 0, loc),
+			},
+		},
+		"runs after the reminder ends are not skipped": {
+			job: cron.Job{
+				Schedule: "0 9 * * *",
+				EndsAt:   &endsAt,
+			},
+			times: 5,
+			expectedRuns: []time.Time{
+				time.Date(2020, time.April, 2, 9, 0, 0, 0, loc),
+				time.Date(2020, time.April, 3, 9, 0, 0, 0, loc),
+			},
+		},
+		"runs of a reminder with a repeat schedule": {
+			job: cron.Job{
+				Schedule:       "0 9 6 4 *",
+				RunOnlyOnce:    true,
+				RepeatSchedule: &cron.JobRepeatSchedule{Days: 14},
+				NextRunAt:      &nextRunAt,
+			},
+			times: 2,
+			expectedRuns: []time.Time{
+				time.Date(2020, time.April, 6, 9, 0, 0, 0, loc),
+				time.Date(2020, time.April, 20, 9, 0, 0, 0, loc),
+			},
+		},
+	}
+
+	for name := range testCases {
+		t.Run(name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			mocks := createMocks(mockCtrl)
+			testCase := testCases[name]
+			rem := &reminder.Reminder{Job: testCase.job}
+			rem.ID = reminderID
+			rem.CronID = cronID
+			rem.ChatID = chatID
+			rem.Status = cron.Active
+			previouslySkipped := len(rem.SkippedRuns)
+			mocks.ReminderStore.EXPECT().GetReminder(chatID, reminderID).Return(rem, nil)
+			mocks.ChatPreferenceStore.EXPECT().GetChatPreference(chatID).Return(chatPreference, nil)
+			mocks.Scheduler.EXPECT().RefreshReminder(rem).Return(nil)
+			mocks.ReminderStore.EXPECT().UpdateReminder(rem).Return(nil)
+
+			service := reminder.NewService(mocks.Scheduler, mocks.ReminderStore, mocks.ChatPreferenceStore, timeNow)
+			runs, err := service.SkipReminder(chatID, reminderID, testCase.times)
+			require.NoError(t, err)
+			require.Len(t, runs, len(testCase.expectedRuns))
+			for i := range testCase.expectedRuns {
+				assert.True(t, testCase.expectedRuns[i].Equal(runs[i]), "expected %s got %s", testCase.expectedRuns[i], runs[i])
+				assert.Equal(t, loc, runs[i].Location())
+			}
+			assert.Len(t, rem.SkippedRuns, previouslySkipped+len(testCase.expectedRuns))
+		})
+	}
+
+	t.Run("failure when reminder is not active", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mocks := createMocks(mockCtrl)
+		mocks.ReminderStore.EXPECT().GetReminder(chatID, reminderID).Return(&reminder.Reminder{
+			Job: cron.Job{ID: reminderID, ChatID: chatID, Schedule: "0 9 * * *", Status: cron.Inactive},
+		}, nil)
+
+		service := reminder.NewService(mocks.Scheduler, mocks.ReminderStore, mocks.ChatPreferenceStore, timeNow)
+		_, err := service.SkipReminder(chatID, reminderID, 1)
+		assert.Error(t, err)
+	})
+
+	t.Run("failure when reminder is not recurring", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		mocks := createMocks(mockCtrl)
+		mocks.ReminderStore.EXPECT().GetReminder(chatID, reminderID).Return(&reminder.Reminder{
+			Job: cron.Job{ID: reminderID, ChatID: chatID, Schedule: "0 9 2 4 *", Status: cron.Active, RunOnlyOnce: true},
+		}, nil)
+
+		service := reminder.NewService(mocks.Scheduler, mocks.ReminderStore, mocks.ChatPreferenceStore, timeNow)
+		_, err := service.SkipReminder(chatID, reminderID, 1)
+		assert.Error(t, err)
+	})
+}
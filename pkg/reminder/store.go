@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 
 	bolt "go.etcd.io/bbolt"
@@ -21,6 +22,7 @@ type Storer interface {
 	GetReminder(chatID, ID int) (*Reminder, error)
 	GetAllRemindersByChat() (map[int][]Reminder, error)
 	GetAllRemindersByChatID(chatID int) ([]Reminder, error)
+	GetRemindersByFilter(chatID int, filter Filter) ([]Reminder, error)
 }
 
 type Store struct {
@@ -50,7 +52,12 @@ func (s *Store) CreateReminder(r *Reminder) (int, error) {
 			return err
 		}
 
-		return chatBucket.Put(itob(r.ID), buf)
+		err = chatBucket.Put(itob(r.ID), buf)
+		if err != nil {
+			return err
+		}
+
+		return indexReminder(tx, r)
 	})
 	if err != nil {
 		return 0, err
@@ -63,13 +70,21 @@ func (s *Store) UpdateReminder(r *Reminder) error {
 	return s.db.Update(func(tx *bolt.Tx) error {
 		reminderBucket := tx.Bucket(RemindersBucket)
 		chatBucket := reminderBucket.Bucket(itob(r.ChatID))
+		if chatBucket == nil {
+			return ErrNotFound
+		}
 
 		buf, err := json.Marshal(r)
 		if err != nil {
 			return err
 		}
 
-		return chatBucket.Put(itob(r.ID), buf)
+		err = chatBucket.Put(itob(r.ID), buf)
+		if err != nil {
+			return err
+		}
+
+		return indexReminder(tx, r)
 	})
 }
 
@@ -108,6 +123,9 @@ func (s *Store) GetAllRemindersByChatID(chatID int) ([]Reminder, error) {
 	err := s.db.View(func(tx *bolt.Tx) error {
 		rootBucket := tx.Bucket(RemindersBucket)
 		chatBucket := rootBucket.Bucket(itob(chatID))
+		if chatBucket == nil {
+			return nil
+		}
 
 		return chatBucket.ForEach(func(k1, v1 []byte) error {
 			var reminder Reminder
@@ -128,12 +146,61 @@ func (s *Store) GetAllRemindersByChatID(chatID int) ([]Reminder, error) {
 	return remindersByChat, nil
 }
 
+// GetRemindersByFilter returns the reminders of a chat the filter selects, ordered by ID.
+// Only the reminders the index of the chat selects are read
+func (s *Store) GetRemindersByFilter(chatID int, filter Filter) ([]Reminder, error) {
+	if filter.IsZero() {
+		return s.GetAllRemindersByChatID(chatID)
+	}
+
+	reminders := []Reminder{}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		chatIndex := chatIndexBucket(tx, chatID)
+		if chatIndex == nil {
+			return nil
+		}
+
+		ids := filteredIDs(chatIndex, filter)
+		sort.Ints(ids)
+		chatBucket := tx.Bucket(RemindersBucket).Bucket(itob(chatID))
+		if chatBucket == nil {
+			return nil
+		}
+
+		for _, id := range ids {
+			v := chatBucket.Get(itob(id))
+			if v == nil {
+				continue
+			}
+
+			var reminder Reminder
+			err := json.Unmarshal(v, &reminder)
+			if err != nil {
+				return err
+			}
+
+			reminders = append(reminders, reminder)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reminders, nil
+}
+
 func (s *Store) GetReminder(chatID, id int) (*Reminder, error) {
 	var reminder Reminder
 
 	err := s.db.View(func(tx *bolt.Tx) error {
 		rootBucket := tx.Bucket(RemindersBucket)
 		chatBucket := rootBucket.Bucket(itob(chatID))
+		if chatBucket == nil {
+			return ErrNotFound
+		}
+
 		v := chatBucket.Get(itob(id))
 		if v == nil {
 			return ErrNotFound
@@ -156,8 +223,20 @@ func (s *Store) DeleteReminder(chatID, id int) error {
 	return s.db.Update(func(tx *bolt.Tx) error {
 		rootBucket := tx.Bucket(RemindersBucket)
 		chatBucket := rootBucket.Bucket(itob(chatID))
+		if chatBucket == nil {
+			return ErrNotFound
+		}
+
+		err := chatBucket.Delete(itob(id))
+		if err != nil {
+			return err
+		}
 
-		return chatBucket.Delete(itob(id))
+		if chatIndex := chatIndexBucket(tx, chatID); chatIndex != nil {
+			return unindexReminder(chatIndex, id)
+		}
+
+		return nil
 	})
 }
 
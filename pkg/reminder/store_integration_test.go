@@ -6,6 +6,7 @@ import (
 	"math/big"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/husol/telegram-reminder-bot/pkg/cron"
 	"github.com/husol/telegram-reminder-bot/pkg/db"
@@ -130,6 +131,11 @@ func TestReminderStore_GetReminder(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, existingReminder, foundReminder)
 	})
+
+	t.Run("a chat which was never set up", func(t *testing.T) {
+		_, err := reminderStore.GetReminder(-chatID-1, id)
+		assert.Equal(t, reminder.ErrNotFound, err)
+	})
 }
 
 func TestReminderStore_GetAllRemindersByChat(t *testing.T) {
@@ -191,6 +197,105 @@ func TestReminderStore_GetAllRemindersByChatID(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, id, reminders[0].ID)
 	})
+
+	t.Run("a chat which was never set up", func(t *testing.T) {
+		reminders, err := reminderStore.GetAllRemindersByChatID(-chatID - 1)
+		assert.NoError(t, err)
+		assert.Empty(t, reminders)
+	})
+}
+
+// nolint:funlen
+func TestReminderStore_GetRemindersByFilter(t *testing.T) {
+	checkSkip(t)
+
+	chatID := generateRandomInt()
+	database, err := db.SetupDB(testDBFile(), []int{chatID})
+	assert.NoError(t, err)
+	defer database.Close()
+
+	reminderStore := reminder.NewStore(database)
+	today := time.Date(2020, time.April, 3, 9, 0, 0, 0, time.UTC)
+	tomorrow := today.Add(24 * time.Hour)
+	newReminder := func(status cron.JobStatus, nextRunAt time.Time, tags ...string) int {
+		id, err := reminderStore.CreateReminder(&reminder.Reminder{
+			Job:  cron.Job{ChatID: chatID, Status: status, NextRunAt: &nextRunAt},
+			Data: reminder.Data{Tags: tags},
+		})
+		assert.NoError(t, err)
+		return id
+	}
+	opsToday := newReminder(cron.Active, today, "ops")
+	billingTomorrow := newReminder(cron.Active, tomorrow, "billing", "ops")
+	pausedOps := newReminder(cron.Inactive, today, "ops")
+
+	ids := func(reminders []reminder.Reminder) []int {
+		var ids []int
+		for i := range reminders {
+			ids = append(ids, reminders[i].ID)
+		}
+		return ids
+	}
+
+	t.Run("by tag", func(t *testing.T) {
+		reminders, err := reminderStore.GetRemindersByFilter(chatID, reminder.Filter{Tag: "ops"})
+		assert.NoError(t, err)
+		assert.Equal(t, []int{opsToday, billingTomorrow, pausedOps}, ids(reminders))
+	})
+
+	t.Run("by status", func(t *testing.T) {
+		reminders, err := reminderStore.GetRemindersByFilter(chatID, reminder.Filter{Status: cron.Active})
+		assert.NoError(t, err)
+		assert.Equal(t, []int{opsToday, billingTomorrow}, ids(reminders))
+	})
+
+	t.Run("due today", func(t *testing.T) {
+		from := time.Date(2020, time.April, 3, 0, 0, 0, 0, time.UTC)
+		before := from.Add(24 * time.Hour)
+		reminders, err := reminderStore.GetRemindersByFilter(chatID, reminder.Filter{DueFrom: &from, DueBefore: &before})
+		assert.NoError(t, err)
+		assert.Equal(t, []int{opsToday}, ids(reminders))
+	})
+
+	t.Run("by tag once it is updated or deleted", func(t *testing.T) {
+		rem, err := reminderStore.GetReminder(chatID, billingTomorrow)
+		assert.NoError(t, err)
+		rem.Data.Tags = []string{"billing"}
+		assert.NoError(t, reminderStore.UpdateReminder(rem))
+		assert.NoError(t, reminderStore.DeleteReminder(chatID, pausedOps))
+
+		reminders, err := reminderStore.GetRemindersByFilter(chatID, reminder.Filter{Tag: "ops"})
+		assert.NoError(t, err)
+		assert.Equal(t, []int{opsToday}, ids(reminders))
+	})
+}
+
+func TestIndexReminders(t *testing.T) {
+	checkSkip(t)
+
+	chatID := generateRandomInt()
+	database, err := db.SetupDB(testDBFile(), []int{chatID})
+	assert.NoError(t, err)
+
+	// reminders stored before their tags were kept have none
+	id, err := reminder.NewStore(database).CreateReminder(&reminder.Reminder{
+		Job:  cron.Job{ChatID: chatID, Status: cron.Active},
+		Data: reminder.Data{Message: "renew the #ops certificates"},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, database.Close())
+
+	database, err = db.SetupDB(testDBFile(), []int{chatID})
+	assert.NoError(t, err)
+	defer database.Close()
+	reminderStore := reminder.NewStore(database)
+
+	reminders, err := reminderStore.GetRemindersByFilter(chatID, reminder.Filter{Tag: "ops"})
+	assert.NoError(t, err)
+	assert.Len(t, reminders, 1)
+	rem, err := reminderStore.GetReminder(chatID, id)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ops"}, rem.Data.Tags)
 }
 
 func checkSkip(t *testing.T) {
@@ -0,0 +1,26 @@
+package reminder
+
+import (
+	"regexp"
+	"strings"
+)
+
+// tagRegexp matches the hashtags of a message e.g. "#ops", which start with a letter
+var tagRegexp = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_&/#])#(\p{L}[\p{L}\p{N}_]*)`)
+
+// ParseTags returns the hashtags of the message of a reminder in lower case without "#", each of them once
+func ParseTags(message string) []string {
+	var tags []string
+	seen := map[string]bool{}
+	for _, match := range tagRegexp.FindAllStringSubmatch(message, -1) {
+		tag := strings.ToLower(match[1])
+		if seen[tag] {
+			continue
+		}
+
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+
+	return tags
+}
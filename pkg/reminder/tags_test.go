@@ -0,0 +1,27 @@
+package reminder_test
+
+import (
+	"testing"
+
+	"github.com/husol/telegram-reminder-bot/pkg/reminder"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTags(t *testing.T) {
+	testCases := map[string]struct {
+		message  string
+		expected []string
+	}{
+		"hashtags":              {message: "Renew the certificate #Ops #billing", expected: []string{"ops", "billing"}},
+		"each tag once":         {message: "#ops check the queue #OPS", expected: []string{"ops"}},
+		"vietnamese":            {message: "Đóng tiền điện #hoáđơn", expected: []string{"hoáđơn"}},
+		"not a hashtag":         {message: "Issue #42 and https://example.com/#anchor", expected: nil},
+		"a message without any": {message: "Update weekly report", expected: nil},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, testCase.expected, reminder.ParseTags(testCase.message))
+		})
+	}
+}
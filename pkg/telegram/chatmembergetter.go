@@ -0,0 +1,10 @@
+package telegram
+
+import (
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+// ChatMemberGetter looks up the membership of a user in a chat, which tells whether they are one of its admins
+type ChatMemberGetter interface {
+	ChatMemberOf(chat *tb.Chat, user *tb.User) (*tb.ChatMember, error)
+}
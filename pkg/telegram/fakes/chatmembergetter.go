@@ -0,0 +1,25 @@
+package fakes
+
+import (
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+// ChatMemberGetter returns the role of users by user ID, users without one are plain members
+type ChatMemberGetter struct {
+	Roles map[int]tb.MemberStatus
+}
+
+func NewChatMemberGetter() *ChatMemberGetter {
+	return &ChatMemberGetter{
+		Roles: make(map[int]tb.MemberStatus),
+	}
+}
+
+func (g *ChatMemberGetter) ChatMemberOf(chat *tb.Chat, user *tb.User) (*tb.ChatMember, error) {
+	role, ok := g.Roles[user.ID]
+	if !ok {
+		role = tb.Member
+	}
+
+	return &tb.ChatMember{User: user, Role: role}, nil
+}
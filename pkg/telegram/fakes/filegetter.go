@@ -0,0 +1,30 @@
+package fakes
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+// FileGetter returns the contents of Files by file ID
+type FileGetter struct {
+	Files map[string]string
+}
+
+func NewFileGetter() *FileGetter {
+	return &FileGetter{
+		Files: make(map[string]string),
+	}
+}
+
+func (f *FileGetter) GetFile(file *tb.File) (io.ReadCloser, error) {
+	content, ok := f.Files[file.FileID]
+	if !ok {
+		return nil, errors.New("file not found")
+	}
+
+	return ioutil.NopCloser(strings.NewReader(content)), nil
+}
@@ -0,0 +1,31 @@
+package fakes
+
+import (
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+// MessageEditor records the texts messages were edited with, along with their inline buttons
+type MessageEditor struct {
+	Edits     []string
+	Keyboards [][][]tb.InlineButton
+}
+
+func NewMessageEditor() *MessageEditor {
+	return &MessageEditor{}
+}
+
+func (e *MessageEditor) Edit(msg tb.Editable, what interface{}, options ...interface{}) (*tb.Message, error) {
+	if text, ok := what.(string); ok {
+		e.Edits = append(e.Edits, text)
+	}
+
+	var keyboard [][]tb.InlineButton
+	for _, option := range options {
+		if sendOptions, ok := option.(*tb.SendOptions); ok && sendOptions.ReplyMarkup != nil {
+			keyboard = sendOptions.ReplyMarkup.InlineKeyboard
+		}
+	}
+	e.Keyboards = append(e.Keyboards, keyboard)
+
+	return &tb.Message{}, nil
+}
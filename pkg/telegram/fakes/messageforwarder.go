@@ -0,0 +1,21 @@
+package fakes
+
+import (
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+// MessageForwarder records the messages which were forwarded
+type MessageForwarder struct {
+	Forwards []tb.StoredMessage
+}
+
+func NewMessageForwarder() *MessageForwarder {
+	return &MessageForwarder{}
+}
+
+func (f *MessageForwarder) Forward(to tb.Recipient, msg tb.Editable, options ...interface{}) (*tb.Message, error) {
+	messageID, chatID := msg.MessageSig()
+	f.Forwards = append(f.Forwards, tb.StoredMessage{MessageID: messageID, ChatID: chatID})
+
+	return &tb.Message{}, nil
+}
@@ -7,6 +7,7 @@ import (
 type TBWrapBot struct {
 	handler              map[string]func(m *tb.Message)
 	OutboundSendMessages []string
+	CallbackResponses    []*tb.CallbackResponse
 }
 
 func NewTBWrapBot() *TBWrapBot {
@@ -23,6 +24,7 @@ func (t *TBWrapBot) Handle(endpoint, h interface{}) {
 }
 
 func (t *TBWrapBot) Respond(callback *tb.Callback, responseOptional ...*tb.CallbackResponse) error {
+	t.CallbackResponses = append(t.CallbackResponses, responseOptional...)
 	return nil
 }
 
@@ -1,6 +1,8 @@
 package fakes
 
 import (
+	"strings"
+
 	tb "gopkg.in/tucnak/telebot.v2"
 )
 
@@ -40,8 +42,10 @@ func (t *TeleBot) Delete(message tb.Editable) error {
 
 func (t *TeleBot) Start() {}
 
+// SimulateIncomingMessageToChat handles a message sent to a chat. Like telebot, a command is handled by the first
+// word of the text e.g. "/remindlist #ops" by the handler of "/remindlist"
 func (t *TeleBot) SimulateIncomingMessageToChat(chatID int64, text string) {
-	if handler, ok := t.handler[text]; ok {
+	if handler, ok := t.handler[strings.SplitN(text, " ", 2)[0]]; ok {
 		handler(&tb.Message{Text: text, Chat: &tb.Chat{ID: chatID}})
 		return
 	}
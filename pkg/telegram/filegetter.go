@@ -0,0 +1,12 @@
+package telegram
+
+import (
+	"io"
+
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+// FileGetter downloads files which were sent to the bot
+type FileGetter interface {
+	GetFile(file *tb.File) (io.ReadCloser, error)
+}
@@ -0,0 +1,10 @@
+package telegram
+
+import (
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+// MessageEditor edits messages the bot has sent
+type MessageEditor interface {
+	Edit(msg tb.Editable, what interface{}, options ...interface{}) (*tb.Message, error)
+}
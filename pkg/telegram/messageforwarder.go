@@ -0,0 +1,10 @@
+package telegram
+
+import (
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+// MessageForwarder forwards messages of a chat to another one
+type MessageForwarder interface {
+	Forward(to tb.Recipient, msg tb.Editable, options ...interface{}) (*tb.Message, error)
+}
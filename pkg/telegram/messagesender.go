@@ -0,0 +1,10 @@
+package telegram
+
+import (
+	tb "gopkg.in/tucnak/telebot.v2"
+)
+
+// MessageSender sends messages to chats other than the one the bot is answering
+type MessageSender interface {
+	Send(to tb.Recipient, what interface{}, options ...interface{}) (*tb.Message, error)
+}
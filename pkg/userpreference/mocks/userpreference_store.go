@@ -0,0 +1,64 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: userpreference_store.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	userpreference "github.com/husol/telegram-reminder-bot/pkg/userpreference"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockStorer is a mock of Storer interface
+type MockStorer struct {
+	ctrl     *gomock.Controller
+	recorder *MockStorerMockRecorder
+}
+
+// MockStorerMockRecorder is the mock recorder for MockStorer
+type MockStorerMockRecorder struct {
+	mock *MockStorer
+}
+
+// NewMockStorer creates a new mock instance
+func NewMockStorer(ctrl *gomock.Controller) *MockStorer {
+	mock := &MockStorer{ctrl: ctrl}
+	mock.recorder = &MockStorerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockStorer) EXPECT() *MockStorerMockRecorder {
+	return m.recorder
+}
+
+// GetUserPreference mocks base method
+func (m *MockStorer) GetUserPreference(userID int) (*userpreference.UserPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserPreference", userID)
+	ret0, _ := ret[0].(*userpreference.UserPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserPreference indicates an expected call of GetUserPreference
+func (mr *MockStorerMockRecorder) GetUserPreference(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserPreference", reflect.TypeOf((*MockStorer)(nil).GetUserPreference), userID)
+}
+
+// UpsertUserPreference mocks base method
+func (m *MockStorer) UpsertUserPreference(arg0 *userpreference.UserPreference) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertUserPreference", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertUserPreference indicates an expected call of UpsertUserPreference
+func (mr *MockStorerMockRecorder) UpsertUserPreference(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertUserPreference", reflect.TypeOf((*MockStorer)(nil).UpsertUserPreference), arg0)
+}
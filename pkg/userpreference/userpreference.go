@@ -0,0 +1,7 @@
+package userpreference
+
+// UserPreference is what a user prefers in every chat they share with the bot
+type UserPreference struct {
+	UserID   int    `json:"user_id"`
+	TimeZone string `json:"time_zone"` // empty for the timezone of the chat
+}
@@ -0,0 +1,65 @@
+package userpreference
+
+//go:generate mockgen -source=$GOFILE -destination=mocks/${GOFILE} -package=mocks
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var UserPreferencesBucket = []byte("userpreferences")
+
+var ErrNotFound = errors.New("user preference not found")
+
+type Storer interface {
+	GetUserPreference(userID int) (*UserPreference, error)
+	UpsertUserPreference(*UserPreference) error
+}
+
+type Store struct {
+	db *bolt.DB
+}
+
+func NewStore(db *bolt.DB) *Store {
+	return &Store{db: db}
+}
+
+func (s *Store) UpsertUserPreference(userPreference *UserPreference) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(UserPreferencesBucket)
+
+		buf, err := json.Marshal(userPreference)
+		if err != nil {
+			return err
+		}
+
+		return bucket.Put(itob(userPreference.UserID), buf)
+	})
+}
+
+func (s *Store) GetUserPreference(userID int) (*UserPreference, error) {
+	var userPreference UserPreference
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(UserPreferencesBucket)
+		v := bucket.Get(itob(userID))
+		if v == nil {
+			return ErrNotFound
+		}
+
+		return json.Unmarshal(v, &userPreference)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &userPreference, nil
+}
+
+// itob converts int to []byte
+func itob(v int) []byte {
+	return []byte(strconv.FormatInt(int64(v), 10))
+}
@@ -0,0 +1,57 @@
+package userpreference_test
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/husol/telegram-reminder-bot/pkg/db"
+	"github.com/husol/telegram-reminder-bot/pkg/userpreference"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserPreferenceStore_UpsertUserPreference(t *testing.T) {
+	checkSkip(t)
+
+	userID := generateRandomInt()
+	database, err := db.SetupDB(testDBFile(), []int{})
+	assert.NoError(t, err)
+	defer database.Close()
+	userPreferenceStore := userpreference.NewStore(database)
+
+	t.Run("success", func(t *testing.T) {
+		up := &userpreference.UserPreference{UserID: userID, TimeZone: "Europe/Berlin"}
+		err := userPreferenceStore.UpsertUserPreference(up)
+		assert.NoError(t, err)
+
+		checkUserPreference, err := userPreferenceStore.GetUserPreference(userID)
+		assert.NoError(t, err)
+		assert.Equal(t, up, checkUserPreference)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := userPreferenceStore.GetUserPreference(-userID)
+		assert.Equal(t, userpreference.ErrNotFound, err)
+	})
+}
+
+func checkSkip(t *testing.T) {
+	testDBFile := os.Getenv("TEST_DB_FILE")
+	if testDBFile == "" {
+		t.Skip()
+	}
+}
+
+func testDBFile() string {
+	return fmt.Sprintf("../%s", os.Getenv("TEST_DB_FILE"))
+}
+
+func generateRandomInt() int {
+	nBig, err := rand.Int(rand.Reader, big.NewInt(10000))
+	if err != nil {
+		panic(err)
+	}
+	return int(nBig.Int64()) + 1
+}